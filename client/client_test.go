@@ -8,7 +8,11 @@ import (
 	"strings"
 	"time"
 
+	"github.com/golang/protobuf/proto"
+	duration "github.com/golang/protobuf/ptypes/duration"
+	structpb "github.com/golang/protobuf/ptypes/struct"
 	timestamp "github.com/golang/protobuf/ptypes/timestamp"
+	wrappers "github.com/golang/protobuf/ptypes/wrappers"
 	"github.com/rs/xid"
 	"google.golang.org/grpc"
 
@@ -20,6 +24,7 @@ import (
 var ctx context.Context
 var configstore *Configstore
 var configstore2 *Configstore
+var namespacedConfigstore *Configstore
 var metaClient ConfigstoreMetaServiceClient
 
 func TestMain(m *testing.M) {
@@ -44,6 +49,12 @@ func TestMain(m *testing.M) {
 		fmt.Println()
 		return
 	}
+	namespacedConfigstore, err = ConnectToConfigstoreNamespace(ctx, conn, "clienttest")
+	if err != nil {
+		fmt.Printf("%v", err)
+		fmt.Println()
+		return
+	}
 	metaClient = NewConfigstoreMetaServiceClient(conn)
 	os.Exit(m.Run())
 }
@@ -238,6 +249,153 @@ func TestCreateWithTimestamp(t *testing.T) {
 	assert.Equal(t, resp.Entity.DateLastLoginUtc.Nanos, int32(123))
 }
 
+func TestUpdateReadonlyField(t *testing.T) {
+	user, err := configstore.Users.Create(ctx, &User{
+		Key:          CreateTopLevel_User_IncompleteKey(&PartitionId{}),
+		EmailAddress: "readonly@example.com",
+		DateLastLoginUtc: &timestamp.Timestamp{
+			Seconds: 1,
+		},
+	})
+	assert.NilError(t, err)
+
+	unchanged := user.Copy()
+	unchanged.PasswordHash = "changed"
+	user, err = configstore.Users.Update(ctx, unchanged)
+	assert.NilError(t, err)
+
+	changed := user.Copy()
+	changed.DateLastLoginUtc = &timestamp.Timestamp{
+		Seconds: 2,
+	}
+	_, err = configstore.Users.Update(ctx, changed)
+	assert.Assert(t, err != nil)
+	violations := GetFieldViolations(err)
+	assert.Equal(t, len(violations), 1)
+	assert.Equal(t, violations[0].FieldName, "dateLastLoginUtc")
+}
+
+func TestCreateValidation(t *testing.T) {
+	_, err := configstore.ValidatorTests.Create(ctx, &ValidatorTest{
+		Key:               CreateTopLevel_ValidatorTest_IncompleteKey(&PartitionId{}),
+		IpAddress:         "not an address",
+		IpAddressPort:     "127.0.0.1",
+		FixedLengthString: "short",
+	})
+	assert.Assert(t, err != nil)
+	fields := make(map[string]bool)
+	for _, violation := range GetFieldViolations(err) {
+		fields[violation.FieldName] = true
+	}
+	assert.Equal(t, len(fields), 6)
+	assert.Assert(t, fields["requiredString"])
+	assert.Assert(t, fields["requiredInt64"])
+	assert.Assert(t, fields["requiredKey"])
+	assert.Assert(t, fields["ipAddress"])
+	assert.Assert(t, fields["ipAddressPort"])
+	assert.Assert(t, fields["fixedLengthString"])
+
+	user, err := configstore.Users.Create(ctx, &User{
+		Key:          CreateTopLevel_User_IncompleteKey(&PartitionId{}),
+		EmailAddress: "validation@example.com",
+	})
+	assert.NilError(t, err)
+
+	entity, err := configstore.ValidatorTests.Create(ctx, &ValidatorTest{
+		Key:            CreateTopLevel_ValidatorTest_IncompleteKey(&PartitionId{}),
+		RequiredString: "value",
+		RequiredInt64:  1,
+		RequiredKey:    user.Key,
+		IpAddress:      "::1",
+		IpAddressPort:  "[::1]:8080",
+	})
+	assert.NilError(t, err)
+	assert.Equal(t, entity.DefaultString, "root")
+	assert.Equal(t, entity.DefaultInt64, int64(22))
+
+	entity.RequiredString = " "
+	_, err = configstore.ValidatorTests.Update(ctx, entity)
+	assert.Assert(t, err != nil)
+	violations := GetFieldViolations(err)
+	assert.Equal(t, len(violations), 1)
+	assert.Equal(t, violations[0].FieldName, "requiredString")
+}
+
+func TestValidators(t *testing.T) {
+	user, err := configstore.Users.Create(ctx, &User{
+		Key:          CreateTopLevel_User_IncompleteKey(&PartitionId{}),
+		EmailAddress: "validators@example.com",
+	})
+	assert.NilError(t, err)
+
+	entity := &ValidatorTest{
+		Key:            CreateTopLevel_ValidatorTest_IncompleteKey(&PartitionId{}),
+		RequiredString: "value",
+		RequiredInt64:  1,
+		RequiredKey:    user.Key,
+		PatternString:  "ABC",
+		RangeInt64:     101,
+		RangeDouble:    -2,
+		LengthString:   "a",
+		AllowedString:  "blue",
+		Email:          "not an email",
+		Url:            "http://example.com",
+		Uri:            "relative/path",
+		RangeTimestamp: &timestamp.Timestamp{
+			Seconds: 1,
+		},
+		RangeUint64: 11,
+	}
+	expected := []string{
+		"patternString",
+		"rangeInt64",
+		"rangeDouble",
+		"lengthString",
+		"allowedString",
+		"email",
+		"url",
+		"uri",
+		"rangeTimestamp",
+		"rangeUint64",
+	}
+
+	// the SDK rejects the entity before sending it
+	err = entity.Validate()
+	assert.Assert(t, err != nil)
+	violations := GetFieldViolations(err)
+	assert.Equal(t, len(violations), len(expected))
+	for i, violation := range violations {
+		assert.Equal(t, violation.FieldName, expected[i])
+	}
+
+	// and so does the server, with the same descriptions
+	_, err = configstore.ValidatorTests.Client().Create(ctx, &CreateValidatorTestRequest{
+		Entity: entity,
+	})
+	assert.Assert(t, err != nil)
+	serverViolations := GetFieldViolations(err)
+	assert.Equal(t, len(serverViolations), len(expected))
+	for i, violation := range serverViolations {
+		assert.Equal(t, violation.FieldName, expected[i])
+		assert.Equal(t, violation.Description, violations[i].Description)
+	}
+
+	entity.PatternString = "abc"
+	entity.RangeInt64 = 100
+	entity.RangeDouble = 1.5
+	entity.LengthString = "ab"
+	entity.AllowedString = "green"
+	entity.Email = "someone@example.com"
+	entity.Url = "https://example.com/path"
+	entity.Uri = "urn:isbn:0451450523"
+	entity.RangeTimestamp = &timestamp.Timestamp{
+		Seconds: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC).Unix(),
+	}
+	entity.RangeUint64 = 10
+	_, err = configstore.ValidatorTests.Create(ctx, entity)
+	assert.NilError(t, err)
+}
+
 func TestList(t *testing.T) {
 	_, err := configstore.Users.Client().List(ctx, &ListUserRequest{
 		Limit: 10,
@@ -340,6 +498,44 @@ func TestStore(t *testing.T) {
 	assert.Equal(t, ok, false)
 }
 
+func TestStoreNamespace(t *testing.T) {
+	user, err := namespacedConfigstore.Users.Create(ctx, &User{
+		Key:          CreateTopLevel_User_IncompleteKey(namespacedConfigstore.PartitionId()),
+		EmailAddress: "namespace@example.com",
+		PasswordHash: "v",
+	})
+	assert.NilError(t, err)
+	assert.Equal(t, user.Key.PartitionId.Namespace, "clienttest")
+
+	resp, err := configstore.Users.Client().List(ctx, &ListUserRequest{
+		PartitionId: namespacedConfigstore.PartitionId(),
+	})
+	assert.NilError(t, err)
+	found := false
+	for _, entity := range resp.Entities {
+		if entity.Key.Path[0].GetName() == user.Key.Path[0].GetName() {
+			found = true
+		}
+	}
+	assert.Equal(t, found, true)
+
+	resp, err = configstore.Users.Client().List(ctx, &ListUserRequest{})
+	assert.NilError(t, err)
+	for _, entity := range resp.Entities {
+		assert.Assert(t, entity.Key.Path[0].GetName() != user.Key.Path[0].GetName())
+	}
+
+	time.Sleep(5 * time.Second)
+
+	_, ok := namespacedConfigstore.Users.GetAndCheck(user.Key)
+	assert.Equal(t, ok, true)
+	_, ok = configstore.Users.GetAndCheck(user.Key)
+	assert.Equal(t, ok, false)
+
+	_, err = namespacedConfigstore.Users.Delete(ctx, user.Key)
+	assert.NilError(t, err)
+}
+
 func TestCreateThenUpdateThenGet(t *testing.T) {
 	resp, err := configstore.Users.Client().Create(ctx, &CreateUserRequest{
 		Entity: &User{
@@ -372,6 +568,497 @@ func TestCreateThenUpdateThenGet(t *testing.T) {
 	assert.Equal(t, resp3.Entity.PasswordHash, "what")
 }
 
+func TestCreateThenUpdateThenGetHistory(t *testing.T) {
+	resp, err := configstore.Users.Client().Create(ctx, &CreateUserRequest{
+		Entity: &User{
+			Key:          CreateTopLevel_User_IncompleteKey(&PartitionId{}),
+			EmailAddress: "history@example.com",
+			PasswordHash: "v1",
+		},
+	})
+	assert.NilError(t, err)
+
+	resp.Entity.PasswordHash = "v2"
+	_, err = configstore.Users.Client().Update(ctx, &UpdateUserRequest{
+		Entity: resp.Entity,
+	})
+	assert.NilError(t, err)
+
+	_, err = configstore.Users.Client().Delete(ctx, &DeleteUserRequest{
+		Key: resp.Entity.Key,
+	})
+	assert.NilError(t, err)
+
+	history, err := configstore.Users.Client().GetHistory(ctx, &GetHistoryUserRequest{
+		Key: resp.Entity.Key,
+	})
+	assert.NilError(t, err)
+	assert.Equal(t, len(history.Versions), 3)
+	assert.Equal(t, history.Versions[0].Version, int64(1))
+	assert.Equal(t, history.Versions[0].Entity.PasswordHash, "v1")
+	assert.Equal(t, history.Versions[1].Entity.PasswordHash, "v2")
+	assert.Assert(t, history.Versions[1].TransactionId != history.Versions[0].TransactionId)
+	assert.Equal(t, history.Versions[2].Deleted, true)
+	assert.Assert(t, history.Versions[2].Entity == nil)
+
+	version, err := configstore.Users.Client().GetAtVersion(ctx, &GetAtVersionUserRequest{
+		Key:     resp.Entity.Key,
+		Version: 1,
+	})
+	assert.NilError(t, err)
+	assert.Equal(t, version.EntityVersion.Entity.PasswordHash, "v1")
+	assert.Equal(t, version.EntityVersion.Entity.EmailAddress, "history@example.com")
+}
+
+func TestFindReferencing(t *testing.T) {
+	user, err := configstore.Users.Create(ctx, &User{
+		Key:          CreateTopLevel_User_IncompleteKey(&PartitionId{}),
+		EmailAddress: "referenced@example.com",
+	})
+	assert.NilError(t, err)
+	project, err := configstore.Projects.Create(ctx, &Project{
+		Key:  CreateTopLevel_Project_IncompleteKey(&PartitionId{}),
+		Name: "referenced",
+	})
+	assert.NilError(t, err)
+	projectAccess, err := configstore.ProjectAccesss.Create(ctx, &ProjectAccess{
+		Key:     CreateTopLevel_ProjectAccess_IncompleteKey(&PartitionId{}),
+		User:    user.Key,
+		Project: project.Key,
+	})
+	assert.NilError(t, err)
+
+	time.Sleep(5 * time.Second)
+
+	resp, err := metaClient.FindReferencing(ctx, &FindReferencingRequest{
+		Key: project.Key,
+	})
+	assert.NilError(t, err)
+	assert.Equal(t, len(resp.Entities), 1)
+	assert.Equal(t, resp.Entities[0].KindName, "ProjectAccess")
+	assert.Equal(t, resp.Entities[0].FieldName, "project")
+	assert.Equal(t, SerializeKey(resp.Entities[0].Entity.Key), SerializeKey(projectAccess.Key))
+
+	referencing := configstore.FindReferencing(project.Key)
+	assert.Equal(t, len(referencing), 1)
+	assert.Equal(t, referencing[0].FieldName, "project")
+	assert.Equal(t, referencing[0].Entity.(*ProjectAccess).User.Path[0].GetName(), user.Key.Path[0].GetName())
+
+	referencing = configstore.FindReferencing(projectAccess.Key)
+	assert.Equal(t, len(referencing), 0)
+
+	// keys nested in struct and map values refer to entities too
+	structTest, err := configstore.StructTests.Create(ctx, &StructTest{
+		Key: CreateTopLevel_StructTest_IncompleteKey(&PartitionId{}),
+		Address: &Address{
+			City:     "Sydney",
+			Landlord: user.Key,
+		},
+	})
+	assert.NilError(t, err)
+	mapTest, err := configstore.MapTests.Create(ctx, &MapTest{
+		Key: CreateTopLevel_MapTest_IncompleteKey(&PartitionId{}),
+		Addresses: map[string]*Address{
+			"home": &Address{
+				City:     "Brisbane",
+				Landlord: user.Key,
+			},
+		},
+	})
+	assert.NilError(t, err)
+
+	time.Sleep(5 * time.Second)
+
+	fieldNames := map[string]string{
+		SerializeKey(projectAccess.Key): "user",
+		SerializeKey(structTest.Key):    "address",
+		SerializeKey(mapTest.Key):       "addresses",
+	}
+	resp, err = metaClient.FindReferencing(ctx, &FindReferencingRequest{
+		Key: user.Key,
+	})
+	assert.NilError(t, err)
+	assert.Equal(t, len(resp.Entities), 3)
+	for _, entity := range resp.Entities {
+		assert.Equal(t, entity.FieldName, fieldNames[SerializeKey(entity.Entity.Key)])
+	}
+	referencing = configstore.FindReferencing(user.Key)
+	assert.Equal(t, len(referencing), 3)
+	for _, entity := range referencing {
+		assert.Equal(t, entity.FieldName, fieldNames[SerializeKey(entity.Key)])
+	}
+}
+
+func TestAncestors(t *testing.T) {
+	project, err := configstore.Projects.Create(ctx, &Project{
+		Key:  CreateTopLevel_Project_IncompleteKey(&PartitionId{}),
+		Name: "ancestor",
+	})
+	assert.NilError(t, err)
+	environment, err := configstore.ProjectEnvironments.Create(ctx, &ProjectEnvironment{
+		Key:  CreateDescendant_ProjectEnvironment_NameKey(project.Key, "production"),
+		Name: "Production",
+	})
+	assert.NilError(t, err)
+	assert.Equal(t, len(environment.Key.Path), 2)
+
+	_, err = configstore.ProjectEnvironments.Create(ctx, &ProjectEnvironment{
+		Key: CreateTopLevel_ProjectEnvironment_IncompleteKey(&PartitionId{}),
+	})
+	assert.Assert(t, err != nil)
+
+	resp, err := configstore.ProjectEnvironments.Client().List(ctx, &ListProjectEnvironmentRequest{
+		Ancestor: project.Key,
+	})
+	assert.NilError(t, err)
+	assert.Equal(t, len(resp.Entities), 1)
+	assert.Equal(t, resp.Entities[0].Name, "Production")
+
+	time.Sleep(5 * time.Second)
+
+	environments := configstore.ProjectEnvironments.GetByAncestor(project.Key)
+	assert.Equal(t, len(environments), 1)
+	assert.Assert(t, CompareKeys(environments[0].Key, environment.Key))
+}
+
+func TestRepeatedFields(t *testing.T) {
+	tag := xid.New().String()[10:]
+	entity := &RepeatedTest{
+		Key:    CreateTopLevel_RepeatedTest_IncompleteKey(&PartitionId{}),
+		Tags:   []string{tag, "shared"},
+		Scores: []int64{3, 1, 2},
+	}
+	created, err := configstore.RepeatedTests.Create(ctx, entity)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, created.Tags, []string{tag, "shared"})
+	assert.DeepEqual(t, created.Scores, []int64{3, 1, 2})
+
+	copied := created.Copy()
+	copied.Tags[0] = "changed"
+	assert.Equal(t, created.Tags[0], tag)
+
+	invalid := &RepeatedTest{
+		Key:    CreateTopLevel_RepeatedTest_IncompleteKey(&PartitionId{}),
+		Tags:   []string{"this tag is too long"},
+		Scores: []int64{-1},
+	}
+	assert.Assert(t, invalid.Validate() != nil)
+	_, err = configstore.RepeatedTests.Create(ctx, invalid)
+	assert.Assert(t, err != nil)
+	_, err = configstore.RepeatedTests.Create(ctx, &RepeatedTest{
+		Key: CreateTopLevel_RepeatedTest_IncompleteKey(&PartitionId{}),
+	})
+	assert.Assert(t, err != nil)
+
+	user, err := configstore.Users.Create(ctx, &User{
+		Key:          CreateTopLevel_User_IncompleteKey(&PartitionId{}),
+		EmailAddress: "repeated@example.com",
+	})
+	assert.NilError(t, err)
+	project, err := configstore.Projects.Create(ctx, &Project{
+		Key:          CreateTopLevel_Project_IncompleteKey(&PartitionId{}),
+		Name:         "repeated",
+		AllowedUsers: []*Key{user.Key},
+	})
+	assert.NilError(t, err)
+	assert.Equal(t, len(project.AllowedUsers), 1)
+
+	time.Sleep(5 * time.Second)
+
+	found := configstore.RepeatedTests.GetByTag(tag)
+	assert.Assert(t, found != nil)
+	assert.Assert(t, CompareKeys(found.Key, created.Key))
+
+	referencing := configstore.FindReferencing(user.Key)
+	assert.Equal(t, len(referencing), 1)
+	assert.Equal(t, referencing[0].FieldName, "allowedUsers")
+
+	_, err = configstore.Users.Delete(ctx, user.Key)
+	assert.NilError(t, err)
+	resp, err := configstore.Projects.Client().Get(ctx, &GetProjectRequest{
+		Key: project.Key,
+	})
+	assert.NilError(t, err)
+	assert.Equal(t, len(resp.Entity.AllowedUsers), 0)
+}
+
+func TestStructFields(t *testing.T) {
+	entity, err := configstore.StructTests.Create(ctx, &StructTest{
+		Key: CreateTopLevel_StructTest_IncompleteKey(&PartitionId{}),
+		Address: &Address{
+			Street: "1 Example Street",
+			City:   "Sydney",
+		},
+		PreviousAddresses: []*Address{
+			&Address{
+				City: "Brisbane",
+			},
+		},
+	})
+	assert.NilError(t, err)
+	assert.Equal(t, entity.Address.City, "Sydney")
+	assert.Equal(t, len(entity.PreviousAddresses), 1)
+	assert.Equal(t, entity.PreviousAddresses[0].City, "Brisbane")
+
+	copied := entity.Copy()
+	copied.Address.City = "Melbourne"
+	copied.PreviousAddresses[0].City = "Perth"
+	assert.Equal(t, entity.Address.City, "Sydney")
+	assert.Equal(t, entity.PreviousAddresses[0].City, "Brisbane")
+
+	_, err = configstore.StructTests.Create(ctx, &StructTest{
+		Key:     CreateTopLevel_StructTest_IncompleteKey(&PartitionId{}),
+		Address: &Address{},
+	})
+	assert.Assert(t, err != nil)
+
+	entity.Address = nil
+	_, err = configstore.StructTests.Update(ctx, entity)
+	assert.Assert(t, err != nil)
+
+	entity.Address = &Address{
+		City: "Melbourne",
+	}
+	entity.PreviousAddresses = nil
+	updated, err := configstore.StructTests.Update(ctx, entity)
+	assert.NilError(t, err)
+	assert.Equal(t, updated.Address.City, "Melbourne")
+	assert.Equal(t, updated.Address.Street, "")
+	assert.Equal(t, len(updated.PreviousAddresses), 0)
+}
+
+func TestMapFields(t *testing.T) {
+	entity, err := configstore.MapTests.Create(ctx, &MapTest{
+		Key: CreateTopLevel_MapTest_IncompleteKey(&PartitionId{}),
+		Limits: map[string]int64{
+			"cpu":    4,
+			"memory": 1024,
+		},
+		Addresses: map[string]*Address{
+			"home": &Address{
+				City: "Sydney",
+			},
+		},
+	})
+	assert.NilError(t, err)
+	assert.Equal(t, entity.Limits["memory"], int64(1024))
+	assert.Equal(t, entity.Addresses["home"].City, "Sydney")
+
+	copied := entity.Copy()
+	copied.Limits["cpu"] = 8
+	copied.Addresses["home"].City = "Melbourne"
+	assert.Equal(t, entity.Limits["cpu"], int64(4))
+	assert.Equal(t, entity.Addresses["home"].City, "Sydney")
+
+	_, err = configstore.MapTests.Create(ctx, &MapTest{
+		Key: CreateTopLevel_MapTest_IncompleteKey(&PartitionId{}),
+		Limits: map[string]int64{
+			"cpu": -1,
+		},
+	})
+	assert.Assert(t, err != nil)
+
+	patched, err := configstore.MapTests.Patch(ctx, &MapTest{
+		Key: entity.Key,
+		Limits: map[string]int64{
+			"disk": 20,
+		},
+	}, "limits.disk", "limits.cpu")
+	assert.NilError(t, err)
+	assert.DeepEqual(t, patched.Limits, map[string]int64{
+		"disk":   20,
+		"memory": 1024,
+	})
+	assert.Equal(t, patched.Addresses["home"].City, "Sydney")
+}
+
+func TestEnumFields(t *testing.T) {
+	entity, err := configstore.EnumTests.Create(ctx, &EnumTest{
+		Key:           CreateTopLevel_EnumTest_IncompleteKey(&PartitionId{}),
+		Tier:          Tier_TIER_STAGING,
+		PreviousTiers: []Tier{Tier_TIER_DEVELOPMENT},
+	})
+	assert.NilError(t, err)
+	assert.Equal(t, entity.Tier, Tier_TIER_STAGING)
+	assert.DeepEqual(t, entity.PreviousTiers, []Tier{Tier_TIER_DEVELOPMENT})
+
+	_, err = configstore.EnumTests.Create(ctx, &EnumTest{
+		Key:  CreateTopLevel_EnumTest_IncompleteKey(&PartitionId{}),
+		Tier: Tier(9),
+	})
+	assert.Assert(t, err != nil)
+
+	time.Sleep(5 * time.Second)
+
+	found := configstore.EnumTests.GetByTier(Tier_TIER_STAGING)
+	assert.Assert(t, found != nil)
+	assert.Assert(t, CompareKeys(found.Key, entity.Key))
+}
+
+func TestDecimalFields(t *testing.T) {
+	entity, err := configstore.DecimalTests.Create(ctx, &DecimalTest{
+		Key:   CreateTopLevel_DecimalTest_IncompleteKey(&PartitionId{}),
+		Price: &DecimalValue{Units: 19, Nanos: 990000000},
+		Rates: map[string]*DecimalValue{
+			"AUD": &DecimalValue{Units: 1, Nanos: 512300000},
+		},
+	})
+	assert.NilError(t, err)
+	assert.DeepEqual(t, entity.Price, &DecimalValue{Units: 19, Nanos: 990000000})
+	assert.DeepEqual(t, entity.Rates["AUD"], &DecimalValue{Units: 1, Nanos: 512300000})
+
+	invalid := &DecimalTest{
+		Key:   CreateTopLevel_DecimalTest_IncompleteKey(&PartitionId{}),
+		Price: &DecimalValue{Units: 19, Nanos: 999000000},
+	}
+	assert.Assert(t, invalid.Validate() != nil)
+	_, err = configstore.DecimalTests.Client().Create(ctx, &CreateDecimalTestRequest{
+		Entity: invalid,
+	})
+	assert.Assert(t, err != nil)
+
+	_, err = configstore.DecimalTests.Create(ctx, &DecimalTest{
+		Key: CreateTopLevel_DecimalTest_IncompleteKey(&PartitionId{}),
+	})
+	assert.Assert(t, err != nil)
+}
+
+func TestDurationGeoPointAndJSONFields(t *testing.T) {
+	settings := &structpb.Struct{
+		Fields: map[string]*structpb.Value{
+			"name": &structpb.Value{Kind: &structpb.Value_StringValue{StringValue: "a"}},
+			"limits": &structpb.Value{Kind: &structpb.Value_StructValue{StructValue: &structpb.Struct{
+				Fields: map[string]*structpb.Value{
+					"cpu": &structpb.Value{Kind: &structpb.Value_NumberValue{NumberValue: 2}},
+				},
+			}}},
+		},
+	}
+	entity, err := configstore.TypesTests.Create(ctx, &TypesTest{
+		Key:         CreateTopLevel_TypesTest_IncompleteKey(&PartitionId{}),
+		Timeout:     &duration.Duration{Seconds: 30},
+		Location:    &GeoPoint{Latitude: -33.8688, Longitude: 151.2093},
+		Settings:    settings,
+		RetryDelays: []*duration.Duration{&duration.Duration{Nanos: 500000000}, &duration.Duration{Seconds: 2}},
+	})
+	assert.NilError(t, err)
+	assert.Assert(t, proto.Equal(entity.Timeout, &duration.Duration{Seconds: 30}))
+	assert.Assert(t, proto.Equal(entity.Location, &GeoPoint{Latitude: -33.8688, Longitude: 151.2093}))
+	assert.Assert(t, proto.Equal(entity.Settings, settings))
+	assert.Equal(t, len(entity.RetryDelays), 2)
+	assert.Assert(t, proto.Equal(entity.RetryDelays[0], &duration.Duration{Nanos: 500000000}))
+
+	// copies don't share JSON with the original
+	copied := entity.Copy()
+	copied.Settings.Fields["name"] = &structpb.Value{Kind: &structpb.Value_StringValue{StringValue: "b"}}
+	assert.Equal(t, entity.Settings.Fields["name"].GetStringValue(), "a")
+
+	_, err = configstore.TypesTests.Create(ctx, &TypesTest{
+		Key:      CreateTopLevel_TypesTest_IncompleteKey(&PartitionId{}),
+		Timeout:  &duration.Duration{Seconds: 30},
+		Location: &GeoPoint{Latitude: 91},
+	})
+	assert.Assert(t, err != nil)
+
+	invalid := &TypesTest{
+		Key: CreateTopLevel_TypesTest_IncompleteKey(&PartitionId{}),
+	}
+	assert.Assert(t, invalid.Validate() != nil)
+	_, err = configstore.TypesTests.Client().Create(ctx, &CreateTypesTestRequest{
+		Entity: invalid,
+	})
+	assert.Assert(t, err != nil)
+}
+
+func TestNullableFields(t *testing.T) {
+	entity, err := configstore.NullableTests.Create(ctx, &NullableTest{
+		Key:      CreateTopLevel_NullableTest_IncompleteKey(&PartitionId{}),
+		Replicas: &wrappers.Int64Value{Value: 3},
+		Enabled:  &wrappers.BoolValue{Value: false},
+	})
+	assert.NilError(t, err)
+
+	resp, err := configstore.NullableTests.Client().Get(ctx, &GetNullableTestRequest{
+		Key: entity.Key,
+	})
+	assert.NilError(t, err)
+	assert.Equal(t, resp.Entity.Replicas.GetValue(), int64(3))
+	assert.Assert(t, resp.Entity.Label == nil)
+	assert.Assert(t, resp.Entity.Enabled != nil)
+	assert.Equal(t, resp.Entity.Enabled.GetValue(), false)
+	assert.Assert(t, resp.Entity.ExpiresAt == nil)
+
+	// validators don't apply to null values, but do apply to zero values
+	valid := &NullableTest{
+		Key: CreateTopLevel_NullableTest_IncompleteKey(&PartitionId{}),
+	}
+	assert.NilError(t, valid.Validate())
+	invalid := &NullableTest{
+		Key:      CreateTopLevel_NullableTest_IncompleteKey(&PartitionId{}),
+		Replicas: &wrappers.Int64Value{Value: 0},
+	}
+	assert.Assert(t, invalid.Validate() != nil)
+	_, err = configstore.NullableTests.Client().Create(ctx, &CreateNullableTestRequest{
+		Entity: invalid,
+	})
+	assert.Assert(t, err != nil)
+}
+
+func TestUpdateVersionConflict(t *testing.T) {
+	user, err := configstore.Users.Create(ctx, &User{
+		Key:          CreateTopLevel_User_IncompleteKey(&PartitionId{}),
+		EmailAddress: "conflict@example.com",
+		PasswordHash: "v1",
+	})
+	assert.NilError(t, err)
+	assert.Equal(t, user.EntityVersion, int64(1))
+
+	first := user.Copy()
+	first.PasswordHash = "first"
+	updated, err := configstore.Users.Update(ctx, first)
+	assert.NilError(t, err)
+	assert.Equal(t, updated.EntityVersion, int64(2))
+
+	second := user.Copy()
+	second.PasswordHash = "second"
+	_, err = configstore.Users.Update(ctx, second)
+	assert.Assert(t, IsVersionConflict(err))
+
+	_, err = configstore.Users.Client().Delete(ctx, &DeleteUserRequest{
+		Key:             user.Key,
+		ExpectedVersion: 1,
+	})
+	assert.Assert(t, IsVersionConflict(err))
+
+	_, err = configstore.Users.Client().Delete(ctx, &DeleteUserRequest{
+		Key:             user.Key,
+		ExpectedVersion: updated.EntityVersion,
+	})
+	assert.NilError(t, err)
+}
+
+func TestPatch(t *testing.T) {
+	user, err := configstore.Users.Create(ctx, &User{
+		Key:          CreateTopLevel_User_IncompleteKey(&PartitionId{}),
+		EmailAddress: "patch@example.com",
+		PasswordHash: "original",
+	})
+	assert.NilError(t, err)
+
+	patched, err := configstore.Users.Patch(ctx, &User{
+		Key:          user.Key,
+		PasswordHash: "patched",
+	}, "passwordHash")
+	assert.NilError(t, err)
+	assert.Equal(t, patched.EmailAddress, "patch@example.com")
+	assert.Equal(t, patched.PasswordHash, "patched")
+
+	_, err = configstore.Users.Patch(ctx, &User{
+		Key: user.Key,
+	}, "notAField")
+	assert.Assert(t, err != nil)
+}
+
 func TestCreateThenDeleteThenGet(t *testing.T) {
 	resp, err := configstore.Users.Client().Create(ctx, &CreateUserRequest{
 		Entity: &User{
@@ -3,19 +3,37 @@ package main
 import (
 	"context"
 	"fmt"
+	"log"
 	"net/http"
 	"strings"
 
 	"github.com/dgrijalva/jwt-go"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 )
 
 type c string
 
 const contextSubjectKey c = "sub"
+const contextTenantKey c = "tenant"
+const contextReadonlyOverrideKey c = "readonlyOverride"
+const contextAdminKey c = "admin"
 
-func authGetUser(w http.ResponseWriter, r *http.Request, signingSecret []byte, requiredScopes []string, iss string, aud string) (string, error) {
-	if authorizationHeader := r.Header.Get("Authorization"); authorizationHeader == "" {
-		return "", fmt.Errorf("missing 'Authorization' header")
+type authUser struct {
+	sub    string
+	tenant string
+	scopes []string
+}
+
+func authGetUser(w http.ResponseWriter, r *http.Request, signingSecret []byte, requiredScopes []string, iss string, aud string, tenantClaim string) (*authUser, error) {
+	return authParseToken(r.Header.Get("Authorization"), signingSecret, requiredScopes, iss, aud, tenantClaim)
+}
+
+func authParseToken(authorizationHeader string, signingSecret []byte, requiredScopes []string, iss string, aud string, tenantClaim string) (*authUser, error) {
+	if authorizationHeader == "" {
+		return nil, fmt.Errorf("missing 'Authorization' header")
 	} else {
 		token, err := jwt.Parse(authorizationHeader, func(token *jwt.Token) (interface{}, error) {
 			if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
@@ -26,15 +44,16 @@ func authGetUser(w http.ResponseWriter, r *http.Request, signingSecret []byte, r
 		})
 
 		if err != nil {
-			return "", fmt.Errorf("failed to parse JWT: %v", err)
+			return nil, fmt.Errorf("failed to parse JWT: %v", err)
 		}
 
 		claims, ok := token.Claims.(jwt.MapClaims)
 		if !ok || !token.Valid {
-			return "", fmt.Errorf("invalid token claims")
+			return nil, fmt.Errorf("invalid token claims")
 		}
 
-		scopes := strings.Split(claims["scope"].(string), " ")
+		scope, _ := claims["scope"].(string)
+		scopes := strings.Split(scope, " ")
 		for _, rscope := range requiredScopes {
 			found := false
 			for _, scope := range scopes {
@@ -44,22 +63,44 @@ func authGetUser(w http.ResponseWriter, r *http.Request, signingSecret []byte, r
 				}
 			}
 			if !found {
-				return "", fmt.Errorf("missing scope: %s", rscope)
+				return nil, fmt.Errorf("missing scope: %s", rscope)
 			}
 		}
 
-		if claims["iss"].(string) != iss ||
-			claims["aud"].(string) != aud {
-			return "", fmt.Errorf("invalid iss or aud field")
+		tokenIss, issOk := claims["iss"].(string)
+		tokenAud, audOk := claims["aud"].(string)
+		if !issOk || !audOk || tokenIss != iss || tokenAud != aud {
+			return nil, fmt.Errorf("invalid iss or aud field")
+		}
+
+		sub, ok := claims["sub"].(string)
+		if !ok {
+			return nil, fmt.Errorf("missing 'sub' claim")
+		}
+
+		user := &authUser{
+			sub:    sub,
+			scopes: scopes,
+		}
+
+		if tenantClaim != "" {
+			tenant, ok := claims[tenantClaim].(string)
+			if !ok || tenant == "" {
+				return nil, fmt.Errorf("missing '%s' claim", tenantClaim)
+			}
+			if !isValidNamespaceName(tenant) {
+				return nil, fmt.Errorf("'%s' claim is not a valid namespace name", tenantClaim)
+			}
+			user.tenant = tenant
 		}
 
-		return claims["sub"].(string), nil
+		return user, nil
 	}
 }
 
-func authMiddleware(handler http.Handler, signingSecret []byte, requiredScopes []string, iss string, aud string) http.Handler {
+func authMiddleware(handler http.Handler, signingSecret []byte, requiredScopes []string, iss string, aud string, tenantClaim string, readonlyOverrideScope string, adminScope string) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		sub, err := authGetUser(w, r, signingSecret, requiredScopes, iss, aud)
+		user, err := authGetUser(w, r, signingSecret, requiredScopes, iss, aud, tenantClaim)
 
 		if err != nil {
 			// CLI user
@@ -68,8 +109,112 @@ func authMiddleware(handler http.Handler, signingSecret []byte, requiredScopes [
 			return
 		}
 
-		ctx := r.Context()
-		ctx = context.WithValue(ctx, contextSubjectKey, sub)
+		ctx := withAuthUser(r.Context(), user, readonlyOverrideScope, adminScope)
 		handler.ServeHTTP(w, r.WithContext(ctx))
 	})
 }
+
+func withAuthUser(ctx context.Context, user *authUser, readonlyOverrideScope string, adminScope string) context.Context {
+	ctx = context.WithValue(ctx, contextSubjectKey, user.sub)
+	if user.tenant != "" {
+		ctx = context.WithValue(ctx, contextTenantKey, user.tenant)
+	}
+	for _, scope := range user.scopes {
+		if readonlyOverrideScope != "" && scope == readonlyOverrideScope {
+			ctx = context.WithValue(ctx, contextReadonlyOverrideKey, true)
+		}
+		if adminScope != "" && scope == adminScope {
+			ctx = context.WithValue(ctx, contextAdminKey, true)
+		}
+	}
+	return ctx
+}
+
+// authGrpcInterceptors authenticate calls made directly to the gRPC port
+// that send a token in their 'authorization' metadata, so that trusted
+// services can use the same scopes as HTTP callers. Calls without a token
+// are served unauthenticated, as they were before.
+func authGrpcInterceptors(signingSecret []byte, requiredScopes []string, iss string, aud string, tenantClaim string, readonlyOverrideScope string, adminScope string) (grpc.UnaryServerInterceptor, grpc.StreamServerInterceptor) {
+	authenticate := func(ctx context.Context) (context.Context, error) {
+		if _, ok := ctx.Value(contextSubjectKey).(string); ok {
+			// already authenticated by authMiddleware
+			return ctx, nil
+		}
+		md, ok := metadata.FromIncomingContext(ctx)
+		if !ok {
+			return ctx, nil
+		}
+		authorization := md.Get("authorization")
+		if len(authorization) == 0 {
+			return ctx, nil
+		}
+		user, err := authParseToken(authorization[0], signingSecret, requiredScopes, iss, aud, tenantClaim)
+		if err != nil {
+			log.Printf("authentication failed: %v", err)
+			return nil, status.Error(codes.Unauthenticated, "invalid token")
+		}
+		return withAuthUser(ctx, user, readonlyOverrideScope, adminScope), nil
+	}
+	return createAuthGrpcInterceptors(authenticate)
+}
+
+// authDisabledGrpcInterceptors are used when authentication is disabled.
+// Every caller is trusted, so they're all allowed administrative
+// operations. They can't modify readonly fields, since that would make
+// readonly fields writable by everyone; restoring or importing still
+// writes them.
+func authDisabledGrpcInterceptors() (grpc.UnaryServerInterceptor, grpc.StreamServerInterceptor) {
+	return createAuthGrpcInterceptors(func(ctx context.Context) (context.Context, error) {
+		return context.WithValue(ctx, contextAdminKey, true), nil
+	})
+}
+
+func createAuthGrpcInterceptors(authenticate func(ctx context.Context) (context.Context, error)) (grpc.UnaryServerInterceptor, grpc.StreamServerInterceptor) {
+	unary := func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx, err := authenticate(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+	stream := func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := authenticate(ss.Context())
+		if err != nil {
+			return err
+		}
+		return handler(srv, &authServerStream{ServerStream: ss, ctx: ctx})
+	}
+	return unary, stream
+}
+
+type authServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *authServerStream) Context() context.Context {
+	return s.ctx
+}
+
+// getContextTenant returns the namespace that the authenticated caller is
+// pinned to, if tenant isolation is enabled.
+func getContextTenant(ctx context.Context) (string, bool) {
+	tenant, ok := ctx.Value(contextTenantKey).(string)
+	return tenant, ok && tenant != ""
+}
+
+// canOverrideReadonly returns whether the authenticated caller has the
+// scope that allows readonly fields to be modified. When authentication is
+// disabled, no caller has it.
+func canOverrideReadonly(ctx context.Context) bool {
+	override, ok := ctx.Value(contextReadonlyOverrideKey).(bool)
+	return ok && override
+}
+
+// isAdmin returns whether the authenticated caller has the scope that
+// allows administrative operations, such as restoring a namespace. When
+// authentication is disabled, every caller is an admin.
+func isAdmin(ctx context.Context) bool {
+	admin, ok := ctx.Value(contextAdminKey).(bool)
+	return ok && admin
+}
@@ -0,0 +1,117 @@
+package main
+
+import (
+	"context"
+
+	"testing"
+
+	"github.com/dgrijalva/jwt-go"
+	timestamp "github.com/golang/protobuf/ptypes/timestamp"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"gotest.tools/assert"
+)
+
+func TestAuthGrpcInterceptor(t *testing.T) {
+	secret := []byte("secret")
+	unary, _ := authGrpcInterceptors(secret, []string{"configstore"}, "iss", "aud", "", "configstore:readonly", "configstore:admin")
+
+	call := func(ctx context.Context) (context.Context, error) {
+		var handled context.Context
+		_, err := unary(ctx, nil, &grpc.UnaryServerInfo{}, func(ctx context.Context, req interface{}) (interface{}, error) {
+			handled = ctx
+			return nil, nil
+		})
+		return handled, err
+	}
+	withClaims := func(claims jwt.MapClaims) context.Context {
+		token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(secret)
+		assert.NilError(t, err)
+		return metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", token))
+	}
+	withToken := func(scope string) context.Context {
+		return withClaims(jwt.MapClaims{
+			"sub":   "service",
+			"scope": scope,
+			"iss":   "iss",
+			"aud":   "aud",
+		})
+	}
+
+	// calls without a token are served as before
+	ctx, err := call(context.Background())
+	assert.NilError(t, err)
+	assert.Assert(t, !canOverrideReadonly(ctx))
+	assert.Assert(t, !isAdmin(ctx))
+
+	ctx, err = call(withToken("configstore configstore:readonly configstore:admin"))
+	assert.NilError(t, err)
+	assert.Equal(t, ctx.Value(contextSubjectKey), "service")
+	assert.Assert(t, canOverrideReadonly(ctx))
+	assert.Assert(t, isAdmin(ctx))
+
+	ctx, err = call(withToken("configstore"))
+	assert.NilError(t, err)
+	assert.Assert(t, !canOverrideReadonly(ctx))
+	assert.Assert(t, !isAdmin(ctx))
+
+	_, err = call(withToken("other"))
+	assert.Equal(t, status.Code(err), codes.Unauthenticated)
+
+	// missing or mistyped claims are rejected rather than crashing the server
+	for _, claims := range []jwt.MapClaims{
+		jwt.MapClaims{"sub": "service", "iss": "iss", "aud": "aud"},
+		jwt.MapClaims{"sub": "service", "scope": 1, "iss": "iss", "aud": "aud"},
+		jwt.MapClaims{"sub": "service", "scope": "configstore", "aud": "aud"},
+		jwt.MapClaims{"sub": "service", "scope": "configstore", "iss": "iss", "aud": []string{"aud"}},
+		jwt.MapClaims{"scope": "configstore", "iss": "iss", "aud": "aud"},
+		jwt.MapClaims{"sub": 1, "scope": "configstore", "iss": "iss", "aud": "aud"},
+	} {
+		_, err = call(withClaims(claims))
+		assert.Equal(t, status.Code(err), codes.Unauthenticated)
+	}
+}
+
+func TestAuthDisabledGrpcInterceptor(t *testing.T) {
+	genResult, err := generate("./schema.json")
+	assert.NilError(t, err)
+
+	storage := createMemoryStorage()
+	defer storage.Close()
+	processor := createTransactionProcessor(storage)
+
+	unary, _ := authDisabledGrpcInterceptors()
+	var ctx context.Context
+	_, err = unary(context.Background(), nil, &grpc.UnaryServerInfo{}, func(handled context.Context, req interface{}) (interface{}, error) {
+		ctx = handled
+		return nil, nil
+	})
+	assert.NilError(t, err)
+	assert.Assert(t, isAdmin(ctx))
+	assert.Assert(t, !canOverrideReadonly(ctx))
+
+	// so readonly fields stay readonly for every caller
+	lastLogin := func(seconds int64) *Value {
+		return &Value{
+			Id:             4,
+			Type:           ValueType_timestamp,
+			TimestampValue: &timestamp.Timestamp{Seconds: seconds},
+		}
+	}
+	resp, err := processor.processTransaction(ctx, genResult.Schema, &MetaTransaction{
+		Operations: []*MetaOperation{
+			createTestOperation(createTestKey("User", "u1"), lastLogin(1)),
+		},
+	})
+	assert.NilError(t, err)
+	assert.Equal(t, getOperationResultCode(resp.OperationResults[0]), codes.OK)
+	resp, err = processor.processTransaction(ctx, genResult.Schema, &MetaTransaction{
+		Operations: []*MetaOperation{
+			updateTestOperation(createTestKey("User", "u1"), lastLogin(2)),
+		},
+	})
+	assert.NilError(t, err)
+	assert.Equal(t, getOperationResultCode(resp.OperationResults[0]), codes.PermissionDenied)
+}
@@ -4,18 +4,21 @@ import (
 	"fmt"
 	"sort"
 
+	duration "github.com/golang/protobuf/ptypes/duration"
+	structpb "github.com/golang/protobuf/ptypes/struct"
 	timestamp "github.com/golang/protobuf/ptypes/timestamp"
+	wrappers "github.com/golang/protobuf/ptypes/wrappers"
 	"github.com/jhump/protoreflect/desc"
 	"github.com/jhump/protoreflect/dynamic"
-
-	"cloud.google.com/go/firestore"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 func convertDynamicMessageIntoMetaEntity(
-	client *firestore.Client,
 	messageFactory *dynamic.MessageFactory,
 	messageDescriptor *desc.MessageDescriptor,
 	message *dynamic.Message,
+	schema *Schema,
 	schemaKind *SchemaKind,
 ) (*MetaEntity, error) {
 	keyRaw, err := message.TryGetFieldByName("key")
@@ -32,10 +35,27 @@ func convertDynamicMessageIntoMetaEntity(
 		Key:    key,
 		Values: nil,
 	}
+	if version, err := message.TryGetFieldByNumber(entityVersionFieldID); err == nil {
+		metaEntity.Version, _ = version.(int64)
+	}
+
+	metaEntity.Values, err = convertDynamicMessageIntoValues(schema, schemaKind.Fields, message)
+	if err != nil {
+		return nil, err
+	}
 
+	return metaEntity, nil
+}
+
+// convertDynamicMessageIntoValues converts the fields of a kind or struct
+// message into values. Fields that aren't in fields, like the key of an
+// entity, are skipped.
+func convertDynamicMessageIntoValues(schema *Schema, fields []*SchemaField, message *dynamic.Message) ([]*Value, error) {
+	var values []*Value
 	setFields := make(map[int32]bool)
 	for _, fieldDescriptor := range message.GetKnownFields() {
-		if fieldDescriptor.GetName() == "key" {
+		schemaField := findSchemaFieldByID(fields, fieldDescriptor.GetNumber())
+		if schemaField == nil {
 			continue
 		}
 
@@ -45,132 +65,226 @@ func convertDynamicMessageIntoMetaEntity(
 
 		rawValue := message.GetField(fieldDescriptor)
 
-		switch value := rawValue.(type) {
-		case float64:
-			setFields[fieldDescriptor.GetNumber()] = true
-			metaEntity.Values = append(
-				metaEntity.Values,
-				&Value{
-					Id:          fieldDescriptor.GetNumber(),
-					Type:        ValueType_double,
-					DoubleValue: value,
-				},
-			)
-			break
-		case int64:
-			setFields[fieldDescriptor.GetNumber()] = true
-			metaEntity.Values = append(
-				metaEntity.Values,
-				&Value{
-					Id:         fieldDescriptor.GetNumber(),
-					Type:       ValueType_int64,
-					Int64Value: value,
-				},
-			)
-			break
-		case string:
-			setFields[fieldDescriptor.GetNumber()] = true
-			metaEntity.Values = append(
-				metaEntity.Values,
-				&Value{
-					Id:          fieldDescriptor.GetNumber(),
-					Type:        ValueType_string,
-					StringValue: value,
-				},
-			)
-			break
-		case bool:
-			setFields[fieldDescriptor.GetNumber()] = true
-			metaEntity.Values = append(
-				metaEntity.Values,
-				&Value{
-					Id:           fieldDescriptor.GetNumber(),
-					Type:         ValueType_boolean,
-					BooleanValue: value,
-				},
-			)
-			break
-		case []byte:
-			setFields[fieldDescriptor.GetNumber()] = true
-			metaEntity.Values = append(
-				metaEntity.Values,
-				&Value{
-					Id:         fieldDescriptor.GetNumber(),
-					Type:       ValueType_bytes,
-					BytesValue: value,
-				},
-			)
-			break
-		case uint64:
-			// We store uint64 as int64 inside Firestore, as Firestore
-			// does not support uint64 natively
-			setFields[fieldDescriptor.GetNumber()] = true
-			metaEntity.Values = append(
-				metaEntity.Values,
-				&Value{
-					Id:          fieldDescriptor.GetNumber(),
-					Type:        ValueType_uint64,
-					Uint64Value: value,
-				},
-			)
-			break
-		case *timestamp.Timestamp:
-			setFields[fieldDescriptor.GetNumber()] = true
-			metaEntity.Values = append(
-				metaEntity.Values,
-				&Value{
-					Id:             fieldDescriptor.GetNumber(),
-					Type:           ValueType_timestamp,
-					TimestampValue: value,
-				},
-			)
-			break
-		case *Key:
-			setFields[fieldDescriptor.GetNumber()] = true
-			metaEntity.Values = append(
-				metaEntity.Values,
-				&Value{
-					Id:       fieldDescriptor.GetNumber(),
-					Type:     ValueType_key,
-					KeyValue: value,
-				},
-			)
-			break
-		default:
-			return nil, fmt.Errorf("field '%s' contained unknown field type '%T' with value: %v", fieldDescriptor.GetName(), rawValue, rawValue)
+		var value *Value
+		if schemaField.Repeated {
+			elements, _ := rawValue.([]interface{})
+			value = &Value{
+				Type:       schemaField.Type,
+				ArrayValue: make([]*Value, 0, len(elements)),
+			}
+			for _, element := range elements {
+				elementValue, err := convertDynamicFieldValueToValue(schema, schemaField, fieldDescriptor, element)
+				if err != nil {
+					return nil, err
+				}
+				value.ArrayValue = append(value.ArrayValue, elementValue)
+			}
+		} else if schemaField.Type == ValueType_map {
+			entries, _ := rawValue.(map[interface{}]interface{})
+			value = &Value{
+				Type:     ValueType_map,
+				MapValue: make(map[string]*Value, len(entries)),
+			}
+			valueField := getMapValueField(schemaField)
+			for entryKey, entry := range entries {
+				entryValue, err := convertDynamicFieldValueToValue(schema, valueField, fieldDescriptor, entry)
+				if err != nil {
+					return nil, err
+				}
+				value.MapValue[fmt.Sprintf("%v", entryKey)] = entryValue
+			}
+		} else {
+			var err error
+			value, err = convertDynamicFieldValueToValue(schema, schemaField, fieldDescriptor, rawValue)
+			if err != nil {
+				return nil, err
+			}
 		}
+		value.Id = fieldDescriptor.GetNumber()
+		setFields[fieldDescriptor.GetNumber()] = true
+		values = append(values, value)
 	}
 
-	// polyfill nil fields (keys and timestamps)
-	for _, schemaField := range schemaKind.Fields {
-		if _, ok := setFields[schemaField.Id]; !ok {
+	// polyfill nil fields (keys, timestamps, structs, decimals, durations,
+	// geopoints and json), enums, which aren't set when they have the
+	// value numbered 0, and nullable fields, which are null when they aren't
+	// set
+	for _, schemaField := range fields {
+		if _, ok := setFields[schemaField.Id]; !ok && !schemaField.Repeated {
 			// need to polyfill this value
-			if schemaField.Type == ValueType_key {
-				metaEntity.Values = append(
-					metaEntity.Values,
+			if schemaField.Nullable {
+				values = append(
+					values,
 					&Value{
-						Id:       schemaField.Id,
-						Type:     ValueType_key,
-						KeyValue: nil,
+						Id:        schemaField.Id,
+						Type:      schemaField.Type,
+						NullValue: true,
 					},
 				)
-			} else if schemaField.Type == ValueType_timestamp {
-				metaEntity.Values = append(
-					metaEntity.Values,
+				continue
+			}
+			switch schemaField.Type {
+			case ValueType_key, ValueType_timestamp, ValueType_struct, ValueType_decimal,
+				ValueType_duration, ValueType_geopoint, ValueType_json:
+				values = append(
+					values,
 					&Value{
-						Id:             schemaField.Id,
-						Type:           ValueType_timestamp,
-						TimestampValue: nil,
+						Id:   schemaField.Id,
+						Type: schemaField.Type,
 					},
 				)
+			case ValueType_enum:
+				value, err := convertDynamicFieldValueToValue(schema, schemaField, nil, int32(0))
+				if err != nil {
+					return nil, err
+				}
+				value.Id = schemaField.Id
+				values = append(values, value)
 			}
 		}
 	}
 
 	// sort, this is mainly so unit tests pass...
-	sort.Slice(metaEntity.Values[:], func(i, j int) bool {
-		return metaEntity.Values[i].Id < metaEntity.Values[j].Id
+	sort.Slice(values[:], func(i, j int) bool {
+		return values[i].Id < values[j].Id
 	})
 
-	return metaEntity, nil
+	return values, nil
+}
+
+// convertDynamicFieldValueToValue converts the value of a field, or one
+// element of a repeated or map field, read from a dynamic message.
+func convertDynamicFieldValueToValue(schema *Schema, schemaField *SchemaField, fieldDescriptor *desc.FieldDescriptor, rawValue interface{}) (*Value, error) {
+	if schemaField.Type == ValueType_struct {
+		structInfo, ok := schema.Structs[schemaField.StructName]
+		if !ok {
+			return nil, fmt.Errorf("field '%s' refers to struct '%s', which isn't in the schema", schemaField.Name, schemaField.StructName)
+		}
+		message, ok := rawValue.(*dynamic.Message)
+		if !ok {
+			return nil, fmt.Errorf("field '%s' contained unknown field type '%T' with value: %v", fieldDescriptor.GetName(), rawValue, rawValue)
+		}
+		values, err := convertDynamicMessageIntoValues(schema, structInfo.Fields, message)
+		if err != nil {
+			return nil, err
+		}
+		return &Value{
+			Type: ValueType_struct,
+			StructValue: &StructValue{
+				Values: values,
+			},
+		}, nil
+	}
+
+	if schemaField.Type == ValueType_enum {
+		enumInfo, ok := schema.Enums[schemaField.EnumName]
+		if !ok {
+			return nil, fmt.Errorf("field '%s' refers to enum '%s', which isn't in the schema", schemaField.Name, schemaField.EnumName)
+		}
+		number, _ := rawValue.(int32)
+		enumValue := findSchemaEnumValueByNumber(enumInfo, number)
+		if enumValue == nil {
+			return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("field '%s' has the value %d, which isn't a value of enum '%s'", schemaField.Name, number, schemaField.EnumName))
+		}
+		return &Value{
+			Type:        ValueType_enum,
+			StringValue: enumValue.Name,
+		}, nil
+	}
+
+	switch value := rawValue.(type) {
+	case float64:
+		return &Value{
+			Type:        ValueType_double,
+			DoubleValue: value,
+		}, nil
+	case int64:
+		return &Value{
+			Type:       ValueType_int64,
+			Int64Value: value,
+		}, nil
+	case string:
+		return &Value{
+			Type:        ValueType_string,
+			StringValue: value,
+		}, nil
+	case bool:
+		return &Value{
+			Type:         ValueType_boolean,
+			BooleanValue: value,
+		}, nil
+	case []byte:
+		return &Value{
+			Type:       ValueType_bytes,
+			BytesValue: value,
+		}, nil
+	case uint64:
+		// We store uint64 as int64 inside Firestore, as Firestore
+		// does not support uint64 natively
+		return &Value{
+			Type:        ValueType_uint64,
+			Uint64Value: value,
+		}, nil
+	case *timestamp.Timestamp:
+		return &Value{
+			Type:           ValueType_timestamp,
+			TimestampValue: value,
+		}, nil
+	case *Key:
+		return &Value{
+			Type:     ValueType_key,
+			KeyValue: value,
+		}, nil
+	case *DecimalValue:
+		return &Value{
+			Type:         ValueType_decimal,
+			DecimalValue: value,
+		}, nil
+	case *duration.Duration:
+		return &Value{
+			Type:          ValueType_duration,
+			DurationValue: value,
+		}, nil
+	case *GeoPoint:
+		return &Value{
+			Type:          ValueType_geopoint,
+			GeopointValue: value,
+		}, nil
+	case *structpb.Struct:
+		return &Value{
+			Type:      ValueType_json,
+			JsonValue: value,
+		}, nil
+	case *wrappers.DoubleValue:
+		return &Value{
+			Type:        ValueType_double,
+			DoubleValue: value.GetValue(),
+		}, nil
+	case *wrappers.Int64Value:
+		return &Value{
+			Type:       ValueType_int64,
+			Int64Value: value.GetValue(),
+		}, nil
+	case *wrappers.UInt64Value:
+		return &Value{
+			Type:        ValueType_uint64,
+			Uint64Value: value.GetValue(),
+		}, nil
+	case *wrappers.StringValue:
+		return &Value{
+			Type:        ValueType_string,
+			StringValue: value.GetValue(),
+		}, nil
+	case *wrappers.BoolValue:
+		return &Value{
+			Type:         ValueType_boolean,
+			BooleanValue: value.GetValue(),
+		}, nil
+	case *wrappers.BytesValue:
+		return &Value{
+			Type:       ValueType_bytes,
+			BytesValue: value.GetValue(),
+		}, nil
+	}
+	return nil, fmt.Errorf("field '%s' contained unknown field type '%T' with value: %v", fieldDescriptor.GetName(), rawValue, rawValue)
 }
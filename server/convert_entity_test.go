@@ -135,7 +135,8 @@ func verifyEntityIntact(t *testing.T, entity *MetaEntity, ty EntityTestType) {
 	assert.NilError(t, err)
 	client, err := app.Firestore(ctx)
 	assert.NilError(t, err)
-	defer client.Close()
+	storage := createFirestoreStorage(client)
+	defer storage.Close()
 
 	// generate schema
 	genResult, err := generate("./schema.json")
@@ -149,14 +150,15 @@ func verifyEntityIntact(t *testing.T, entity *MetaEntity, ty EntityTestType) {
 			genResult.MessageMap["UnitTest001"],
 			entity,
 			genResult.CommonMessageDescriptors,
+			genResult.Schema,
 			genResult.KindMap[genResult.ServiceMap["UnitTest001"]],
 		)
 		assert.NilError(t, err)
 		resultEntity, err := convertDynamicMessageIntoMetaEntity(
-			client,
 			messageFactory,
 			genResult.MessageMap["UnitTest001"],
 			message,
+			genResult.Schema,
 			genResult.KindMap[genResult.ServiceMap["UnitTest001"]],
 		)
 		assert.NilError(t, err)
@@ -166,20 +168,27 @@ func verifyEntityIntact(t *testing.T, entity *MetaEntity, ty EntityTestType) {
 	}
 
 	if ty == EntityTestType_All || ty == EntityTestType_FirestoreOnly {
-		// convert key meta -> ref/map -> firestore -> meta
-		ref, data, err := convertMetaEntityToRefAndDataMap(
-			client,
+		// convert key meta -> key/map -> firestore -> meta
+		key, data, err := convertMetaEntityToKeyAndDataMap(
 			entity,
+			genResult.Schema,
 			genResult.KindMap[genResult.ServiceMap["UnitTest001"]],
 		)
 		assert.NilError(t, err)
 		// this technically writes to and reads from firestore for the test,
 		// which actually allows us to test persistent to firestore as well
-		_, err = ref.Set(context.Background(), data)
+		err = storage.RunTransaction(ctx, func(ctx context.Context, tx storageTransaction) error {
+			return tx.Set(key, data)
+		})
 		assert.NilError(t, err)
-		snapshot, err := ref.Get(context.Background())
+		var snapshot *storageSnapshot
+		err = storage.RunTransaction(ctx, func(ctx context.Context, tx storageTransaction) error {
+			snapshot, err = tx.Get(key)
+			return err
+		})
 		assert.NilError(t, err)
 		resultEntity2, err := convertSnapshotToMetaEntity(
+			genResult.Schema,
 			genResult.KindMap[genResult.ServiceMap["UnitTest001"]],
 			snapshot,
 		)
@@ -3,6 +3,7 @@ package main
 import (
 	"fmt"
 
+	wrappers "github.com/golang/protobuf/ptypes/wrappers"
 	"github.com/jhump/protoreflect/desc"
 	"github.com/jhump/protoreflect/dynamic"
 )
@@ -12,13 +13,35 @@ func convertMetaEntityToDynamicMessage(
 	messageDescriptor *desc.MessageDescriptor,
 	entity *MetaEntity,
 	common *commonMessageDescriptors,
+	schema *Schema,
 	schemaKind *SchemaKind,
 ) (*dynamic.Message, error) {
 	out := messageFactory.NewDynamicMessage(messageDescriptor)
 	out.SetFieldByName("key", entity.Key)
+	out.SetFieldByName(entityVersionFieldName, entity.Version)
+	if len(entity.FieldViolations) > 0 {
+		out.SetFieldByName(entityFieldViolationsFieldName, entity.FieldViolations)
+	}
+
+	setDynamicMessageValues(messageFactory, out, schema, schemaKind.Fields, entity.Values)
+
+	return out, nil
+}
 
-	for _, value := range entity.Values {
-		field := findSchemaFieldByID(schemaKind, value.Id)
+// setDynamicMessageValues sets the fields of a kind or struct message from
+// values. Values for fields that aren't in fields are ignored.
+func setDynamicMessageValues(
+	messageFactory *dynamic.MessageFactory,
+	out *dynamic.Message,
+	schema *Schema,
+	fields []*SchemaField,
+	values []*Value,
+) {
+	for _, value := range values {
+		if value == nil {
+			continue
+		}
+		field := findSchemaFieldByID(fields, value.Id)
 		if field == nil {
 			// extra data not specified in the schema any more
 			// we can safely ignore this
@@ -26,49 +49,126 @@ func convertMetaEntityToDynamicMessage(
 		}
 
 		var err error
-		if value == nil {
-			err = out.TryClearFieldByName(field.Name)
-		} else {
-			switch field.Type {
-			case ValueType_double:
-				err = out.TrySetFieldByName(field.Name, value.DoubleValue)
-				break
-			case ValueType_int64:
-				err = out.TrySetFieldByName(field.Name, value.Int64Value)
-				break
-			case ValueType_string:
-				err = out.TrySetFieldByName(field.Name, value.StringValue)
-				break
-			case ValueType_timestamp:
-				if value.TimestampValue == nil {
-					err = out.TryClearFieldByName(field.Name)
-				} else {
-					err = out.TrySetFieldByName(field.Name, value.TimestampValue)
+		if field.Repeated {
+			elements := make([]interface{}, 0, len(value.ArrayValue))
+			for _, element := range value.ArrayValue {
+				if converted := convertValueToDynamicFieldValue(messageFactory, out, schema, field, element); converted != nil {
+					elements = append(elements, converted)
 				}
-				break
-			case ValueType_boolean:
-				err = out.TrySetFieldByName(field.Name, value.BooleanValue)
-				break
-			case ValueType_bytes:
-				err = out.TrySetFieldByName(field.Name, value.BytesValue)
-				break
-			case ValueType_key:
-				if value.KeyValue == nil {
-					err = out.TryClearFieldByName(field.Name)
-				} else {
-					err = out.TrySetFieldByName(field.Name, value.KeyValue)
+			}
+			err = out.TrySetFieldByName(field.Name, elements)
+		} else if field.Type == ValueType_map {
+			valueField := getMapValueField(field)
+			entries := make(map[interface{}]interface{}, len(value.MapValue))
+			for entryKey, entry := range value.MapValue {
+				if converted := convertValueToDynamicFieldValue(messageFactory, out, schema, valueField, entry); converted != nil {
+					entries[entryKey] = converted
 				}
-				break
-			case ValueType_uint64:
-				err = out.TrySetFieldByName(field.Name, value.Uint64Value)
-				break
 			}
+			err = out.TrySetFieldByName(field.Name, entries)
+		} else if converted := convertValueToDynamicFieldValue(messageFactory, out, schema, field, value); converted == nil {
+			err = out.TryClearFieldByName(field.Name)
+		} else {
+			err = out.TrySetFieldByName(field.Name, converted)
 		}
 
 		if err != nil {
 			fmt.Printf("warning: encountered error while retrieving data from field '%s' on entity of kind '%s' with ID '%s' from Firestore: %v\n", field.Name, "todo", "todo", err)
 		}
 	}
+}
 
-	return out, nil
+// convertValueToDynamicFieldValue returns the value of field, or an element
+// of a repeated or map field, as it's set on the dynamic message out. It returns nil
+// if the value is unset or null. The values of nullable fields of scalar types
+// are wrapped in the google.protobuf wrapper types.
+func convertValueToDynamicFieldValue(
+	messageFactory *dynamic.MessageFactory,
+	out *dynamic.Message,
+	schema *Schema,
+	field *SchemaField,
+	value *Value,
+) interface{} {
+	if value == nil || value.NullValue {
+		return nil
+	}
+	if field.Nullable {
+		switch field.Type {
+		case ValueType_double:
+			return &wrappers.DoubleValue{Value: value.DoubleValue}
+		case ValueType_int64:
+			return &wrappers.Int64Value{Value: value.Int64Value}
+		case ValueType_uint64:
+			return &wrappers.UInt64Value{Value: value.Uint64Value}
+		case ValueType_string:
+			return &wrappers.StringValue{Value: value.StringValue}
+		case ValueType_boolean:
+			return &wrappers.BoolValue{Value: value.BooleanValue}
+		case ValueType_bytes:
+			return &wrappers.BytesValue{Value: value.BytesValue}
+		}
+	}
+	switch field.Type {
+	case ValueType_double:
+		return value.DoubleValue
+	case ValueType_int64:
+		return value.Int64Value
+	case ValueType_string:
+		return value.StringValue
+	case ValueType_timestamp:
+		if value.TimestampValue == nil {
+			return nil
+		}
+		return value.TimestampValue
+	case ValueType_boolean:
+		return value.BooleanValue
+	case ValueType_bytes:
+		return value.BytesValue
+	case ValueType_key:
+		if value.KeyValue == nil {
+			return nil
+		}
+		return value.KeyValue
+	case ValueType_uint64:
+		return value.Uint64Value
+	case ValueType_decimal:
+		if value.DecimalValue == nil {
+			return nil
+		}
+		return value.DecimalValue
+	case ValueType_duration:
+		if value.DurationValue == nil {
+			return nil
+		}
+		return value.DurationValue
+	case ValueType_geopoint:
+		if value.GeopointValue == nil {
+			return nil
+		}
+		return value.GeopointValue
+	case ValueType_json:
+		if value.JsonValue == nil {
+			return nil
+		}
+		return value.JsonValue
+	case ValueType_enum:
+		enumValue := findSchemaEnumValueByName(schema.Enums[field.EnumName], value.StringValue)
+		if enumValue == nil {
+			return nil
+		}
+		return enumValue.Number
+	case ValueType_struct:
+		structInfo, ok := schema.Structs[field.StructName]
+		fieldDescriptor := out.FindFieldDescriptorByName(field.Name)
+		if fieldDescriptor != nil && fieldDescriptor.IsMap() {
+			fieldDescriptor = fieldDescriptor.GetMapValueType()
+		}
+		if value.StructValue == nil || !ok || fieldDescriptor == nil || fieldDescriptor.GetMessageType() == nil {
+			return nil
+		}
+		nested := messageFactory.NewDynamicMessage(fieldDescriptor.GetMessageType())
+		setDynamicMessageValues(messageFactory, nested, schema, structInfo.Fields, value.StructValue.Values)
+		return nested
+	}
+	return nil
 }
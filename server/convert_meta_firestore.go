@@ -15,25 +15,14 @@ func convertMetaKeyToDocumentRef(
 	}
 
 	namespace := key.PartitionId.Namespace
-
-	firestoreTestCollection := client.Collection("Test")
-	firestoreNamespace := firestoreTestCollection.Path[0:(len(firestoreTestCollection.Path) - len(firestoreTestCollection.ID) - 1)]
-
-	if namespace == "" {
-		namespace = firestoreNamespace
-	}
-	if namespace != firestoreNamespace {
-		return nil, fmt.Errorf("namespace must be either omitted, or match '%s' for this Firestore-backed entity", firestoreNamespace)
+	namespaceRef, err := getFirestoreNamespaceRef(client, namespace)
+	if err != nil {
+		return nil, err
 	}
 
-	var ref *firestore.DocumentRef
+	ref := namespaceRef
 	for _, pathElement := range key.Path {
-		var collectionRef *firestore.CollectionRef
-		if ref == nil {
-			collectionRef = client.Collection(pathElement.Kind)
-		} else {
-			collectionRef = ref.Collection(pathElement.Kind)
-		}
+		collectionRef := getFirestoreCollectionRef(client, ref, pathElement.Kind)
 
 		if pathElement.IdType == nil {
 			// unset, automatically generate an ID
@@ -49,9 +38,38 @@ func convertMetaKeyToDocumentRef(
 			}
 		}
 	}
-	if ref == nil {
+	if ref == nil || ref == namespaceRef {
 		return nil, fmt.Errorf("inbound key did not contain any path components: namespace '%s'", namespace)
 	}
 
 	return ref, nil
 }
+
+// firestoreNamespaceCollection is the top-level collection that holds
+// entities of non-default namespaces; each namespace is a document in this
+// collection, and its entities live in subcollections of that document.
+const firestoreNamespaceCollection = "ConfigstoreNamespace"
+
+func getFirestoreDefaultNamespace(client *firestore.Client) string {
+	firestoreTestCollection := client.Collection("Test")
+	return firestoreTestCollection.Path[0:(len(firestoreTestCollection.Path) - len(firestoreTestCollection.ID) - 1)]
+}
+
+// getFirestoreNamespaceRef returns the document that entities in namespace
+// are stored under, or nil for the default namespace.
+func getFirestoreNamespaceRef(client *firestore.Client, namespace string) (*firestore.DocumentRef, error) {
+	if namespace == "" || namespace == getFirestoreDefaultNamespace(client) {
+		return nil, nil
+	}
+	if !isValidNamespaceName(namespace) {
+		return nil, fmt.Errorf("namespace '%s' is not a valid namespace name; namespaces must be omitted, match '%s', or consist of up to 100 letters, digits, '_', '-' or '.'", namespace, getFirestoreDefaultNamespace(client))
+	}
+	return client.Collection(firestoreNamespaceCollection).Doc(namespace), nil
+}
+
+func getFirestoreCollectionRef(client *firestore.Client, parent *firestore.DocumentRef, kindName string) *firestore.CollectionRef {
+	if parent == nil {
+		return client.Collection(kindName)
+	}
+	return parent.Collection(kindName)
+}
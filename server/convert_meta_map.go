@@ -1,101 +1,166 @@
 package main
 
 import (
-	"cloud.google.com/go/firestore"
+	"fmt"
 
 	"github.com/google/uuid"
 )
 
-func convertMetaEntityToRefAndDataMap(
-	client *firestore.Client,
+func convertMetaEntityToKeyAndDataMap(
 	entity *MetaEntity,
-	schema *SchemaKind,
-) (*firestore.DocumentRef, map[string]interface{}, error) {
-	key, err := convertMetaKeyToDocumentRef(
-		client,
-		entity.Key,
-	)
-	if err != nil {
-		return nil, nil, err
+	schema *Schema,
+	kindInfo *SchemaKind,
+) (*Key, map[string]interface{}, error) {
+	if entity.Key == nil || entity.Key.PartitionId == nil {
+		return nil, nil, fmt.Errorf("key or key partition ID is nil; if the caller wants to allow nil keys, it must check to see if the input key is nil first")
 	}
+	key := entity.Key
 
-	m := make(map[string]interface{})
+	violations := checkStorableValues(schema, getKeyKindName(key), "", kindInfo.Fields, entity.Values)
+	if len(violations) > 0 {
+		return nil, nil, createValidationError(getKeyKindName(key), violations)
+	}
+
+	m := convertMetaValuesToDataMap(schema, kindInfo.Fields, entity.Values)
 	u, err := uuid.NewRandom()
 	if err != nil {
 		return nil, nil, err
 	}
 	m["_forceFirestoreSnapshotGeneration"] = u.String()
 
-	for _, value := range entity.Values {
-		name := ""
-		for _, field := range schema.Fields {
-			if field.Id == value.Id {
-				name = field.Name
-				break
-			}
-		}
-		if name == "" {
+	// readonly fields are enforced against the stored entity by
+	// checkReadonlyFields in the update operation
+
+	return key, m, nil
+}
+
+// convertMetaValuesToDataMap returns the values as they are stored in an
+// entity's data map, or in the map of a struct value. Values for fields that
+// aren't in fields are dropped.
+func convertMetaValuesToDataMap(schema *Schema, fields []*SchemaField, values []*Value) map[string]interface{} {
+	m := make(map[string]interface{})
+	for _, value := range values {
+		field := findSchemaFieldByID(fields, value.Id)
+		if field == nil {
 			// field not found?
 			continue
 		}
 
-		switch value.Type {
-		case ValueType_double:
-			m[name] = value.DoubleValue
-			break
-		case ValueType_int64:
-			m[name] = value.Int64Value
-			break
-		case ValueType_string:
-			m[name] = value.StringValue
-			break
-		case ValueType_timestamp:
-			m[name] = value.TimestampValue
-			break
-		case ValueType_boolean:
-			m[name] = value.BooleanValue
-			break
-		case ValueType_bytes:
-			m[name] = value.BytesValue
-			break
-		case ValueType_key:
-			if value.KeyValue == nil {
-				m[name] = nil
-			} else {
-				ref, err := convertMetaKeyToDocumentRef(
-					client,
-					value.KeyValue,
-				)
-				if err != nil {
-					return nil, nil, err
-				}
-				m[name] = ref
-			}
-			break
-		case ValueType_uint64:
-			// We store uint64 as int64 inside Firestore, as Firestore
-			// does not support uint64 natively
-			m[name] = int64(value.Uint64Value)
-			break
-		default:
-			// todo, log?
-			break
+		if converted, ok := convertFieldValueToDataMapValue(schema, field, value); ok {
+			m[field.Name] = converted
 		}
 	}
+	return m
+}
 
-	// TODO: implement readonly safety
-	/*
-		if currentSnapshot != nil {
-			for _, field := range schemaKind.Fields {
-				if field.Readonly {
-					// Verify that the property hasn't changed.
-					if reflect.DeepEqual(m[field.Name], currentSnapshot.Data()[field.Name]) {
-						return nil, nil, fmt.Errorf("readonly field '%s' contains mutated value", field.Name)
-					}
-				}
+// convertFieldValueToDataMapValue returns the value of field as it is stored
+// in a data map. The elements of repeated fields and the entries of map
+// fields are converted as the field's value type, and unset values of
+// message types, like keys and structs, are dropped from them. Null values
+// are stored as nil.
+func convertFieldValueToDataMapValue(schema *Schema, field *SchemaField, value *Value) (interface{}, bool) {
+	if value.NullValue {
+		// only nullable fields can be null, which checkStorableValues
+		// checks before values are converted
+		return nil, true
+	}
+	if field.Type == ValueType_map {
+		valueField := getMapValueField(field)
+		entries := make(map[string]interface{}, len(value.MapValue))
+		for entryKey, entry := range value.MapValue {
+			if entry == nil {
+				continue
 			}
+			if converted, ok := convertElementValueToDataMapValue(schema, valueField, entry); ok && converted != nil {
+				entries[entryKey] = converted
+			}
+		}
+		return entries, true
+	}
+	if !field.Repeated {
+		return convertElementValueToDataMapValue(schema, field, value)
+	}
+	elements := make([]interface{}, 0, len(value.ArrayValue))
+	for _, element := range value.ArrayValue {
+		if element == nil {
+			continue
+		}
+		if converted, ok := convertElementValueToDataMapValue(schema, field, element); ok && converted != nil {
+			elements = append(elements, converted)
 		}
-	*/
+	}
+	return elements, true
+}
 
-	return key, m, nil
+func convertElementValueToDataMapValue(schema *Schema, field *SchemaField, value *Value) (interface{}, bool) {
+	// values that can't be stored are rejected by checkStorableValues before
+	// they're converted
+	switch field.Type {
+	case ValueType_decimal:
+		scaled, _ := convertDecimalToScaledInt64(value.DecimalValue, field.DecimalScale)
+		return scaled, true
+	case ValueType_duration:
+		if value.DurationValue == nil {
+			return nil, true
+		}
+		nanoseconds, _ := convertDurationToNanoseconds(value.DurationValue)
+		return nanoseconds, true
+	case ValueType_geopoint:
+		if value.GeopointValue == nil {
+			return nil, true
+		}
+		return convertGeoPointToLatLng(value.GeopointValue), true
+	case ValueType_json:
+		if value.JsonValue == nil {
+			return nil, true
+		}
+		return convertJSONStructToDataMap(value.JsonValue), true
+	}
+	if field.Type != ValueType_struct {
+		return convertTypedValueToDataMapValue(field.Type, value)
+	}
+	structInfo, ok := schema.Structs[field.StructName]
+	if !ok {
+		return nil, false
+	}
+	if value.StructValue == nil {
+		return nil, true
+	}
+	return convertMetaValuesToDataMap(schema, structInfo.Fields, value.StructValue.Values), true
+}
+
+// convertValueToDataMapValue returns the value as it is stored in an entity's
+// data map, or false if the value type isn't supported.
+func convertValueToDataMapValue(value *Value) (interface{}, bool) {
+	return convertTypedValueToDataMapValue(value.Type, value)
+}
+
+func convertTypedValueToDataMapValue(valueType ValueType, value *Value) (interface{}, bool) {
+	switch valueType {
+	case ValueType_double:
+		return value.DoubleValue, true
+	case ValueType_int64:
+		return value.Int64Value, true
+	case ValueType_string, ValueType_enum:
+		return value.StringValue, true
+	case ValueType_timestamp:
+		if value.TimestampValue == nil {
+			return nil, true
+		}
+		return convertTimestampToTime(value.TimestampValue), true
+	case ValueType_boolean:
+		return value.BooleanValue, true
+	case ValueType_bytes:
+		return value.BytesValue, true
+	case ValueType_key:
+		if value.KeyValue == nil {
+			return nil, true
+		}
+		return value.KeyValue, true
+	case ValueType_uint64:
+		// We store uint64 as int64 inside Firestore, as Firestore
+		// does not support uint64 natively
+		return int64(value.Uint64Value), true
+	}
+	return nil, false
 }
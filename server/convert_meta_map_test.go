@@ -0,0 +1,586 @@
+package main
+
+import (
+	"context"
+	"math"
+
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	duration "github.com/golang/protobuf/ptypes/duration"
+	structpb "github.com/golang/protobuf/ptypes/struct"
+	"github.com/jhump/protoreflect/dynamic"
+	"google.golang.org/genproto/googleapis/type/latlng"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gotest.tools/assert"
+)
+
+func createStructTestAddress(id int32, city string) *Value {
+	return &Value{
+		Id:   id,
+		Type: ValueType_struct,
+		StructValue: &StructValue{
+			Values: []*Value{
+				&Value{
+					Id:          1,
+					Type:        ValueType_string,
+					StringValue: "1 Example Street",
+				},
+				&Value{
+					Id:          2,
+					Type:        ValueType_string,
+					StringValue: city,
+				},
+				&Value{
+					Id:   3,
+					Type: ValueType_timestamp,
+				},
+				&Value{
+					Id:   4,
+					Type: ValueType_key,
+				},
+			},
+		},
+	}
+}
+
+func TestStructFields(t *testing.T) {
+	ctx := context.Background()
+
+	genResult, err := generate("./schema.json")
+	assert.NilError(t, err)
+
+	storage := createMemoryStorage()
+	defer storage.Close()
+	processor := createTransactionProcessor(storage)
+
+	previousAddresses := &Value{
+		Id:   3,
+		Type: ValueType_struct,
+		ArrayValue: []*Value{
+			createStructTestAddress(0, "Brisbane"),
+			createStructTestAddress(0, ""),
+		},
+	}
+	resp, err := processor.processTransaction(ctx, genResult.Schema, &MetaTransaction{
+		Operations: []*MetaOperation{
+			createTestOperation(createTestKey("StructTest", "s1"), createStructTestAddress(2, "Sydney")),
+			createTestOperation(createTestKey("StructTest", "s2"), createStructTestAddress(2, "Sydney"), previousAddresses),
+			createTestOperation(createTestKey("StructTest", "s3")),
+		},
+	})
+	assert.NilError(t, err)
+	assert.Equal(t, getOperationResultCode(resp.OperationResults[0]), codes.OK)
+	assert.Equal(t, getOperationResultCode(resp.OperationResults[1]), codes.InvalidArgument)
+	assert.Equal(t, resp.OperationResults[1].Error.FieldViolations[0].FieldName, "previousAddresses.1.city")
+	assert.Equal(t, getOperationResultCode(resp.OperationResults[2]), codes.InvalidArgument)
+	assert.Equal(t, resp.OperationResults[2].Error.FieldViolations[0].FieldName, "address")
+
+	// structs are stored as maps, keyed by field name
+	snapshots, err := storage.Query(ctx, &storageQuery{KindName: "StructTest"})
+	assert.NilError(t, err)
+	assert.Equal(t, len(snapshots), 1)
+	address, ok := snapshots[0].Data["address"].(map[string]interface{})
+	assert.Assert(t, ok)
+	assert.Equal(t, address["city"], "Sydney")
+
+	kindInfo := genResult.Schema.Kinds["StructTest"]
+	entity, err := convertSnapshotToMetaEntity(genResult.Schema, kindInfo, snapshots[0])
+	assert.NilError(t, err)
+	assert.DeepEqual(t, entity.Values[0], createStructTestAddress(2, "Sydney"))
+
+	// and as nested messages in the generated services
+	messageFactory := dynamic.NewMessageFactoryWithDefaults()
+	entity.Values = append(entity.Values, previousAddresses)
+	message, err := convertMetaEntityToDynamicMessage(
+		messageFactory,
+		genResult.MessageMap["StructTest"],
+		entity,
+		genResult.CommonMessageDescriptors,
+		genResult.Schema,
+		kindInfo,
+	)
+	assert.NilError(t, err)
+	resultEntity, err := convertDynamicMessageIntoMetaEntity(
+		messageFactory,
+		genResult.MessageMap["StructTest"],
+		message,
+		genResult.Schema,
+		kindInfo,
+	)
+	assert.NilError(t, err)
+	assert.Equal(t, len(resultEntity.Values), 2)
+	assert.DeepEqual(t, resultEntity.Values[0], createStructTestAddress(2, "Sydney"))
+	assert.Equal(t, len(resultEntity.Values[1].ArrayValue), 2)
+	assert.DeepEqual(t, resultEntity.Values[1].ArrayValue[0].StructValue, createStructTestAddress(0, "Brisbane").StructValue)
+}
+
+func TestMapFields(t *testing.T) {
+	ctx := context.Background()
+
+	genResult, err := generate("./schema.json")
+	assert.NilError(t, err)
+
+	storage := createMemoryStorage()
+	defer storage.Close()
+	processor := createTransactionProcessor(storage)
+
+	limits := &Value{
+		Id:   2,
+		Type: ValueType_map,
+		MapValue: map[string]*Value{
+			"cpu":    &Value{Type: ValueType_int64, Int64Value: 4},
+			"memory": &Value{Type: ValueType_int64, Int64Value: 1024},
+		},
+	}
+	invalidAddresses := &Value{
+		Id:   3,
+		Type: ValueType_map,
+		MapValue: map[string]*Value{
+			"home": createStructTestAddress(0, ""),
+		},
+	}
+	resp, err := processor.processTransaction(ctx, genResult.Schema, &MetaTransaction{
+		Operations: []*MetaOperation{
+			createTestOperation(createTestKey("MapTest", "m1"), limits),
+			createTestOperation(createTestKey("MapTest", "m2"), invalidAddresses),
+		},
+	})
+	assert.NilError(t, err)
+	assert.Equal(t, getOperationResultCode(resp.OperationResults[0]), codes.OK)
+	assert.Equal(t, getOperationResultCode(resp.OperationResults[1]), codes.InvalidArgument)
+	assert.Equal(t, resp.OperationResults[1].Error.FieldViolations[0].FieldName, "addresses.home.city")
+
+	// a single entry can be updated or removed with a field mask, leaving
+	// the other entries as they are stored
+	resp, err = processor.processTransaction(ctx, genResult.Schema, &MetaTransaction{
+		Operations: []*MetaOperation{
+			&MetaOperation{
+				Operation: &MetaOperation_UpdateRequest{
+					UpdateRequest: &MetaUpdateEntityRequest{
+						Entity: &MetaEntity{
+							Key: createTestKey("MapTest", "m1"),
+							Values: []*Value{
+								&Value{
+									Id:   2,
+									Type: ValueType_map,
+									MapValue: map[string]*Value{
+										"disk": &Value{Type: ValueType_int64, Int64Value: 20},
+									},
+								},
+							},
+						},
+						FieldMask: []string{"limits.disk", "limits.cpu"},
+					},
+				},
+			},
+		},
+	})
+	assert.NilError(t, err)
+	assert.Equal(t, getOperationResultCode(resp.OperationResults[0]), codes.OK)
+
+	snapshots, err := storage.Query(ctx, &storageQuery{KindName: "MapTest"})
+	assert.NilError(t, err)
+	assert.Equal(t, len(snapshots), 1)
+	assert.DeepEqual(t, snapshots[0].Data["limits"], map[string]interface{}{
+		"disk":   int64(20),
+		"memory": int64(1024),
+	})
+
+	// and maps are converted to and from the generated services
+	kindInfo := genResult.Schema.Kinds["MapTest"]
+	entity, err := convertSnapshotToMetaEntity(genResult.Schema, kindInfo, snapshots[0])
+	assert.NilError(t, err)
+	entity.Values = append(entity.Values, &Value{
+		Id:   3,
+		Type: ValueType_map,
+		MapValue: map[string]*Value{
+			"home": createStructTestAddress(0, "Sydney"),
+		},
+	})
+	messageFactory := dynamic.NewMessageFactoryWithDefaults()
+	message, err := convertMetaEntityToDynamicMessage(
+		messageFactory,
+		genResult.MessageMap["MapTest"],
+		entity,
+		genResult.CommonMessageDescriptors,
+		genResult.Schema,
+		kindInfo,
+	)
+	assert.NilError(t, err)
+	resultEntity, err := convertDynamicMessageIntoMetaEntity(
+		messageFactory,
+		genResult.MessageMap["MapTest"],
+		message,
+		genResult.Schema,
+		kindInfo,
+	)
+	assert.NilError(t, err)
+	assert.Equal(t, len(resultEntity.Values), 2)
+	assert.Equal(t, resultEntity.Values[0].MapValue["disk"].Int64Value, int64(20))
+	assert.Equal(t, resultEntity.Values[0].MapValue["memory"].Int64Value, int64(1024))
+	assert.DeepEqual(t, resultEntity.Values[1].MapValue["home"].StructValue, createStructTestAddress(0, "Sydney").StructValue)
+}
+
+func TestEnumFields(t *testing.T) {
+	ctx := context.Background()
+
+	genResult, err := generate("./schema.json")
+	assert.NilError(t, err)
+
+	storage := createMemoryStorage()
+	defer storage.Close()
+	processor := createTransactionProcessor(storage)
+
+	resp, err := processor.processTransaction(ctx, genResult.Schema, &MetaTransaction{
+		Operations: []*MetaOperation{
+			createTestOperation(createTestKey("EnumTest", "e1"), &Value{
+				Id:          2,
+				Type:        ValueType_enum,
+				StringValue: "TIER_PRODUCTION",
+			}),
+			createTestOperation(createTestKey("EnumTest", "e2"), &Value{
+				Id:   3,
+				Type: ValueType_enum,
+				ArrayValue: []*Value{
+					&Value{Type: ValueType_enum, StringValue: "TIER_STAGING"},
+					&Value{Type: ValueType_enum, StringValue: "TIER_TESTING"},
+				},
+			}),
+		},
+	})
+	assert.NilError(t, err)
+	assert.Equal(t, getOperationResultCode(resp.OperationResults[0]), codes.OK)
+	assert.Equal(t, getOperationResultCode(resp.OperationResults[1]), codes.InvalidArgument)
+	assert.Equal(t, resp.OperationResults[1].Error.FieldViolations[0].Description, "element 1 must be one of: TIER_UNSPECIFIED, TIER_DEVELOPMENT, TIER_STAGING, TIER_PRODUCTION")
+
+	// enums are stored by name, so that they can be renumbered
+	snapshots, err := storage.Query(ctx, &storageQuery{KindName: "EnumTest"})
+	assert.NilError(t, err)
+	assert.Equal(t, len(snapshots), 1)
+	assert.Equal(t, snapshots[0].Data["tier"], "TIER_PRODUCTION")
+
+	// and by number in the generated services
+	kindInfo := genResult.Schema.Kinds["EnumTest"]
+	entity, err := convertSnapshotToMetaEntity(genResult.Schema, kindInfo, snapshots[0])
+	assert.NilError(t, err)
+	messageFactory := dynamic.NewMessageFactoryWithDefaults()
+	message, err := convertMetaEntityToDynamicMessage(
+		messageFactory,
+		genResult.MessageMap["EnumTest"],
+		entity,
+		genResult.CommonMessageDescriptors,
+		genResult.Schema,
+		kindInfo,
+	)
+	assert.NilError(t, err)
+	assert.Equal(t, message.GetFieldByName("tier"), int32(3))
+
+	// unset enums have the value numbered 0
+	message.ClearFieldByName("tier")
+	resultEntity, err := convertDynamicMessageIntoMetaEntity(
+		messageFactory,
+		genResult.MessageMap["EnumTest"],
+		message,
+		genResult.Schema,
+		kindInfo,
+	)
+	assert.NilError(t, err)
+	assert.Equal(t, resultEntity.Values[0].StringValue, "TIER_UNSPECIFIED")
+
+	message.SetFieldByName("tier", int32(7))
+	_, err = convertDynamicMessageIntoMetaEntity(
+		messageFactory,
+		genResult.MessageMap["EnumTest"],
+		message,
+		genResult.Schema,
+		kindInfo,
+	)
+	assert.Equal(t, status.Code(err), codes.InvalidArgument)
+}
+
+func TestDecimalFields(t *testing.T) {
+	ctx := context.Background()
+
+	genResult, err := generate("./schema.json")
+	assert.NilError(t, err)
+
+	storage := createMemoryStorage()
+	defer storage.Close()
+	processor := createTransactionProcessor(storage)
+
+	price := &Value{
+		Id:           2,
+		Type:         ValueType_decimal,
+		DecimalValue: &DecimalValue{Units: -12, Nanos: -340000000},
+	}
+	resp, err := processor.processTransaction(ctx, genResult.Schema, &MetaTransaction{
+		Operations: []*MetaOperation{
+			createTestOperation(createTestKey("DecimalTest", "d1"), price),
+			createTestOperation(createTestKey("DecimalTest", "d2"), &Value{
+				Id:           2,
+				Type:         ValueType_decimal,
+				DecimalValue: &DecimalValue{Units: 1, Nanos: 5000000},
+			}),
+			createTestOperation(createTestKey("DecimalTest", "d3"), price, &Value{
+				Id:   3,
+				Type: ValueType_map,
+				MapValue: map[string]*Value{
+					"AUD": &Value{Type: ValueType_decimal, DecimalValue: &DecimalValue{Units: 1, Nanos: 500000000}},
+					"USD": &Value{Type: ValueType_decimal, DecimalValue: &DecimalValue{Units: 1, Nanos: -500000000}},
+				},
+			}),
+			createTestOperation(createTestKey("DecimalTest", "d4"), &Value{
+				Id:           2,
+				Type:         ValueType_decimal,
+				DecimalValue: &DecimalValue{Units: math.MaxInt64/100 + 1},
+			}),
+		},
+	})
+	assert.NilError(t, err)
+	assert.Equal(t, getOperationResultCode(resp.OperationResults[0]), codes.OK)
+	assert.Equal(t, getOperationResultCode(resp.OperationResults[1]), codes.InvalidArgument)
+	assert.Equal(t, resp.OperationResults[1].Error.FieldViolations[0].Description, "must have at most 2 decimal places")
+	assert.Equal(t, getOperationResultCode(resp.OperationResults[2]), codes.InvalidArgument)
+	assert.Equal(t, resp.OperationResults[2].Error.FieldViolations[0].Description, "entry 'USD' units and nanos must have the same sign")
+	assert.Equal(t, getOperationResultCode(resp.OperationResults[3]), codes.InvalidArgument)
+	assert.Equal(t, resp.OperationResults[3].Error.FieldViolations[0].Description, "is too large to store with 2 decimal places")
+
+	// decimals are stored as a count of the smallest unit at their scale
+	snapshots, err := storage.Query(ctx, &storageQuery{KindName: "DecimalTest"})
+	assert.NilError(t, err)
+	assert.Equal(t, len(snapshots), 1)
+	assert.Equal(t, snapshots[0].Data["price"], int64(-1234))
+
+	kindInfo := genResult.Schema.Kinds["DecimalTest"]
+	entity, err := convertSnapshotToMetaEntity(genResult.Schema, kindInfo, snapshots[0])
+	assert.NilError(t, err)
+	assert.DeepEqual(t, entity.Values[0], price)
+}
+
+func TestDurationGeoPointAndJSONFields(t *testing.T) {
+	ctx := context.Background()
+
+	genResult, err := generate("./schema.json")
+	assert.NilError(t, err)
+
+	storage := createMemoryStorage()
+	defer storage.Close()
+	processor := createTransactionProcessor(storage)
+
+	timeout := &Value{
+		Id:            2,
+		Type:          ValueType_duration,
+		DurationValue: &duration.Duration{Seconds: -1, Nanos: -500000000},
+	}
+	location := &Value{
+		Id:            3,
+		Type:          ValueType_geopoint,
+		GeopointValue: &GeoPoint{Latitude: -33.8688, Longitude: 151.2093},
+	}
+	settings := &Value{
+		Id:   4,
+		Type: ValueType_json,
+		JsonValue: &structpb.Struct{
+			Fields: map[string]*structpb.Value{
+				"name":    &structpb.Value{Kind: &structpb.Value_StringValue{StringValue: "a"}},
+				"enabled": &structpb.Value{Kind: &structpb.Value_BoolValue{BoolValue: true}},
+				"ports": &structpb.Value{Kind: &structpb.Value_ListValue{ListValue: &structpb.ListValue{
+					Values: []*structpb.Value{
+						&structpb.Value{Kind: &structpb.Value_NumberValue{NumberValue: 80}},
+						&structpb.Value{Kind: &structpb.Value_NullValue{}},
+					},
+				}}},
+			},
+		},
+	}
+	resp, err := processor.processTransaction(ctx, genResult.Schema, &MetaTransaction{
+		Operations: []*MetaOperation{
+			createTestOperation(createTestKey("TypesTest", "t1"), timeout, location, settings),
+			createTestOperation(createTestKey("TypesTest", "t2"), timeout, &Value{
+				Id:            3,
+				Type:          ValueType_geopoint,
+				GeopointValue: &GeoPoint{Latitude: 91},
+			}),
+			createTestOperation(createTestKey("TypesTest", "t3"), timeout, &Value{
+				Id:   4,
+				Type: ValueType_json,
+				JsonValue: &structpb.Struct{
+					Fields: map[string]*structpb.Value{
+						"matrix": &structpb.Value{Kind: &structpb.Value_ListValue{ListValue: &structpb.ListValue{
+							Values: []*structpb.Value{
+								&structpb.Value{Kind: &structpb.Value_ListValue{ListValue: &structpb.ListValue{}}},
+							},
+						}}},
+					},
+				},
+			}),
+			createTestOperation(createTestKey("TypesTest", "t4"), &Value{
+				Id:            2,
+				Type:          ValueType_duration,
+				DurationValue: &duration.Duration{Seconds: 1, Nanos: -1},
+			}),
+			createTestOperation(createTestKey("TypesTest", "t5"), location),
+		},
+	})
+	assert.NilError(t, err)
+	assert.Equal(t, getOperationResultCode(resp.OperationResults[0]), codes.OK)
+	assert.Equal(t, getOperationResultCode(resp.OperationResults[1]), codes.InvalidArgument)
+	assert.Equal(t, resp.OperationResults[1].Error.FieldViolations[0].Description, "latitude must be between -90 and 90")
+	assert.Equal(t, getOperationResultCode(resp.OperationResults[2]), codes.InvalidArgument)
+	assert.Equal(t, resp.OperationResults[2].Error.FieldViolations[0].Description, "element 0 of a list is a list, but lists can't directly contain other lists")
+	assert.Equal(t, getOperationResultCode(resp.OperationResults[3]), codes.InvalidArgument)
+	assert.Equal(t, resp.OperationResults[3].Error.FieldViolations[0].Description, "seconds and nanos must have the same sign")
+	assert.Equal(t, getOperationResultCode(resp.OperationResults[4]), codes.InvalidArgument)
+	assert.Equal(t, resp.OperationResults[4].Error.FieldViolations[0].Description, "a non-zero value is required")
+
+	// durations are stored as nanoseconds, locations as GeoPoints and JSON
+	// as maps
+	snapshots, err := storage.Query(ctx, &storageQuery{KindName: "TypesTest"})
+	assert.NilError(t, err)
+	assert.Equal(t, len(snapshots), 1)
+	assert.Equal(t, snapshots[0].Data["timeout"], int64(-1500000000))
+	assert.Assert(t, proto.Equal(snapshots[0].Data["location"].(*latlng.LatLng), &latlng.LatLng{Latitude: -33.8688, Longitude: 151.2093}))
+	assert.DeepEqual(t, snapshots[0].Data["settings"], map[string]interface{}{
+		"name":    "a",
+		"enabled": true,
+		"ports":   []interface{}{float64(80), nil},
+	})
+
+	kindInfo := genResult.Schema.Kinds["TypesTest"]
+	entity, err := convertSnapshotToMetaEntity(genResult.Schema, kindInfo, snapshots[0])
+	assert.NilError(t, err)
+	assert.Equal(t, len(entity.Values), 3)
+	assert.Assert(t, proto.Equal(entity.Values[0], timeout))
+	assert.Assert(t, proto.Equal(entity.Values[1], location))
+	assert.Assert(t, proto.Equal(entity.Values[2], settings))
+}
+
+func TestNullableFields(t *testing.T) {
+	ctx := context.Background()
+
+	genResult, err := generate("./schema.json")
+	assert.NilError(t, err)
+
+	storage := createMemoryStorage()
+	defer storage.Close()
+	processor := createTransactionProcessor(storage)
+
+	resp, err := processor.processTransaction(ctx, genResult.Schema, &MetaTransaction{
+		Operations: []*MetaOperation{
+			createTestOperation(createTestKey("NullableTest", "n1"), &Value{
+				Id:        2,
+				Type:      ValueType_int64,
+				NullValue: true,
+			}, &Value{
+				Id:   4,
+				Type: ValueType_boolean,
+			}),
+			createTestOperation(createTestKey("NullableTest", "n2"), &Value{
+				Id:        6,
+				Type:      ValueType_double,
+				NullValue: true,
+			}),
+			createTestOperation(createTestKey("NullableTest", "n3"), &Value{
+				Id:         2,
+				Type:       ValueType_int64,
+				Int64Value: 0,
+			}),
+		},
+	})
+	assert.NilError(t, err)
+	assert.Equal(t, getOperationResultCode(resp.OperationResults[0]), codes.OK)
+	assert.Equal(t, getOperationResultCode(resp.OperationResults[1]), codes.InvalidArgument)
+	assert.Equal(t, resp.OperationResults[1].Error.FieldViolations[0].Description, "can't be null, because the field isn't nullable")
+	// validators only apply to nullable fields that aren't null
+	assert.Equal(t, getOperationResultCode(resp.OperationResults[2]), codes.InvalidArgument)
+	assert.Equal(t, resp.OperationResults[2].Error.FieldViolations[0].Description, "must be at least 1")
+
+	// null and unset nullable fields read as null, while explicit zero
+	// values and fields that aren't nullable read as their values
+	snapshots, err := storage.Query(ctx, &storageQuery{KindName: "NullableTest"})
+	assert.NilError(t, err)
+	assert.Equal(t, len(snapshots), 1)
+	entity, err := convertSnapshotToMetaEntity(genResult.Schema, genResult.Schema.Kinds["NullableTest"], snapshots[0])
+	assert.NilError(t, err)
+	assert.Equal(t, len(entity.Values), 4)
+	assert.Equal(t, entity.Values[0].Id, int32(2))
+	assert.Assert(t, entity.Values[0].NullValue)
+	assert.Equal(t, entity.Values[1].Id, int32(3))
+	assert.Assert(t, entity.Values[1].NullValue)
+	assert.Equal(t, entity.Values[2].Id, int32(4))
+	assert.Assert(t, !entity.Values[2].NullValue)
+	assert.Equal(t, entity.Values[2].BooleanValue, false)
+	assert.Equal(t, entity.Values[3].Id, int32(5))
+	assert.Assert(t, entity.Values[3].NullValue)
+
+	// values of the wrong type are reported rather than read as zero values
+	err = storage.RunTransaction(ctx, func(ctx context.Context, tx storageTransaction) error {
+		return tx.Create(createTestKey("NullableTest", "n4"), map[string]interface{}{
+			"replicas": "three",
+		})
+	})
+	assert.NilError(t, err)
+	resp, err = processor.processTransaction(ctx, genResult.Schema, &MetaTransaction{
+		Operations: []*MetaOperation{
+			&MetaOperation{
+				Operation: &MetaOperation_GetRequest{
+					GetRequest: &MetaGetEntityRequest{
+						KindName: "NullableTest",
+						Key:      createTestKey("NullableTest", "n4"),
+					},
+				},
+			},
+		},
+	})
+	assert.NilError(t, err)
+	assert.Equal(t, getOperationResultCode(resp.OperationResults[0]), codes.DataLoss)
+
+	// but listings return the entity with that field unset and reported
+	resp, err = processor.processTransaction(ctx, genResult.Schema, &MetaTransaction{
+		Operations: []*MetaOperation{
+			&MetaOperation{
+				Operation: &MetaOperation_ListRequest{
+					ListRequest: &MetaListEntitiesRequest{
+						KindName: "NullableTest",
+						Limit:    2,
+					},
+				},
+			},
+		},
+	})
+	assert.NilError(t, err)
+	assert.Equal(t, getOperationResultCode(resp.OperationResults[0]), codes.OK)
+	entities := resp.OperationResults[0].GetListResponse().Entities
+	assert.Equal(t, len(entities), 2)
+	assert.Assert(t, resp.OperationResults[0].GetListResponse().MoreResults)
+	assert.Equal(t, serializeKey(entities[1].Key), serializeKey(normalizeEntityKey(storage, createTestKey("NullableTest", "n4"))))
+	assert.Equal(t, len(entities[1].FieldViolations), 1)
+	assert.Equal(t, entities[1].FieldViolations[0].FieldName, "replicas")
+	assert.Equal(t, len(entities[1].Values), 3)
+	for _, value := range entities[1].Values {
+		assert.Assert(t, value.Id != 2)
+	}
+	assert.Equal(t, len(entities[0].FieldViolations), 0)
+
+	// and so do the generated services
+	message, err := convertMetaEntityToDynamicMessage(
+		dynamic.NewMessageFactoryWithDefaults(),
+		genResult.MessageMap["NullableTest"],
+		entities[1],
+		genResult.CommonMessageDescriptors,
+		genResult.Schema,
+		genResult.Schema.Kinds["NullableTest"],
+	)
+	assert.NilError(t, err)
+	violations := message.GetFieldByName(entityFieldViolationsFieldName).([]interface{})
+	assert.Equal(t, len(violations), 1)
+	assert.Equal(t, violations[0].(*MetaFieldViolation).FieldName, "replicas")
+
+	// lists, maps and enums can't be null
+	err = checkSchemaStructFields(genResult.Schema, "kind 'NullableTest'", []*SchemaField{
+		&SchemaField{Id: 2, Name: "tags", Type: ValueType_string, Repeated: true, Nullable: true},
+	})
+	assert.ErrorContains(t, err, "can't be nullable")
+}
@@ -0,0 +1,321 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"google.golang.org/genproto/googleapis/type/latlng"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func convertSnapshotToMetaEntity(schema *Schema, kindInfo *SchemaKind, snapshot *storageSnapshot) (*MetaEntity, error) {
+	entity := &MetaEntity{
+		Key: snapshot.Key,
+	}
+	entity.Version, _ = snapshot.Data[entityVersionDataField].(int64)
+	values, err := convertDataMapToValues(schema, kindInfo.Fields, snapshot.Data)
+	if err != nil {
+		return nil, err
+	}
+	entity.Values = values
+	return entity, nil
+}
+
+// convertDataMapToValues converts an entity's data map, or the map of a
+// struct value, into the values of fields. Nullable fields that aren't in the
+// map are null.
+func convertDataMapToValues(schema *Schema, fields []*SchemaField, data map[string]interface{}) ([]*Value, error) {
+	var values []*Value
+	for _, field := range fields {
+		if _, ok := data[field.Name]; !ok && field.Nullable {
+			values = append(values, &Value{
+				Id:        field.Id,
+				Type:      field.Type,
+				NullValue: true,
+			})
+		}
+	}
+	for key, value := range data {
+		for _, field := range fields {
+			if field.Name == key {
+				f, err := convertDataMapValueToValue(schema, field, value)
+				if err != nil {
+					return nil, err
+				}
+				values = append(values, f)
+				break
+			}
+		}
+	}
+
+	// sort, this is mainly so unit tests pass...
+	sort.Slice(values[:], func(i, j int) bool {
+		return values[i].Id < values[j].Id
+	})
+
+	return values, nil
+}
+
+// convertDataMapValueToValue converts a value from an entity's data map into
+// the value of field. The elements of repeated fields are converted one by one.
+// Null values read as the zero value of the field's type, unless the field is
+// nullable, and values of the wrong type are reported as data loss rather
+// than read as zero values.
+func convertDataMapValueToValue(schema *Schema, field *SchemaField, value interface{}) (*Value, error) {
+	f := &Value{
+		Id:   field.Id,
+		Type: field.Type,
+	}
+	if value == nil && field.Nullable {
+		f.NullValue = true
+		return f, nil
+	}
+	if field.Repeated {
+		elements, ok := value.([]interface{})
+		if !ok && value != nil {
+			return nil, createStorageTypeMismatchError(field, value)
+		}
+		f.ArrayValue = make([]*Value, 0, len(elements))
+		for _, element := range elements {
+			e := &Value{
+				Type: field.Type,
+			}
+			err := setValueFromDataMapValue(schema, field, e, element)
+			if err != nil {
+				return nil, err
+			}
+			f.ArrayValue = append(f.ArrayValue, e)
+		}
+		return f, nil
+	}
+	err := setValueFromDataMapValue(schema, field, f, value)
+	if err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+func setValueFromDataMapValue(schema *Schema, field *SchemaField, f *Value, value interface{}) error {
+	switch field.Type {
+	case ValueType_double:
+		switch v := value.(type) {
+		case float64:
+			f.DoubleValue = v
+		case int64:
+			// Firestore holds whole numbers written by other clients as
+			// integers
+			f.DoubleValue = float64(v)
+		case nil:
+			f.DoubleValue = 0
+		default:
+			return createStorageTypeMismatchError(field, value)
+		}
+	case ValueType_int64:
+		switch v := value.(type) {
+		case int64:
+			f.Int64Value = v
+		case nil:
+			f.Int64Value = 0
+		default:
+			return createStorageTypeMismatchError(field, value)
+		}
+	case ValueType_uint64:
+		switch v := value.(type) {
+		case int64:
+			f.Uint64Value = uint64(v)
+		case nil:
+			f.Uint64Value = 0
+		default:
+			return createStorageTypeMismatchError(field, value)
+		}
+	case ValueType_string, ValueType_enum:
+		switch v := value.(type) {
+		case string:
+			f.StringValue = v
+		case nil:
+			f.StringValue = ""
+		default:
+			return createStorageTypeMismatchError(field, value)
+		}
+	case ValueType_timestamp:
+		switch v := value.(type) {
+		case time.Time:
+			f.TimestampValue = convertTimeToTimestamp(v)
+		case nil:
+			f.TimestampValue = nil
+		default:
+			return createStorageTypeMismatchError(field, value)
+		}
+	case ValueType_boolean:
+		switch v := value.(type) {
+		case bool:
+			f.BooleanValue = v
+		case nil:
+			f.BooleanValue = false
+		default:
+			return createStorageTypeMismatchError(field, value)
+		}
+	case ValueType_bytes:
+		switch v := value.(type) {
+		case []byte:
+			f.BytesValue = v
+		case nil:
+			f.BytesValue = nil
+		default:
+			return createStorageTypeMismatchError(field, value)
+		}
+	case ValueType_key:
+		switch v := value.(type) {
+		case *Key:
+			f.KeyValue = v
+		case nil:
+			f.KeyValue = nil
+		default:
+			return createStorageTypeMismatchError(field, value)
+		}
+	case ValueType_struct:
+		structInfo, ok := schema.Structs[field.StructName]
+		if !ok {
+			return fmt.Errorf("field '%s' refers to struct '%s', which isn't in the schema", field.Name, field.StructName)
+		}
+		switch v := value.(type) {
+		case map[string]interface{}:
+			values, err := convertDataMapToValues(schema, structInfo.Fields, v)
+			if err != nil {
+				return err
+			}
+			f.StructValue = &StructValue{
+				Values: values,
+			}
+		case nil:
+			f.StructValue = nil
+		default:
+			return createStorageTypeMismatchError(field, value)
+		}
+	case ValueType_decimal:
+		switch v := value.(type) {
+		case int64:
+			f.DecimalValue = convertScaledInt64ToDecimal(v, field.DecimalScale)
+		case nil:
+			f.DecimalValue = nil
+		default:
+			return createStorageTypeMismatchError(field, value)
+		}
+	case ValueType_duration:
+		switch v := value.(type) {
+		case int64:
+			f.DurationValue = convertNanosecondsToDuration(v)
+		case nil:
+			f.DurationValue = nil
+		default:
+			return createStorageTypeMismatchError(field, value)
+		}
+	case ValueType_geopoint:
+		switch v := value.(type) {
+		case *latlng.LatLng:
+			if v != nil {
+				f.GeopointValue = convertLatLngToGeoPoint(v)
+			}
+		case nil:
+			f.GeopointValue = nil
+		default:
+			return createStorageTypeMismatchError(field, value)
+		}
+	case ValueType_json:
+		switch v := value.(type) {
+		case map[string]interface{}:
+			f.JsonValue = convertDataMapToJSONStruct(v)
+		case nil:
+			f.JsonValue = nil
+		default:
+			return createStorageTypeMismatchError(field, value)
+		}
+	case ValueType_map:
+		entries, ok := value.(map[string]interface{})
+		if !ok && value != nil {
+			return createStorageTypeMismatchError(field, value)
+		}
+		valueField := getMapValueField(field)
+		f.MapValue = make(map[string]*Value, len(entries))
+		for entryKey, entry := range entries {
+			e := &Value{
+				Type: field.MapValueType,
+			}
+			err := setValueFromDataMapValue(schema, valueField, e, entry)
+			if err != nil {
+				return err
+			}
+			f.MapValue[entryKey] = e
+		}
+	default:
+		return fmt.Errorf("field type %d not supported in convertSnapshotToMetaEntity", field.Type)
+	}
+	return nil
+}
+
+func createStorageTypeMismatchError(field *SchemaField, value interface{}) error {
+	return status.Error(codes.DataLoss, fmt.Sprintf("field '%s' is stored as a value of type %T, which doesn't match its type '%s'", field.Name, value, field.Type))
+}
+
+// convertSnapshotToReadableMetaEntity converts a snapshot like
+// convertSnapshotToMetaEntity, but fields whose values can't be converted are
+// left unset and reported in the entity's field violations instead of failing
+// the conversion. It's used when returning many entities, where one mistyped
+// value shouldn't hide the entity or fail the whole response.
+func convertSnapshotToReadableMetaEntity(schema *Schema, kindInfo *SchemaKind, snapshot *storageSnapshot) *MetaEntity {
+	entity, err := convertSnapshotToMetaEntity(schema, kindInfo, snapshot)
+	if err == nil {
+		return entity
+	}
+	entity = &MetaEntity{
+		Key: snapshot.Key,
+	}
+	entity.Version, _ = snapshot.Data[entityVersionDataField].(int64)
+	kindName := snapshot.Key.Path[len(snapshot.Key.Path)-1].Kind
+	for _, field := range kindInfo.Fields {
+		data := make(map[string]interface{})
+		if value, ok := snapshot.Data[field.Name]; ok {
+			data[field.Name] = value
+		}
+		values, err := convertDataMapToValues(schema, []*SchemaField{field}, data)
+		if err != nil {
+			entity.FieldViolations = append(entity.FieldViolations, &MetaFieldViolation{
+				KindName:    kindName,
+				FieldName:   field.Name,
+				Description: status.Convert(err).Message(),
+			})
+			continue
+		}
+		entity.Values = append(entity.Values, values...)
+	}
+	sort.Slice(entity.Values, func(i, j int) bool {
+		return entity.Values[i].Id < entity.Values[j].Id
+	})
+	return entity
+}
+
+// convertSnapshotToMetaEntityVersion converts a version record written by
+// appendEntityVersion for the entity with the given key.
+func convertSnapshotToMetaEntityVersion(schema *Schema, kindInfo *SchemaKind, key *Key, snapshot *storageSnapshot) (*MetaEntityVersion, error) {
+	version := &MetaEntityVersion{}
+	version.Version, _ = snapshot.Data["version"].(int64)
+	version.TransactionId, _ = snapshot.Data["transactionId"].(string)
+	version.AuthSub, _ = snapshot.Data["authSub"].(string)
+	version.Deleted, _ = snapshot.Data["deleted"].(bool)
+	if dateSubmitted, ok := snapshot.Data["dateSubmitted"].(time.Time); ok {
+		version.DateSubmitted = convertTimeToTimestamp(dateSubmitted)
+	}
+	if values, ok := snapshot.Data["values"].(map[string]interface{}); ok && !version.Deleted {
+		entity, err := convertSnapshotToMetaEntity(schema, kindInfo, &storageSnapshot{
+			Key:  key,
+			Data: values,
+		})
+		if err != nil {
+			return nil, err
+		}
+		version.Entity = entity
+	}
+	return version, nil
+}
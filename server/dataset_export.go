@@ -0,0 +1,89 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/proto"
+)
+
+// datasetLine is a single line of an NDJSON export. Keys in the exported
+// namespace are written without a namespace, so that an export can be
+// imported into a different namespace or project.
+type datasetLine struct {
+	Key    string          `json:"key"`
+	Entity json.RawMessage `json:"entity"`
+}
+
+func relativizeDatasetKey(key *Key, namespace string) *Key {
+	if key == nil {
+		return nil
+	}
+	if key.PartitionId == nil || key.PartitionId.Namespace != namespace {
+		return key
+	}
+	result := proto.Clone(key).(*Key)
+	result.PartitionId.Namespace = ""
+	return result
+}
+
+// exportDataset writes every entity of every kind in the namespace to w,
+// including nested entities, one MetaEntity per line, and returns the
+// number of entities written.
+func exportDataset(ctx context.Context, storage storageBackend, schema *Schema, namespace string, w io.Writer) (int, error) {
+	namespace = normalizeNamespace(storage, namespace)
+
+	var kindNames []string
+	for kindName := range schema.Kinds {
+		kindNames = append(kindNames, kindName)
+	}
+	sort.Strings(kindNames)
+
+	marshaler := &jsonpb.Marshaler{}
+	count := 0
+	for _, kindName := range kindNames {
+		snapshots, err := storage.Query(ctx, &storageQuery{
+			Namespace:      namespace,
+			KindName:       kindName,
+			AllDescendants: len(schema.Kinds[kindName].Ancestors) > 0,
+		})
+		if err != nil {
+			return count, fmt.Errorf("can't query entities of kind '%s': %v", kindName, err)
+		}
+
+		for _, snapshot := range snapshots {
+			entity, err := convertSnapshotToMetaEntity(schema, schema.Kinds[kindName], snapshot)
+			if err != nil {
+				return count, err
+			}
+			entity.Key = relativizeDatasetKey(entity.Key, namespace)
+			forEachNestedValue(entity.Values, func(value *Value) error {
+				value.KeyValue = relativizeDatasetKey(value.KeyValue, namespace)
+				return nil
+			})
+
+			encodedEntity, err := marshaler.MarshalToString(entity)
+			if err != nil {
+				return count, fmt.Errorf("can't encode entity %s: %v", serializeKey(entity.Key), err)
+			}
+			line, err := json.Marshal(&datasetLine{
+				Key:    serializeKey(entity.Key),
+				Entity: json.RawMessage(encodedEntity),
+			})
+			if err != nil {
+				return count, err
+			}
+			_, err = w.Write(append(line, '\n'))
+			if err != nil {
+				return count, err
+			}
+			count++
+		}
+	}
+
+	return count, nil
+}
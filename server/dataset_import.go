@@ -0,0 +1,214 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/golang/protobuf/jsonpb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type datasetConflictMode string
+
+const (
+	datasetConflictSkip      datasetConflictMode = "skip"
+	datasetConflictOverwrite datasetConflictMode = "overwrite"
+	datasetConflictFail      datasetConflictMode = "fail"
+)
+
+func parseDatasetConflictMode(value string) (datasetConflictMode, error) {
+	switch datasetConflictMode(value) {
+	case datasetConflictSkip, datasetConflictOverwrite, datasetConflictFail:
+		return datasetConflictMode(value), nil
+	}
+	return "", fmt.Errorf("unknown conflict mode '%s', expected 'skip', 'overwrite' or 'fail'", value)
+}
+
+type datasetImportResult struct {
+	Imported int
+	Skipped  int
+}
+
+type datasetImportEntry struct {
+	line   int
+	entity *MetaEntity
+}
+
+func absolutizeDatasetKey(key *Key, namespace string) {
+	if key == nil {
+		return
+	}
+	if key.PartitionId == nil {
+		key.PartitionId = &PartitionId{}
+	}
+	if key.PartitionId.Namespace == "" {
+		key.PartitionId.Namespace = namespace
+	}
+}
+
+// importDataset reads entities written by exportDataset and applies them
+// through the transaction processor in batches of at most batchSize
+// entities. Entities without a namespace are imported into namespace.
+func importDataset(
+	ctx context.Context,
+	storage storageBackend,
+	schema *Schema,
+	namespace string,
+	r io.Reader,
+	conflictMode datasetConflictMode,
+	batchSize int,
+	description string,
+) (*datasetImportResult, error) {
+	if batchSize <= 0 {
+		return nil, fmt.Errorf("batch size must be greater than 0")
+	}
+	namespace = normalizeNamespace(storage, namespace)
+
+	processor := createTransactionProcessor(storage)
+	result := &datasetImportResult{}
+
+	// imports are run by an operator, and overwriting has to be able to
+	// replace readonly fields
+	ctx = context.WithValue(ctx, contextReadonlyOverrideKey, true)
+
+	// entities are imported in kind order, not reference order
+	ctx = context.WithValue(ctx, contextSkipReferenceChecksKey, true)
+
+	// a batch that fails is written in full or not at all, so that the
+	// import can be fixed and run again
+	ctx = context.WithValue(ctx, contextAbortOnErrorKey, true)
+
+	var batch []*datasetImportEntry
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		defer func() {
+			batch = nil
+		}()
+
+		if conflictMode == datasetConflictSkip {
+			existing, err := findExistingDatasetEntities(ctx, storage, batch)
+			if err != nil {
+				return err
+			}
+			var remaining []*datasetImportEntry
+			for _, entry := range batch {
+				if existing[serializeKey(entry.entity.Key)] {
+					result.Skipped++
+				} else {
+					remaining = append(remaining, entry)
+				}
+			}
+			batch = remaining
+			if len(batch) == 0 {
+				return nil
+			}
+		}
+
+		transaction := &MetaTransaction{
+			Description: description,
+		}
+		for _, entry := range batch {
+			if conflictMode == datasetConflictOverwrite {
+				transaction.Operations = append(transaction.Operations, &MetaOperation{
+					Operation: &MetaOperation_UpdateRequest{
+						UpdateRequest: &MetaUpdateEntityRequest{
+							Entity: entry.entity,
+						},
+					},
+				})
+			} else {
+				transaction.Operations = append(transaction.Operations, &MetaOperation{
+					Operation: &MetaOperation_CreateRequest{
+						CreateRequest: &MetaCreateEntityRequest{
+							KindName: getKeyKindName(entry.entity.Key),
+							Entity:   entry.entity,
+						},
+					},
+				})
+			}
+		}
+
+		_, err := processor.processTransaction(ctx, schema, transaction)
+		if err != nil {
+			return fmt.Errorf("can't import lines %d to %d: %v", batch[0].line, batch[len(batch)-1].line, err)
+		}
+		result.Imported += len(batch)
+		return nil
+	}
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+
+		var line datasetLine
+		err := json.Unmarshal([]byte(text), &line)
+		if err != nil {
+			return result, fmt.Errorf("can't parse line %d: %v", lineNumber, err)
+		}
+		entity := &MetaEntity{}
+		err = jsonpb.UnmarshalString(string(line.Entity), entity)
+		if err != nil {
+			return result, fmt.Errorf("can't parse entity on line %d: %v", lineNumber, err)
+		}
+		if entity.Key == nil || isKeyIncomplete(entity.Key) {
+			return result, fmt.Errorf("entity on line %d does not have a complete key", lineNumber)
+		}
+		absolutizeDatasetKey(entity.Key, namespace)
+		forEachNestedValue(entity.Values, func(value *Value) error {
+			absolutizeDatasetKey(value.KeyValue, namespace)
+			return nil
+		})
+
+		batch = append(batch, &datasetImportEntry{
+			line:   lineNumber,
+			entity: entity,
+		})
+		if len(batch) >= batchSize {
+			err = flush()
+			if err != nil {
+				return result, err
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return result, err
+	}
+
+	err := flush()
+	if err != nil {
+		return result, err
+	}
+	return result, nil
+}
+
+func findExistingDatasetEntities(ctx context.Context, storage storageBackend, batch []*datasetImportEntry) (map[string]bool, error) {
+	var existing map[string]bool
+	err := storage.RunTransaction(ctx, func(ctx context.Context, tx storageTransaction) error {
+		existing = make(map[string]bool)
+		for _, entry := range batch {
+			_, err := tx.Get(entry.entity.Key)
+			if err != nil {
+				if status.Code(err) == codes.NotFound {
+					continue
+				}
+				return err
+			}
+			existing[serializeKey(entry.entity.Key)] = true
+		}
+		return nil
+	})
+	return existing, err
+}
@@ -0,0 +1,135 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"strings"
+
+	"testing"
+
+	"gotest.tools/assert"
+)
+
+func TestDatasetExportImportRoundTrip(t *testing.T) {
+	ctx := context.Background()
+
+	genResult, err := generate("./schema.json")
+	assert.NilError(t, err)
+
+	source := createMemoryStorage()
+	defer source.Close()
+	_, err = createTransactionProcessor(source).processTransaction(ctx, genResult.Schema, &MetaTransaction{
+		Operations: []*MetaOperation{
+			&MetaOperation{
+				Operation: &MetaOperation_CreateRequest{
+					CreateRequest: &MetaCreateEntityRequest{
+						KindName: "IntegerTest",
+						Entity:   createMemoryTestEntity("a", 5),
+					},
+				},
+			},
+			&MetaOperation{
+				Operation: &MetaOperation_CreateRequest{
+					CreateRequest: &MetaCreateEntityRequest{
+						KindName: "IntegerTest",
+						Entity:   createMemoryTestEntity("b", 6),
+					},
+				},
+			},
+			createTestOperation(createTestKey("User", "u")),
+			// keys in repeated fields and in structs are exported without
+			// their namespace too
+			createTestOperation(createTestKey("Project", "p"), &Value{
+				Id:   3,
+				Type: ValueType_key,
+				ArrayValue: []*Value{
+					&Value{
+						Type:     ValueType_key,
+						KeyValue: normalizeEntityKey(source, createTestKey("User", "u")),
+					},
+				},
+			}),
+			createTestOperation(createTestKey("StructTest", "s"), &Value{
+				Id:   2,
+				Type: ValueType_struct,
+				StructValue: &StructValue{
+					Values: []*Value{
+						&Value{
+							Id:          2,
+							Type:        ValueType_string,
+							StringValue: "Sydney",
+						},
+						&Value{
+							Id:       4,
+							Type:     ValueType_key,
+							KeyValue: normalizeEntityKey(source, createTestKey("User", "u")),
+						},
+					},
+				},
+			}),
+		},
+	})
+	assert.NilError(t, err)
+
+	var buffer bytes.Buffer
+	count, err := exportDataset(ctx, source, genResult.Schema, "", &buffer)
+	assert.NilError(t, err)
+	assert.Equal(t, count, 5)
+	exported := buffer.String()
+	assert.Assert(t, strings.Contains(exported, `"key":"ns=|IntegerTest:name=a"`))
+	assert.Assert(t, !strings.Contains(exported, source.DefaultNamespace()))
+
+	target := createMemoryStorage()
+	defer target.Close()
+
+	result, err := importDataset(ctx, target, genResult.Schema, "copy", strings.NewReader(exported), datasetConflictFail, 1, "test")
+	assert.NilError(t, err)
+	assert.Equal(t, result.Imported, 5)
+
+	snapshots, err := target.Query(ctx, &storageQuery{Namespace: "copy", KindName: "IntegerTest"})
+	assert.NilError(t, err)
+	assert.Equal(t, len(snapshots), 2)
+	assert.Equal(t, snapshots[0].Key.PartitionId.Namespace, "copy")
+	assert.Equal(t, snapshots[0].Data["unsignedInt"], int64(5))
+
+	// and are imported into the target namespace
+	snapshots, err = target.Query(ctx, &storageQuery{Namespace: "copy", KindName: "Project"})
+	assert.NilError(t, err)
+	assert.Equal(t, len(snapshots), 1)
+	allowedUsers := snapshots[0].Data["allowedUsers"].([]interface{})
+	assert.Equal(t, allowedUsers[0].(*Key).PartitionId.Namespace, "copy")
+	snapshots, err = target.Query(ctx, &storageQuery{Namespace: "copy", KindName: "StructTest"})
+	assert.NilError(t, err)
+	assert.Equal(t, len(snapshots), 1)
+	address := snapshots[0].Data["address"].(map[string]interface{})
+	assert.Equal(t, address["landlord"].(*Key).PartitionId.Namespace, "copy")
+
+	// importing the same data again depends on the conflict mode
+	_, err = importDataset(ctx, target, genResult.Schema, "copy", strings.NewReader(exported), datasetConflictFail, 100, "test")
+	assert.Assert(t, err != nil)
+
+	result, err = importDataset(ctx, target, genResult.Schema, "copy", strings.NewReader(exported), datasetConflictSkip, 100, "test")
+	assert.NilError(t, err)
+	assert.Equal(t, result.Imported, 0)
+	assert.Equal(t, result.Skipped, 5)
+
+	result, err = importDataset(ctx, target, genResult.Schema, "copy", strings.NewReader(exported), datasetConflictOverwrite, 100, "test")
+	assert.NilError(t, err)
+	assert.Equal(t, result.Imported, 5)
+
+	// a batch with an entity that can't be imported is not partly written
+	_, err = createTransactionProcessor(target).processTransaction(ctx, genResult.Schema, &MetaTransaction{
+		Operations: []*MetaOperation{
+			createTestOperation(createTestKey("UniqueTest", "x"), createUniqueTestValue("dup")),
+		},
+	})
+	assert.NilError(t, err)
+	_, err = importDataset(ctx, target, genResult.Schema, "", strings.NewReader(`
+{"key":"ns=|IntegerTest:name=c","entity":{"key":{"path":[{"kind":"IntegerTest","name":"c"}]}}}
+{"key":"ns=|UniqueTest:name=y","entity":{"key":{"path":[{"kind":"UniqueTest","name":"y"}]},"values":[{"id":2,"type":"string","stringValue":"dup"}]}}
+`), datasetConflictFail, 100, "test")
+	assert.ErrorContains(t, err, "can't import lines 2 to 3")
+	snapshots, err = target.Query(ctx, &storageQuery{KindName: "IntegerTest"})
+	assert.NilError(t, err)
+	assert.Equal(t, len(snapshots), 0)
+}
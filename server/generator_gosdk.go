@@ -13,7 +13,10 @@ import (
 	dpb "github.com/golang/protobuf/protoc-gen-go/descriptor"
 	"github.com/golang/protobuf/protoc-gen-go/generator"
 	_ "github.com/golang/protobuf/protoc-gen-go/grpc"
+	_ "github.com/golang/protobuf/ptypes/duration"
+	_ "github.com/golang/protobuf/ptypes/struct"
 	_ "github.com/golang/protobuf/ptypes/timestamp"
+	_ "github.com/golang/protobuf/ptypes/wrappers"
 	"github.com/jhump/protoreflect/desc"
 )
 
@@ -33,12 +36,6 @@ func generateGoCode(fileDesc *desc.FileDescriptor, schema *Schema) (string, erro
 	protoFileName := fmt.Sprintf("%s.proto", schema.Name)
 	parameter := "plugins=grpc"
 
-	timestampFileDescriptor, err := desc.LoadFileDescriptor("google/protobuf/timestamp.proto")
-	if err != nil {
-		return "", err
-	}
-	timestampFileProto := timestampFileDescriptor.AsFileDescriptorProto()
-
 	str := packageName
 	strName := protoFileName
 	fileDescProto := fileDesc.AsFileDescriptorProto()
@@ -56,17 +53,31 @@ func generateGoCode(fileDesc *desc.FileDescriptor, schema *Schema) (string, erro
 		g.Request.ProtoFile,
 		fileDescProto,
 	)
-	g.Request.ProtoFile = append(
-		g.Request.ProtoFile,
-		timestampFileProto,
-	)
 
 	genFiles := make(map[string]*generator.FileDescriptor)
 	genFiles[protoFileName] = &generator.FileDescriptor{
 		FileDescriptorProto: fileDescProto,
 	}
-	genFiles["google/protobuf/timestamp.proto"] = &generator.FileDescriptor{
-		FileDescriptorProto: timestampFileProto,
+
+	// the well-known types that field values can have
+	for _, importedFileName := range []string{
+		"google/protobuf/timestamp.proto",
+		"google/protobuf/duration.proto",
+		"google/protobuf/struct.proto",
+		"google/protobuf/wrappers.proto",
+	} {
+		importedFileDescriptor, err := desc.LoadFileDescriptor(importedFileName)
+		if err != nil {
+			return "", err
+		}
+		importedFileProto := importedFileDescriptor.AsFileDescriptorProto()
+		g.Request.ProtoFile = append(
+			g.Request.ProtoFile,
+			importedFileProto,
+		)
+		genFiles[importedFileName] = &generator.FileDescriptor{
+			FileDescriptorProto: importedFileProto,
+		}
 	}
 
 	g.CommandLineParameters(g.Request.GetParameter())
@@ -113,6 +124,9 @@ func generateGoCode(fileDesc *desc.FileDescriptor, schema *Schema) (string, erro
 				return false
 			}
 		},
+		"getmapvaluefield": func(field *SchemaField) *SchemaField {
+			return getMapValueField(field)
+		},
 		"iscomputedindex": func(index *SchemaIndex) bool {
 			switch index.Value.(type) {
 			case *SchemaIndex_Computed:
@@ -243,6 +257,13 @@ func generateGoCode(fileDesc *desc.FileDescriptor, schema *Schema) (string, erro
 			}
 			return lookupFieldByName(schema.Kinds[kindName], computed.GetFnv32APair().Field2)
 		},
+		"getvalidatorchecks": getGoValidatorChecks,
+		"getkeycontainsexpr": func(field *SchemaField, expr string) string {
+			return getGoKeyContainsExpr(schema, field, expr)
+		},
+		"structhaskeys": func(structName string) bool {
+			return schemaStructHasKeys(schema, structName, make(map[string]bool))
+		},
 	})
 	_, err = tmpl.Parse(string(tmplCode))
 	if err != nil {
@@ -266,6 +287,14 @@ func generateGoCode(fileDesc *desc.FileDescriptor, schema *Schema) (string, erro
 		"\"google.golang.org/grpc/status\"",
 		"\"google.golang.org/grpc/codes\"",
 		"\"hash/fnv\"",
+		"\"net\"",
+		"\"net/mail\"",
+		"\"net/url\"",
+		"\"reflect\"",
+		"\"regexp\"",
+		"\"sort\"",
+		"\"strconv\"",
+		"\"unicode/utf8\"",
 	}
 	if !strings.Contains(standardCode, "github.com/golang/protobuf/ptypes/timestamp") {
 		imports = append(
@@ -273,6 +302,18 @@ func generateGoCode(fileDesc *desc.FileDescriptor, schema *Schema) (string, erro
 			"timestamp \"github.com/golang/protobuf/ptypes/timestamp\"",
 		)
 	}
+	if !strings.Contains(standardCode, "github.com/golang/protobuf/ptypes/duration") {
+		imports = append(
+			imports,
+			"duration \"github.com/golang/protobuf/ptypes/duration\"",
+		)
+	}
+	if !strings.Contains(standardCode, "github.com/golang/protobuf/ptypes/struct") {
+		imports = append(
+			imports,
+			"_struct \"github.com/golang/protobuf/ptypes/struct\"",
+		)
+	}
 	standardCode = strings.Replace(standardCode, "import (", fmt.Sprintf("import (%s", strings.Join(imports, "\n    ")), 1)
 
 	standardCode = fmt.Sprintf("%s\n%s", standardCode, tpl.String())
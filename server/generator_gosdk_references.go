@@ -0,0 +1,67 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/golang/protobuf/protoc-gen-go/generator"
+)
+
+// schemaStructHasKeys returns whether the struct has a key field, or a
+// field that holds other structs with key fields.
+func schemaStructHasKeys(schema *Schema, structName string, visited map[string]bool) bool {
+	if visited[structName] {
+		return false
+	}
+	visited[structName] = true
+	structInfo, ok := schema.Structs[structName]
+	if !ok {
+		return false
+	}
+	for _, field := range structInfo.Fields {
+		valueType := field.Type
+		if field.Type == ValueType_map {
+			valueType = field.MapValueType
+		}
+		if valueType == ValueType_key {
+			return true
+		}
+		if valueType == ValueType_struct && schemaStructHasKeys(schema, field.StructName, visited) {
+			return true
+		}
+	}
+	return false
+}
+
+// getGoKeyContainsExpr returns a Go expression that checks whether the
+// value of field in expr holds the serialized key in target, including in
+// its struct values and map entries. It returns an empty string if the
+// field can't hold keys.
+func getGoKeyContainsExpr(schema *Schema, field *SchemaField, expr string) string {
+	valueType := field.Type
+	if field.Type == ValueType_map {
+		valueType = field.MapValueType
+	}
+	switch valueType {
+	case ValueType_key:
+		if field.Type == ValueType_map {
+			return fmt.Sprintf("containsSerializedKeyInMap(%s, target)", expr)
+		}
+		if field.Repeated {
+			return fmt.Sprintf("containsSerializedKey(%s, target)", expr)
+		}
+		return fmt.Sprintf("%s != nil && SerializeKey(%s) == target", expr, expr)
+	case ValueType_struct:
+		if !schemaStructHasKeys(schema, field.StructName, make(map[string]bool)) {
+			return ""
+		}
+		helper := fmt.Sprintf("containsSerializedKeyIn%s", generator.CamelCase(field.StructName))
+		if field.Type == ValueType_map {
+			return fmt.Sprintf("%sMap(%s, target)", helper, expr)
+		}
+		if field.Repeated {
+			return fmt.Sprintf("%sList(%s, target)", helper, expr)
+		}
+		return fmt.Sprintf("%s(%s, target)", helper, expr)
+	}
+	return ""
+}
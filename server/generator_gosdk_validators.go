@@ -0,0 +1,257 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/golang/protobuf/protoc-gen-go/generator"
+)
+
+// getGoValidatorChecks returns a Go expression for each validator on the
+// field, for use in the generated Validate methods. Each expression
+// evaluates to a description of the violation, or an empty string if the
+// entity "e" passes. The descriptions match the ones returned by the server
+// in validateFieldValue. For repeated and map fields, every validator except
+// required is checked against each element or entry value. Decimal values
+// are also checked to fit their field's scale.
+func getGoValidatorChecks(field *SchemaField) []string {
+	if field.Editor == nil && field.Type != ValueType_decimal && field.MapValueType != ValueType_decimal {
+		return nil
+	}
+
+	hasDefault := false
+	for _, validator := range field.Editor.GetValidators() {
+		if def := validator.GetDefault(); def != nil && def.Value != nil && def.Value.Type == field.Type {
+			hasDefault = true
+		}
+	}
+
+	name := fmt.Sprintf("e.%s", generator.CamelCase(field.Name))
+	list := name
+	isMap := field.Type == ValueType_map
+	if field.Repeated {
+		name = fmt.Sprintf("%s[i]", list)
+	} else if isMap {
+		name = fmt.Sprintf("%s[key]", list)
+		field = getMapValueField(field)
+	}
+	// nullable fields of scalar types are generated with wrapper types, and
+	// their checks are skipped when they're null, like on the server
+	value := name
+	nullable := field.Nullable && isWrappedValueType(field.Type)
+	if nullable {
+		name = fmt.Sprintf("%s.GetValue()", value)
+	}
+	length := ""
+	unit := ""
+	switch field.Type {
+	case ValueType_string:
+		length = fmt.Sprintf("utf8.RuneCountInString(%s)", name)
+		unit = "characters"
+	case ValueType_bytes:
+		length = fmt.Sprintf("len(%s)", name)
+		unit = "bytes"
+	}
+
+	var checks []string
+	var results []string
+	if field.Type == ValueType_decimal {
+		checks = append(checks, fmt.Sprintf("validateDecimal(%s, %d)", name, field.DecimalScale))
+	}
+	for _, validator := range field.Editor.GetValidators() {
+		switch v := validator.Validator.(type) {
+		case *SchemaFieldEditorValidator_Required:
+			if field.Repeated {
+				results = append(results, fmt.Sprintf("validateCondition(len(%s) > 0, \"at least one value is required\")", list))
+				continue
+			}
+			if isMap {
+				results = append(results, fmt.Sprintf("validateCondition(len(%s) > 0, \"at least one entry is required\")", list))
+				continue
+			}
+			if hasDefault {
+				// the server fills in the default before checking
+				continue
+			}
+			if nullable {
+				description := "a non-empty value is required"
+				switch field.Type {
+				case ValueType_double, ValueType_int64, ValueType_uint64:
+					description = "a non-zero value is required"
+				}
+				results = append(results, fmt.Sprintf("validateCondition(%s != nil, %q)", value, description))
+			}
+			switch field.Type {
+			case ValueType_double:
+				checks = append(checks, fmt.Sprintf("validateCondition(%s != 0 && !math.IsNaN(%s), \"a non-zero value is required\")", name, name))
+			case ValueType_int64, ValueType_uint64:
+				checks = append(checks, fmt.Sprintf("validateCondition(%s != 0, \"a non-zero value is required\")", name))
+			case ValueType_string:
+				checks = append(checks, fmt.Sprintf("validateCondition(strings.TrimSpace(%s) != \"\", \"a non-empty value is required\")", name))
+			case ValueType_boolean:
+				checks = append(checks, fmt.Sprintf("validateCondition(%s, \"a non-empty value is required\")", name))
+			case ValueType_bytes:
+				checks = append(checks, fmt.Sprintf("validateCondition(len(%s) > 0, \"a non-empty value is required\")", name))
+			case ValueType_timestamp:
+				checks = append(checks, fmt.Sprintf("validateCondition(%s != nil, \"a non-empty value is required\")", name))
+			case ValueType_key:
+				checks = append(checks, fmt.Sprintf("validateCondition(%s != nil, \"a key is required\")", name))
+			case ValueType_struct:
+				checks = append(checks, fmt.Sprintf("validateCondition(%s != nil, \"a non-empty value is required\")", name))
+			case ValueType_decimal:
+				checks = append(checks, fmt.Sprintf("validateCondition(%s.GetUnits() != 0 || %s.GetNanos() != 0, \"a non-zero value is required\")", name, name))
+			case ValueType_duration:
+				checks = append(checks, fmt.Sprintf("validateCondition(%s.GetSeconds() != 0 || %s.GetNanos() != 0, \"a non-zero value is required\")", name, name))
+			case ValueType_geopoint:
+				checks = append(checks, fmt.Sprintf("validateCondition(%s != nil, \"a non-empty value is required\")", name))
+			case ValueType_json:
+				checks = append(checks, fmt.Sprintf("validateCondition(len(%s.GetFields()) > 0, \"a non-empty value is required\")", name))
+			}
+		case *SchemaFieldEditorValidator_FixedLength:
+			if length != "" && v.FixedLength != nil {
+				checks = append(checks, fmt.Sprintf("validateFixedLength(%s, %d, %q)", length, v.FixedLength.Length, unit))
+			}
+		case *SchemaFieldEditorValidator_MinimumLength:
+			if length != "" {
+				checks = append(checks, fmt.Sprintf("validateMinimumLength(%s, %d, %q)", length, v.MinimumLength.GetLength(), unit))
+			}
+		case *SchemaFieldEditorValidator_MaximumLength:
+			if length != "" {
+				checks = append(checks, fmt.Sprintf("validateMaximumLength(%s, %d, %q)", length, v.MaximumLength.GetLength(), unit))
+			}
+		case *SchemaFieldEditorValidator_FormatIPAddress:
+			if field.Type == ValueType_string {
+				checks = append(checks, fmt.Sprintf("validateIPAddress(%s)", name))
+			}
+		case *SchemaFieldEditorValidator_FormatIPAddressPort:
+			if field.Type == ValueType_string {
+				checks = append(checks, fmt.Sprintf("validateIPAddressPort(%s)", name))
+			}
+		case *SchemaFieldEditorValidator_Pattern:
+			if field.Type == ValueType_string {
+				checks = append(checks, fmt.Sprintf("validatePattern(%s, %q)", name, v.Pattern.GetPattern()))
+			}
+		case *SchemaFieldEditorValidator_Int64Minimum:
+			if field.Type == ValueType_int64 {
+				checks = append(checks, fmt.Sprintf("validateCondition(%s >= %d, \"must be at least %d\")", name, v.Int64Minimum.GetValue(), v.Int64Minimum.GetValue()))
+			}
+		case *SchemaFieldEditorValidator_Int64Maximum:
+			if field.Type == ValueType_int64 {
+				checks = append(checks, fmt.Sprintf("validateCondition(%s <= %d, \"must be at most %d\")", name, v.Int64Maximum.GetValue(), v.Int64Maximum.GetValue()))
+			}
+		case *SchemaFieldEditorValidator_Uint64Minimum:
+			if field.Type == ValueType_uint64 {
+				checks = append(checks, fmt.Sprintf("validateCondition(%s >= %d, \"must be at least %d\")", name, v.Uint64Minimum.GetValue(), v.Uint64Minimum.GetValue()))
+			}
+		case *SchemaFieldEditorValidator_Uint64Maximum:
+			if field.Type == ValueType_uint64 {
+				checks = append(checks, fmt.Sprintf("validateCondition(%s <= %d, \"must be at most %d\")", name, v.Uint64Maximum.GetValue(), v.Uint64Maximum.GetValue()))
+			}
+		case *SchemaFieldEditorValidator_DoubleMinimum:
+			if field.Type == ValueType_double {
+				checks = append(checks, fmt.Sprintf("validateCondition(%s >= %s, \"must be at least %v\")", name, formatGoFloat(v.DoubleMinimum.GetValue()), v.DoubleMinimum.GetValue()))
+			}
+		case *SchemaFieldEditorValidator_DoubleMaximum:
+			if field.Type == ValueType_double {
+				checks = append(checks, fmt.Sprintf("validateCondition(%s <= %s, \"must be at most %v\")", name, formatGoFloat(v.DoubleMaximum.GetValue()), v.DoubleMaximum.GetValue()))
+			}
+		case *SchemaFieldEditorValidator_AllowedValues:
+			var conditions []string
+			var allowed []string
+			switch field.Type {
+			case ValueType_string:
+				conditions = append(conditions, fmt.Sprintf("%s == \"\"", name))
+			}
+			for _, value := range v.AllowedValues.GetValues() {
+				switch field.Type {
+				case ValueType_string:
+					conditions = append(conditions, fmt.Sprintf("%s == %s", name, strconv.Quote(value.StringValue)))
+					allowed = append(allowed, value.StringValue)
+				case ValueType_int64:
+					conditions = append(conditions, fmt.Sprintf("%s == %d", name, value.Int64Value))
+					allowed = append(allowed, strconv.FormatInt(value.Int64Value, 10))
+				case ValueType_uint64:
+					conditions = append(conditions, fmt.Sprintf("%s == %d", name, value.Uint64Value))
+					allowed = append(allowed, strconv.FormatUint(value.Uint64Value, 10))
+				case ValueType_double:
+					conditions = append(conditions, fmt.Sprintf("%s == %s", name, formatGoFloat(value.DoubleValue)))
+					allowed = append(allowed, fmt.Sprintf("%v", value.DoubleValue))
+				}
+			}
+			if len(conditions) > 0 {
+				checks = append(checks, fmt.Sprintf("validateCondition(%s, %s)", strings.Join(conditions, " || "), strconv.Quote(fmt.Sprintf("must be one of: %s", strings.Join(allowed, ", ")))))
+			}
+		case *SchemaFieldEditorValidator_FormatEmail:
+			if field.Type == ValueType_string {
+				checks = append(checks, fmt.Sprintf("validateEmail(%s)", name))
+			}
+		case *SchemaFieldEditorValidator_FormatURL:
+			if field.Type == ValueType_string {
+				var schemes []string
+				for _, scheme := range v.FormatURL.GetSchemes() {
+					schemes = append(schemes, strconv.Quote(scheme))
+				}
+				checks = append(checks, fmt.Sprintf("validateURL(%s, []string{%s})", name, strings.Join(schemes, ", ")))
+			}
+		case *SchemaFieldEditorValidator_FormatURI:
+			if field.Type == ValueType_string {
+				checks = append(checks, fmt.Sprintf("validateURI(%s)", name))
+			}
+		case *SchemaFieldEditorValidator_TimestampMinimum:
+			if field.Type == ValueType_timestamp && v.TimestampMinimum.GetValue() != nil {
+				minimum := v.TimestampMinimum.GetValue()
+				checks = append(checks, fmt.Sprintf(
+					"validateCondition(%s == nil || compareTimestamp(%s, %d, %d) >= 0, %q)",
+					name,
+					name,
+					minimum.Seconds,
+					minimum.Nanos,
+					fmt.Sprintf("must not be before %s", convertTimestampToTime(minimum).UTC().Format(time.RFC3339Nano)),
+				))
+			}
+		case *SchemaFieldEditorValidator_TimestampMaximum:
+			if field.Type == ValueType_timestamp && v.TimestampMaximum.GetValue() != nil {
+				maximum := v.TimestampMaximum.GetValue()
+				checks = append(checks, fmt.Sprintf(
+					"validateCondition(%s == nil || compareTimestamp(%s, %d, %d) <= 0, %q)",
+					name,
+					name,
+					maximum.Seconds,
+					maximum.Nanos,
+					fmt.Sprintf("must not be after %s", convertTimestampToTime(maximum).UTC().Format(time.RFC3339Nano)),
+				))
+			}
+		}
+	}
+	for _, check := range checks {
+		if field.Repeated {
+			check = fmt.Sprintf("validateElements(len(%s), func(i int) string { return %s })", list, check)
+		} else if isMap {
+			check = fmt.Sprintf("validateEntries(%s, func(key string) string { return %s })", list, check)
+		} else if nullable {
+			check = fmt.Sprintf("validateUnlessNull(%s == nil, func() string { return %s })", value, check)
+		}
+		results = append(results, check)
+	}
+	return results
+}
+
+func formatGoFloat(value float64) string {
+	s := strconv.FormatFloat(value, 'g', -1, 64)
+	if !strings.ContainsAny(s, ".eE") {
+		s += ".0"
+	}
+	return s
+}
+
+// isWrappedValueType returns whether nullable fields of the type are
+// generated with a google.protobuf wrapper type, rather than the type itself.
+func isWrappedValueType(valueType ValueType) bool {
+	switch valueType {
+	case ValueType_double, ValueType_int64, ValueType_uint64, ValueType_string, ValueType_boolean, ValueType_bytes:
+		return true
+	}
+	return false
+}
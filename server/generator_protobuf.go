@@ -6,18 +6,36 @@ import (
 	"os"
 
 	"github.com/golang/protobuf/jsonpb"
+	dpb "github.com/golang/protobuf/protoc-gen-go/descriptor"
+	_ "github.com/golang/protobuf/ptypes/duration"
+	_ "github.com/golang/protobuf/ptypes/struct"
 	_ "github.com/golang/protobuf/ptypes/timestamp"
+	_ "github.com/golang/protobuf/ptypes/wrappers"
 	"github.com/jhump/protoreflect/desc"
 	"github.com/jhump/protoreflect/desc/builder"
-	dpb "github.com/golang/protobuf/protoc-gen-go/descriptor"
 )
 
-func convertToType(
-	t ValueType,
-	keyMessage *builder.MessageBuilder,
-	timestampMessage *desc.MessageDescriptor,
-) *builder.FieldType {
-	switch t {
+// fieldTypeMessages holds the messages and enums that schema fields can use
+// as their types.
+type fieldTypeMessages struct {
+	Key       *builder.MessageBuilder
+	Decimal   *builder.MessageBuilder
+	GeoPoint  *builder.MessageBuilder
+	Timestamp *desc.MessageDescriptor
+	Duration  *desc.MessageDescriptor
+	JSON      *desc.MessageDescriptor
+	Structs   map[string]*builder.MessageBuilder
+	Enums     map[string]*builder.EnumBuilder
+	// The google.protobuf wrapper types that nullable fields of scalar
+	// types use instead.
+	Wrappers map[ValueType]*desc.MessageDescriptor
+}
+
+func convertToType(field *SchemaField, types *fieldTypeMessages) *builder.FieldType {
+	if wrapper, ok := types.Wrappers[field.Type]; ok && field.Nullable {
+		return builder.FieldTypeImportedMessage(wrapper)
+	}
+	switch field.Type {
 	case ValueType_double:
 		return builder.FieldTypeDouble()
 	case ValueType_int64:
@@ -27,19 +45,80 @@ func convertToType(
 	case ValueType_string:
 		return builder.FieldTypeString()
 	case ValueType_timestamp:
-		return builder.FieldTypeImportedMessage(timestampMessage)
+		return builder.FieldTypeImportedMessage(types.Timestamp)
 	case ValueType_boolean:
 		return builder.FieldTypeBool()
 	case ValueType_bytes:
 		return builder.FieldTypeBytes()
 	case ValueType_key:
-		return builder.FieldTypeMessage(keyMessage)
+		return builder.FieldTypeMessage(types.Key)
+	case ValueType_decimal:
+		return builder.FieldTypeMessage(types.Decimal)
+	case ValueType_duration:
+		return builder.FieldTypeImportedMessage(types.Duration)
+	case ValueType_geopoint:
+		return builder.FieldTypeMessage(types.GeoPoint)
+	case ValueType_json:
+		return builder.FieldTypeImportedMessage(types.JSON)
+	case ValueType_struct:
+		if structMessage, ok := types.Structs[field.StructName]; ok {
+			return builder.FieldTypeMessage(structMessage)
+		}
+	case ValueType_enum:
+		if enum, ok := types.Enums[field.EnumName]; ok {
+			return builder.FieldTypeEnum(enum)
+		}
 	}
-	fmt.Printf("fatal: no such field type '%s'", string(t))
+	fmt.Printf("fatal: no such field type '%s'", field.Type)
 	os.Exit(1)
 	return builder.FieldTypeString() // never reached
 }
 
+func convertToFieldBuilder(
+	field *SchemaField,
+	types *fieldTypeMessages,
+	jsNumberAsStringOptions *dpb.FieldOptions,
+) *builder.FieldBuilder {
+	if field.Type == ValueType_map {
+		return builder.NewMapField(
+			field.Name,
+			builder.FieldTypeString(),
+			convertToType(getMapValueField(field), types),
+		).
+			SetNumber(field.Id).
+			SetComments(builder.Comments{LeadingComment: fmt.Sprintf(" %s", field.Comment)})
+	}
+	mfb := builder.NewField(
+		field.Name,
+		convertToType(field, types),
+	).
+		SetNumber(field.Id).
+		SetComments(builder.Comments{LeadingComment: fmt.Sprintf(" %s", field.Comment)})
+	if !field.Nullable {
+		switch field.Type {
+		case ValueType_int64:
+			mfb = mfb.SetOptions(jsNumberAsStringOptions)
+		case ValueType_uint64:
+			mfb = mfb.SetOptions(jsNumberAsStringOptions)
+		}
+	}
+	if field.Repeated {
+		mfb = mfb.SetRepeated()
+	}
+	return mfb
+}
+
+// entityVersionFieldName and entityVersionFieldID identify the field that
+// holds MetaEntity.Version in each generated kind message. The ID is the
+// highest allowed, so that it doesn't collide with schema fields.
+const entityVersionFieldName = "entityVersion"
+const entityVersionFieldID = 536870911
+
+// entityFieldViolationsFieldName and entityFieldViolationsFieldID identify the
+// field that holds MetaEntity.FieldViolations in each generated kind message.
+const entityFieldViolationsFieldName = "entityFieldViolations"
+const entityFieldViolationsFieldID = 536870910
+
 type watchTypeEnumValues struct {
 	Created *desc.EnumValueDescriptor
 	Updated *desc.EnumValueDescriptor
@@ -104,6 +183,30 @@ func generate(path string) (*generatorResult, error) {
 	if err != nil {
 		return nil, fmt.Errorf("unable to deserialize schema.json: %v", err)
 	}
+	err = checkSchemaValidators(&schema)
+	if err != nil {
+		return nil, err
+	}
+	err = checkSchemaUniqueFields(&schema)
+	if err != nil {
+		return nil, err
+	}
+	err = checkSchemaReferences(&schema)
+	if err != nil {
+		return nil, err
+	}
+	err = checkSchemaAncestors(&schema)
+	if err != nil {
+		return nil, err
+	}
+	err = checkSchemaStructs(&schema)
+	if err != nil {
+		return nil, err
+	}
+	err = checkSchemaEnums(&schema)
+	if err != nil {
+		return nil, err
+	}
 
 	// use meta.proto as the base file builder
 	metaFileDescriptor, err := desc.LoadFileDescriptor("meta.proto")
@@ -114,6 +217,25 @@ func generate(path string) (*generatorResult, error) {
 	if err != nil {
 		return nil, err
 	}
+	// enum values are scoped to the package rather than the enum, so they
+	// can't reuse any name declared at the top level of meta.proto
+	metaNames := make(map[string]bool)
+	for _, message := range metaFileDescriptor.GetMessageTypes() {
+		metaNames[message.GetName()] = true
+	}
+	for _, enum := range metaFileDescriptor.GetEnumTypes() {
+		metaNames[enum.GetName()] = true
+		for _, value := range enum.GetValues() {
+			metaNames[value.GetName()] = true
+		}
+	}
+	for enumName, enumInfo := range schema.Enums {
+		for _, value := range enumInfo.Values {
+			if metaNames[value.Name] {
+				return nil, fmt.Errorf("enum '%s' has the value '%s', which is already declared in meta.proto", enumName, value.Name)
+			}
+		}
+	}
 
 	timestampFileDescriptor, err := desc.LoadFileDescriptor("google/protobuf/timestamp.proto")
 	if err != nil {
@@ -122,6 +244,21 @@ func generate(path string) (*generatorResult, error) {
 
 	timestampMessage := getMessageDescriptorFromFile(timestampFileDescriptor, "Timestamp")
 
+	durationFileDescriptor, err := desc.LoadFileDescriptor("google/protobuf/duration.proto")
+	if err != nil {
+		return nil, err
+	}
+
+	structFileDescriptor, err := desc.LoadFileDescriptor("google/protobuf/struct.proto")
+	if err != nil {
+		return nil, err
+	}
+
+	wrappersFileDescriptor, err := desc.LoadFileDescriptor("google/protobuf/wrappers.proto")
+	if err != nil {
+		return nil, err
+	}
+
 	strTemp := dpb.FieldOptions_JS_STRING
 	jsNumberAsStringOptions := &dpb.FieldOptions{
 		Jstype: &strTemp,
@@ -139,6 +276,7 @@ func generate(path string) (*generatorResult, error) {
 	partitionIDMessage := fileBuilder.GetMessage("PartitionId")
 	pathElementMessage := fileBuilder.GetMessage("PathElement")
 	keyMessage := fileBuilder.GetMessage("Key")
+	fieldViolationMessage := fileBuilder.GetMessage("MetaFieldViolation")
 
 	partitionIDDescriptor, err := partitionIDMessage.Build()
 	if err != nil {
@@ -189,6 +327,47 @@ func generate(path string) (*generatorResult, error) {
 		Deleted: deletedBuilt,
 	}
 
+	// Build the enums and the messages for structs first, so that kinds and
+	// other structs can use them as field types
+	enumMap := make(map[string]*builder.EnumBuilder)
+	for name, enumInfo := range schema.Enums {
+		enum := builder.NewEnum(name)
+		for _, value := range enumInfo.Values {
+			enum.AddValue(builder.NewEnumValue(value.Name).SetNumber(value.Number))
+		}
+		enumMap[name] = enum
+		enums = append(enums, enum)
+	}
+	structMessageMap := make(map[string]*builder.MessageBuilder)
+	for name := range schema.Structs {
+		structMessageMap[name] = builder.NewMessage(name)
+	}
+	types := &fieldTypeMessages{
+		Key:       keyMessage,
+		Decimal:   fileBuilder.GetMessage("DecimalValue"),
+		GeoPoint:  fileBuilder.GetMessage("GeoPoint"),
+		Timestamp: timestampMessage,
+		Duration:  getMessageDescriptorFromFile(durationFileDescriptor, "Duration"),
+		JSON:      getMessageDescriptorFromFile(structFileDescriptor, "Struct"),
+		Structs:   structMessageMap,
+		Enums:     enumMap,
+		Wrappers: map[ValueType]*desc.MessageDescriptor{
+			ValueType_double:  getMessageDescriptorFromFile(wrappersFileDescriptor, "DoubleValue"),
+			ValueType_int64:   getMessageDescriptorFromFile(wrappersFileDescriptor, "Int64Value"),
+			ValueType_uint64:  getMessageDescriptorFromFile(wrappersFileDescriptor, "UInt64Value"),
+			ValueType_string:  getMessageDescriptorFromFile(wrappersFileDescriptor, "StringValue"),
+			ValueType_boolean: getMessageDescriptorFromFile(wrappersFileDescriptor, "BoolValue"),
+			ValueType_bytes:   getMessageDescriptorFromFile(wrappersFileDescriptor, "BytesValue"),
+		},
+	}
+	for name, structInfo := range schema.Structs {
+		message := structMessageMap[name]
+		for _, field := range structInfo.Fields {
+			message.AddField(convertToFieldBuilder(field, types, jsNumberAsStringOptions))
+		}
+		messages = append(messages, message)
+	}
+
 	for name, kind := range schema.Kinds {
 		// Build the message descriptor for the entity itself
 		message := builder.NewMessage(name)
@@ -197,23 +376,29 @@ func generate(path string) (*generatorResult, error) {
 				SetNumber(1).
 				SetComments(builder.Comments{LeadingComment: fmt.Sprintf(" The key of the %s", name)}),
 		)
+		message.AddField(
+			builder.NewField(entityVersionFieldName, builder.FieldTypeInt64()).
+				SetNumber(entityVersionFieldID).
+				SetOptions(jsNumberAsStringOptions).
+				SetComments(builder.Comments{LeadingComment: fmt.Sprintf(" The version of the %s when it was read or written; pass it as expectedVersion to detect conflicting writes", name)}),
+		)
+		message.AddField(
+			builder.NewField(entityFieldViolationsFieldName, builder.FieldTypeMessage(fieldViolationMessage)).
+				SetNumber(entityFieldViolationsFieldID).
+				SetRepeated().
+				SetComments(builder.Comments{LeadingComment: fmt.Sprintf(" The fields of the stored %s whose values don't match their type; these fields are left unset", name)}),
+		)
 		for _, field := range kind.Fields {
 			if field.Id == 1 {
 				log.Fatalln("unexpected ID 1 in kind field; IDs must start at 2")
 			}
-			mfb := builder.NewField(
-				field.Name,
-				convertToType(field.Type, keyMessage, timestampMessage),
-			).
-				SetNumber(field.Id).
-				SetComments(builder.Comments{LeadingComment: fmt.Sprintf(" %s", field.Comment)})
-			switch field.Type {
-			case ValueType_int64:
-				mfb = mfb.SetOptions(jsNumberAsStringOptions)
-			case ValueType_uint64:
-				mfb = mfb.SetOptions(jsNumberAsStringOptions)
+			if field.Id == entityVersionFieldID || field.Name == entityVersionFieldName {
+				log.Fatalln(fmt.Sprintf("kind field '%s' conflicts with the reserved field '%s' (ID %d)", field.Name, entityVersionFieldName, entityVersionFieldID))
+			}
+			if field.Id == entityFieldViolationsFieldID || field.Name == entityFieldViolationsFieldName {
+				log.Fatalln(fmt.Sprintf("kind field '%s' conflicts with the reserved field '%s' (ID %d)", field.Name, entityFieldViolationsFieldName, entityFieldViolationsFieldID))
 			}
-			message.AddField(mfb)
+			message.AddField(convertToFieldBuilder(field, types, jsNumberAsStringOptions))
 		}
 		messages = append(messages, message)
 		kindMessageMap[name] = message
@@ -221,7 +406,9 @@ func generate(path string) (*generatorResult, error) {
 		// Build the request-response messages for the List method
 		listRequestMessage := builder.NewMessage(fmt.Sprintf("List%sRequest", name)).
 			AddField(builder.NewField("start", builder.FieldTypeBytes()).SetComments(builder.Comments{LeadingComment: " The start cursor from a previous List call, or null"})).
-			AddField(builder.NewField("limit", builder.FieldTypeUInt32()).SetComments(builder.Comments{LeadingComment: " The maximum number of results to return, or null for no limit"}))
+			AddField(builder.NewField("limit", builder.FieldTypeUInt32()).SetComments(builder.Comments{LeadingComment: " The maximum number of results to return, or null for no limit"})).
+			AddField(builder.NewField("partitionId", builder.FieldTypeMessage(partitionIDMessage)).SetComments(builder.Comments{LeadingComment: " The namespace to list entities from, or null for the default namespace"})).
+			AddField(builder.NewField("ancestor", builder.FieldTypeMessage(keyMessage)).SetComments(builder.Comments{LeadingComment: fmt.Sprintf(" If set, only %ss nested under this entity are listed, and partitionId is ignored", name)}))
 		listResponseMessage := builder.NewMessage(fmt.Sprintf("List%sResponse", name)).
 			AddField(builder.NewField("next", builder.FieldTypeBytes()).SetComments(builder.Comments{LeadingComment: " The cursor to pass to the start field of the next List call"})).
 			AddField(builder.NewField("moreResults", builder.FieldTypeBool()).SetComments(builder.Comments{LeadingComment: " True if there are more results available in a future List call"})).
@@ -234,7 +421,9 @@ func generate(path string) (*generatorResult, error) {
 			AddField(builder.NewField("entity", builder.FieldTypeMessage(message)).SetComments(builder.Comments{LeadingComment: fmt.Sprintf(" The %s that was fetched, or null if it didn't exist", name)}))
 
 		// Build the request-response message for the Watch method
-		watchRequestMessage := builder.NewMessage(fmt.Sprintf("Watch%sRequest", name))
+		watchRequestMessage := builder.NewMessage(fmt.Sprintf("Watch%sRequest", name)).
+			AddField(builder.NewField("partitionId", builder.FieldTypeMessage(partitionIDMessage)).SetComments(builder.Comments{LeadingComment: " The namespace to watch entities in, or null for the default namespace"})).
+			AddField(builder.NewField("ancestor", builder.FieldTypeMessage(keyMessage)).SetComments(builder.Comments{LeadingComment: fmt.Sprintf(" If set, only %ss nested under this entity are watched, and partitionId is ignored", name)}))
 		watchEventMessage := builder.NewMessage(fmt.Sprintf("Watch%sEvent", name)).
 			AddField(builder.NewField("type", builder.FieldTypeEnum(watchEventTypeEnum)).SetComments(builder.Comments{LeadingComment: " The type of modification"})).
 			AddField(builder.NewField("entity", builder.FieldTypeMessage(message)).SetComments(builder.Comments{LeadingComment: fmt.Sprintf(" The %s that was created, modified or deleted", name)})).
@@ -243,7 +432,9 @@ func generate(path string) (*generatorResult, error) {
 
 		// Build the request-response message for the Update method
 		updateRequestMessage := builder.NewMessage(fmt.Sprintf("Update%sRequest", name)).
-			AddField(builder.NewField("entity", builder.FieldTypeMessage(message)).SetComments(builder.Comments{LeadingComment: fmt.Sprintf(" The %s entity to update", name)}))
+			AddField(builder.NewField("entity", builder.FieldTypeMessage(message)).SetComments(builder.Comments{LeadingComment: fmt.Sprintf(" The %s entity to update", name)})).
+			AddField(builder.NewField("expectedVersion", builder.FieldTypeInt64()).SetOptions(jsNumberAsStringOptions).SetComments(builder.Comments{LeadingComment: fmt.Sprintf(" If not 0, the update fails with ABORTED unless the %s is currently at this version", name)})).
+			AddField(builder.NewField("fieldMask", builder.FieldTypeString()).SetRepeated().SetComments(builder.Comments{LeadingComment: fmt.Sprintf(" If set, only these fields of the %s are updated, and the rest are left as they are stored; a single entry of a map field can be named as \"field.key\"", name)}))
 		updateResponseMessage := builder.NewMessage(fmt.Sprintf("Update%sResponse", name)).
 			AddField(builder.NewField("entity", builder.FieldTypeMessage(message)).SetComments(builder.Comments{LeadingComment: fmt.Sprintf(" The stored version of the %s entity", name)}))
 
@@ -255,10 +446,29 @@ func generate(path string) (*generatorResult, error) {
 
 		// Build the request-response message for the Delete method
 		deleteRequestMessage := builder.NewMessage(fmt.Sprintf("Delete%sRequest", name)).
-			AddField(builder.NewField("key", builder.FieldTypeMessage(keyMessage)).SetComments(builder.Comments{LeadingComment: fmt.Sprintf(" The ID of the %s to delete", name)}))
+			AddField(builder.NewField("key", builder.FieldTypeMessage(keyMessage)).SetComments(builder.Comments{LeadingComment: fmt.Sprintf(" The ID of the %s to delete", name)})).
+			AddField(builder.NewField("expectedVersion", builder.FieldTypeInt64()).SetOptions(jsNumberAsStringOptions).SetComments(builder.Comments{LeadingComment: fmt.Sprintf(" If not 0, the delete fails with ABORTED unless the %s is currently at this version", name)}))
 		deleteResponseMessage := builder.NewMessage(fmt.Sprintf("Delete%sResponse", name)).
 			AddField(builder.NewField("entity", builder.FieldTypeMessage(message)).SetComments(builder.Comments{LeadingComment: fmt.Sprintf(" The version of the %s entity that was deleted", name)}))
 
+		// Build the messages for the GetHistory and GetAtVersion methods
+		versionMessage := builder.NewMessage(fmt.Sprintf("%sVersion", name)).
+			AddField(builder.NewField("version", builder.FieldTypeInt64()).SetOptions(jsNumberAsStringOptions).SetComments(builder.Comments{LeadingComment: " The version number, starting at 1"})).
+			AddField(builder.NewField("transactionId", builder.FieldTypeString()).SetComments(builder.Comments{LeadingComment: " The ID of the transaction that created this version"})).
+			AddField(builder.NewField("authSub", builder.FieldTypeString()).SetComments(builder.Comments{LeadingComment: " The subject of the user that submitted the transaction, if known"})).
+			AddField(builder.NewField("dateSubmitted", builder.FieldTypeImportedMessage(timestampMessage)).SetComments(builder.Comments{LeadingComment: " When the transaction was submitted"})).
+			AddField(builder.NewField("deleted", builder.FieldTypeBool()).SetComments(builder.Comments{LeadingComment: fmt.Sprintf(" True if this version deleted the %s", name)})).
+			AddField(builder.NewField("entity", builder.FieldTypeMessage(message)).SetComments(builder.Comments{LeadingComment: fmt.Sprintf(" The %s as of this version, or null if it was deleted", name)}))
+		getHistoryRequestMessage := builder.NewMessage(fmt.Sprintf("GetHistory%sRequest", name)).
+			AddField(builder.NewField("key", builder.FieldTypeMessage(keyMessage)).SetComments(builder.Comments{LeadingComment: fmt.Sprintf(" The ID of the %s to load the history of", name)}))
+		getHistoryResponseMessage := builder.NewMessage(fmt.Sprintf("GetHistory%sResponse", name)).
+			AddField(builder.NewField("versions", builder.FieldTypeMessage(versionMessage)).SetRepeated().SetComments(builder.Comments{LeadingComment: fmt.Sprintf(" Every version of the %s, oldest first", name)}))
+		getAtVersionRequestMessage := builder.NewMessage(fmt.Sprintf("GetAtVersion%sRequest", name)).
+			AddField(builder.NewField("key", builder.FieldTypeMessage(keyMessage)).SetComments(builder.Comments{LeadingComment: fmt.Sprintf(" The ID of the %s to load", name)})).
+			AddField(builder.NewField("version", builder.FieldTypeInt64()).SetOptions(jsNumberAsStringOptions).SetComments(builder.Comments{LeadingComment: " The version number to load"}))
+		getAtVersionResponseMessage := builder.NewMessage(fmt.Sprintf("GetAtVersion%sResponse", name)).
+			AddField(builder.NewField("entityVersion", builder.FieldTypeMessage(versionMessage)).SetComments(builder.Comments{LeadingComment: fmt.Sprintf(" The requested version of the %s", name)}))
+
 		messages = append(messages, listRequestMessage)
 		messages = append(messages, listResponseMessage)
 		messages = append(messages, getRequestMessage)
@@ -271,6 +481,11 @@ func generate(path string) (*generatorResult, error) {
 		messages = append(messages, createResponseMessage)
 		messages = append(messages, deleteRequestMessage)
 		messages = append(messages, deleteResponseMessage)
+		messages = append(messages, versionMessage)
+		messages = append(messages, getHistoryRequestMessage)
+		messages = append(messages, getHistoryResponseMessage)
+		messages = append(messages, getAtVersionRequestMessage)
+		messages = append(messages, getAtVersionResponseMessage)
 
 		service := builder.NewService(fmt.Sprintf("%sService", name)).
 			AddMethod(builder.NewMethod(
@@ -302,7 +517,17 @@ func generate(path string) (*generatorResult, error) {
 				"Delete",
 				builder.RpcTypeMessage(deleteRequestMessage, false),
 				builder.RpcTypeMessage(deleteResponseMessage, false),
-			).SetComments(builder.Comments{LeadingComment: fmt.Sprintf(" Delete a single %s", name)}))
+			).SetComments(builder.Comments{LeadingComment: fmt.Sprintf(" Delete a single %s", name)})).
+			AddMethod(builder.NewMethod(
+				"GetHistory",
+				builder.RpcTypeMessage(getHistoryRequestMessage, false),
+				builder.RpcTypeMessage(getHistoryResponseMessage, false),
+			).SetComments(builder.Comments{LeadingComment: fmt.Sprintf(" Retrieve every recorded version of a single %s", name)})).
+			AddMethod(builder.NewMethod(
+				"GetAtVersion",
+				builder.RpcTypeMessage(getAtVersionRequestMessage, false),
+				builder.RpcTypeMessage(getAtVersionResponseMessage, false),
+			).SetComments(builder.Comments{LeadingComment: fmt.Sprintf(" Retrieve a single recorded version of a %s", name)}))
 		services = append(services, service)
 
 		kindMap[service] = kind
@@ -343,6 +568,9 @@ func generate(path string) (*generatorResult, error) {
 		)
 
 		typedWatchTransactionsRequest := builder.NewMessage("TypedWatchTransactionsRequest")
+		typedWatchTransactionsRequest.AddField(
+			builder.NewField("partitionId", builder.FieldTypeMessage(partitionIDMessage)).SetNumber(1),
+		)
 
 		typedWatchTransactionsResponse := builder.NewMessage("TypedWatchTransactionsResponse")
 		typedWatchTransactionsResponse.AddOneOf(
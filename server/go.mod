@@ -16,6 +16,8 @@ require (
 	github.com/pkg/errors v0.8.1 // indirect
 	github.com/rs/cors v1.6.0 // indirect
 	github.com/rs/xid v1.2.1 // indirect
+	go.etcd.io/bbolt v1.3.5
+	google.golang.org/genproto v0.0.0-20190201180003-4b09977fb922
 	google.golang.org/grpc v1.18.0
 	gotest.tools v2.2.0+incompatible
 )
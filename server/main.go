@@ -29,30 +29,46 @@ type emptyServerInterface interface {
 }
 
 type runtimeConfig struct {
-	GoogleCloudProjectID          string `envconfig:"GOOGLE_CLOUD_PROJECT_ID" required:"true"`
-	GrpcPort                      uint16 `envconfig:"GRPC_PORT" required:"true"`
-	HTTPPort                      uint16 `envconfig:"HTTP_PORT" required:"true"`
-	SchemaPath                    string `envconfig:"SCHEMA_PATH" required:"true"`
-	AllowedOrigins                string `envconfig:"ALLOWED_ORIGINS"`
-	AuthEnabled                   bool   `envconfig:"AUTH_ENABLED"`
-	AuthSigningKey                string `envconfig:"AUTH_SIGNING_KEY"`
-	AuthRequiredScopes            string `envconfig:"AUTH_REQUIRED_SCOPES"`
-	AuthIss                       string `envconfig:"AUTH_ISS"`
-	AuthAud                       string `envconfig:"AUTH_AUD"`
+	Backend                       string        `envconfig:"BACKEND" default:"firestore"`
+	BoltPath                      string        `envconfig:"BOLT_PATH" default:"configstore.db"`
+	GoogleCloudProjectID          string        `envconfig:"GOOGLE_CLOUD_PROJECT_ID"`
+	GrpcPort                      uint16        `envconfig:"GRPC_PORT" required:"true"`
+	HTTPPort                      uint16        `envconfig:"HTTP_PORT" required:"true"`
+	SchemaPath                    string        `envconfig:"SCHEMA_PATH" required:"true"`
+	AllowedOrigins                string        `envconfig:"ALLOWED_ORIGINS"`
+	AuthEnabled                   bool          `envconfig:"AUTH_ENABLED"`
+	AuthSigningKey                string        `envconfig:"AUTH_SIGNING_KEY"`
+	AuthRequiredScopes            string        `envconfig:"AUTH_REQUIRED_SCOPES"`
+	AuthIss                       string        `envconfig:"AUTH_ISS"`
+	AuthAud                       string        `envconfig:"AUTH_AUD"`
+	AuthTenantClaim               string        `envconfig:"AUTH_TENANT_CLAIM"`
+	AuthReadonlyOverrideScope     string        `envconfig:"AUTH_READONLY_OVERRIDE_SCOPE"`
+	AuthAdminScope                string        `envconfig:"AUTH_ADMIN_SCOPE"`
+	TransactionRetention          time.Duration `envconfig:"TRANSACTION_RETENTION"`
+	TransactionRetentionCount     int           `envconfig:"TRANSACTION_RETENTION_COUNT"`
+	TransactionArchivePath        string        `envconfig:"TRANSACTION_ARCHIVE_PATH"`
+	TransactionCompactionInterval time.Duration `envconfig:"TRANSACTION_COMPACTION_INTERVAL" default:"10m"`
 }
 
 type runMode string
 
 const (
-	runModeServe    runMode = "serve"
-	runModeGenerate runMode = "generate"
+	runModeServe         runMode = "serve"
+	runModeGenerate      runMode = "generate"
 	runModeGenerateProto runMode = "generate-proto"
+	runModeExport        runMode = "export"
+	runModeImport        runMode = "import"
 )
 
 func main() {
 	mode := runModeServe
 	generateFlag := flag.Bool("generate", false, "emit Go client code instead of serving traffic")
 	generateProtoFlag := flag.Bool("generate-proto", false, "emit Protobuf instead of serving traffic")
+	exportFlag := flag.String("export", "", "export every entity to the given NDJSON file instead of serving traffic")
+	importFlag := flag.String("import", "", "import entities from the given NDJSON file instead of serving traffic")
+	importConflictFlag := flag.String("import-conflict", "fail", "what to do when an imported entity already exists: skip, overwrite or fail")
+	importBatchSizeFlag := flag.Int("import-batch-size", 100, "the maximum number of entities to import in a single transaction")
+	namespaceFlag := flag.String("namespace", "", "the namespace to export from or import into, instead of the default namespace")
 	flag.Parse()
 	if *generateFlag {
 		mode = runModeGenerate
@@ -60,6 +76,12 @@ func main() {
 	if *generateProtoFlag {
 		mode = runModeGenerateProto
 	}
+	if *exportFlag != "" {
+		mode = runModeExport
+	}
+	if *importFlag != "" {
+		mode = runModeImport
+	}
 
 	config := &runtimeConfig{}
 	err := envconfig.Process("CONFIGSTORE", config)
@@ -95,39 +117,77 @@ func main() {
 	}
 
 	if mode == runModeServe {
-		// Connect to Firestore using Application Default Credentials
-		conf := &firebase.Config{ProjectID: config.GoogleCloudProjectID}
-		app, err := firebase.NewApp(ctx, conf)
+		// Connect to the storage backend
+		storage, err := createStorage(ctx, config)
 		if err != nil {
-			log.Fatalln(fmt.Errorf("can't connect to Firebase: %v", err))
+			log.Fatalln(err)
 		}
-		client, err := app.Firestore(ctx)
+		defer storage.Close()
+
+		// Guard the values of entities written before their fields were
+		// marked unique
+		err = backfillUniqueGuards(ctx, storage, genResult.Schema)
 		if err != nil {
-			log.Fatalln(fmt.Errorf("can't connect to Firestore: %v", err))
+			log.Fatalln(fmt.Errorf("can't guard unique fields: %v", err))
 		}
-		defer client.Close()
 
-		// Start the transaction watcher
-		transactionWatcher, err := createTransactionWatcher(ctx, client, genResult.Schema)
+		// Start the transaction watcher for the default namespace
+		transactionWatchers, err := createTransactionWatcherSet(ctx, storage, genResult.Schema)
 		if err != nil {
 			log.Fatalln(fmt.Errorf("can't create transaction watcher: %v", err))
 		}
 
+		// Periodically remove old transactions if a retention is configured
+		if config.TransactionRetention > 0 || config.TransactionRetentionCount > 0 {
+			compactor := createTransactionCompactor(
+				storage,
+				config.TransactionRetention,
+				config.TransactionRetentionCount,
+				config.TransactionArchivePath,
+				config.TransactionCompactionInterval,
+			)
+			go compactor.run(ctx)
+		}
+
 		// Serve the configstore gRPC server
 		lis, err := net.Listen("tcp", fmt.Sprintf(":%d", config.GrpcPort))
 		if err != nil {
 			log.Fatalln(fmt.Errorf("can't serve the gRPC server: %v", err))
 		}
-		grpcServer := grpc.NewServer()
+		var grpcOptions []grpc.ServerOption
+		if config.AuthEnabled {
+			unaryInterceptor, streamInterceptor := authGrpcInterceptors(
+				[]byte(config.AuthSigningKey),
+				strings.Split(config.AuthRequiredScopes, ","),
+				config.AuthIss,
+				config.AuthAud,
+				config.AuthTenantClaim,
+				config.AuthReadonlyOverrideScope,
+				config.AuthAdminScope,
+			)
+			grpcOptions = append(
+				grpcOptions,
+				grpc.UnaryInterceptor(unaryInterceptor),
+				grpc.StreamInterceptor(streamInterceptor),
+			)
+		} else {
+			unaryInterceptor, streamInterceptor := authDisabledGrpcInterceptors()
+			grpcOptions = append(
+				grpcOptions,
+				grpc.UnaryInterceptor(unaryInterceptor),
+				grpc.StreamInterceptor(streamInterceptor),
+			)
+		}
+		grpcServer := grpc.NewServer(grpcOptions...)
 		emptyServer := new(emptyServerInterface)
 		for _, service := range genResult.Services {
 			dynamicProtobufServer := createConfigstoreDynamicProtobufServer(
-				client,
+				storage,
 				genResult,
 				service,
 				genResult.KindNameMap[service],
 				genResult.Schema,
-				transactionWatcher,
+				transactionWatchers,
 			)
 
 			grpcServer.RegisterService(
@@ -170,6 +230,20 @@ func main() {
 								return out, err
 							},
 						},
+						{
+							MethodName: "GetHistory",
+							Handler: func(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+								out, err := dynamicProtobufServer.dynamicProtobufGetHistory(srv, ctx, dec, interceptor)
+								return out, err
+							},
+						},
+						{
+							MethodName: "GetAtVersion",
+							Handler: func(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+								out, err := dynamicProtobufServer.dynamicProtobufGetAtVersion(srv, ctx, dec, interceptor)
+								return out, err
+							},
+						},
 					},
 					Streams: []grpc.StreamDesc{
 						{
@@ -188,11 +262,11 @@ func main() {
 		}
 
 		dynamicProtobufTransactionServer := createConfigstoreDynamicProtobufTransactionServer(
-			client,
+			storage,
 			genResult,
 			genResult.TransactionService,
 			genResult.Schema,
-			transactionWatcher,
+			transactionWatchers,
 		)
 		grpcServer.RegisterService(
 			&grpc.ServiceDesc{
@@ -216,10 +290,10 @@ func main() {
 
 		// Add the metadata server.
 		metaServer := createConfigstoreMetaServiceServer(
-			client,
+			storage,
 			genResult.Schema,
-			createTransactionProcessor(client),
-			transactionWatcher,
+			createTransactionProcessor(storage),
+			transactionWatchers,
 		)
 		RegisterConfigstoreMetaServiceServer(grpcServer, metaServer)
 		grpcServer.RegisterService(&grpc.ServiceDesc{
@@ -262,6 +336,22 @@ func main() {
 					MethodName: "GetTransactionQueueCount",
 					Handler:    _ConfigstoreMetaService_GetTransactionQueueCount_Handler,
 				},
+				{
+					MethodName: "RestoreToTime",
+					Handler:    _ConfigstoreMetaService_RestoreToTime_Handler,
+				},
+				{
+					MethodName: "MetaGetHistory",
+					Handler:    _ConfigstoreMetaService_MetaGetHistory_Handler,
+				},
+				{
+					MethodName: "MetaGetAtVersion",
+					Handler:    _ConfigstoreMetaService_MetaGetAtVersion_Handler,
+				},
+				{
+					MethodName: "FindReferencing",
+					Handler:    _ConfigstoreMetaService_FindReferencing_Handler,
+				},
 			},
 			Streams: []grpc.StreamDesc{
 				{
@@ -312,6 +402,9 @@ func main() {
 						strings.Split(config.AuthRequiredScopes, ","),
 						config.AuthIss,
 						config.AuthAud,
+						config.AuthTenantClaim,
+						config.AuthReadonlyOverrideScope,
+						config.AuthAdminScope,
 					).ServeHTTP(w, r)
 				} else {
 					h.ServeHTTP(w, r)
@@ -332,6 +425,58 @@ func main() {
 			}
 			return false
 		})
+	} else if mode == runModeExport {
+		storage, err := createStorage(ctx, config)
+		if err != nil {
+			log.Fatalln(err)
+		}
+		defer storage.Close()
+
+		file, err := os.Create(*exportFlag)
+		if err != nil {
+			log.Fatalln(fmt.Errorf("can't create export file: %v", err))
+		}
+		defer file.Close()
+
+		count, err := exportDataset(ctx, storage, genResult.Schema, *namespaceFlag, file)
+		if err != nil {
+			log.Fatalln(fmt.Errorf("can't export entities: %v", err))
+		}
+		log.Printf("exported %d entities to '%s'", count, *exportFlag)
+	} else if mode == runModeImport {
+		conflictMode, err := parseDatasetConflictMode(*importConflictFlag)
+		if err != nil {
+			log.Fatalln(err)
+		}
+
+		storage, err := createStorage(ctx, config)
+		if err != nil {
+			log.Fatalln(err)
+		}
+		defer storage.Close()
+
+		file, err := os.Open(*importFlag)
+		if err != nil {
+			log.Fatalln(fmt.Errorf("can't open import file: %v", err))
+		}
+		defer file.Close()
+
+		result, err := importDataset(
+			ctx,
+			storage,
+			genResult.Schema,
+			*namespaceFlag,
+			file,
+			conflictMode,
+			*importBatchSizeFlag,
+			fmt.Sprintf("import from '%s'", *importFlag),
+		)
+		if result != nil {
+			log.Printf("imported %d entities, skipped %d existing entities", result.Imported, result.Skipped)
+		}
+		if err != nil {
+			log.Fatalln(fmt.Errorf("can't import entities: %v", err))
+		}
 	} else if mode == runModeGenerate {
 		fmt.Println(clientProtoGoCode)
 	} else if mode == runModeGenerateProto {
@@ -339,6 +484,35 @@ func main() {
 	}
 }
 
+func createStorage(ctx context.Context, config *runtimeConfig) (storageBackend, error) {
+	switch config.Backend {
+	case "firestore":
+		if config.GoogleCloudProjectID == "" {
+			return nil, fmt.Errorf("CONFIGSTORE_GOOGLE_CLOUD_PROJECT_ID must be set when using the Firestore backend")
+		}
+
+		// Connect to Firestore using Application Default Credentials
+		conf := &firebase.Config{ProjectID: config.GoogleCloudProjectID}
+		app, err := firebase.NewApp(ctx, conf)
+		if err != nil {
+			return nil, fmt.Errorf("can't connect to Firebase: %v", err)
+		}
+		client, err := app.Firestore(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("can't connect to Firestore: %v", err)
+		}
+		return createFirestoreStorage(client), nil
+	case "memory":
+		log.Println("using in-memory storage; all data will be lost when configstore exits")
+		return createMemoryStorage(), nil
+	case "bolt":
+		log.Printf("using bolt storage at '%s'", config.BoltPath)
+		return createBoltStorage(config.BoltPath)
+	default:
+		return nil, fmt.Errorf("unknown storage backend '%s', expected 'firestore', 'memory' or 'bolt'", config.Backend)
+	}
+}
+
 // setDummyClientHeaders sets two empty headers "grpc-status" and "grpc-message", which
 // the JS client tries to read on responses. We don't actually populate them with anything
 // but the server-side gRPC handler looks at the headers that were sent by the server
@@ -7,6 +7,8 @@ import (
 	context "context"
 	fmt "fmt"
 	proto "github.com/golang/protobuf/proto"
+	duration "github.com/golang/protobuf/ptypes/duration"
+	_struct "github.com/golang/protobuf/ptypes/struct"
 	timestamp "github.com/golang/protobuf/ptypes/timestamp"
 	grpc "google.golang.org/grpc"
 	codes "google.golang.org/grpc/codes"
@@ -37,18 +39,39 @@ const (
 	ValueType_bytes     ValueType = 6
 	ValueType_key       ValueType = 7
 	ValueType_uint64    ValueType = 8
+	// A value made of the fields of one of the schema's structs.
+	ValueType_struct ValueType = 9
+	// A map from strings to values of the field's mapValueType.
+	ValueType_map ValueType = 10
+	// One of the values of the field's enum, held by name in stringValue.
+	ValueType_enum ValueType = 11
+	// An exact decimal number with the field's decimalScale.
+	ValueType_decimal ValueType = 12
+	// A length of time, stored as a number of nanoseconds.
+	ValueType_duration ValueType = 13
+	// A latitude and longitude, stored as a Firestore GeoPoint.
+	ValueType_geopoint ValueType = 14
+	// A JSON object, stored as a map.
+	ValueType_json ValueType = 15
 )
 
 var ValueType_name = map[int32]string{
-	0: "unknown",
-	1: "double",
-	2: "int64",
-	3: "string",
-	4: "timestamp",
-	5: "boolean",
-	6: "bytes",
-	7: "key",
-	8: "uint64",
+	0:  "unknown",
+	1:  "double",
+	2:  "int64",
+	3:  "string",
+	4:  "timestamp",
+	5:  "boolean",
+	6:  "bytes",
+	7:  "key",
+	8:  "uint64",
+	9:  "struct",
+	10: "map",
+	11: "enum",
+	12: "decimal",
+	13: "duration",
+	14: "geopoint",
+	15: "json",
 }
 
 var ValueType_value = map[string]int32{
@@ -61,6 +84,13 @@ var ValueType_value = map[string]int32{
 	"bytes":     6,
 	"key":       7,
 	"uint64":    8,
+	"struct":    9,
+	"map":       10,
+	"enum":      11,
+	"decimal":   12,
+	"duration":  13,
+	"geopoint":  14,
+	"json":      15,
 }
 
 func (x ValueType) String() string {
@@ -71,6 +101,41 @@ func (ValueType) EnumDescriptor() ([]byte, []int) {
 	return fileDescriptor_3b5ea8fe65782bcc, []int{0}
 }
 
+type SchemaFieldOnDelete int32
+
+const (
+	// The reference is left as it is.
+	SchemaFieldOnDelete_noAction SchemaFieldOnDelete = 0
+	// The referenced entity can't be deleted while this entity refers to it.
+	SchemaFieldOnDelete_restrict SchemaFieldOnDelete = 1
+	// This entity is deleted along with the referenced entity.
+	SchemaFieldOnDelete_cascade SchemaFieldOnDelete = 2
+	// This field is cleared.
+	SchemaFieldOnDelete_setNull SchemaFieldOnDelete = 3
+)
+
+var SchemaFieldOnDelete_name = map[int32]string{
+	0: "noAction",
+	1: "restrict",
+	2: "cascade",
+	3: "setNull",
+}
+
+var SchemaFieldOnDelete_value = map[string]int32{
+	"noAction": 0,
+	"restrict": 1,
+	"cascade":  2,
+	"setNull":  3,
+}
+
+func (x SchemaFieldOnDelete) String() string {
+	return proto.EnumName(SchemaFieldOnDelete_name, int32(x))
+}
+
+func (SchemaFieldOnDelete) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_3b5ea8fe65782bcc, []int{1}
+}
+
 type SchemaFieldEditorInfoType int32
 
 const (
@@ -96,7 +161,7 @@ func (x SchemaFieldEditorInfoType) String() string {
 }
 
 func (SchemaFieldEditorInfoType) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_3b5ea8fe65782bcc, []int{1}
+	return fileDescriptor_3b5ea8fe65782bcc, []int{2}
 }
 
 type SchemaIndexType int32
@@ -121,7 +186,7 @@ func (x SchemaIndexType) String() string {
 }
 
 func (SchemaIndexType) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_3b5ea8fe65782bcc, []int{2}
+	return fileDescriptor_3b5ea8fe65782bcc, []int{3}
 }
 
 type ConfigstoreTraceEntry_ConfigstoreTraceEntryType int32
@@ -236,7 +301,7 @@ func (x ConfigstoreTraceEntry_ConfigstoreTraceEntryType) String() string {
 }
 
 func (ConfigstoreTraceEntry_ConfigstoreTraceEntryType) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_3b5ea8fe65782bcc, []int{48, 0}
+	return fileDescriptor_3b5ea8fe65782bcc, []int{80, 0}
 }
 
 type PartitionId struct {
@@ -414,19 +479,35 @@ func (m *Key) GetPath() []*PathElement {
 }
 
 type Value struct {
-	Id                   int32                `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	Type                 ValueType            `protobuf:"varint,2,opt,name=type,proto3,enum=meta.ValueType" json:"type,omitempty"`
-	DoubleValue          float64              `protobuf:"fixed64,3,opt,name=doubleValue,proto3" json:"doubleValue,omitempty"`
-	Int64Value           int64                `protobuf:"varint,4,opt,name=int64Value,proto3" json:"int64Value,omitempty"`
-	StringValue          string               `protobuf:"bytes,5,opt,name=stringValue,proto3" json:"stringValue,omitempty"`
-	TimestampValue       *timestamp.Timestamp `protobuf:"bytes,6,opt,name=timestampValue,proto3" json:"timestampValue,omitempty"`
-	BooleanValue         bool                 `protobuf:"varint,7,opt,name=booleanValue,proto3" json:"booleanValue,omitempty"`
-	BytesValue           []byte               `protobuf:"bytes,8,opt,name=bytesValue,proto3" json:"bytesValue,omitempty"`
-	KeyValue             *Key                 `protobuf:"bytes,9,opt,name=keyValue,proto3" json:"keyValue,omitempty"`
-	Uint64Value          uint64               `protobuf:"varint,10,opt,name=uint64Value,proto3" json:"uint64Value,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
-	XXX_unrecognized     []byte               `json:"-"`
-	XXX_sizecache        int32                `json:"-"`
+	Id             int32                `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Type           ValueType            `protobuf:"varint,2,opt,name=type,proto3,enum=meta.ValueType" json:"type,omitempty"`
+	DoubleValue    float64              `protobuf:"fixed64,3,opt,name=doubleValue,proto3" json:"doubleValue,omitempty"`
+	Int64Value     int64                `protobuf:"varint,4,opt,name=int64Value,proto3" json:"int64Value,omitempty"`
+	StringValue    string               `protobuf:"bytes,5,opt,name=stringValue,proto3" json:"stringValue,omitempty"`
+	TimestampValue *timestamp.Timestamp `protobuf:"bytes,6,opt,name=timestampValue,proto3" json:"timestampValue,omitempty"`
+	BooleanValue   bool                 `protobuf:"varint,7,opt,name=booleanValue,proto3" json:"booleanValue,omitempty"`
+	BytesValue     []byte               `protobuf:"bytes,8,opt,name=bytesValue,proto3" json:"bytesValue,omitempty"`
+	KeyValue       *Key                 `protobuf:"bytes,9,opt,name=keyValue,proto3" json:"keyValue,omitempty"`
+	Uint64Value    uint64               `protobuf:"varint,10,opt,name=uint64Value,proto3" json:"uint64Value,omitempty"`
+	// The elements of a repeated field, each of the same type as this value.
+	// The other values are unused when the field is repeated.
+	ArrayValue []*Value `protobuf:"bytes,11,rep,name=arrayValue,proto3" json:"arrayValue,omitempty"`
+	// For struct fields, the values of the struct's fields, or null if the
+	// struct isn't set.
+	StructValue *StructValue `protobuf:"bytes,12,opt,name=structValue,proto3" json:"structValue,omitempty"`
+	// For map fields, the entries of the map, each of the same type as the
+	// field's mapValueType.
+	MapValue      map[string]*Value  `protobuf:"bytes,13,rep,name=mapValue,proto3" json:"mapValue,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	DecimalValue  *DecimalValue      `protobuf:"bytes,14,opt,name=decimalValue,proto3" json:"decimalValue,omitempty"`
+	DurationValue *duration.Duration `protobuf:"bytes,15,opt,name=durationValue,proto3" json:"durationValue,omitempty"`
+	GeopointValue *GeoPoint          `protobuf:"bytes,16,opt,name=geopointValue,proto3" json:"geopointValue,omitempty"`
+	JsonValue     *_struct.Struct    `protobuf:"bytes,17,opt,name=jsonValue,proto3" json:"jsonValue,omitempty"`
+	// For nullable fields, the field is null and the other values are
+	// unused.
+	NullValue            bool     `protobuf:"varint,18,opt,name=nullValue,proto3" json:"nullValue,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *Value) Reset()         { *m = Value{} }
@@ -524,23 +605,247 @@ func (m *Value) GetUint64Value() uint64 {
 	return 0
 }
 
+func (m *Value) GetArrayValue() []*Value {
+	if m != nil {
+		return m.ArrayValue
+	}
+	return nil
+}
+
+func (m *Value) GetStructValue() *StructValue {
+	if m != nil {
+		return m.StructValue
+	}
+	return nil
+}
+
+func (m *Value) GetMapValue() map[string]*Value {
+	if m != nil {
+		return m.MapValue
+	}
+	return nil
+}
+
+func (m *Value) GetDecimalValue() *DecimalValue {
+	if m != nil {
+		return m.DecimalValue
+	}
+	return nil
+}
+
+func (m *Value) GetDurationValue() *duration.Duration {
+	if m != nil {
+		return m.DurationValue
+	}
+	return nil
+}
+
+func (m *Value) GetGeopointValue() *GeoPoint {
+	if m != nil {
+		return m.GeopointValue
+	}
+	return nil
+}
+
+func (m *Value) GetJsonValue() *_struct.Struct {
+	if m != nil {
+		return m.JsonValue
+	}
+	return nil
+}
+
+func (m *Value) GetNullValue() bool {
+	if m != nil {
+		return m.NullValue
+	}
+	return false
+}
+
+// An exact decimal number, in the same form as google.type.Money: the value
+// is units + nanos / 10^9, and units and nanos have the same sign. Decimal
+// fields are generated with this message as their type.
+type DecimalValue struct {
+	Units                int64    `protobuf:"varint,1,opt,name=units,proto3" json:"units,omitempty"`
+	Nanos                int32    `protobuf:"varint,2,opt,name=nanos,proto3" json:"nanos,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DecimalValue) Reset()         { *m = DecimalValue{} }
+func (m *DecimalValue) String() string { return proto.CompactTextString(m) }
+func (*DecimalValue) ProtoMessage()    {}
+func (*DecimalValue) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b5ea8fe65782bcc, []int{4}
+}
+
+func (m *DecimalValue) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DecimalValue.Unmarshal(m, b)
+}
+func (m *DecimalValue) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DecimalValue.Marshal(b, m, deterministic)
+}
+func (m *DecimalValue) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DecimalValue.Merge(m, src)
+}
+func (m *DecimalValue) XXX_Size() int {
+	return xxx_messageInfo_DecimalValue.Size(m)
+}
+func (m *DecimalValue) XXX_DiscardUnknown() {
+	xxx_messageInfo_DecimalValue.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DecimalValue proto.InternalMessageInfo
+
+func (m *DecimalValue) GetUnits() int64 {
+	if m != nil {
+		return m.Units
+	}
+	return 0
+}
+
+func (m *DecimalValue) GetNanos() int32 {
+	if m != nil {
+		return m.Nanos
+	}
+	return 0
+}
+
+// A point on the earth, in degrees. Geopoint fields are generated with this
+// message as their type.
+type GeoPoint struct {
+	// From -90 to 90.
+	Latitude float64 `protobuf:"fixed64,1,opt,name=latitude,proto3" json:"latitude,omitempty"`
+	// From -180 to 180.
+	Longitude            float64  `protobuf:"fixed64,2,opt,name=longitude,proto3" json:"longitude,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GeoPoint) Reset()         { *m = GeoPoint{} }
+func (m *GeoPoint) String() string { return proto.CompactTextString(m) }
+func (*GeoPoint) ProtoMessage()    {}
+func (*GeoPoint) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b5ea8fe65782bcc, []int{5}
+}
+
+func (m *GeoPoint) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GeoPoint.Unmarshal(m, b)
+}
+func (m *GeoPoint) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GeoPoint.Marshal(b, m, deterministic)
+}
+func (m *GeoPoint) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GeoPoint.Merge(m, src)
+}
+func (m *GeoPoint) XXX_Size() int {
+	return xxx_messageInfo_GeoPoint.Size(m)
+}
+func (m *GeoPoint) XXX_DiscardUnknown() {
+	xxx_messageInfo_GeoPoint.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GeoPoint proto.InternalMessageInfo
+
+func (m *GeoPoint) GetLatitude() float64 {
+	if m != nil {
+		return m.Latitude
+	}
+	return 0
+}
+
+func (m *GeoPoint) GetLongitude() float64 {
+	if m != nil {
+		return m.Longitude
+	}
+	return 0
+}
+
+// The values of the fields of a struct, in the same form as the values of an
+// entity.
+type StructValue struct {
+	Values               []*Value `protobuf:"bytes,1,rep,name=values,proto3" json:"values,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *StructValue) Reset()         { *m = StructValue{} }
+func (m *StructValue) String() string { return proto.CompactTextString(m) }
+func (*StructValue) ProtoMessage()    {}
+func (*StructValue) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b5ea8fe65782bcc, []int{6}
+}
+
+func (m *StructValue) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StructValue.Unmarshal(m, b)
+}
+func (m *StructValue) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StructValue.Marshal(b, m, deterministic)
+}
+func (m *StructValue) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StructValue.Merge(m, src)
+}
+func (m *StructValue) XXX_Size() int {
+	return xxx_messageInfo_StructValue.Size(m)
+}
+func (m *StructValue) XXX_DiscardUnknown() {
+	xxx_messageInfo_StructValue.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StructValue proto.InternalMessageInfo
+
+func (m *StructValue) GetValues() []*Value {
+	if m != nil {
+		return m.Values
+	}
+	return nil
+}
+
 type SchemaField struct {
-	Id                   int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	Name                 string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	Type                 ValueType              `protobuf:"varint,3,opt,name=type,proto3,enum=meta.ValueType" json:"type,omitempty"`
-	Comment              string                 `protobuf:"bytes,4,opt,name=comment,proto3" json:"comment,omitempty"`
-	Editor               *SchemaFieldEditorInfo `protobuf:"bytes,5,opt,name=editor,proto3" json:"editor,omitempty"`
-	Readonly             bool                   `protobuf:"varint,6,opt,name=readonly,proto3" json:"readonly,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}               `json:"-"`
-	XXX_unrecognized     []byte                 `json:"-"`
-	XXX_sizecache        int32                  `json:"-"`
+	Id       int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name     string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Type     ValueType              `protobuf:"varint,3,opt,name=type,proto3,enum=meta.ValueType" json:"type,omitempty"`
+	Comment  string                 `protobuf:"bytes,4,opt,name=comment,proto3" json:"comment,omitempty"`
+	Editor   *SchemaFieldEditorInfo `protobuf:"bytes,5,opt,name=editor,proto3" json:"editor,omitempty"`
+	Readonly bool                   `protobuf:"varint,6,opt,name=readonly,proto3" json:"readonly,omitempty"`
+	// No two entities of the kind in a namespace may have the same non-empty
+	// value for this field.
+	Unique bool `protobuf:"varint,7,opt,name=unique,proto3" json:"unique,omitempty"`
+	// What happens to this entity when the entity this key field refers to
+	// is deleted. Only applies to key fields with editor.allowedKinds.
+	OnDelete SchemaFieldOnDelete `protobuf:"varint,8,opt,name=onDelete,proto3,enum=meta.SchemaFieldOnDelete" json:"onDelete,omitempty"`
+	// The field holds a list of values of its type, rather than a single
+	// value.
+	Repeated bool `protobuf:"varint,9,opt,name=repeated,proto3" json:"repeated,omitempty"`
+	// For struct fields, or map fields with struct values, the name of the
+	// struct in the schema's structs.
+	StructName string `protobuf:"bytes,10,opt,name=structName,proto3" json:"structName,omitempty"`
+	// For map fields, the type of the values in the map.
+	MapValueType ValueType `protobuf:"varint,11,opt,name=mapValueType,proto3,enum=meta.ValueType" json:"mapValueType,omitempty"`
+	// For enum fields, or map fields with enum values, the name of the enum
+	// in the schema's enums.
+	EnumName string `protobuf:"bytes,12,opt,name=enumName,proto3" json:"enumName,omitempty"`
+	// For decimal fields, or map fields with decimal values, the number of
+	// digits after the decimal point, from 0 to 9. Values with more digits
+	// are rejected rather than rounded.
+	DecimalScale int32 `protobuf:"varint,13,opt,name=decimalScale,proto3" json:"decimalScale,omitempty"`
+	// The field can be null, which is stored and returned separately from the
+	// zero value of its type. Nullable fields of scalar types are generated
+	// with the google.protobuf wrapper types, like google.protobuf.Int64Value.
+	// Repeated, map and enum fields can't be nullable.
+	Nullable             bool     `protobuf:"varint,14,opt,name=nullable,proto3" json:"nullable,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *SchemaField) Reset()         { *m = SchemaField{} }
 func (m *SchemaField) String() string { return proto.CompactTextString(m) }
 func (*SchemaField) ProtoMessage()    {}
 func (*SchemaField) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3b5ea8fe65782bcc, []int{4}
+	return fileDescriptor_3b5ea8fe65782bcc, []int{7}
 }
 
 func (m *SchemaField) XXX_Unmarshal(b []byte) error {
@@ -603,6 +908,62 @@ func (m *SchemaField) GetReadonly() bool {
 	return false
 }
 
+func (m *SchemaField) GetUnique() bool {
+	if m != nil {
+		return m.Unique
+	}
+	return false
+}
+
+func (m *SchemaField) GetOnDelete() SchemaFieldOnDelete {
+	if m != nil {
+		return m.OnDelete
+	}
+	return SchemaFieldOnDelete_noAction
+}
+
+func (m *SchemaField) GetRepeated() bool {
+	if m != nil {
+		return m.Repeated
+	}
+	return false
+}
+
+func (m *SchemaField) GetStructName() string {
+	if m != nil {
+		return m.StructName
+	}
+	return ""
+}
+
+func (m *SchemaField) GetMapValueType() ValueType {
+	if m != nil {
+		return m.MapValueType
+	}
+	return ValueType_unknown
+}
+
+func (m *SchemaField) GetEnumName() string {
+	if m != nil {
+		return m.EnumName
+	}
+	return ""
+}
+
+func (m *SchemaField) GetDecimalScale() int32 {
+	if m != nil {
+		return m.DecimalScale
+	}
+	return 0
+}
+
+func (m *SchemaField) GetNullable() bool {
+	if m != nil {
+		return m.Nullable
+	}
+	return false
+}
+
 type SchemaFieldEditorInfo struct {
 	DisplayName                           string                        `protobuf:"bytes,1,opt,name=displayName,proto3" json:"displayName,omitempty"`
 	Type                                  SchemaFieldEditorInfoType     `protobuf:"varint,2,opt,name=type,proto3,enum=meta.SchemaFieldEditorInfoType" json:"type,omitempty"`
@@ -620,7 +981,7 @@ func (m *SchemaFieldEditorInfo) Reset()         { *m = SchemaFieldEditorInfo{} }
 func (m *SchemaFieldEditorInfo) String() string { return proto.CompactTextString(m) }
 func (*SchemaFieldEditorInfo) ProtoMessage()    {}
 func (*SchemaFieldEditorInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3b5ea8fe65782bcc, []int{5}
+	return fileDescriptor_3b5ea8fe65782bcc, []int{8}
 }
 
 func (m *SchemaFieldEditorInfo) XXX_Unmarshal(b []byte) error {
@@ -697,6 +1058,21 @@ type SchemaFieldEditorValidator struct {
 	//	*SchemaFieldEditorValidator_Default
 	//	*SchemaFieldEditorValidator_FormatIPAddress
 	//	*SchemaFieldEditorValidator_FormatIPAddressPort
+	//	*SchemaFieldEditorValidator_Pattern
+	//	*SchemaFieldEditorValidator_Int64Minimum
+	//	*SchemaFieldEditorValidator_Int64Maximum
+	//	*SchemaFieldEditorValidator_Uint64Minimum
+	//	*SchemaFieldEditorValidator_Uint64Maximum
+	//	*SchemaFieldEditorValidator_DoubleMinimum
+	//	*SchemaFieldEditorValidator_DoubleMaximum
+	//	*SchemaFieldEditorValidator_MinimumLength
+	//	*SchemaFieldEditorValidator_MaximumLength
+	//	*SchemaFieldEditorValidator_AllowedValues
+	//	*SchemaFieldEditorValidator_FormatEmail
+	//	*SchemaFieldEditorValidator_FormatURL
+	//	*SchemaFieldEditorValidator_FormatURI
+	//	*SchemaFieldEditorValidator_TimestampMinimum
+	//	*SchemaFieldEditorValidator_TimestampMaximum
 	Validator            isSchemaFieldEditorValidator_Validator `protobuf_oneof:"validator"`
 	XXX_NoUnkeyedLiteral struct{}                               `json:"-"`
 	XXX_unrecognized     []byte                                 `json:"-"`
@@ -707,7 +1083,7 @@ func (m *SchemaFieldEditorValidator) Reset()         { *m = SchemaFieldEditorVal
 func (m *SchemaFieldEditorValidator) String() string { return proto.CompactTextString(m) }
 func (*SchemaFieldEditorValidator) ProtoMessage()    {}
 func (*SchemaFieldEditorValidator) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3b5ea8fe65782bcc, []int{6}
+	return fileDescriptor_3b5ea8fe65782bcc, []int{9}
 }
 
 func (m *SchemaFieldEditorValidator) XXX_Unmarshal(b []byte) error {
@@ -752,6 +1128,66 @@ type SchemaFieldEditorValidator_FormatIPAddressPort struct {
 	FormatIPAddressPort *SchemaFieldEditorValidatorFormatIPAddressPort `protobuf:"bytes,5,opt,name=formatIPAddressPort,proto3,oneof"`
 }
 
+type SchemaFieldEditorValidator_Pattern struct {
+	Pattern *SchemaFieldEditorValidatorPattern `protobuf:"bytes,6,opt,name=pattern,proto3,oneof"`
+}
+
+type SchemaFieldEditorValidator_Int64Minimum struct {
+	Int64Minimum *SchemaFieldEditorValidatorInt64Minimum `protobuf:"bytes,7,opt,name=int64Minimum,proto3,oneof"`
+}
+
+type SchemaFieldEditorValidator_Int64Maximum struct {
+	Int64Maximum *SchemaFieldEditorValidatorInt64Maximum `protobuf:"bytes,8,opt,name=int64Maximum,proto3,oneof"`
+}
+
+type SchemaFieldEditorValidator_Uint64Minimum struct {
+	Uint64Minimum *SchemaFieldEditorValidatorUint64Minimum `protobuf:"bytes,9,opt,name=uint64Minimum,proto3,oneof"`
+}
+
+type SchemaFieldEditorValidator_Uint64Maximum struct {
+	Uint64Maximum *SchemaFieldEditorValidatorUint64Maximum `protobuf:"bytes,10,opt,name=uint64Maximum,proto3,oneof"`
+}
+
+type SchemaFieldEditorValidator_DoubleMinimum struct {
+	DoubleMinimum *SchemaFieldEditorValidatorDoubleMinimum `protobuf:"bytes,11,opt,name=doubleMinimum,proto3,oneof"`
+}
+
+type SchemaFieldEditorValidator_DoubleMaximum struct {
+	DoubleMaximum *SchemaFieldEditorValidatorDoubleMaximum `protobuf:"bytes,12,opt,name=doubleMaximum,proto3,oneof"`
+}
+
+type SchemaFieldEditorValidator_MinimumLength struct {
+	MinimumLength *SchemaFieldEditorValidatorMinimumLength `protobuf:"bytes,13,opt,name=minimumLength,proto3,oneof"`
+}
+
+type SchemaFieldEditorValidator_MaximumLength struct {
+	MaximumLength *SchemaFieldEditorValidatorMaximumLength `protobuf:"bytes,14,opt,name=maximumLength,proto3,oneof"`
+}
+
+type SchemaFieldEditorValidator_AllowedValues struct {
+	AllowedValues *SchemaFieldEditorValidatorAllowedValues `protobuf:"bytes,15,opt,name=allowedValues,proto3,oneof"`
+}
+
+type SchemaFieldEditorValidator_FormatEmail struct {
+	FormatEmail *SchemaFieldEditorValidatorFormatEmail `protobuf:"bytes,16,opt,name=formatEmail,proto3,oneof"`
+}
+
+type SchemaFieldEditorValidator_FormatURL struct {
+	FormatURL *SchemaFieldEditorValidatorFormatURL `protobuf:"bytes,17,opt,name=formatURL,proto3,oneof"`
+}
+
+type SchemaFieldEditorValidator_FormatURI struct {
+	FormatURI *SchemaFieldEditorValidatorFormatURI `protobuf:"bytes,18,opt,name=formatURI,proto3,oneof"`
+}
+
+type SchemaFieldEditorValidator_TimestampMinimum struct {
+	TimestampMinimum *SchemaFieldEditorValidatorTimestampMinimum `protobuf:"bytes,19,opt,name=timestampMinimum,proto3,oneof"`
+}
+
+type SchemaFieldEditorValidator_TimestampMaximum struct {
+	TimestampMaximum *SchemaFieldEditorValidatorTimestampMaximum `protobuf:"bytes,20,opt,name=timestampMaximum,proto3,oneof"`
+}
+
 func (*SchemaFieldEditorValidator_Required) isSchemaFieldEditorValidator_Validator() {}
 
 func (*SchemaFieldEditorValidator_FixedLength) isSchemaFieldEditorValidator_Validator() {}
@@ -762,6 +1198,36 @@ func (*SchemaFieldEditorValidator_FormatIPAddress) isSchemaFieldEditorValidator_
 
 func (*SchemaFieldEditorValidator_FormatIPAddressPort) isSchemaFieldEditorValidator_Validator() {}
 
+func (*SchemaFieldEditorValidator_Pattern) isSchemaFieldEditorValidator_Validator() {}
+
+func (*SchemaFieldEditorValidator_Int64Minimum) isSchemaFieldEditorValidator_Validator() {}
+
+func (*SchemaFieldEditorValidator_Int64Maximum) isSchemaFieldEditorValidator_Validator() {}
+
+func (*SchemaFieldEditorValidator_Uint64Minimum) isSchemaFieldEditorValidator_Validator() {}
+
+func (*SchemaFieldEditorValidator_Uint64Maximum) isSchemaFieldEditorValidator_Validator() {}
+
+func (*SchemaFieldEditorValidator_DoubleMinimum) isSchemaFieldEditorValidator_Validator() {}
+
+func (*SchemaFieldEditorValidator_DoubleMaximum) isSchemaFieldEditorValidator_Validator() {}
+
+func (*SchemaFieldEditorValidator_MinimumLength) isSchemaFieldEditorValidator_Validator() {}
+
+func (*SchemaFieldEditorValidator_MaximumLength) isSchemaFieldEditorValidator_Validator() {}
+
+func (*SchemaFieldEditorValidator_AllowedValues) isSchemaFieldEditorValidator_Validator() {}
+
+func (*SchemaFieldEditorValidator_FormatEmail) isSchemaFieldEditorValidator_Validator() {}
+
+func (*SchemaFieldEditorValidator_FormatURL) isSchemaFieldEditorValidator_Validator() {}
+
+func (*SchemaFieldEditorValidator_FormatURI) isSchemaFieldEditorValidator_Validator() {}
+
+func (*SchemaFieldEditorValidator_TimestampMinimum) isSchemaFieldEditorValidator_Validator() {}
+
+func (*SchemaFieldEditorValidator_TimestampMaximum) isSchemaFieldEditorValidator_Validator() {}
+
 func (m *SchemaFieldEditorValidator) GetValidator() isSchemaFieldEditorValidator_Validator {
 	if m != nil {
 		return m.Validator
@@ -804,32 +1270,152 @@ func (m *SchemaFieldEditorValidator) GetFormatIPAddressPort() *SchemaFieldEditor
 	return nil
 }
 
-// XXX_OneofWrappers is for the internal use of the proto package.
-func (*SchemaFieldEditorValidator) XXX_OneofWrappers() []interface{} {
-	return []interface{}{
-		(*SchemaFieldEditorValidator_Required)(nil),
-		(*SchemaFieldEditorValidator_FixedLength)(nil),
-		(*SchemaFieldEditorValidator_Default)(nil),
-		(*SchemaFieldEditorValidator_FormatIPAddress)(nil),
-		(*SchemaFieldEditorValidator_FormatIPAddressPort)(nil),
+func (m *SchemaFieldEditorValidator) GetPattern() *SchemaFieldEditorValidatorPattern {
+	if x, ok := m.GetValidator().(*SchemaFieldEditorValidator_Pattern); ok {
+		return x.Pattern
 	}
+	return nil
 }
 
-type SchemaFieldEditorValidatorRequired struct {
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+func (m *SchemaFieldEditorValidator) GetInt64Minimum() *SchemaFieldEditorValidatorInt64Minimum {
+	if x, ok := m.GetValidator().(*SchemaFieldEditorValidator_Int64Minimum); ok {
+		return x.Int64Minimum
+	}
+	return nil
 }
 
-func (m *SchemaFieldEditorValidatorRequired) Reset()         { *m = SchemaFieldEditorValidatorRequired{} }
-func (m *SchemaFieldEditorValidatorRequired) String() string { return proto.CompactTextString(m) }
-func (*SchemaFieldEditorValidatorRequired) ProtoMessage()    {}
-func (*SchemaFieldEditorValidatorRequired) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3b5ea8fe65782bcc, []int{7}
+func (m *SchemaFieldEditorValidator) GetInt64Maximum() *SchemaFieldEditorValidatorInt64Maximum {
+	if x, ok := m.GetValidator().(*SchemaFieldEditorValidator_Int64Maximum); ok {
+		return x.Int64Maximum
+	}
+	return nil
 }
 
-func (m *SchemaFieldEditorValidatorRequired) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_SchemaFieldEditorValidatorRequired.Unmarshal(m, b)
+func (m *SchemaFieldEditorValidator) GetUint64Minimum() *SchemaFieldEditorValidatorUint64Minimum {
+	if x, ok := m.GetValidator().(*SchemaFieldEditorValidator_Uint64Minimum); ok {
+		return x.Uint64Minimum
+	}
+	return nil
+}
+
+func (m *SchemaFieldEditorValidator) GetUint64Maximum() *SchemaFieldEditorValidatorUint64Maximum {
+	if x, ok := m.GetValidator().(*SchemaFieldEditorValidator_Uint64Maximum); ok {
+		return x.Uint64Maximum
+	}
+	return nil
+}
+
+func (m *SchemaFieldEditorValidator) GetDoubleMinimum() *SchemaFieldEditorValidatorDoubleMinimum {
+	if x, ok := m.GetValidator().(*SchemaFieldEditorValidator_DoubleMinimum); ok {
+		return x.DoubleMinimum
+	}
+	return nil
+}
+
+func (m *SchemaFieldEditorValidator) GetDoubleMaximum() *SchemaFieldEditorValidatorDoubleMaximum {
+	if x, ok := m.GetValidator().(*SchemaFieldEditorValidator_DoubleMaximum); ok {
+		return x.DoubleMaximum
+	}
+	return nil
+}
+
+func (m *SchemaFieldEditorValidator) GetMinimumLength() *SchemaFieldEditorValidatorMinimumLength {
+	if x, ok := m.GetValidator().(*SchemaFieldEditorValidator_MinimumLength); ok {
+		return x.MinimumLength
+	}
+	return nil
+}
+
+func (m *SchemaFieldEditorValidator) GetMaximumLength() *SchemaFieldEditorValidatorMaximumLength {
+	if x, ok := m.GetValidator().(*SchemaFieldEditorValidator_MaximumLength); ok {
+		return x.MaximumLength
+	}
+	return nil
+}
+
+func (m *SchemaFieldEditorValidator) GetAllowedValues() *SchemaFieldEditorValidatorAllowedValues {
+	if x, ok := m.GetValidator().(*SchemaFieldEditorValidator_AllowedValues); ok {
+		return x.AllowedValues
+	}
+	return nil
+}
+
+func (m *SchemaFieldEditorValidator) GetFormatEmail() *SchemaFieldEditorValidatorFormatEmail {
+	if x, ok := m.GetValidator().(*SchemaFieldEditorValidator_FormatEmail); ok {
+		return x.FormatEmail
+	}
+	return nil
+}
+
+func (m *SchemaFieldEditorValidator) GetFormatURL() *SchemaFieldEditorValidatorFormatURL {
+	if x, ok := m.GetValidator().(*SchemaFieldEditorValidator_FormatURL); ok {
+		return x.FormatURL
+	}
+	return nil
+}
+
+func (m *SchemaFieldEditorValidator) GetFormatURI() *SchemaFieldEditorValidatorFormatURI {
+	if x, ok := m.GetValidator().(*SchemaFieldEditorValidator_FormatURI); ok {
+		return x.FormatURI
+	}
+	return nil
+}
+
+func (m *SchemaFieldEditorValidator) GetTimestampMinimum() *SchemaFieldEditorValidatorTimestampMinimum {
+	if x, ok := m.GetValidator().(*SchemaFieldEditorValidator_TimestampMinimum); ok {
+		return x.TimestampMinimum
+	}
+	return nil
+}
+
+func (m *SchemaFieldEditorValidator) GetTimestampMaximum() *SchemaFieldEditorValidatorTimestampMaximum {
+	if x, ok := m.GetValidator().(*SchemaFieldEditorValidator_TimestampMaximum); ok {
+		return x.TimestampMaximum
+	}
+	return nil
+}
+
+// XXX_OneofWrappers is for the internal use of the proto package.
+func (*SchemaFieldEditorValidator) XXX_OneofWrappers() []interface{} {
+	return []interface{}{
+		(*SchemaFieldEditorValidator_Required)(nil),
+		(*SchemaFieldEditorValidator_FixedLength)(nil),
+		(*SchemaFieldEditorValidator_Default)(nil),
+		(*SchemaFieldEditorValidator_FormatIPAddress)(nil),
+		(*SchemaFieldEditorValidator_FormatIPAddressPort)(nil),
+		(*SchemaFieldEditorValidator_Pattern)(nil),
+		(*SchemaFieldEditorValidator_Int64Minimum)(nil),
+		(*SchemaFieldEditorValidator_Int64Maximum)(nil),
+		(*SchemaFieldEditorValidator_Uint64Minimum)(nil),
+		(*SchemaFieldEditorValidator_Uint64Maximum)(nil),
+		(*SchemaFieldEditorValidator_DoubleMinimum)(nil),
+		(*SchemaFieldEditorValidator_DoubleMaximum)(nil),
+		(*SchemaFieldEditorValidator_MinimumLength)(nil),
+		(*SchemaFieldEditorValidator_MaximumLength)(nil),
+		(*SchemaFieldEditorValidator_AllowedValues)(nil),
+		(*SchemaFieldEditorValidator_FormatEmail)(nil),
+		(*SchemaFieldEditorValidator_FormatURL)(nil),
+		(*SchemaFieldEditorValidator_FormatURI)(nil),
+		(*SchemaFieldEditorValidator_TimestampMinimum)(nil),
+		(*SchemaFieldEditorValidator_TimestampMaximum)(nil),
+	}
+}
+
+type SchemaFieldEditorValidatorRequired struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SchemaFieldEditorValidatorRequired) Reset()         { *m = SchemaFieldEditorValidatorRequired{} }
+func (m *SchemaFieldEditorValidatorRequired) String() string { return proto.CompactTextString(m) }
+func (*SchemaFieldEditorValidatorRequired) ProtoMessage()    {}
+func (*SchemaFieldEditorValidatorRequired) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b5ea8fe65782bcc, []int{10}
+}
+
+func (m *SchemaFieldEditorValidatorRequired) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SchemaFieldEditorValidatorRequired.Unmarshal(m, b)
 }
 func (m *SchemaFieldEditorValidatorRequired) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	return xxx_messageInfo_SchemaFieldEditorValidatorRequired.Marshal(b, m, deterministic)
@@ -857,7 +1443,7 @@ func (m *SchemaFieldEditorValidatorFixedLength) Reset()         { *m = SchemaFie
 func (m *SchemaFieldEditorValidatorFixedLength) String() string { return proto.CompactTextString(m) }
 func (*SchemaFieldEditorValidatorFixedLength) ProtoMessage()    {}
 func (*SchemaFieldEditorValidatorFixedLength) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3b5ea8fe65782bcc, []int{8}
+	return fileDescriptor_3b5ea8fe65782bcc, []int{11}
 }
 
 func (m *SchemaFieldEditorValidatorFixedLength) XXX_Unmarshal(b []byte) error {
@@ -896,7 +1482,7 @@ func (m *SchemaFieldEditorValidatorDefault) Reset()         { *m = SchemaFieldEd
 func (m *SchemaFieldEditorValidatorDefault) String() string { return proto.CompactTextString(m) }
 func (*SchemaFieldEditorValidatorDefault) ProtoMessage()    {}
 func (*SchemaFieldEditorValidatorDefault) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3b5ea8fe65782bcc, []int{9}
+	return fileDescriptor_3b5ea8fe65782bcc, []int{12}
 }
 
 func (m *SchemaFieldEditorValidatorDefault) XXX_Unmarshal(b []byte) error {
@@ -936,7 +1522,7 @@ func (m *SchemaFieldEditorValidatorFormatIPAddress) Reset() {
 func (m *SchemaFieldEditorValidatorFormatIPAddress) String() string { return proto.CompactTextString(m) }
 func (*SchemaFieldEditorValidatorFormatIPAddress) ProtoMessage()    {}
 func (*SchemaFieldEditorValidatorFormatIPAddress) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3b5ea8fe65782bcc, []int{10}
+	return fileDescriptor_3b5ea8fe65782bcc, []int{13}
 }
 
 func (m *SchemaFieldEditorValidatorFormatIPAddress) XXX_Unmarshal(b []byte) error {
@@ -971,7 +1557,7 @@ func (m *SchemaFieldEditorValidatorFormatIPAddressPort) String() string {
 }
 func (*SchemaFieldEditorValidatorFormatIPAddressPort) ProtoMessage() {}
 func (*SchemaFieldEditorValidatorFormatIPAddressPort) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3b5ea8fe65782bcc, []int{11}
+	return fileDescriptor_3b5ea8fe65782bcc, []int{14}
 }
 
 func (m *SchemaFieldEditorValidatorFormatIPAddressPort) XXX_Unmarshal(b []byte) error {
@@ -992,1275 +1578,2578 @@ func (m *SchemaFieldEditorValidatorFormatIPAddressPort) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_SchemaFieldEditorValidatorFormatIPAddressPort proto.InternalMessageInfo
 
-type SchemaKindEditor struct {
-	Singular                      string   `protobuf:"bytes,1,opt,name=singular,proto3" json:"singular,omitempty"`
-	Plural                        string   `protobuf:"bytes,2,opt,name=plural,proto3" json:"plural,omitempty"`
-	RenderEditorDropdownWithField string   `protobuf:"bytes,3,opt,name=renderEditorDropdownWithField,proto3" json:"renderEditorDropdownWithField,omitempty"`
-	KeyComment                    string   `protobuf:"bytes,4,opt,name=keyComment,proto3" json:"keyComment,omitempty"`
-	SortByField                   string   `protobuf:"bytes,5,opt,name=sortByField,proto3" json:"sortByField,omitempty"`
-	XXX_NoUnkeyedLiteral          struct{} `json:"-"`
-	XXX_unrecognized              []byte   `json:"-"`
-	XXX_sizecache                 int32    `json:"-"`
+// A regular expression (RE2 syntax) that string values must match. Add ^
+// and $ to match the whole value.
+type SchemaFieldEditorValidatorPattern struct {
+	Pattern              string   `protobuf:"bytes,1,opt,name=pattern,proto3" json:"pattern,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *SchemaKindEditor) Reset()         { *m = SchemaKindEditor{} }
-func (m *SchemaKindEditor) String() string { return proto.CompactTextString(m) }
-func (*SchemaKindEditor) ProtoMessage()    {}
-func (*SchemaKindEditor) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3b5ea8fe65782bcc, []int{12}
+func (m *SchemaFieldEditorValidatorPattern) Reset()         { *m = SchemaFieldEditorValidatorPattern{} }
+func (m *SchemaFieldEditorValidatorPattern) String() string { return proto.CompactTextString(m) }
+func (*SchemaFieldEditorValidatorPattern) ProtoMessage()    {}
+func (*SchemaFieldEditorValidatorPattern) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b5ea8fe65782bcc, []int{15}
 }
 
-func (m *SchemaKindEditor) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_SchemaKindEditor.Unmarshal(m, b)
+func (m *SchemaFieldEditorValidatorPattern) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SchemaFieldEditorValidatorPattern.Unmarshal(m, b)
 }
-func (m *SchemaKindEditor) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_SchemaKindEditor.Marshal(b, m, deterministic)
+func (m *SchemaFieldEditorValidatorPattern) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SchemaFieldEditorValidatorPattern.Marshal(b, m, deterministic)
 }
-func (m *SchemaKindEditor) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_SchemaKindEditor.Merge(m, src)
+func (m *SchemaFieldEditorValidatorPattern) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SchemaFieldEditorValidatorPattern.Merge(m, src)
 }
-func (m *SchemaKindEditor) XXX_Size() int {
-	return xxx_messageInfo_SchemaKindEditor.Size(m)
+func (m *SchemaFieldEditorValidatorPattern) XXX_Size() int {
+	return xxx_messageInfo_SchemaFieldEditorValidatorPattern.Size(m)
 }
-func (m *SchemaKindEditor) XXX_DiscardUnknown() {
-	xxx_messageInfo_SchemaKindEditor.DiscardUnknown(m)
+func (m *SchemaFieldEditorValidatorPattern) XXX_DiscardUnknown() {
+	xxx_messageInfo_SchemaFieldEditorValidatorPattern.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_SchemaKindEditor proto.InternalMessageInfo
+var xxx_messageInfo_SchemaFieldEditorValidatorPattern proto.InternalMessageInfo
 
-func (m *SchemaKindEditor) GetSingular() string {
+func (m *SchemaFieldEditorValidatorPattern) GetPattern() string {
 	if m != nil {
-		return m.Singular
+		return m.Pattern
 	}
 	return ""
 }
 
-func (m *SchemaKindEditor) GetPlural() string {
-	if m != nil {
-		return m.Plural
-	}
-	return ""
+type SchemaFieldEditorValidatorInt64Minimum struct {
+	Value                int64    `protobuf:"varint,1,opt,name=value,proto3" json:"value,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *SchemaKindEditor) GetRenderEditorDropdownWithField() string {
-	if m != nil {
-		return m.RenderEditorDropdownWithField
-	}
-	return ""
+func (m *SchemaFieldEditorValidatorInt64Minimum) Reset() {
+	*m = SchemaFieldEditorValidatorInt64Minimum{}
+}
+func (m *SchemaFieldEditorValidatorInt64Minimum) String() string { return proto.CompactTextString(m) }
+func (*SchemaFieldEditorValidatorInt64Minimum) ProtoMessage()    {}
+func (*SchemaFieldEditorValidatorInt64Minimum) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b5ea8fe65782bcc, []int{16}
 }
 
-func (m *SchemaKindEditor) GetKeyComment() string {
-	if m != nil {
-		return m.KeyComment
-	}
-	return ""
+func (m *SchemaFieldEditorValidatorInt64Minimum) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SchemaFieldEditorValidatorInt64Minimum.Unmarshal(m, b)
+}
+func (m *SchemaFieldEditorValidatorInt64Minimum) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SchemaFieldEditorValidatorInt64Minimum.Marshal(b, m, deterministic)
+}
+func (m *SchemaFieldEditorValidatorInt64Minimum) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SchemaFieldEditorValidatorInt64Minimum.Merge(m, src)
+}
+func (m *SchemaFieldEditorValidatorInt64Minimum) XXX_Size() int {
+	return xxx_messageInfo_SchemaFieldEditorValidatorInt64Minimum.Size(m)
+}
+func (m *SchemaFieldEditorValidatorInt64Minimum) XXX_DiscardUnknown() {
+	xxx_messageInfo_SchemaFieldEditorValidatorInt64Minimum.DiscardUnknown(m)
 }
 
-func (m *SchemaKindEditor) GetSortByField() string {
+var xxx_messageInfo_SchemaFieldEditorValidatorInt64Minimum proto.InternalMessageInfo
+
+func (m *SchemaFieldEditorValidatorInt64Minimum) GetValue() int64 {
 	if m != nil {
-		return m.SortByField
+		return m.Value
 	}
-	return ""
+	return 0
 }
 
-type SchemaKind struct {
-	Fields               []*SchemaField    `protobuf:"bytes,2,rep,name=fields,proto3" json:"fields,omitempty"`
-	Editor               *SchemaKindEditor `protobuf:"bytes,3,opt,name=editor,proto3" json:"editor,omitempty"`
-	Indexes              []*SchemaIndex    `protobuf:"bytes,4,rep,name=indexes,proto3" json:"indexes,omitempty"`
-	Ancestors            []string          `protobuf:"bytes,5,rep,name=ancestors,proto3" json:"ancestors,omitempty"`
-	Id                   int32             `protobuf:"varint,6,opt,name=id,proto3" json:"id,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
-	XXX_unrecognized     []byte            `json:"-"`
-	XXX_sizecache        int32             `json:"-"`
+type SchemaFieldEditorValidatorInt64Maximum struct {
+	Value                int64    `protobuf:"varint,1,opt,name=value,proto3" json:"value,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *SchemaKind) Reset()         { *m = SchemaKind{} }
-func (m *SchemaKind) String() string { return proto.CompactTextString(m) }
-func (*SchemaKind) ProtoMessage()    {}
-func (*SchemaKind) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3b5ea8fe65782bcc, []int{13}
+func (m *SchemaFieldEditorValidatorInt64Maximum) Reset() {
+	*m = SchemaFieldEditorValidatorInt64Maximum{}
+}
+func (m *SchemaFieldEditorValidatorInt64Maximum) String() string { return proto.CompactTextString(m) }
+func (*SchemaFieldEditorValidatorInt64Maximum) ProtoMessage()    {}
+func (*SchemaFieldEditorValidatorInt64Maximum) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b5ea8fe65782bcc, []int{17}
 }
 
-func (m *SchemaKind) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_SchemaKind.Unmarshal(m, b)
+func (m *SchemaFieldEditorValidatorInt64Maximum) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SchemaFieldEditorValidatorInt64Maximum.Unmarshal(m, b)
 }
-func (m *SchemaKind) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_SchemaKind.Marshal(b, m, deterministic)
+func (m *SchemaFieldEditorValidatorInt64Maximum) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SchemaFieldEditorValidatorInt64Maximum.Marshal(b, m, deterministic)
 }
-func (m *SchemaKind) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_SchemaKind.Merge(m, src)
+func (m *SchemaFieldEditorValidatorInt64Maximum) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SchemaFieldEditorValidatorInt64Maximum.Merge(m, src)
 }
-func (m *SchemaKind) XXX_Size() int {
-	return xxx_messageInfo_SchemaKind.Size(m)
+func (m *SchemaFieldEditorValidatorInt64Maximum) XXX_Size() int {
+	return xxx_messageInfo_SchemaFieldEditorValidatorInt64Maximum.Size(m)
 }
-func (m *SchemaKind) XXX_DiscardUnknown() {
-	xxx_messageInfo_SchemaKind.DiscardUnknown(m)
+func (m *SchemaFieldEditorValidatorInt64Maximum) XXX_DiscardUnknown() {
+	xxx_messageInfo_SchemaFieldEditorValidatorInt64Maximum.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_SchemaKind proto.InternalMessageInfo
+var xxx_messageInfo_SchemaFieldEditorValidatorInt64Maximum proto.InternalMessageInfo
 
-func (m *SchemaKind) GetFields() []*SchemaField {
+func (m *SchemaFieldEditorValidatorInt64Maximum) GetValue() int64 {
 	if m != nil {
-		return m.Fields
+		return m.Value
 	}
-	return nil
+	return 0
 }
 
-func (m *SchemaKind) GetEditor() *SchemaKindEditor {
-	if m != nil {
-		return m.Editor
-	}
-	return nil
+type SchemaFieldEditorValidatorUint64Minimum struct {
+	Value                uint64   `protobuf:"varint,1,opt,name=value,proto3" json:"value,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *SchemaKind) GetIndexes() []*SchemaIndex {
-	if m != nil {
-		return m.Indexes
-	}
-	return nil
+func (m *SchemaFieldEditorValidatorUint64Minimum) Reset() {
+	*m = SchemaFieldEditorValidatorUint64Minimum{}
+}
+func (m *SchemaFieldEditorValidatorUint64Minimum) String() string { return proto.CompactTextString(m) }
+func (*SchemaFieldEditorValidatorUint64Minimum) ProtoMessage()    {}
+func (*SchemaFieldEditorValidatorUint64Minimum) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b5ea8fe65782bcc, []int{18}
 }
 
-func (m *SchemaKind) GetAncestors() []string {
-	if m != nil {
-		return m.Ancestors
-	}
-	return nil
+func (m *SchemaFieldEditorValidatorUint64Minimum) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SchemaFieldEditorValidatorUint64Minimum.Unmarshal(m, b)
+}
+func (m *SchemaFieldEditorValidatorUint64Minimum) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SchemaFieldEditorValidatorUint64Minimum.Marshal(b, m, deterministic)
+}
+func (m *SchemaFieldEditorValidatorUint64Minimum) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SchemaFieldEditorValidatorUint64Minimum.Merge(m, src)
+}
+func (m *SchemaFieldEditorValidatorUint64Minimum) XXX_Size() int {
+	return xxx_messageInfo_SchemaFieldEditorValidatorUint64Minimum.Size(m)
+}
+func (m *SchemaFieldEditorValidatorUint64Minimum) XXX_DiscardUnknown() {
+	xxx_messageInfo_SchemaFieldEditorValidatorUint64Minimum.DiscardUnknown(m)
 }
 
-func (m *SchemaKind) GetId() int32 {
+var xxx_messageInfo_SchemaFieldEditorValidatorUint64Minimum proto.InternalMessageInfo
+
+func (m *SchemaFieldEditorValidatorUint64Minimum) GetValue() uint64 {
 	if m != nil {
-		return m.Id
+		return m.Value
 	}
 	return 0
 }
 
-type SchemaIndex struct {
-	Name string          `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	Type SchemaIndexType `protobuf:"varint,2,opt,name=type,proto3,enum=meta.SchemaIndexType" json:"type,omitempty"`
-	// Types that are valid to be assigned to Value:
-	//	*SchemaIndex_Computed
-	//	*SchemaIndex_Field
-	Value                isSchemaIndex_Value `protobuf_oneof:"value"`
-	XXX_NoUnkeyedLiteral struct{}            `json:"-"`
-	XXX_unrecognized     []byte              `json:"-"`
-	XXX_sizecache        int32               `json:"-"`
+type SchemaFieldEditorValidatorUint64Maximum struct {
+	Value                uint64   `protobuf:"varint,1,opt,name=value,proto3" json:"value,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *SchemaIndex) Reset()         { *m = SchemaIndex{} }
-func (m *SchemaIndex) String() string { return proto.CompactTextString(m) }
-func (*SchemaIndex) ProtoMessage()    {}
-func (*SchemaIndex) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3b5ea8fe65782bcc, []int{14}
+func (m *SchemaFieldEditorValidatorUint64Maximum) Reset() {
+	*m = SchemaFieldEditorValidatorUint64Maximum{}
+}
+func (m *SchemaFieldEditorValidatorUint64Maximum) String() string { return proto.CompactTextString(m) }
+func (*SchemaFieldEditorValidatorUint64Maximum) ProtoMessage()    {}
+func (*SchemaFieldEditorValidatorUint64Maximum) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b5ea8fe65782bcc, []int{19}
 }
 
-func (m *SchemaIndex) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_SchemaIndex.Unmarshal(m, b)
+func (m *SchemaFieldEditorValidatorUint64Maximum) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SchemaFieldEditorValidatorUint64Maximum.Unmarshal(m, b)
 }
-func (m *SchemaIndex) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_SchemaIndex.Marshal(b, m, deterministic)
+func (m *SchemaFieldEditorValidatorUint64Maximum) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SchemaFieldEditorValidatorUint64Maximum.Marshal(b, m, deterministic)
 }
-func (m *SchemaIndex) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_SchemaIndex.Merge(m, src)
+func (m *SchemaFieldEditorValidatorUint64Maximum) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SchemaFieldEditorValidatorUint64Maximum.Merge(m, src)
 }
-func (m *SchemaIndex) XXX_Size() int {
-	return xxx_messageInfo_SchemaIndex.Size(m)
+func (m *SchemaFieldEditorValidatorUint64Maximum) XXX_Size() int {
+	return xxx_messageInfo_SchemaFieldEditorValidatorUint64Maximum.Size(m)
 }
-func (m *SchemaIndex) XXX_DiscardUnknown() {
-	xxx_messageInfo_SchemaIndex.DiscardUnknown(m)
+func (m *SchemaFieldEditorValidatorUint64Maximum) XXX_DiscardUnknown() {
+	xxx_messageInfo_SchemaFieldEditorValidatorUint64Maximum.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_SchemaIndex proto.InternalMessageInfo
+var xxx_messageInfo_SchemaFieldEditorValidatorUint64Maximum proto.InternalMessageInfo
 
-func (m *SchemaIndex) GetName() string {
+func (m *SchemaFieldEditorValidatorUint64Maximum) GetValue() uint64 {
 	if m != nil {
-		return m.Name
+		return m.Value
 	}
-	return ""
+	return 0
 }
 
-func (m *SchemaIndex) GetType() SchemaIndexType {
-	if m != nil {
-		return m.Type
-	}
-	return SchemaIndexType_unspecified
+type SchemaFieldEditorValidatorDoubleMinimum struct {
+	Value                float64  `protobuf:"fixed64,1,opt,name=value,proto3" json:"value,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-type isSchemaIndex_Value interface {
-	isSchemaIndex_Value()
+func (m *SchemaFieldEditorValidatorDoubleMinimum) Reset() {
+	*m = SchemaFieldEditorValidatorDoubleMinimum{}
 }
-
-type SchemaIndex_Computed struct {
-	Computed *SchemaComputedIndex `protobuf:"bytes,3,opt,name=computed,proto3,oneof"`
+func (m *SchemaFieldEditorValidatorDoubleMinimum) String() string { return proto.CompactTextString(m) }
+func (*SchemaFieldEditorValidatorDoubleMinimum) ProtoMessage()    {}
+func (*SchemaFieldEditorValidatorDoubleMinimum) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b5ea8fe65782bcc, []int{20}
 }
 
-type SchemaIndex_Field struct {
-	Field string `protobuf:"bytes,4,opt,name=field,proto3,oneof"`
+func (m *SchemaFieldEditorValidatorDoubleMinimum) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SchemaFieldEditorValidatorDoubleMinimum.Unmarshal(m, b)
+}
+func (m *SchemaFieldEditorValidatorDoubleMinimum) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SchemaFieldEditorValidatorDoubleMinimum.Marshal(b, m, deterministic)
+}
+func (m *SchemaFieldEditorValidatorDoubleMinimum) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SchemaFieldEditorValidatorDoubleMinimum.Merge(m, src)
+}
+func (m *SchemaFieldEditorValidatorDoubleMinimum) XXX_Size() int {
+	return xxx_messageInfo_SchemaFieldEditorValidatorDoubleMinimum.Size(m)
+}
+func (m *SchemaFieldEditorValidatorDoubleMinimum) XXX_DiscardUnknown() {
+	xxx_messageInfo_SchemaFieldEditorValidatorDoubleMinimum.DiscardUnknown(m)
 }
 
-func (*SchemaIndex_Computed) isSchemaIndex_Value() {}
-
-func (*SchemaIndex_Field) isSchemaIndex_Value() {}
+var xxx_messageInfo_SchemaFieldEditorValidatorDoubleMinimum proto.InternalMessageInfo
 
-func (m *SchemaIndex) GetValue() isSchemaIndex_Value {
+func (m *SchemaFieldEditorValidatorDoubleMinimum) GetValue() float64 {
 	if m != nil {
 		return m.Value
 	}
-	return nil
-}
-
-func (m *SchemaIndex) GetComputed() *SchemaComputedIndex {
-	if x, ok := m.GetValue().(*SchemaIndex_Computed); ok {
-		return x.Computed
-	}
-	return nil
-}
-
-func (m *SchemaIndex) GetField() string {
-	if x, ok := m.GetValue().(*SchemaIndex_Field); ok {
-		return x.Field
-	}
-	return ""
+	return 0
 }
 
-// XXX_OneofWrappers is for the internal use of the proto package.
-func (*SchemaIndex) XXX_OneofWrappers() []interface{} {
-	return []interface{}{
-		(*SchemaIndex_Computed)(nil),
-		(*SchemaIndex_Field)(nil),
-	}
+type SchemaFieldEditorValidatorDoubleMaximum struct {
+	Value                float64  `protobuf:"fixed64,1,opt,name=value,proto3" json:"value,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-type SchemaComputedIndex struct {
-	// Types that are valid to be assigned to Algorithm:
-	//	*SchemaComputedIndex_Fnv64A
-	//	*SchemaComputedIndex_Fnv64APair
-	//	*SchemaComputedIndex_Fnv32A
-	//	*SchemaComputedIndex_Fnv32APair
-	Algorithm            isSchemaComputedIndex_Algorithm `protobuf_oneof:"algorithm"`
-	XXX_NoUnkeyedLiteral struct{}                        `json:"-"`
-	XXX_unrecognized     []byte                          `json:"-"`
-	XXX_sizecache        int32                           `json:"-"`
+func (m *SchemaFieldEditorValidatorDoubleMaximum) Reset() {
+	*m = SchemaFieldEditorValidatorDoubleMaximum{}
 }
-
-func (m *SchemaComputedIndex) Reset()         { *m = SchemaComputedIndex{} }
-func (m *SchemaComputedIndex) String() string { return proto.CompactTextString(m) }
-func (*SchemaComputedIndex) ProtoMessage()    {}
-func (*SchemaComputedIndex) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3b5ea8fe65782bcc, []int{15}
+func (m *SchemaFieldEditorValidatorDoubleMaximum) String() string { return proto.CompactTextString(m) }
+func (*SchemaFieldEditorValidatorDoubleMaximum) ProtoMessage()    {}
+func (*SchemaFieldEditorValidatorDoubleMaximum) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b5ea8fe65782bcc, []int{21}
 }
 
-func (m *SchemaComputedIndex) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_SchemaComputedIndex.Unmarshal(m, b)
+func (m *SchemaFieldEditorValidatorDoubleMaximum) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SchemaFieldEditorValidatorDoubleMaximum.Unmarshal(m, b)
 }
-func (m *SchemaComputedIndex) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_SchemaComputedIndex.Marshal(b, m, deterministic)
+func (m *SchemaFieldEditorValidatorDoubleMaximum) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SchemaFieldEditorValidatorDoubleMaximum.Marshal(b, m, deterministic)
 }
-func (m *SchemaComputedIndex) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_SchemaComputedIndex.Merge(m, src)
+func (m *SchemaFieldEditorValidatorDoubleMaximum) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SchemaFieldEditorValidatorDoubleMaximum.Merge(m, src)
 }
-func (m *SchemaComputedIndex) XXX_Size() int {
-	return xxx_messageInfo_SchemaComputedIndex.Size(m)
+func (m *SchemaFieldEditorValidatorDoubleMaximum) XXX_Size() int {
+	return xxx_messageInfo_SchemaFieldEditorValidatorDoubleMaximum.Size(m)
 }
-func (m *SchemaComputedIndex) XXX_DiscardUnknown() {
-	xxx_messageInfo_SchemaComputedIndex.DiscardUnknown(m)
+func (m *SchemaFieldEditorValidatorDoubleMaximum) XXX_DiscardUnknown() {
+	xxx_messageInfo_SchemaFieldEditorValidatorDoubleMaximum.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_SchemaComputedIndex proto.InternalMessageInfo
+var xxx_messageInfo_SchemaFieldEditorValidatorDoubleMaximum proto.InternalMessageInfo
 
-type isSchemaComputedIndex_Algorithm interface {
-	isSchemaComputedIndex_Algorithm()
+func (m *SchemaFieldEditorValidatorDoubleMaximum) GetValue() float64 {
+	if m != nil {
+		return m.Value
+	}
+	return 0
 }
 
-type SchemaComputedIndex_Fnv64A struct {
-	Fnv64A *SchemaComputedIndexFnv64A `protobuf:"bytes,1,opt,name=fnv64a,proto3,oneof"`
+// Length is measured in characters for strings and bytes for bytes.
+type SchemaFieldEditorValidatorMinimumLength struct {
+	Length               uint32   `protobuf:"varint,1,opt,name=length,proto3" json:"length,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-type SchemaComputedIndex_Fnv64APair struct {
-	Fnv64APair *SchemaComputedIndexFnv64APair `protobuf:"bytes,2,opt,name=fnv64a_pair,json=fnv64aPair,proto3,oneof"`
+func (m *SchemaFieldEditorValidatorMinimumLength) Reset() {
+	*m = SchemaFieldEditorValidatorMinimumLength{}
 }
-
-type SchemaComputedIndex_Fnv32A struct {
-	Fnv32A *SchemaComputedIndexFnv32A `protobuf:"bytes,3,opt,name=fnv32a,proto3,oneof"`
+func (m *SchemaFieldEditorValidatorMinimumLength) String() string { return proto.CompactTextString(m) }
+func (*SchemaFieldEditorValidatorMinimumLength) ProtoMessage()    {}
+func (*SchemaFieldEditorValidatorMinimumLength) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b5ea8fe65782bcc, []int{22}
 }
 
-type SchemaComputedIndex_Fnv32APair struct {
-	Fnv32APair *SchemaComputedIndexFnv32APair `protobuf:"bytes,4,opt,name=fnv32a_pair,json=fnv32aPair,proto3,oneof"`
+func (m *SchemaFieldEditorValidatorMinimumLength) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SchemaFieldEditorValidatorMinimumLength.Unmarshal(m, b)
 }
-
-func (*SchemaComputedIndex_Fnv64A) isSchemaComputedIndex_Algorithm() {}
-
-func (*SchemaComputedIndex_Fnv64APair) isSchemaComputedIndex_Algorithm() {}
-
-func (*SchemaComputedIndex_Fnv32A) isSchemaComputedIndex_Algorithm() {}
-
-func (*SchemaComputedIndex_Fnv32APair) isSchemaComputedIndex_Algorithm() {}
-
-func (m *SchemaComputedIndex) GetAlgorithm() isSchemaComputedIndex_Algorithm {
-	if m != nil {
-		return m.Algorithm
-	}
-	return nil
+func (m *SchemaFieldEditorValidatorMinimumLength) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SchemaFieldEditorValidatorMinimumLength.Marshal(b, m, deterministic)
 }
-
-func (m *SchemaComputedIndex) GetFnv64A() *SchemaComputedIndexFnv64A {
-	if x, ok := m.GetAlgorithm().(*SchemaComputedIndex_Fnv64A); ok {
-		return x.Fnv64A
-	}
-	return nil
+func (m *SchemaFieldEditorValidatorMinimumLength) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SchemaFieldEditorValidatorMinimumLength.Merge(m, src)
 }
-
-func (m *SchemaComputedIndex) GetFnv64APair() *SchemaComputedIndexFnv64APair {
-	if x, ok := m.GetAlgorithm().(*SchemaComputedIndex_Fnv64APair); ok {
-		return x.Fnv64APair
-	}
-	return nil
+func (m *SchemaFieldEditorValidatorMinimumLength) XXX_Size() int {
+	return xxx_messageInfo_SchemaFieldEditorValidatorMinimumLength.Size(m)
 }
-
-func (m *SchemaComputedIndex) GetFnv32A() *SchemaComputedIndexFnv32A {
-	if x, ok := m.GetAlgorithm().(*SchemaComputedIndex_Fnv32A); ok {
-		return x.Fnv32A
-	}
-	return nil
+func (m *SchemaFieldEditorValidatorMinimumLength) XXX_DiscardUnknown() {
+	xxx_messageInfo_SchemaFieldEditorValidatorMinimumLength.DiscardUnknown(m)
 }
 
-func (m *SchemaComputedIndex) GetFnv32APair() *SchemaComputedIndexFnv32APair {
-	if x, ok := m.GetAlgorithm().(*SchemaComputedIndex_Fnv32APair); ok {
-		return x.Fnv32APair
-	}
-	return nil
-}
+var xxx_messageInfo_SchemaFieldEditorValidatorMinimumLength proto.InternalMessageInfo
 
-// XXX_OneofWrappers is for the internal use of the proto package.
-func (*SchemaComputedIndex) XXX_OneofWrappers() []interface{} {
-	return []interface{}{
-		(*SchemaComputedIndex_Fnv64A)(nil),
-		(*SchemaComputedIndex_Fnv64APair)(nil),
-		(*SchemaComputedIndex_Fnv32A)(nil),
-		(*SchemaComputedIndex_Fnv32APair)(nil),
+func (m *SchemaFieldEditorValidatorMinimumLength) GetLength() uint32 {
+	if m != nil {
+		return m.Length
 	}
+	return 0
 }
 
-type SchemaComputedIndexFnv64A struct {
-	Field                string   `protobuf:"bytes,1,opt,name=field,proto3" json:"field,omitempty"`
+type SchemaFieldEditorValidatorMaximumLength struct {
+	Length               uint32   `protobuf:"varint,1,opt,name=length,proto3" json:"length,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *SchemaComputedIndexFnv64A) Reset()         { *m = SchemaComputedIndexFnv64A{} }
-func (m *SchemaComputedIndexFnv64A) String() string { return proto.CompactTextString(m) }
-func (*SchemaComputedIndexFnv64A) ProtoMessage()    {}
-func (*SchemaComputedIndexFnv64A) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3b5ea8fe65782bcc, []int{16}
+func (m *SchemaFieldEditorValidatorMaximumLength) Reset() {
+	*m = SchemaFieldEditorValidatorMaximumLength{}
+}
+func (m *SchemaFieldEditorValidatorMaximumLength) String() string { return proto.CompactTextString(m) }
+func (*SchemaFieldEditorValidatorMaximumLength) ProtoMessage()    {}
+func (*SchemaFieldEditorValidatorMaximumLength) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b5ea8fe65782bcc, []int{23}
 }
 
-func (m *SchemaComputedIndexFnv64A) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_SchemaComputedIndexFnv64A.Unmarshal(m, b)
+func (m *SchemaFieldEditorValidatorMaximumLength) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SchemaFieldEditorValidatorMaximumLength.Unmarshal(m, b)
 }
-func (m *SchemaComputedIndexFnv64A) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_SchemaComputedIndexFnv64A.Marshal(b, m, deterministic)
+func (m *SchemaFieldEditorValidatorMaximumLength) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SchemaFieldEditorValidatorMaximumLength.Marshal(b, m, deterministic)
 }
-func (m *SchemaComputedIndexFnv64A) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_SchemaComputedIndexFnv64A.Merge(m, src)
+func (m *SchemaFieldEditorValidatorMaximumLength) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SchemaFieldEditorValidatorMaximumLength.Merge(m, src)
 }
-func (m *SchemaComputedIndexFnv64A) XXX_Size() int {
-	return xxx_messageInfo_SchemaComputedIndexFnv64A.Size(m)
+func (m *SchemaFieldEditorValidatorMaximumLength) XXX_Size() int {
+	return xxx_messageInfo_SchemaFieldEditorValidatorMaximumLength.Size(m)
 }
-func (m *SchemaComputedIndexFnv64A) XXX_DiscardUnknown() {
-	xxx_messageInfo_SchemaComputedIndexFnv64A.DiscardUnknown(m)
+func (m *SchemaFieldEditorValidatorMaximumLength) XXX_DiscardUnknown() {
+	xxx_messageInfo_SchemaFieldEditorValidatorMaximumLength.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_SchemaComputedIndexFnv64A proto.InternalMessageInfo
+var xxx_messageInfo_SchemaFieldEditorValidatorMaximumLength proto.InternalMessageInfo
 
-func (m *SchemaComputedIndexFnv64A) GetField() string {
+func (m *SchemaFieldEditorValidatorMaximumLength) GetLength() uint32 {
 	if m != nil {
-		return m.Field
+		return m.Length
 	}
-	return ""
+	return 0
 }
 
-type SchemaComputedIndexFnv64APair struct {
-	Field1               string   `protobuf:"bytes,1,opt,name=field1,proto3" json:"field1,omitempty"`
-	Field2               string   `protobuf:"bytes,2,opt,name=field2,proto3" json:"field2,omitempty"`
+// The values must be of the same type as the field. Supported for string,
+// int64, uint64 and double fields.
+type SchemaFieldEditorValidatorAllowedValues struct {
+	Values               []*Value `protobuf:"bytes,1,rep,name=values,proto3" json:"values,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *SchemaComputedIndexFnv64APair) Reset()         { *m = SchemaComputedIndexFnv64APair{} }
-func (m *SchemaComputedIndexFnv64APair) String() string { return proto.CompactTextString(m) }
-func (*SchemaComputedIndexFnv64APair) ProtoMessage()    {}
-func (*SchemaComputedIndexFnv64APair) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3b5ea8fe65782bcc, []int{17}
+func (m *SchemaFieldEditorValidatorAllowedValues) Reset() {
+	*m = SchemaFieldEditorValidatorAllowedValues{}
+}
+func (m *SchemaFieldEditorValidatorAllowedValues) String() string { return proto.CompactTextString(m) }
+func (*SchemaFieldEditorValidatorAllowedValues) ProtoMessage()    {}
+func (*SchemaFieldEditorValidatorAllowedValues) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b5ea8fe65782bcc, []int{24}
 }
 
-func (m *SchemaComputedIndexFnv64APair) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_SchemaComputedIndexFnv64APair.Unmarshal(m, b)
+func (m *SchemaFieldEditorValidatorAllowedValues) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SchemaFieldEditorValidatorAllowedValues.Unmarshal(m, b)
 }
-func (m *SchemaComputedIndexFnv64APair) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_SchemaComputedIndexFnv64APair.Marshal(b, m, deterministic)
+func (m *SchemaFieldEditorValidatorAllowedValues) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SchemaFieldEditorValidatorAllowedValues.Marshal(b, m, deterministic)
 }
-func (m *SchemaComputedIndexFnv64APair) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_SchemaComputedIndexFnv64APair.Merge(m, src)
+func (m *SchemaFieldEditorValidatorAllowedValues) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SchemaFieldEditorValidatorAllowedValues.Merge(m, src)
 }
-func (m *SchemaComputedIndexFnv64APair) XXX_Size() int {
-	return xxx_messageInfo_SchemaComputedIndexFnv64APair.Size(m)
+func (m *SchemaFieldEditorValidatorAllowedValues) XXX_Size() int {
+	return xxx_messageInfo_SchemaFieldEditorValidatorAllowedValues.Size(m)
 }
-func (m *SchemaComputedIndexFnv64APair) XXX_DiscardUnknown() {
-	xxx_messageInfo_SchemaComputedIndexFnv64APair.DiscardUnknown(m)
+func (m *SchemaFieldEditorValidatorAllowedValues) XXX_DiscardUnknown() {
+	xxx_messageInfo_SchemaFieldEditorValidatorAllowedValues.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_SchemaComputedIndexFnv64APair proto.InternalMessageInfo
-
-func (m *SchemaComputedIndexFnv64APair) GetField1() string {
-	if m != nil {
-		return m.Field1
-	}
-	return ""
-}
+var xxx_messageInfo_SchemaFieldEditorValidatorAllowedValues proto.InternalMessageInfo
 
-func (m *SchemaComputedIndexFnv64APair) GetField2() string {
+func (m *SchemaFieldEditorValidatorAllowedValues) GetValues() []*Value {
 	if m != nil {
-		return m.Field2
+		return m.Values
 	}
-	return ""
+	return nil
 }
 
-type SchemaComputedIndexFnv32A struct {
-	Field                string   `protobuf:"bytes,1,opt,name=field,proto3" json:"field,omitempty"`
+type SchemaFieldEditorValidatorFormatEmail struct {
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *SchemaComputedIndexFnv32A) Reset()         { *m = SchemaComputedIndexFnv32A{} }
-func (m *SchemaComputedIndexFnv32A) String() string { return proto.CompactTextString(m) }
-func (*SchemaComputedIndexFnv32A) ProtoMessage()    {}
-func (*SchemaComputedIndexFnv32A) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3b5ea8fe65782bcc, []int{18}
+func (m *SchemaFieldEditorValidatorFormatEmail) Reset()         { *m = SchemaFieldEditorValidatorFormatEmail{} }
+func (m *SchemaFieldEditorValidatorFormatEmail) String() string { return proto.CompactTextString(m) }
+func (*SchemaFieldEditorValidatorFormatEmail) ProtoMessage()    {}
+func (*SchemaFieldEditorValidatorFormatEmail) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b5ea8fe65782bcc, []int{25}
 }
 
-func (m *SchemaComputedIndexFnv32A) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_SchemaComputedIndexFnv32A.Unmarshal(m, b)
+func (m *SchemaFieldEditorValidatorFormatEmail) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SchemaFieldEditorValidatorFormatEmail.Unmarshal(m, b)
 }
-func (m *SchemaComputedIndexFnv32A) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_SchemaComputedIndexFnv32A.Marshal(b, m, deterministic)
+func (m *SchemaFieldEditorValidatorFormatEmail) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SchemaFieldEditorValidatorFormatEmail.Marshal(b, m, deterministic)
 }
-func (m *SchemaComputedIndexFnv32A) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_SchemaComputedIndexFnv32A.Merge(m, src)
+func (m *SchemaFieldEditorValidatorFormatEmail) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SchemaFieldEditorValidatorFormatEmail.Merge(m, src)
 }
-func (m *SchemaComputedIndexFnv32A) XXX_Size() int {
-	return xxx_messageInfo_SchemaComputedIndexFnv32A.Size(m)
+func (m *SchemaFieldEditorValidatorFormatEmail) XXX_Size() int {
+	return xxx_messageInfo_SchemaFieldEditorValidatorFormatEmail.Size(m)
 }
-func (m *SchemaComputedIndexFnv32A) XXX_DiscardUnknown() {
-	xxx_messageInfo_SchemaComputedIndexFnv32A.DiscardUnknown(m)
+func (m *SchemaFieldEditorValidatorFormatEmail) XXX_DiscardUnknown() {
+	xxx_messageInfo_SchemaFieldEditorValidatorFormatEmail.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_SchemaComputedIndexFnv32A proto.InternalMessageInfo
-
-func (m *SchemaComputedIndexFnv32A) GetField() string {
-	if m != nil {
-		return m.Field
-	}
-	return ""
-}
+var xxx_messageInfo_SchemaFieldEditorValidatorFormatEmail proto.InternalMessageInfo
 
-type SchemaComputedIndexFnv32APair struct {
-	Field1               string   `protobuf:"bytes,1,opt,name=field1,proto3" json:"field1,omitempty"`
-	Field2               string   `protobuf:"bytes,2,opt,name=field2,proto3" json:"field2,omitempty"`
+// An absolute URL with a host. If schemes is set, the URL must use one of
+// them.
+type SchemaFieldEditorValidatorFormatURL struct {
+	Schemes              []string `protobuf:"bytes,1,rep,name=schemes,proto3" json:"schemes,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *SchemaComputedIndexFnv32APair) Reset()         { *m = SchemaComputedIndexFnv32APair{} }
-func (m *SchemaComputedIndexFnv32APair) String() string { return proto.CompactTextString(m) }
-func (*SchemaComputedIndexFnv32APair) ProtoMessage()    {}
-func (*SchemaComputedIndexFnv32APair) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3b5ea8fe65782bcc, []int{19}
+func (m *SchemaFieldEditorValidatorFormatURL) Reset()         { *m = SchemaFieldEditorValidatorFormatURL{} }
+func (m *SchemaFieldEditorValidatorFormatURL) String() string { return proto.CompactTextString(m) }
+func (*SchemaFieldEditorValidatorFormatURL) ProtoMessage()    {}
+func (*SchemaFieldEditorValidatorFormatURL) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b5ea8fe65782bcc, []int{26}
 }
 
-func (m *SchemaComputedIndexFnv32APair) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_SchemaComputedIndexFnv32APair.Unmarshal(m, b)
+func (m *SchemaFieldEditorValidatorFormatURL) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SchemaFieldEditorValidatorFormatURL.Unmarshal(m, b)
 }
-func (m *SchemaComputedIndexFnv32APair) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_SchemaComputedIndexFnv32APair.Marshal(b, m, deterministic)
+func (m *SchemaFieldEditorValidatorFormatURL) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SchemaFieldEditorValidatorFormatURL.Marshal(b, m, deterministic)
 }
-func (m *SchemaComputedIndexFnv32APair) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_SchemaComputedIndexFnv32APair.Merge(m, src)
+func (m *SchemaFieldEditorValidatorFormatURL) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SchemaFieldEditorValidatorFormatURL.Merge(m, src)
 }
-func (m *SchemaComputedIndexFnv32APair) XXX_Size() int {
-	return xxx_messageInfo_SchemaComputedIndexFnv32APair.Size(m)
+func (m *SchemaFieldEditorValidatorFormatURL) XXX_Size() int {
+	return xxx_messageInfo_SchemaFieldEditorValidatorFormatURL.Size(m)
 }
-func (m *SchemaComputedIndexFnv32APair) XXX_DiscardUnknown() {
-	xxx_messageInfo_SchemaComputedIndexFnv32APair.DiscardUnknown(m)
+func (m *SchemaFieldEditorValidatorFormatURL) XXX_DiscardUnknown() {
+	xxx_messageInfo_SchemaFieldEditorValidatorFormatURL.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_SchemaComputedIndexFnv32APair proto.InternalMessageInfo
+var xxx_messageInfo_SchemaFieldEditorValidatorFormatURL proto.InternalMessageInfo
 
-func (m *SchemaComputedIndexFnv32APair) GetField1() string {
+func (m *SchemaFieldEditorValidatorFormatURL) GetSchemes() []string {
 	if m != nil {
-		return m.Field1
+		return m.Schemes
 	}
-	return ""
+	return nil
 }
 
-func (m *SchemaComputedIndexFnv32APair) GetField2() string {
-	if m != nil {
-		return m.Field2
-	}
-	return ""
+// Any absolute URI, such as "urn:isbn:0451450523".
+type SchemaFieldEditorValidatorFormatURI struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-type Schema struct {
-	Name                 string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	Kinds                map[string]*SchemaKind `protobuf:"bytes,2,rep,name=kinds,proto3" json:"kinds,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
-	XXX_NoUnkeyedLiteral struct{}               `json:"-"`
-	XXX_unrecognized     []byte                 `json:"-"`
-	XXX_sizecache        int32                  `json:"-"`
+func (m *SchemaFieldEditorValidatorFormatURI) Reset()         { *m = SchemaFieldEditorValidatorFormatURI{} }
+func (m *SchemaFieldEditorValidatorFormatURI) String() string { return proto.CompactTextString(m) }
+func (*SchemaFieldEditorValidatorFormatURI) ProtoMessage()    {}
+func (*SchemaFieldEditorValidatorFormatURI) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b5ea8fe65782bcc, []int{27}
 }
 
-func (m *Schema) Reset()         { *m = Schema{} }
-func (m *Schema) String() string { return proto.CompactTextString(m) }
-func (*Schema) ProtoMessage()    {}
-func (*Schema) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3b5ea8fe65782bcc, []int{20}
+func (m *SchemaFieldEditorValidatorFormatURI) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SchemaFieldEditorValidatorFormatURI.Unmarshal(m, b)
 }
-
-func (m *Schema) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_Schema.Unmarshal(m, b)
+func (m *SchemaFieldEditorValidatorFormatURI) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SchemaFieldEditorValidatorFormatURI.Marshal(b, m, deterministic)
 }
-func (m *Schema) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_Schema.Marshal(b, m, deterministic)
+func (m *SchemaFieldEditorValidatorFormatURI) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SchemaFieldEditorValidatorFormatURI.Merge(m, src)
 }
-func (m *Schema) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_Schema.Merge(m, src)
+func (m *SchemaFieldEditorValidatorFormatURI) XXX_Size() int {
+	return xxx_messageInfo_SchemaFieldEditorValidatorFormatURI.Size(m)
 }
-func (m *Schema) XXX_Size() int {
-	return xxx_messageInfo_Schema.Size(m)
-}
-func (m *Schema) XXX_DiscardUnknown() {
-	xxx_messageInfo_Schema.DiscardUnknown(m)
+func (m *SchemaFieldEditorValidatorFormatURI) XXX_DiscardUnknown() {
+	xxx_messageInfo_SchemaFieldEditorValidatorFormatURI.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_Schema proto.InternalMessageInfo
+var xxx_messageInfo_SchemaFieldEditorValidatorFormatURI proto.InternalMessageInfo
 
-func (m *Schema) GetName() string {
-	if m != nil {
-		return m.Name
-	}
-	return ""
+type SchemaFieldEditorValidatorTimestampMinimum struct {
+	Value                *timestamp.Timestamp `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
 }
 
-func (m *Schema) GetKinds() map[string]*SchemaKind {
-	if m != nil {
-		return m.Kinds
-	}
-	return nil
+func (m *SchemaFieldEditorValidatorTimestampMinimum) Reset() {
+	*m = SchemaFieldEditorValidatorTimestampMinimum{}
 }
-
-type GetSchemaRequest struct {
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+func (m *SchemaFieldEditorValidatorTimestampMinimum) String() string {
+	return proto.CompactTextString(m)
 }
-
-func (m *GetSchemaRequest) Reset()         { *m = GetSchemaRequest{} }
-func (m *GetSchemaRequest) String() string { return proto.CompactTextString(m) }
-func (*GetSchemaRequest) ProtoMessage()    {}
-func (*GetSchemaRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3b5ea8fe65782bcc, []int{21}
+func (*SchemaFieldEditorValidatorTimestampMinimum) ProtoMessage() {}
+func (*SchemaFieldEditorValidatorTimestampMinimum) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b5ea8fe65782bcc, []int{28}
 }
 
-func (m *GetSchemaRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_GetSchemaRequest.Unmarshal(m, b)
+func (m *SchemaFieldEditorValidatorTimestampMinimum) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SchemaFieldEditorValidatorTimestampMinimum.Unmarshal(m, b)
 }
-func (m *GetSchemaRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_GetSchemaRequest.Marshal(b, m, deterministic)
+func (m *SchemaFieldEditorValidatorTimestampMinimum) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SchemaFieldEditorValidatorTimestampMinimum.Marshal(b, m, deterministic)
 }
-func (m *GetSchemaRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_GetSchemaRequest.Merge(m, src)
+func (m *SchemaFieldEditorValidatorTimestampMinimum) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SchemaFieldEditorValidatorTimestampMinimum.Merge(m, src)
 }
-func (m *GetSchemaRequest) XXX_Size() int {
-	return xxx_messageInfo_GetSchemaRequest.Size(m)
+func (m *SchemaFieldEditorValidatorTimestampMinimum) XXX_Size() int {
+	return xxx_messageInfo_SchemaFieldEditorValidatorTimestampMinimum.Size(m)
 }
-func (m *GetSchemaRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_GetSchemaRequest.DiscardUnknown(m)
+func (m *SchemaFieldEditorValidatorTimestampMinimum) XXX_DiscardUnknown() {
+	xxx_messageInfo_SchemaFieldEditorValidatorTimestampMinimum.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_GetSchemaRequest proto.InternalMessageInfo
+var xxx_messageInfo_SchemaFieldEditorValidatorTimestampMinimum proto.InternalMessageInfo
 
-type GetSchemaResponse struct {
-	Schema               *Schema  `protobuf:"bytes,1,opt,name=schema,proto3" json:"schema,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+func (m *SchemaFieldEditorValidatorTimestampMinimum) GetValue() *timestamp.Timestamp {
+	if m != nil {
+		return m.Value
+	}
+	return nil
 }
 
-func (m *GetSchemaResponse) Reset()         { *m = GetSchemaResponse{} }
-func (m *GetSchemaResponse) String() string { return proto.CompactTextString(m) }
-func (*GetSchemaResponse) ProtoMessage()    {}
-func (*GetSchemaResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3b5ea8fe65782bcc, []int{22}
+type SchemaFieldEditorValidatorTimestampMaximum struct {
+	Value                *timestamp.Timestamp `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
 }
 
-func (m *GetSchemaResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_GetSchemaResponse.Unmarshal(m, b)
+func (m *SchemaFieldEditorValidatorTimestampMaximum) Reset() {
+	*m = SchemaFieldEditorValidatorTimestampMaximum{}
 }
-func (m *GetSchemaResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_GetSchemaResponse.Marshal(b, m, deterministic)
+func (m *SchemaFieldEditorValidatorTimestampMaximum) String() string {
+	return proto.CompactTextString(m)
 }
-func (m *GetSchemaResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_GetSchemaResponse.Merge(m, src)
+func (*SchemaFieldEditorValidatorTimestampMaximum) ProtoMessage() {}
+func (*SchemaFieldEditorValidatorTimestampMaximum) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b5ea8fe65782bcc, []int{29}
 }
-func (m *GetSchemaResponse) XXX_Size() int {
-	return xxx_messageInfo_GetSchemaResponse.Size(m)
+
+func (m *SchemaFieldEditorValidatorTimestampMaximum) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SchemaFieldEditorValidatorTimestampMaximum.Unmarshal(m, b)
 }
-func (m *GetSchemaResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_GetSchemaResponse.DiscardUnknown(m)
+func (m *SchemaFieldEditorValidatorTimestampMaximum) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SchemaFieldEditorValidatorTimestampMaximum.Marshal(b, m, deterministic)
+}
+func (m *SchemaFieldEditorValidatorTimestampMaximum) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SchemaFieldEditorValidatorTimestampMaximum.Merge(m, src)
+}
+func (m *SchemaFieldEditorValidatorTimestampMaximum) XXX_Size() int {
+	return xxx_messageInfo_SchemaFieldEditorValidatorTimestampMaximum.Size(m)
+}
+func (m *SchemaFieldEditorValidatorTimestampMaximum) XXX_DiscardUnknown() {
+	xxx_messageInfo_SchemaFieldEditorValidatorTimestampMaximum.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_GetSchemaResponse proto.InternalMessageInfo
+var xxx_messageInfo_SchemaFieldEditorValidatorTimestampMaximum proto.InternalMessageInfo
 
-func (m *GetSchemaResponse) GetSchema() *Schema {
+func (m *SchemaFieldEditorValidatorTimestampMaximum) GetValue() *timestamp.Timestamp {
 	if m != nil {
-		return m.Schema
+		return m.Value
 	}
 	return nil
 }
 
-type MetaListEntitiesRequest struct {
-	Start []byte `protobuf:"bytes,1,opt,name=start,proto3" json:"start,omitempty"`
-	// a limit of 0 or lower indicates no limit to the number of entities returned
-	Limit                uint32   `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
-	KindName             string   `protobuf:"bytes,3,opt,name=kindName,proto3" json:"kindName,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+type SchemaKindEditor struct {
+	Singular                      string   `protobuf:"bytes,1,opt,name=singular,proto3" json:"singular,omitempty"`
+	Plural                        string   `protobuf:"bytes,2,opt,name=plural,proto3" json:"plural,omitempty"`
+	RenderEditorDropdownWithField string   `protobuf:"bytes,3,opt,name=renderEditorDropdownWithField,proto3" json:"renderEditorDropdownWithField,omitempty"`
+	KeyComment                    string   `protobuf:"bytes,4,opt,name=keyComment,proto3" json:"keyComment,omitempty"`
+	SortByField                   string   `protobuf:"bytes,5,opt,name=sortByField,proto3" json:"sortByField,omitempty"`
+	XXX_NoUnkeyedLiteral          struct{} `json:"-"`
+	XXX_unrecognized              []byte   `json:"-"`
+	XXX_sizecache                 int32    `json:"-"`
 }
 
-func (m *MetaListEntitiesRequest) Reset()         { *m = MetaListEntitiesRequest{} }
-func (m *MetaListEntitiesRequest) String() string { return proto.CompactTextString(m) }
-func (*MetaListEntitiesRequest) ProtoMessage()    {}
-func (*MetaListEntitiesRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3b5ea8fe65782bcc, []int{23}
+func (m *SchemaKindEditor) Reset()         { *m = SchemaKindEditor{} }
+func (m *SchemaKindEditor) String() string { return proto.CompactTextString(m) }
+func (*SchemaKindEditor) ProtoMessage()    {}
+func (*SchemaKindEditor) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b5ea8fe65782bcc, []int{30}
 }
 
-func (m *MetaListEntitiesRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_MetaListEntitiesRequest.Unmarshal(m, b)
+func (m *SchemaKindEditor) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SchemaKindEditor.Unmarshal(m, b)
 }
-func (m *MetaListEntitiesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_MetaListEntitiesRequest.Marshal(b, m, deterministic)
+func (m *SchemaKindEditor) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SchemaKindEditor.Marshal(b, m, deterministic)
 }
-func (m *MetaListEntitiesRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_MetaListEntitiesRequest.Merge(m, src)
+func (m *SchemaKindEditor) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SchemaKindEditor.Merge(m, src)
 }
-func (m *MetaListEntitiesRequest) XXX_Size() int {
-	return xxx_messageInfo_MetaListEntitiesRequest.Size(m)
+func (m *SchemaKindEditor) XXX_Size() int {
+	return xxx_messageInfo_SchemaKindEditor.Size(m)
 }
-func (m *MetaListEntitiesRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_MetaListEntitiesRequest.DiscardUnknown(m)
+func (m *SchemaKindEditor) XXX_DiscardUnknown() {
+	xxx_messageInfo_SchemaKindEditor.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_MetaListEntitiesRequest proto.InternalMessageInfo
+var xxx_messageInfo_SchemaKindEditor proto.InternalMessageInfo
 
-func (m *MetaListEntitiesRequest) GetStart() []byte {
+func (m *SchemaKindEditor) GetSingular() string {
 	if m != nil {
-		return m.Start
+		return m.Singular
 	}
-	return nil
+	return ""
 }
 
-func (m *MetaListEntitiesRequest) GetLimit() uint32 {
+func (m *SchemaKindEditor) GetPlural() string {
 	if m != nil {
-		return m.Limit
+		return m.Plural
 	}
-	return 0
+	return ""
 }
 
-func (m *MetaListEntitiesRequest) GetKindName() string {
+func (m *SchemaKindEditor) GetRenderEditorDropdownWithField() string {
 	if m != nil {
-		return m.KindName
+		return m.RenderEditorDropdownWithField
 	}
 	return ""
 }
 
-type MetaListEntitiesResponse struct {
-	Next                 []byte        `protobuf:"bytes,1,opt,name=next,proto3" json:"next,omitempty"`
-	MoreResults          bool          `protobuf:"varint,2,opt,name=moreResults,proto3" json:"moreResults,omitempty"`
-	Entities             []*MetaEntity `protobuf:"bytes,3,rep,name=entities,proto3" json:"entities,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
-	XXX_unrecognized     []byte        `json:"-"`
-	XXX_sizecache        int32         `json:"-"`
+func (m *SchemaKindEditor) GetKeyComment() string {
+	if m != nil {
+		return m.KeyComment
+	}
+	return ""
 }
 
-func (m *MetaListEntitiesResponse) Reset()         { *m = MetaListEntitiesResponse{} }
-func (m *MetaListEntitiesResponse) String() string { return proto.CompactTextString(m) }
-func (*MetaListEntitiesResponse) ProtoMessage()    {}
-func (*MetaListEntitiesResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3b5ea8fe65782bcc, []int{24}
+func (m *SchemaKindEditor) GetSortByField() string {
+	if m != nil {
+		return m.SortByField
+	}
+	return ""
 }
 
-func (m *MetaListEntitiesResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_MetaListEntitiesResponse.Unmarshal(m, b)
+type SchemaKind struct {
+	Fields  []*SchemaField    `protobuf:"bytes,2,rep,name=fields,proto3" json:"fields,omitempty"`
+	Editor  *SchemaKindEditor `protobuf:"bytes,3,opt,name=editor,proto3" json:"editor,omitempty"`
+	Indexes []*SchemaIndex    `protobuf:"bytes,4,rep,name=indexes,proto3" json:"indexes,omitempty"`
+	// the kinds that entities of this kind are created under; if empty, entities of this kind are top-level
+	Ancestors            []string `protobuf:"bytes,5,rep,name=ancestors,proto3" json:"ancestors,omitempty"`
+	Id                   int32    `protobuf:"varint,6,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
-func (m *MetaListEntitiesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_MetaListEntitiesResponse.Marshal(b, m, deterministic)
+
+func (m *SchemaKind) Reset()         { *m = SchemaKind{} }
+func (m *SchemaKind) String() string { return proto.CompactTextString(m) }
+func (*SchemaKind) ProtoMessage()    {}
+func (*SchemaKind) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b5ea8fe65782bcc, []int{31}
 }
-func (m *MetaListEntitiesResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_MetaListEntitiesResponse.Merge(m, src)
+
+func (m *SchemaKind) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SchemaKind.Unmarshal(m, b)
 }
-func (m *MetaListEntitiesResponse) XXX_Size() int {
-	return xxx_messageInfo_MetaListEntitiesResponse.Size(m)
+func (m *SchemaKind) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SchemaKind.Marshal(b, m, deterministic)
 }
-func (m *MetaListEntitiesResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_MetaListEntitiesResponse.DiscardUnknown(m)
+func (m *SchemaKind) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SchemaKind.Merge(m, src)
+}
+func (m *SchemaKind) XXX_Size() int {
+	return xxx_messageInfo_SchemaKind.Size(m)
+}
+func (m *SchemaKind) XXX_DiscardUnknown() {
+	xxx_messageInfo_SchemaKind.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_MetaListEntitiesResponse proto.InternalMessageInfo
+var xxx_messageInfo_SchemaKind proto.InternalMessageInfo
 
-func (m *MetaListEntitiesResponse) GetNext() []byte {
+func (m *SchemaKind) GetFields() []*SchemaField {
 	if m != nil {
-		return m.Next
+		return m.Fields
 	}
 	return nil
 }
 
-func (m *MetaListEntitiesResponse) GetMoreResults() bool {
+func (m *SchemaKind) GetEditor() *SchemaKindEditor {
 	if m != nil {
-		return m.MoreResults
+		return m.Editor
 	}
-	return false
+	return nil
 }
 
-func (m *MetaListEntitiesResponse) GetEntities() []*MetaEntity {
+func (m *SchemaKind) GetIndexes() []*SchemaIndex {
 	if m != nil {
-		return m.Entities
+		return m.Indexes
 	}
 	return nil
 }
 
-type MetaEntity struct {
-	Key                  *Key     `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
-	Values               []*Value `protobuf:"bytes,2,rep,name=values,proto3" json:"values,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+func (m *SchemaKind) GetAncestors() []string {
+	if m != nil {
+		return m.Ancestors
+	}
+	return nil
 }
 
-func (m *MetaEntity) Reset()         { *m = MetaEntity{} }
-func (m *MetaEntity) String() string { return proto.CompactTextString(m) }
-func (*MetaEntity) ProtoMessage()    {}
-func (*MetaEntity) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3b5ea8fe65782bcc, []int{25}
+func (m *SchemaKind) GetId() int32 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
 }
 
-func (m *MetaEntity) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_MetaEntity.Unmarshal(m, b)
+type SchemaIndex struct {
+	Name string          `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Type SchemaIndexType `protobuf:"varint,2,opt,name=type,proto3,enum=meta.SchemaIndexType" json:"type,omitempty"`
+	// Types that are valid to be assigned to Value:
+	//	*SchemaIndex_Computed
+	//	*SchemaIndex_Field
+	Value                isSchemaIndex_Value `protobuf_oneof:"value"`
+	XXX_NoUnkeyedLiteral struct{}            `json:"-"`
+	XXX_unrecognized     []byte              `json:"-"`
+	XXX_sizecache        int32               `json:"-"`
 }
-func (m *MetaEntity) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_MetaEntity.Marshal(b, m, deterministic)
+
+func (m *SchemaIndex) Reset()         { *m = SchemaIndex{} }
+func (m *SchemaIndex) String() string { return proto.CompactTextString(m) }
+func (*SchemaIndex) ProtoMessage()    {}
+func (*SchemaIndex) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b5ea8fe65782bcc, []int{32}
+}
+
+func (m *SchemaIndex) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SchemaIndex.Unmarshal(m, b)
+}
+func (m *SchemaIndex) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SchemaIndex.Marshal(b, m, deterministic)
+}
+func (m *SchemaIndex) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SchemaIndex.Merge(m, src)
+}
+func (m *SchemaIndex) XXX_Size() int {
+	return xxx_messageInfo_SchemaIndex.Size(m)
+}
+func (m *SchemaIndex) XXX_DiscardUnknown() {
+	xxx_messageInfo_SchemaIndex.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SchemaIndex proto.InternalMessageInfo
+
+func (m *SchemaIndex) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *SchemaIndex) GetType() SchemaIndexType {
+	if m != nil {
+		return m.Type
+	}
+	return SchemaIndexType_unspecified
+}
+
+type isSchemaIndex_Value interface {
+	isSchemaIndex_Value()
+}
+
+type SchemaIndex_Computed struct {
+	Computed *SchemaComputedIndex `protobuf:"bytes,3,opt,name=computed,proto3,oneof"`
+}
+
+type SchemaIndex_Field struct {
+	Field string `protobuf:"bytes,4,opt,name=field,proto3,oneof"`
+}
+
+func (*SchemaIndex_Computed) isSchemaIndex_Value() {}
+
+func (*SchemaIndex_Field) isSchemaIndex_Value() {}
+
+func (m *SchemaIndex) GetValue() isSchemaIndex_Value {
+	if m != nil {
+		return m.Value
+	}
+	return nil
+}
+
+func (m *SchemaIndex) GetComputed() *SchemaComputedIndex {
+	if x, ok := m.GetValue().(*SchemaIndex_Computed); ok {
+		return x.Computed
+	}
+	return nil
+}
+
+func (m *SchemaIndex) GetField() string {
+	if x, ok := m.GetValue().(*SchemaIndex_Field); ok {
+		return x.Field
+	}
+	return ""
+}
+
+// XXX_OneofWrappers is for the internal use of the proto package.
+func (*SchemaIndex) XXX_OneofWrappers() []interface{} {
+	return []interface{}{
+		(*SchemaIndex_Computed)(nil),
+		(*SchemaIndex_Field)(nil),
+	}
+}
+
+type SchemaComputedIndex struct {
+	// Types that are valid to be assigned to Algorithm:
+	//	*SchemaComputedIndex_Fnv64A
+	//	*SchemaComputedIndex_Fnv64APair
+	//	*SchemaComputedIndex_Fnv32A
+	//	*SchemaComputedIndex_Fnv32APair
+	Algorithm            isSchemaComputedIndex_Algorithm `protobuf_oneof:"algorithm"`
+	XXX_NoUnkeyedLiteral struct{}                        `json:"-"`
+	XXX_unrecognized     []byte                          `json:"-"`
+	XXX_sizecache        int32                           `json:"-"`
+}
+
+func (m *SchemaComputedIndex) Reset()         { *m = SchemaComputedIndex{} }
+func (m *SchemaComputedIndex) String() string { return proto.CompactTextString(m) }
+func (*SchemaComputedIndex) ProtoMessage()    {}
+func (*SchemaComputedIndex) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b5ea8fe65782bcc, []int{33}
+}
+
+func (m *SchemaComputedIndex) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SchemaComputedIndex.Unmarshal(m, b)
+}
+func (m *SchemaComputedIndex) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SchemaComputedIndex.Marshal(b, m, deterministic)
+}
+func (m *SchemaComputedIndex) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SchemaComputedIndex.Merge(m, src)
+}
+func (m *SchemaComputedIndex) XXX_Size() int {
+	return xxx_messageInfo_SchemaComputedIndex.Size(m)
+}
+func (m *SchemaComputedIndex) XXX_DiscardUnknown() {
+	xxx_messageInfo_SchemaComputedIndex.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SchemaComputedIndex proto.InternalMessageInfo
+
+type isSchemaComputedIndex_Algorithm interface {
+	isSchemaComputedIndex_Algorithm()
+}
+
+type SchemaComputedIndex_Fnv64A struct {
+	Fnv64A *SchemaComputedIndexFnv64A `protobuf:"bytes,1,opt,name=fnv64a,proto3,oneof"`
+}
+
+type SchemaComputedIndex_Fnv64APair struct {
+	Fnv64APair *SchemaComputedIndexFnv64APair `protobuf:"bytes,2,opt,name=fnv64a_pair,json=fnv64aPair,proto3,oneof"`
+}
+
+type SchemaComputedIndex_Fnv32A struct {
+	Fnv32A *SchemaComputedIndexFnv32A `protobuf:"bytes,3,opt,name=fnv32a,proto3,oneof"`
+}
+
+type SchemaComputedIndex_Fnv32APair struct {
+	Fnv32APair *SchemaComputedIndexFnv32APair `protobuf:"bytes,4,opt,name=fnv32a_pair,json=fnv32aPair,proto3,oneof"`
+}
+
+func (*SchemaComputedIndex_Fnv64A) isSchemaComputedIndex_Algorithm() {}
+
+func (*SchemaComputedIndex_Fnv64APair) isSchemaComputedIndex_Algorithm() {}
+
+func (*SchemaComputedIndex_Fnv32A) isSchemaComputedIndex_Algorithm() {}
+
+func (*SchemaComputedIndex_Fnv32APair) isSchemaComputedIndex_Algorithm() {}
+
+func (m *SchemaComputedIndex) GetAlgorithm() isSchemaComputedIndex_Algorithm {
+	if m != nil {
+		return m.Algorithm
+	}
+	return nil
+}
+
+func (m *SchemaComputedIndex) GetFnv64A() *SchemaComputedIndexFnv64A {
+	if x, ok := m.GetAlgorithm().(*SchemaComputedIndex_Fnv64A); ok {
+		return x.Fnv64A
+	}
+	return nil
+}
+
+func (m *SchemaComputedIndex) GetFnv64APair() *SchemaComputedIndexFnv64APair {
+	if x, ok := m.GetAlgorithm().(*SchemaComputedIndex_Fnv64APair); ok {
+		return x.Fnv64APair
+	}
+	return nil
+}
+
+func (m *SchemaComputedIndex) GetFnv32A() *SchemaComputedIndexFnv32A {
+	if x, ok := m.GetAlgorithm().(*SchemaComputedIndex_Fnv32A); ok {
+		return x.Fnv32A
+	}
+	return nil
+}
+
+func (m *SchemaComputedIndex) GetFnv32APair() *SchemaComputedIndexFnv32APair {
+	if x, ok := m.GetAlgorithm().(*SchemaComputedIndex_Fnv32APair); ok {
+		return x.Fnv32APair
+	}
+	return nil
+}
+
+// XXX_OneofWrappers is for the internal use of the proto package.
+func (*SchemaComputedIndex) XXX_OneofWrappers() []interface{} {
+	return []interface{}{
+		(*SchemaComputedIndex_Fnv64A)(nil),
+		(*SchemaComputedIndex_Fnv64APair)(nil),
+		(*SchemaComputedIndex_Fnv32A)(nil),
+		(*SchemaComputedIndex_Fnv32APair)(nil),
+	}
+}
+
+type SchemaComputedIndexFnv64A struct {
+	Field                string   `protobuf:"bytes,1,opt,name=field,proto3" json:"field,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SchemaComputedIndexFnv64A) Reset()         { *m = SchemaComputedIndexFnv64A{} }
+func (m *SchemaComputedIndexFnv64A) String() string { return proto.CompactTextString(m) }
+func (*SchemaComputedIndexFnv64A) ProtoMessage()    {}
+func (*SchemaComputedIndexFnv64A) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b5ea8fe65782bcc, []int{34}
+}
+
+func (m *SchemaComputedIndexFnv64A) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SchemaComputedIndexFnv64A.Unmarshal(m, b)
+}
+func (m *SchemaComputedIndexFnv64A) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SchemaComputedIndexFnv64A.Marshal(b, m, deterministic)
+}
+func (m *SchemaComputedIndexFnv64A) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SchemaComputedIndexFnv64A.Merge(m, src)
+}
+func (m *SchemaComputedIndexFnv64A) XXX_Size() int {
+	return xxx_messageInfo_SchemaComputedIndexFnv64A.Size(m)
+}
+func (m *SchemaComputedIndexFnv64A) XXX_DiscardUnknown() {
+	xxx_messageInfo_SchemaComputedIndexFnv64A.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SchemaComputedIndexFnv64A proto.InternalMessageInfo
+
+func (m *SchemaComputedIndexFnv64A) GetField() string {
+	if m != nil {
+		return m.Field
+	}
+	return ""
+}
+
+type SchemaComputedIndexFnv64APair struct {
+	Field1               string   `protobuf:"bytes,1,opt,name=field1,proto3" json:"field1,omitempty"`
+	Field2               string   `protobuf:"bytes,2,opt,name=field2,proto3" json:"field2,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SchemaComputedIndexFnv64APair) Reset()         { *m = SchemaComputedIndexFnv64APair{} }
+func (m *SchemaComputedIndexFnv64APair) String() string { return proto.CompactTextString(m) }
+func (*SchemaComputedIndexFnv64APair) ProtoMessage()    {}
+func (*SchemaComputedIndexFnv64APair) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b5ea8fe65782bcc, []int{35}
+}
+
+func (m *SchemaComputedIndexFnv64APair) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SchemaComputedIndexFnv64APair.Unmarshal(m, b)
+}
+func (m *SchemaComputedIndexFnv64APair) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SchemaComputedIndexFnv64APair.Marshal(b, m, deterministic)
+}
+func (m *SchemaComputedIndexFnv64APair) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SchemaComputedIndexFnv64APair.Merge(m, src)
+}
+func (m *SchemaComputedIndexFnv64APair) XXX_Size() int {
+	return xxx_messageInfo_SchemaComputedIndexFnv64APair.Size(m)
+}
+func (m *SchemaComputedIndexFnv64APair) XXX_DiscardUnknown() {
+	xxx_messageInfo_SchemaComputedIndexFnv64APair.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SchemaComputedIndexFnv64APair proto.InternalMessageInfo
+
+func (m *SchemaComputedIndexFnv64APair) GetField1() string {
+	if m != nil {
+		return m.Field1
+	}
+	return ""
+}
+
+func (m *SchemaComputedIndexFnv64APair) GetField2() string {
+	if m != nil {
+		return m.Field2
+	}
+	return ""
+}
+
+type SchemaComputedIndexFnv32A struct {
+	Field                string   `protobuf:"bytes,1,opt,name=field,proto3" json:"field,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SchemaComputedIndexFnv32A) Reset()         { *m = SchemaComputedIndexFnv32A{} }
+func (m *SchemaComputedIndexFnv32A) String() string { return proto.CompactTextString(m) }
+func (*SchemaComputedIndexFnv32A) ProtoMessage()    {}
+func (*SchemaComputedIndexFnv32A) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b5ea8fe65782bcc, []int{36}
+}
+
+func (m *SchemaComputedIndexFnv32A) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SchemaComputedIndexFnv32A.Unmarshal(m, b)
+}
+func (m *SchemaComputedIndexFnv32A) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SchemaComputedIndexFnv32A.Marshal(b, m, deterministic)
+}
+func (m *SchemaComputedIndexFnv32A) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SchemaComputedIndexFnv32A.Merge(m, src)
+}
+func (m *SchemaComputedIndexFnv32A) XXX_Size() int {
+	return xxx_messageInfo_SchemaComputedIndexFnv32A.Size(m)
+}
+func (m *SchemaComputedIndexFnv32A) XXX_DiscardUnknown() {
+	xxx_messageInfo_SchemaComputedIndexFnv32A.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SchemaComputedIndexFnv32A proto.InternalMessageInfo
+
+func (m *SchemaComputedIndexFnv32A) GetField() string {
+	if m != nil {
+		return m.Field
+	}
+	return ""
+}
+
+type SchemaComputedIndexFnv32APair struct {
+	Field1               string   `protobuf:"bytes,1,opt,name=field1,proto3" json:"field1,omitempty"`
+	Field2               string   `protobuf:"bytes,2,opt,name=field2,proto3" json:"field2,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SchemaComputedIndexFnv32APair) Reset()         { *m = SchemaComputedIndexFnv32APair{} }
+func (m *SchemaComputedIndexFnv32APair) String() string { return proto.CompactTextString(m) }
+func (*SchemaComputedIndexFnv32APair) ProtoMessage()    {}
+func (*SchemaComputedIndexFnv32APair) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b5ea8fe65782bcc, []int{37}
+}
+
+func (m *SchemaComputedIndexFnv32APair) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SchemaComputedIndexFnv32APair.Unmarshal(m, b)
+}
+func (m *SchemaComputedIndexFnv32APair) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SchemaComputedIndexFnv32APair.Marshal(b, m, deterministic)
+}
+func (m *SchemaComputedIndexFnv32APair) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SchemaComputedIndexFnv32APair.Merge(m, src)
+}
+func (m *SchemaComputedIndexFnv32APair) XXX_Size() int {
+	return xxx_messageInfo_SchemaComputedIndexFnv32APair.Size(m)
+}
+func (m *SchemaComputedIndexFnv32APair) XXX_DiscardUnknown() {
+	xxx_messageInfo_SchemaComputedIndexFnv32APair.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SchemaComputedIndexFnv32APair proto.InternalMessageInfo
+
+func (m *SchemaComputedIndexFnv32APair) GetField1() string {
+	if m != nil {
+		return m.Field1
+	}
+	return ""
+}
+
+func (m *SchemaComputedIndexFnv32APair) GetField2() string {
+	if m != nil {
+		return m.Field2
+	}
+	return ""
+}
+
+type Schema struct {
+	Name                 string                   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Kinds                map[string]*SchemaKind   `protobuf:"bytes,2,rep,name=kinds,proto3" json:"kinds,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Structs              map[string]*SchemaStruct `protobuf:"bytes,3,rep,name=structs,proto3" json:"structs,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Enums                map[string]*SchemaEnum   `protobuf:"bytes,4,rep,name=enums,proto3" json:"enums,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}                 `json:"-"`
+	XXX_unrecognized     []byte                   `json:"-"`
+	XXX_sizecache        int32                    `json:"-"`
+}
+
+func (m *Schema) Reset()         { *m = Schema{} }
+func (m *Schema) String() string { return proto.CompactTextString(m) }
+func (*Schema) ProtoMessage()    {}
+func (*Schema) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b5ea8fe65782bcc, []int{38}
+}
+
+func (m *Schema) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Schema.Unmarshal(m, b)
+}
+func (m *Schema) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Schema.Marshal(b, m, deterministic)
+}
+func (m *Schema) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Schema.Merge(m, src)
+}
+func (m *Schema) XXX_Size() int {
+	return xxx_messageInfo_Schema.Size(m)
+}
+func (m *Schema) XXX_DiscardUnknown() {
+	xxx_messageInfo_Schema.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Schema proto.InternalMessageInfo
+
+func (m *Schema) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *Schema) GetKinds() map[string]*SchemaKind {
+	if m != nil {
+		return m.Kinds
+	}
+	return nil
+}
+
+func (m *Schema) GetStructs() map[string]*SchemaStruct {
+	if m != nil {
+		return m.Structs
+	}
+	return nil
+}
+
+func (m *Schema) GetEnums() map[string]*SchemaEnum {
+	if m != nil {
+		return m.Enums
+	}
+	return nil
+}
+
+// A named type made of fields, which kinds can use as the type of a field.
+// Structs are generated as nested messages, and stored as maps.
+type SchemaStruct struct {
+	Fields               []*SchemaField `protobuf:"bytes,1,rep,name=fields,proto3" json:"fields,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *SchemaStruct) Reset()         { *m = SchemaStruct{} }
+func (m *SchemaStruct) String() string { return proto.CompactTextString(m) }
+func (*SchemaStruct) ProtoMessage()    {}
+func (*SchemaStruct) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b5ea8fe65782bcc, []int{39}
+}
+
+func (m *SchemaStruct) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SchemaStruct.Unmarshal(m, b)
+}
+func (m *SchemaStruct) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SchemaStruct.Marshal(b, m, deterministic)
+}
+func (m *SchemaStruct) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SchemaStruct.Merge(m, src)
+}
+func (m *SchemaStruct) XXX_Size() int {
+	return xxx_messageInfo_SchemaStruct.Size(m)
+}
+func (m *SchemaStruct) XXX_DiscardUnknown() {
+	xxx_messageInfo_SchemaStruct.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SchemaStruct proto.InternalMessageInfo
+
+func (m *SchemaStruct) GetFields() []*SchemaField {
+	if m != nil {
+		return m.Fields
+	}
+	return nil
+}
+
+// A named set of values, which kinds and structs can use as the type of a
+// field. Enums are generated as protobuf enums, and their values are stored
+// by name, so that they can be renumbered.
+type SchemaEnum struct {
+	Values               []*SchemaEnumValue `protobuf:"bytes,1,rep,name=values,proto3" json:"values,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
+}
+
+func (m *SchemaEnum) Reset()         { *m = SchemaEnum{} }
+func (m *SchemaEnum) String() string { return proto.CompactTextString(m) }
+func (*SchemaEnum) ProtoMessage()    {}
+func (*SchemaEnum) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b5ea8fe65782bcc, []int{40}
+}
+
+func (m *SchemaEnum) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SchemaEnum.Unmarshal(m, b)
+}
+func (m *SchemaEnum) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SchemaEnum.Marshal(b, m, deterministic)
+}
+func (m *SchemaEnum) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SchemaEnum.Merge(m, src)
+}
+func (m *SchemaEnum) XXX_Size() int {
+	return xxx_messageInfo_SchemaEnum.Size(m)
+}
+func (m *SchemaEnum) XXX_DiscardUnknown() {
+	xxx_messageInfo_SchemaEnum.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SchemaEnum proto.InternalMessageInfo
+
+func (m *SchemaEnum) GetValues() []*SchemaEnumValue {
+	if m != nil {
+		return m.Values
+	}
+	return nil
+}
+
+type SchemaEnumValue struct {
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// The number of the value in the generated protobuf enum. The first
+	// value of every enum must be numbered 0, which unset fields have.
+	Number               int32    `protobuf:"varint,2,opt,name=number,proto3" json:"number,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SchemaEnumValue) Reset()         { *m = SchemaEnumValue{} }
+func (m *SchemaEnumValue) String() string { return proto.CompactTextString(m) }
+func (*SchemaEnumValue) ProtoMessage()    {}
+func (*SchemaEnumValue) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b5ea8fe65782bcc, []int{41}
+}
+
+func (m *SchemaEnumValue) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SchemaEnumValue.Unmarshal(m, b)
+}
+func (m *SchemaEnumValue) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SchemaEnumValue.Marshal(b, m, deterministic)
+}
+func (m *SchemaEnumValue) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SchemaEnumValue.Merge(m, src)
+}
+func (m *SchemaEnumValue) XXX_Size() int {
+	return xxx_messageInfo_SchemaEnumValue.Size(m)
+}
+func (m *SchemaEnumValue) XXX_DiscardUnknown() {
+	xxx_messageInfo_SchemaEnumValue.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SchemaEnumValue proto.InternalMessageInfo
+
+func (m *SchemaEnumValue) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *SchemaEnumValue) GetNumber() int32 {
+	if m != nil {
+		return m.Number
+	}
+	return 0
+}
+
+type GetSchemaRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetSchemaRequest) Reset()         { *m = GetSchemaRequest{} }
+func (m *GetSchemaRequest) String() string { return proto.CompactTextString(m) }
+func (*GetSchemaRequest) ProtoMessage()    {}
+func (*GetSchemaRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b5ea8fe65782bcc, []int{42}
+}
+
+func (m *GetSchemaRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetSchemaRequest.Unmarshal(m, b)
+}
+func (m *GetSchemaRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetSchemaRequest.Marshal(b, m, deterministic)
+}
+func (m *GetSchemaRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetSchemaRequest.Merge(m, src)
+}
+func (m *GetSchemaRequest) XXX_Size() int {
+	return xxx_messageInfo_GetSchemaRequest.Size(m)
+}
+func (m *GetSchemaRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetSchemaRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetSchemaRequest proto.InternalMessageInfo
+
+type GetSchemaResponse struct {
+	Schema               *Schema  `protobuf:"bytes,1,opt,name=schema,proto3" json:"schema,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetSchemaResponse) Reset()         { *m = GetSchemaResponse{} }
+func (m *GetSchemaResponse) String() string { return proto.CompactTextString(m) }
+func (*GetSchemaResponse) ProtoMessage()    {}
+func (*GetSchemaResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b5ea8fe65782bcc, []int{43}
+}
+
+func (m *GetSchemaResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetSchemaResponse.Unmarshal(m, b)
+}
+func (m *GetSchemaResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetSchemaResponse.Marshal(b, m, deterministic)
+}
+func (m *GetSchemaResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetSchemaResponse.Merge(m, src)
+}
+func (m *GetSchemaResponse) XXX_Size() int {
+	return xxx_messageInfo_GetSchemaResponse.Size(m)
+}
+func (m *GetSchemaResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetSchemaResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetSchemaResponse proto.InternalMessageInfo
+
+func (m *GetSchemaResponse) GetSchema() *Schema {
+	if m != nil {
+		return m.Schema
+	}
+	return nil
+}
+
+type MetaListEntitiesRequest struct {
+	Start []byte `protobuf:"bytes,1,opt,name=start,proto3" json:"start,omitempty"`
+	// a limit of 0 or lower indicates no limit to the number of entities returned
+	Limit    uint32 `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	KindName string `protobuf:"bytes,3,opt,name=kindName,proto3" json:"kindName,omitempty"`
+	// if omitted, entities are listed from the default namespace
+	PartitionId *PartitionId `protobuf:"bytes,4,opt,name=partitionId,proto3" json:"partitionId,omitempty"`
+	// if set, only entities nested under this key (at any depth) are listed, and partitionId is ignored
+	Ancestor             *Key     `protobuf:"bytes,5,opt,name=ancestor,proto3" json:"ancestor,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *MetaListEntitiesRequest) Reset()         { *m = MetaListEntitiesRequest{} }
+func (m *MetaListEntitiesRequest) String() string { return proto.CompactTextString(m) }
+func (*MetaListEntitiesRequest) ProtoMessage()    {}
+func (*MetaListEntitiesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b5ea8fe65782bcc, []int{44}
+}
+
+func (m *MetaListEntitiesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MetaListEntitiesRequest.Unmarshal(m, b)
+}
+func (m *MetaListEntitiesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MetaListEntitiesRequest.Marshal(b, m, deterministic)
+}
+func (m *MetaListEntitiesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MetaListEntitiesRequest.Merge(m, src)
+}
+func (m *MetaListEntitiesRequest) XXX_Size() int {
+	return xxx_messageInfo_MetaListEntitiesRequest.Size(m)
+}
+func (m *MetaListEntitiesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_MetaListEntitiesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MetaListEntitiesRequest proto.InternalMessageInfo
+
+func (m *MetaListEntitiesRequest) GetStart() []byte {
+	if m != nil {
+		return m.Start
+	}
+	return nil
+}
+
+func (m *MetaListEntitiesRequest) GetLimit() uint32 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+func (m *MetaListEntitiesRequest) GetKindName() string {
+	if m != nil {
+		return m.KindName
+	}
+	return ""
+}
+
+func (m *MetaListEntitiesRequest) GetPartitionId() *PartitionId {
+	if m != nil {
+		return m.PartitionId
+	}
+	return nil
+}
+
+func (m *MetaListEntitiesRequest) GetAncestor() *Key {
+	if m != nil {
+		return m.Ancestor
+	}
+	return nil
+}
+
+type MetaListEntitiesResponse struct {
+	Next                 []byte        `protobuf:"bytes,1,opt,name=next,proto3" json:"next,omitempty"`
+	MoreResults          bool          `protobuf:"varint,2,opt,name=moreResults,proto3" json:"moreResults,omitempty"`
+	Entities             []*MetaEntity `protobuf:"bytes,3,rep,name=entities,proto3" json:"entities,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *MetaListEntitiesResponse) Reset()         { *m = MetaListEntitiesResponse{} }
+func (m *MetaListEntitiesResponse) String() string { return proto.CompactTextString(m) }
+func (*MetaListEntitiesResponse) ProtoMessage()    {}
+func (*MetaListEntitiesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b5ea8fe65782bcc, []int{45}
+}
+
+func (m *MetaListEntitiesResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MetaListEntitiesResponse.Unmarshal(m, b)
+}
+func (m *MetaListEntitiesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MetaListEntitiesResponse.Marshal(b, m, deterministic)
+}
+func (m *MetaListEntitiesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MetaListEntitiesResponse.Merge(m, src)
+}
+func (m *MetaListEntitiesResponse) XXX_Size() int {
+	return xxx_messageInfo_MetaListEntitiesResponse.Size(m)
+}
+func (m *MetaListEntitiesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_MetaListEntitiesResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MetaListEntitiesResponse proto.InternalMessageInfo
+
+func (m *MetaListEntitiesResponse) GetNext() []byte {
+	if m != nil {
+		return m.Next
+	}
+	return nil
+}
+
+func (m *MetaListEntitiesResponse) GetMoreResults() bool {
+	if m != nil {
+		return m.MoreResults
+	}
+	return false
+}
+
+func (m *MetaListEntitiesResponse) GetEntities() []*MetaEntity {
+	if m != nil {
+		return m.Entities
+	}
+	return nil
+}
+
+type MetaEntity struct {
+	Key    *Key     `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Values []*Value `protobuf:"bytes,2,rep,name=values,proto3" json:"values,omitempty"`
+	// the version of the entity when it was read or written, or 0 if it has never been versioned
+	Version int64 `protobuf:"varint,3,opt,name=version,proto3" json:"version,omitempty"`
+	// the fields whose stored values don't match their type in the schema; these fields are left unset in values
+	FieldViolations      []*MetaFieldViolation `protobuf:"bytes,4,rep,name=fieldViolations,proto3" json:"fieldViolations,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
+	XXX_unrecognized     []byte                `json:"-"`
+	XXX_sizecache        int32                 `json:"-"`
+}
+
+func (m *MetaEntity) Reset()         { *m = MetaEntity{} }
+func (m *MetaEntity) String() string { return proto.CompactTextString(m) }
+func (*MetaEntity) ProtoMessage()    {}
+func (*MetaEntity) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b5ea8fe65782bcc, []int{46}
+}
+
+func (m *MetaEntity) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MetaEntity.Unmarshal(m, b)
+}
+func (m *MetaEntity) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MetaEntity.Marshal(b, m, deterministic)
+}
+func (m *MetaEntity) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MetaEntity.Merge(m, src)
+}
+func (m *MetaEntity) XXX_Size() int {
+	return xxx_messageInfo_MetaEntity.Size(m)
+}
+func (m *MetaEntity) XXX_DiscardUnknown() {
+	xxx_messageInfo_MetaEntity.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MetaEntity proto.InternalMessageInfo
+
+func (m *MetaEntity) GetKey() *Key {
+	if m != nil {
+		return m.Key
+	}
+	return nil
+}
+
+func (m *MetaEntity) GetValues() []*Value {
+	if m != nil {
+		return m.Values
+	}
+	return nil
+}
+
+func (m *MetaEntity) GetVersion() int64 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
+func (m *MetaEntity) GetFieldViolations() []*MetaFieldViolation {
+	if m != nil {
+		return m.FieldViolations
+	}
+	return nil
+}
+
+type GetDefaultPartitionIdRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetDefaultPartitionIdRequest) Reset()         { *m = GetDefaultPartitionIdRequest{} }
+func (m *GetDefaultPartitionIdRequest) String() string { return proto.CompactTextString(m) }
+func (*GetDefaultPartitionIdRequest) ProtoMessage()    {}
+func (*GetDefaultPartitionIdRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b5ea8fe65782bcc, []int{47}
+}
+
+func (m *GetDefaultPartitionIdRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetDefaultPartitionIdRequest.Unmarshal(m, b)
+}
+func (m *GetDefaultPartitionIdRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetDefaultPartitionIdRequest.Marshal(b, m, deterministic)
+}
+func (m *GetDefaultPartitionIdRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetDefaultPartitionIdRequest.Merge(m, src)
+}
+func (m *GetDefaultPartitionIdRequest) XXX_Size() int {
+	return xxx_messageInfo_GetDefaultPartitionIdRequest.Size(m)
+}
+func (m *GetDefaultPartitionIdRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetDefaultPartitionIdRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetDefaultPartitionIdRequest proto.InternalMessageInfo
+
+type GetDefaultPartitionIdResponse struct {
+	Namespace            string   `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetDefaultPartitionIdResponse) Reset()         { *m = GetDefaultPartitionIdResponse{} }
+func (m *GetDefaultPartitionIdResponse) String() string { return proto.CompactTextString(m) }
+func (*GetDefaultPartitionIdResponse) ProtoMessage()    {}
+func (*GetDefaultPartitionIdResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b5ea8fe65782bcc, []int{48}
+}
+
+func (m *GetDefaultPartitionIdResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetDefaultPartitionIdResponse.Unmarshal(m, b)
+}
+func (m *GetDefaultPartitionIdResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetDefaultPartitionIdResponse.Marshal(b, m, deterministic)
+}
+func (m *GetDefaultPartitionIdResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetDefaultPartitionIdResponse.Merge(m, src)
+}
+func (m *GetDefaultPartitionIdResponse) XXX_Size() int {
+	return xxx_messageInfo_GetDefaultPartitionIdResponse.Size(m)
+}
+func (m *GetDefaultPartitionIdResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetDefaultPartitionIdResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetDefaultPartitionIdResponse proto.InternalMessageInfo
+
+func (m *GetDefaultPartitionIdResponse) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
+}
+
+type MetaGetEntityRequest struct {
+	Key                  *Key     `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	KindName             string   `protobuf:"bytes,2,opt,name=kindName,proto3" json:"kindName,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *MetaGetEntityRequest) Reset()         { *m = MetaGetEntityRequest{} }
+func (m *MetaGetEntityRequest) String() string { return proto.CompactTextString(m) }
+func (*MetaGetEntityRequest) ProtoMessage()    {}
+func (*MetaGetEntityRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b5ea8fe65782bcc, []int{49}
+}
+
+func (m *MetaGetEntityRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MetaGetEntityRequest.Unmarshal(m, b)
+}
+func (m *MetaGetEntityRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MetaGetEntityRequest.Marshal(b, m, deterministic)
+}
+func (m *MetaGetEntityRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MetaGetEntityRequest.Merge(m, src)
+}
+func (m *MetaGetEntityRequest) XXX_Size() int {
+	return xxx_messageInfo_MetaGetEntityRequest.Size(m)
+}
+func (m *MetaGetEntityRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_MetaGetEntityRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MetaGetEntityRequest proto.InternalMessageInfo
+
+func (m *MetaGetEntityRequest) GetKey() *Key {
+	if m != nil {
+		return m.Key
+	}
+	return nil
+}
+
+func (m *MetaGetEntityRequest) GetKindName() string {
+	if m != nil {
+		return m.KindName
+	}
+	return ""
+}
+
+type MetaGetEntityResponse struct {
+	Entity               *MetaEntity `protobuf:"bytes,1,opt,name=entity,proto3" json:"entity,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *MetaGetEntityResponse) Reset()         { *m = MetaGetEntityResponse{} }
+func (m *MetaGetEntityResponse) String() string { return proto.CompactTextString(m) }
+func (*MetaGetEntityResponse) ProtoMessage()    {}
+func (*MetaGetEntityResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b5ea8fe65782bcc, []int{50}
+}
+
+func (m *MetaGetEntityResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MetaGetEntityResponse.Unmarshal(m, b)
+}
+func (m *MetaGetEntityResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MetaGetEntityResponse.Marshal(b, m, deterministic)
+}
+func (m *MetaGetEntityResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MetaGetEntityResponse.Merge(m, src)
+}
+func (m *MetaGetEntityResponse) XXX_Size() int {
+	return xxx_messageInfo_MetaGetEntityResponse.Size(m)
+}
+func (m *MetaGetEntityResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_MetaGetEntityResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MetaGetEntityResponse proto.InternalMessageInfo
+
+func (m *MetaGetEntityResponse) GetEntity() *MetaEntity {
+	if m != nil {
+		return m.Entity
+	}
+	return nil
+}
+
+type MetaUpdateEntityRequest struct {
+	Entity *MetaEntity `protobuf:"bytes,1,opt,name=entity,proto3" json:"entity,omitempty"`
+	// if not 0, the update fails with ABORTED unless the entity is currently at this version
+	ExpectedVersion int64 `protobuf:"varint,2,opt,name=expectedVersion,proto3" json:"expectedVersion,omitempty"`
+	// if set, only the named schema fields are updated, and the rest are left as they are stored;
+	// a single entry of a map field can be named as "field.key"
+	FieldMask            []string `protobuf:"bytes,3,rep,name=fieldMask,proto3" json:"fieldMask,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *MetaUpdateEntityRequest) Reset()         { *m = MetaUpdateEntityRequest{} }
+func (m *MetaUpdateEntityRequest) String() string { return proto.CompactTextString(m) }
+func (*MetaUpdateEntityRequest) ProtoMessage()    {}
+func (*MetaUpdateEntityRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b5ea8fe65782bcc, []int{51}
+}
+
+func (m *MetaUpdateEntityRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MetaUpdateEntityRequest.Unmarshal(m, b)
+}
+func (m *MetaUpdateEntityRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MetaUpdateEntityRequest.Marshal(b, m, deterministic)
+}
+func (m *MetaUpdateEntityRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MetaUpdateEntityRequest.Merge(m, src)
+}
+func (m *MetaUpdateEntityRequest) XXX_Size() int {
+	return xxx_messageInfo_MetaUpdateEntityRequest.Size(m)
+}
+func (m *MetaUpdateEntityRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_MetaUpdateEntityRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MetaUpdateEntityRequest proto.InternalMessageInfo
+
+func (m *MetaUpdateEntityRequest) GetEntity() *MetaEntity {
+	if m != nil {
+		return m.Entity
+	}
+	return nil
+}
+
+func (m *MetaUpdateEntityRequest) GetExpectedVersion() int64 {
+	if m != nil {
+		return m.ExpectedVersion
+	}
+	return 0
+}
+
+func (m *MetaUpdateEntityRequest) GetFieldMask() []string {
+	if m != nil {
+		return m.FieldMask
+	}
+	return nil
+}
+
+type MetaUpdateEntityResponse struct {
+	Entity               *MetaEntity `protobuf:"bytes,1,opt,name=entity,proto3" json:"entity,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *MetaUpdateEntityResponse) Reset()         { *m = MetaUpdateEntityResponse{} }
+func (m *MetaUpdateEntityResponse) String() string { return proto.CompactTextString(m) }
+func (*MetaUpdateEntityResponse) ProtoMessage()    {}
+func (*MetaUpdateEntityResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b5ea8fe65782bcc, []int{52}
+}
+
+func (m *MetaUpdateEntityResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MetaUpdateEntityResponse.Unmarshal(m, b)
+}
+func (m *MetaUpdateEntityResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MetaUpdateEntityResponse.Marshal(b, m, deterministic)
+}
+func (m *MetaUpdateEntityResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MetaUpdateEntityResponse.Merge(m, src)
+}
+func (m *MetaUpdateEntityResponse) XXX_Size() int {
+	return xxx_messageInfo_MetaUpdateEntityResponse.Size(m)
+}
+func (m *MetaUpdateEntityResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_MetaUpdateEntityResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MetaUpdateEntityResponse proto.InternalMessageInfo
+
+func (m *MetaUpdateEntityResponse) GetEntity() *MetaEntity {
+	if m != nil {
+		return m.Entity
+	}
+	return nil
+}
+
+type MetaCreateEntityRequest struct {
+	Entity               *MetaEntity `protobuf:"bytes,1,opt,name=entity,proto3" json:"entity,omitempty"`
+	KindName             string      `protobuf:"bytes,2,opt,name=kindName,proto3" json:"kindName,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *MetaCreateEntityRequest) Reset()         { *m = MetaCreateEntityRequest{} }
+func (m *MetaCreateEntityRequest) String() string { return proto.CompactTextString(m) }
+func (*MetaCreateEntityRequest) ProtoMessage()    {}
+func (*MetaCreateEntityRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b5ea8fe65782bcc, []int{53}
+}
+
+func (m *MetaCreateEntityRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MetaCreateEntityRequest.Unmarshal(m, b)
+}
+func (m *MetaCreateEntityRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MetaCreateEntityRequest.Marshal(b, m, deterministic)
+}
+func (m *MetaCreateEntityRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MetaCreateEntityRequest.Merge(m, src)
+}
+func (m *MetaCreateEntityRequest) XXX_Size() int {
+	return xxx_messageInfo_MetaCreateEntityRequest.Size(m)
+}
+func (m *MetaCreateEntityRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_MetaCreateEntityRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MetaCreateEntityRequest proto.InternalMessageInfo
+
+func (m *MetaCreateEntityRequest) GetEntity() *MetaEntity {
+	if m != nil {
+		return m.Entity
+	}
+	return nil
+}
+
+func (m *MetaCreateEntityRequest) GetKindName() string {
+	if m != nil {
+		return m.KindName
+	}
+	return ""
+}
+
+type MetaCreateEntityResponse struct {
+	Entity               *MetaEntity `protobuf:"bytes,1,opt,name=entity,proto3" json:"entity,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *MetaCreateEntityResponse) Reset()         { *m = MetaCreateEntityResponse{} }
+func (m *MetaCreateEntityResponse) String() string { return proto.CompactTextString(m) }
+func (*MetaCreateEntityResponse) ProtoMessage()    {}
+func (*MetaCreateEntityResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b5ea8fe65782bcc, []int{54}
+}
+
+func (m *MetaCreateEntityResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MetaCreateEntityResponse.Unmarshal(m, b)
+}
+func (m *MetaCreateEntityResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MetaCreateEntityResponse.Marshal(b, m, deterministic)
+}
+func (m *MetaCreateEntityResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MetaCreateEntityResponse.Merge(m, src)
+}
+func (m *MetaCreateEntityResponse) XXX_Size() int {
+	return xxx_messageInfo_MetaCreateEntityResponse.Size(m)
+}
+func (m *MetaCreateEntityResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_MetaCreateEntityResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MetaCreateEntityResponse proto.InternalMessageInfo
+
+func (m *MetaCreateEntityResponse) GetEntity() *MetaEntity {
+	if m != nil {
+		return m.Entity
+	}
+	return nil
+}
+
+type MetaDeleteEntityRequest struct {
+	Key      *Key   `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	KindName string `protobuf:"bytes,2,opt,name=kindName,proto3" json:"kindName,omitempty"`
+	// if not 0, the delete fails with ABORTED unless the entity is currently at this version
+	ExpectedVersion      int64    `protobuf:"varint,3,opt,name=expectedVersion,proto3" json:"expectedVersion,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *MetaDeleteEntityRequest) Reset()         { *m = MetaDeleteEntityRequest{} }
+func (m *MetaDeleteEntityRequest) String() string { return proto.CompactTextString(m) }
+func (*MetaDeleteEntityRequest) ProtoMessage()    {}
+func (*MetaDeleteEntityRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b5ea8fe65782bcc, []int{55}
+}
+
+func (m *MetaDeleteEntityRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MetaDeleteEntityRequest.Unmarshal(m, b)
+}
+func (m *MetaDeleteEntityRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MetaDeleteEntityRequest.Marshal(b, m, deterministic)
+}
+func (m *MetaDeleteEntityRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MetaDeleteEntityRequest.Merge(m, src)
+}
+func (m *MetaDeleteEntityRequest) XXX_Size() int {
+	return xxx_messageInfo_MetaDeleteEntityRequest.Size(m)
+}
+func (m *MetaDeleteEntityRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_MetaDeleteEntityRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MetaDeleteEntityRequest proto.InternalMessageInfo
+
+func (m *MetaDeleteEntityRequest) GetKey() *Key {
+	if m != nil {
+		return m.Key
+	}
+	return nil
+}
+
+func (m *MetaDeleteEntityRequest) GetKindName() string {
+	if m != nil {
+		return m.KindName
+	}
+	return ""
+}
+
+func (m *MetaDeleteEntityRequest) GetExpectedVersion() int64 {
+	if m != nil {
+		return m.ExpectedVersion
+	}
+	return 0
+}
+
+type MetaDeleteEntityResponse struct {
+	Entity               *MetaEntity `protobuf:"bytes,1,opt,name=entity,proto3" json:"entity,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *MetaDeleteEntityResponse) Reset()         { *m = MetaDeleteEntityResponse{} }
+func (m *MetaDeleteEntityResponse) String() string { return proto.CompactTextString(m) }
+func (*MetaDeleteEntityResponse) ProtoMessage()    {}
+func (*MetaDeleteEntityResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b5ea8fe65782bcc, []int{56}
+}
+
+func (m *MetaDeleteEntityResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MetaDeleteEntityResponse.Unmarshal(m, b)
+}
+func (m *MetaDeleteEntityResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MetaDeleteEntityResponse.Marshal(b, m, deterministic)
+}
+func (m *MetaDeleteEntityResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MetaDeleteEntityResponse.Merge(m, src)
+}
+func (m *MetaDeleteEntityResponse) XXX_Size() int {
+	return xxx_messageInfo_MetaDeleteEntityResponse.Size(m)
+}
+func (m *MetaDeleteEntityResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_MetaDeleteEntityResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MetaDeleteEntityResponse proto.InternalMessageInfo
+
+func (m *MetaDeleteEntityResponse) GetEntity() *MetaEntity {
+	if m != nil {
+		return m.Entity
+	}
+	return nil
+}
+
+type GetTransactionQueueCountRequest struct {
+	PartitionId          *PartitionId `protobuf:"bytes,1,opt,name=partitionId,proto3" json:"partitionId,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *GetTransactionQueueCountRequest) Reset()         { *m = GetTransactionQueueCountRequest{} }
+func (m *GetTransactionQueueCountRequest) String() string { return proto.CompactTextString(m) }
+func (*GetTransactionQueueCountRequest) ProtoMessage()    {}
+func (*GetTransactionQueueCountRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b5ea8fe65782bcc, []int{57}
+}
+
+func (m *GetTransactionQueueCountRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetTransactionQueueCountRequest.Unmarshal(m, b)
+}
+func (m *GetTransactionQueueCountRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetTransactionQueueCountRequest.Marshal(b, m, deterministic)
+}
+func (m *GetTransactionQueueCountRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetTransactionQueueCountRequest.Merge(m, src)
+}
+func (m *GetTransactionQueueCountRequest) XXX_Size() int {
+	return xxx_messageInfo_GetTransactionQueueCountRequest.Size(m)
+}
+func (m *GetTransactionQueueCountRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetTransactionQueueCountRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetTransactionQueueCountRequest proto.InternalMessageInfo
+
+func (m *GetTransactionQueueCountRequest) GetPartitionId() *PartitionId {
+	if m != nil {
+		return m.PartitionId
+	}
+	return nil
+}
+
+type GetTransactionQueueCountResponse struct {
+	TransactionQueueCount uint32   `protobuf:"varint,1,opt,name=transactionQueueCount,proto3" json:"transactionQueueCount,omitempty"`
+	XXX_NoUnkeyedLiteral  struct{} `json:"-"`
+	XXX_unrecognized      []byte   `json:"-"`
+	XXX_sizecache         int32    `json:"-"`
+}
+
+func (m *GetTransactionQueueCountResponse) Reset()         { *m = GetTransactionQueueCountResponse{} }
+func (m *GetTransactionQueueCountResponse) String() string { return proto.CompactTextString(m) }
+func (*GetTransactionQueueCountResponse) ProtoMessage()    {}
+func (*GetTransactionQueueCountResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b5ea8fe65782bcc, []int{58}
+}
+
+func (m *GetTransactionQueueCountResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetTransactionQueueCountResponse.Unmarshal(m, b)
+}
+func (m *GetTransactionQueueCountResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetTransactionQueueCountResponse.Marshal(b, m, deterministic)
+}
+func (m *GetTransactionQueueCountResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetTransactionQueueCountResponse.Merge(m, src)
+}
+func (m *GetTransactionQueueCountResponse) XXX_Size() int {
+	return xxx_messageInfo_GetTransactionQueueCountResponse.Size(m)
+}
+func (m *GetTransactionQueueCountResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetTransactionQueueCountResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetTransactionQueueCountResponse proto.InternalMessageInfo
+
+func (m *GetTransactionQueueCountResponse) GetTransactionQueueCount() uint32 {
+	if m != nil {
+		return m.TransactionQueueCount
+	}
+	return 0
+}
+
+type RestoreToTimeRequest struct {
+	// entities modified after this time are restored to the state they were in at this time
+	Timestamp *timestamp.Timestamp `protobuf:"bytes,1,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	// if omitted, entities in the default namespace are restored
+	PartitionId          *PartitionId `protobuf:"bytes,2,opt,name=partitionId,proto3" json:"partitionId,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *RestoreToTimeRequest) Reset()         { *m = RestoreToTimeRequest{} }
+func (m *RestoreToTimeRequest) String() string { return proto.CompactTextString(m) }
+func (*RestoreToTimeRequest) ProtoMessage()    {}
+func (*RestoreToTimeRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b5ea8fe65782bcc, []int{59}
+}
+
+func (m *RestoreToTimeRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RestoreToTimeRequest.Unmarshal(m, b)
 }
-func (m *MetaEntity) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_MetaEntity.Merge(m, src)
+func (m *RestoreToTimeRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RestoreToTimeRequest.Marshal(b, m, deterministic)
 }
-func (m *MetaEntity) XXX_Size() int {
-	return xxx_messageInfo_MetaEntity.Size(m)
+func (m *RestoreToTimeRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RestoreToTimeRequest.Merge(m, src)
 }
-func (m *MetaEntity) XXX_DiscardUnknown() {
-	xxx_messageInfo_MetaEntity.DiscardUnknown(m)
+func (m *RestoreToTimeRequest) XXX_Size() int {
+	return xxx_messageInfo_RestoreToTimeRequest.Size(m)
+}
+func (m *RestoreToTimeRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RestoreToTimeRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_MetaEntity proto.InternalMessageInfo
+var xxx_messageInfo_RestoreToTimeRequest proto.InternalMessageInfo
 
-func (m *MetaEntity) GetKey() *Key {
+func (m *RestoreToTimeRequest) GetTimestamp() *timestamp.Timestamp {
 	if m != nil {
-		return m.Key
+		return m.Timestamp
 	}
 	return nil
 }
 
-func (m *MetaEntity) GetValues() []*Value {
+func (m *RestoreToTimeRequest) GetPartitionId() *PartitionId {
 	if m != nil {
-		return m.Values
+		return m.PartitionId
 	}
 	return nil
 }
 
-type GetDefaultPartitionIdRequest struct {
+type RestoreToTimeResponse struct {
+	// the entities that were restored to an earlier version
+	RestoredKeys []*Key `protobuf:"bytes,1,rep,name=restoredKeys,proto3" json:"restoredKeys,omitempty"`
+	// the entities that were deleted because they didn't exist at the given time
+	DeletedKeys          []*Key   `protobuf:"bytes,2,rep,name=deletedKeys,proto3" json:"deletedKeys,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *GetDefaultPartitionIdRequest) Reset()         { *m = GetDefaultPartitionIdRequest{} }
-func (m *GetDefaultPartitionIdRequest) String() string { return proto.CompactTextString(m) }
-func (*GetDefaultPartitionIdRequest) ProtoMessage()    {}
-func (*GetDefaultPartitionIdRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3b5ea8fe65782bcc, []int{26}
+func (m *RestoreToTimeResponse) Reset()         { *m = RestoreToTimeResponse{} }
+func (m *RestoreToTimeResponse) String() string { return proto.CompactTextString(m) }
+func (*RestoreToTimeResponse) ProtoMessage()    {}
+func (*RestoreToTimeResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b5ea8fe65782bcc, []int{60}
 }
 
-func (m *GetDefaultPartitionIdRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_GetDefaultPartitionIdRequest.Unmarshal(m, b)
-}
-func (m *GetDefaultPartitionIdRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_GetDefaultPartitionIdRequest.Marshal(b, m, deterministic)
+func (m *RestoreToTimeResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RestoreToTimeResponse.Unmarshal(m, b)
 }
-func (m *GetDefaultPartitionIdRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_GetDefaultPartitionIdRequest.Merge(m, src)
+func (m *RestoreToTimeResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RestoreToTimeResponse.Marshal(b, m, deterministic)
 }
-func (m *GetDefaultPartitionIdRequest) XXX_Size() int {
-	return xxx_messageInfo_GetDefaultPartitionIdRequest.Size(m)
+func (m *RestoreToTimeResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RestoreToTimeResponse.Merge(m, src)
 }
-func (m *GetDefaultPartitionIdRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_GetDefaultPartitionIdRequest.DiscardUnknown(m)
+func (m *RestoreToTimeResponse) XXX_Size() int {
+	return xxx_messageInfo_RestoreToTimeResponse.Size(m)
 }
-
-var xxx_messageInfo_GetDefaultPartitionIdRequest proto.InternalMessageInfo
-
-type GetDefaultPartitionIdResponse struct {
-	Namespace            string   `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+func (m *RestoreToTimeResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_RestoreToTimeResponse.DiscardUnknown(m)
 }
 
-func (m *GetDefaultPartitionIdResponse) Reset()         { *m = GetDefaultPartitionIdResponse{} }
-func (m *GetDefaultPartitionIdResponse) String() string { return proto.CompactTextString(m) }
-func (*GetDefaultPartitionIdResponse) ProtoMessage()    {}
-func (*GetDefaultPartitionIdResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3b5ea8fe65782bcc, []int{27}
-}
+var xxx_messageInfo_RestoreToTimeResponse proto.InternalMessageInfo
 
-func (m *GetDefaultPartitionIdResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_GetDefaultPartitionIdResponse.Unmarshal(m, b)
-}
-func (m *GetDefaultPartitionIdResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_GetDefaultPartitionIdResponse.Marshal(b, m, deterministic)
-}
-func (m *GetDefaultPartitionIdResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_GetDefaultPartitionIdResponse.Merge(m, src)
-}
-func (m *GetDefaultPartitionIdResponse) XXX_Size() int {
-	return xxx_messageInfo_GetDefaultPartitionIdResponse.Size(m)
-}
-func (m *GetDefaultPartitionIdResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_GetDefaultPartitionIdResponse.DiscardUnknown(m)
+func (m *RestoreToTimeResponse) GetRestoredKeys() []*Key {
+	if m != nil {
+		return m.RestoredKeys
+	}
+	return nil
 }
 
-var xxx_messageInfo_GetDefaultPartitionIdResponse proto.InternalMessageInfo
-
-func (m *GetDefaultPartitionIdResponse) GetNamespace() string {
+func (m *RestoreToTimeResponse) GetDeletedKeys() []*Key {
 	if m != nil {
-		return m.Namespace
+		return m.DeletedKeys
 	}
-	return ""
+	return nil
 }
 
-type MetaGetEntityRequest struct {
-	Key                  *Key     `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
-	KindName             string   `protobuf:"bytes,2,opt,name=kindName,proto3" json:"kindName,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+type MetaEntityVersion struct {
+	// versions start at 1 and increase by 1 with every create, update or delete of the entity
+	Version       int64                `protobuf:"varint,1,opt,name=version,proto3" json:"version,omitempty"`
+	TransactionId string               `protobuf:"bytes,2,opt,name=transactionId,proto3" json:"transactionId,omitempty"`
+	AuthSub       string               `protobuf:"bytes,3,opt,name=authSub,proto3" json:"authSub,omitempty"`
+	DateSubmitted *timestamp.Timestamp `protobuf:"bytes,4,opt,name=dateSubmitted,proto3" json:"dateSubmitted,omitempty"`
+	// true if this version deleted the entity, in which case entity is not set
+	Deleted              bool        `protobuf:"varint,5,opt,name=deleted,proto3" json:"deleted,omitempty"`
+	Entity               *MetaEntity `protobuf:"bytes,6,opt,name=entity,proto3" json:"entity,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
 }
 
-func (m *MetaGetEntityRequest) Reset()         { *m = MetaGetEntityRequest{} }
-func (m *MetaGetEntityRequest) String() string { return proto.CompactTextString(m) }
-func (*MetaGetEntityRequest) ProtoMessage()    {}
-func (*MetaGetEntityRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3b5ea8fe65782bcc, []int{28}
+func (m *MetaEntityVersion) Reset()         { *m = MetaEntityVersion{} }
+func (m *MetaEntityVersion) String() string { return proto.CompactTextString(m) }
+func (*MetaEntityVersion) ProtoMessage()    {}
+func (*MetaEntityVersion) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b5ea8fe65782bcc, []int{61}
 }
 
-func (m *MetaGetEntityRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_MetaGetEntityRequest.Unmarshal(m, b)
+func (m *MetaEntityVersion) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MetaEntityVersion.Unmarshal(m, b)
 }
-func (m *MetaGetEntityRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_MetaGetEntityRequest.Marshal(b, m, deterministic)
+func (m *MetaEntityVersion) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MetaEntityVersion.Marshal(b, m, deterministic)
 }
-func (m *MetaGetEntityRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_MetaGetEntityRequest.Merge(m, src)
+func (m *MetaEntityVersion) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MetaEntityVersion.Merge(m, src)
 }
-func (m *MetaGetEntityRequest) XXX_Size() int {
-	return xxx_messageInfo_MetaGetEntityRequest.Size(m)
+func (m *MetaEntityVersion) XXX_Size() int {
+	return xxx_messageInfo_MetaEntityVersion.Size(m)
 }
-func (m *MetaGetEntityRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_MetaGetEntityRequest.DiscardUnknown(m)
+func (m *MetaEntityVersion) XXX_DiscardUnknown() {
+	xxx_messageInfo_MetaEntityVersion.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_MetaGetEntityRequest proto.InternalMessageInfo
+var xxx_messageInfo_MetaEntityVersion proto.InternalMessageInfo
 
-func (m *MetaGetEntityRequest) GetKey() *Key {
+func (m *MetaEntityVersion) GetVersion() int64 {
 	if m != nil {
-		return m.Key
+		return m.Version
 	}
-	return nil
+	return 0
 }
 
-func (m *MetaGetEntityRequest) GetKindName() string {
+func (m *MetaEntityVersion) GetTransactionId() string {
 	if m != nil {
-		return m.KindName
+		return m.TransactionId
 	}
 	return ""
 }
 
-type MetaGetEntityResponse struct {
-	Entity               *MetaEntity `protobuf:"bytes,1,opt,name=entity,proto3" json:"entity,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
-	XXX_unrecognized     []byte      `json:"-"`
-	XXX_sizecache        int32       `json:"-"`
+func (m *MetaEntityVersion) GetAuthSub() string {
+	if m != nil {
+		return m.AuthSub
+	}
+	return ""
 }
 
-func (m *MetaGetEntityResponse) Reset()         { *m = MetaGetEntityResponse{} }
-func (m *MetaGetEntityResponse) String() string { return proto.CompactTextString(m) }
-func (*MetaGetEntityResponse) ProtoMessage()    {}
-func (*MetaGetEntityResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3b5ea8fe65782bcc, []int{29}
+func (m *MetaEntityVersion) GetDateSubmitted() *timestamp.Timestamp {
+	if m != nil {
+		return m.DateSubmitted
+	}
+	return nil
 }
 
-func (m *MetaGetEntityResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_MetaGetEntityResponse.Unmarshal(m, b)
-}
-func (m *MetaGetEntityResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_MetaGetEntityResponse.Marshal(b, m, deterministic)
-}
-func (m *MetaGetEntityResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_MetaGetEntityResponse.Merge(m, src)
-}
-func (m *MetaGetEntityResponse) XXX_Size() int {
-	return xxx_messageInfo_MetaGetEntityResponse.Size(m)
-}
-func (m *MetaGetEntityResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_MetaGetEntityResponse.DiscardUnknown(m)
+func (m *MetaEntityVersion) GetDeleted() bool {
+	if m != nil {
+		return m.Deleted
+	}
+	return false
 }
 
-var xxx_messageInfo_MetaGetEntityResponse proto.InternalMessageInfo
-
-func (m *MetaGetEntityResponse) GetEntity() *MetaEntity {
+func (m *MetaEntityVersion) GetEntity() *MetaEntity {
 	if m != nil {
 		return m.Entity
 	}
 	return nil
 }
 
-type MetaUpdateEntityRequest struct {
-	Entity               *MetaEntity `protobuf:"bytes,1,opt,name=entity,proto3" json:"entity,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
-	XXX_unrecognized     []byte      `json:"-"`
-	XXX_sizecache        int32       `json:"-"`
+type MetaGetHistoryRequest struct {
+	Key                  *Key     `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *MetaUpdateEntityRequest) Reset()         { *m = MetaUpdateEntityRequest{} }
-func (m *MetaUpdateEntityRequest) String() string { return proto.CompactTextString(m) }
-func (*MetaUpdateEntityRequest) ProtoMessage()    {}
-func (*MetaUpdateEntityRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3b5ea8fe65782bcc, []int{30}
+func (m *MetaGetHistoryRequest) Reset()         { *m = MetaGetHistoryRequest{} }
+func (m *MetaGetHistoryRequest) String() string { return proto.CompactTextString(m) }
+func (*MetaGetHistoryRequest) ProtoMessage()    {}
+func (*MetaGetHistoryRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b5ea8fe65782bcc, []int{62}
 }
 
-func (m *MetaUpdateEntityRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_MetaUpdateEntityRequest.Unmarshal(m, b)
+func (m *MetaGetHistoryRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MetaGetHistoryRequest.Unmarshal(m, b)
 }
-func (m *MetaUpdateEntityRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_MetaUpdateEntityRequest.Marshal(b, m, deterministic)
+func (m *MetaGetHistoryRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MetaGetHistoryRequest.Marshal(b, m, deterministic)
 }
-func (m *MetaUpdateEntityRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_MetaUpdateEntityRequest.Merge(m, src)
+func (m *MetaGetHistoryRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MetaGetHistoryRequest.Merge(m, src)
 }
-func (m *MetaUpdateEntityRequest) XXX_Size() int {
-	return xxx_messageInfo_MetaUpdateEntityRequest.Size(m)
+func (m *MetaGetHistoryRequest) XXX_Size() int {
+	return xxx_messageInfo_MetaGetHistoryRequest.Size(m)
 }
-func (m *MetaUpdateEntityRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_MetaUpdateEntityRequest.DiscardUnknown(m)
+func (m *MetaGetHistoryRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_MetaGetHistoryRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_MetaUpdateEntityRequest proto.InternalMessageInfo
+var xxx_messageInfo_MetaGetHistoryRequest proto.InternalMessageInfo
 
-func (m *MetaUpdateEntityRequest) GetEntity() *MetaEntity {
+func (m *MetaGetHistoryRequest) GetKey() *Key {
 	if m != nil {
-		return m.Entity
+		return m.Key
 	}
 	return nil
 }
 
-type MetaUpdateEntityResponse struct {
-	Entity               *MetaEntity `protobuf:"bytes,1,opt,name=entity,proto3" json:"entity,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
-	XXX_unrecognized     []byte      `json:"-"`
-	XXX_sizecache        int32       `json:"-"`
+type MetaGetHistoryResponse struct {
+	// every version of the entity, oldest first
+	Versions             []*MetaEntityVersion `protobuf:"bytes,1,rep,name=versions,proto3" json:"versions,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
 }
 
-func (m *MetaUpdateEntityResponse) Reset()         { *m = MetaUpdateEntityResponse{} }
-func (m *MetaUpdateEntityResponse) String() string { return proto.CompactTextString(m) }
-func (*MetaUpdateEntityResponse) ProtoMessage()    {}
-func (*MetaUpdateEntityResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3b5ea8fe65782bcc, []int{31}
+func (m *MetaGetHistoryResponse) Reset()         { *m = MetaGetHistoryResponse{} }
+func (m *MetaGetHistoryResponse) String() string { return proto.CompactTextString(m) }
+func (*MetaGetHistoryResponse) ProtoMessage()    {}
+func (*MetaGetHistoryResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b5ea8fe65782bcc, []int{63}
 }
 
-func (m *MetaUpdateEntityResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_MetaUpdateEntityResponse.Unmarshal(m, b)
+func (m *MetaGetHistoryResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MetaGetHistoryResponse.Unmarshal(m, b)
 }
-func (m *MetaUpdateEntityResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_MetaUpdateEntityResponse.Marshal(b, m, deterministic)
+func (m *MetaGetHistoryResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MetaGetHistoryResponse.Marshal(b, m, deterministic)
 }
-func (m *MetaUpdateEntityResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_MetaUpdateEntityResponse.Merge(m, src)
+func (m *MetaGetHistoryResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MetaGetHistoryResponse.Merge(m, src)
 }
-func (m *MetaUpdateEntityResponse) XXX_Size() int {
-	return xxx_messageInfo_MetaUpdateEntityResponse.Size(m)
+func (m *MetaGetHistoryResponse) XXX_Size() int {
+	return xxx_messageInfo_MetaGetHistoryResponse.Size(m)
 }
-func (m *MetaUpdateEntityResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_MetaUpdateEntityResponse.DiscardUnknown(m)
+func (m *MetaGetHistoryResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_MetaGetHistoryResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_MetaUpdateEntityResponse proto.InternalMessageInfo
+var xxx_messageInfo_MetaGetHistoryResponse proto.InternalMessageInfo
 
-func (m *MetaUpdateEntityResponse) GetEntity() *MetaEntity {
+func (m *MetaGetHistoryResponse) GetVersions() []*MetaEntityVersion {
 	if m != nil {
-		return m.Entity
+		return m.Versions
 	}
 	return nil
 }
 
-type MetaCreateEntityRequest struct {
-	Entity               *MetaEntity `protobuf:"bytes,1,opt,name=entity,proto3" json:"entity,omitempty"`
-	KindName             string      `protobuf:"bytes,2,opt,name=kindName,proto3" json:"kindName,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
-	XXX_unrecognized     []byte      `json:"-"`
-	XXX_sizecache        int32       `json:"-"`
+type MetaGetAtVersionRequest struct {
+	Key                  *Key     `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Version              int64    `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *MetaCreateEntityRequest) Reset()         { *m = MetaCreateEntityRequest{} }
-func (m *MetaCreateEntityRequest) String() string { return proto.CompactTextString(m) }
-func (*MetaCreateEntityRequest) ProtoMessage()    {}
-func (*MetaCreateEntityRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3b5ea8fe65782bcc, []int{32}
+func (m *MetaGetAtVersionRequest) Reset()         { *m = MetaGetAtVersionRequest{} }
+func (m *MetaGetAtVersionRequest) String() string { return proto.CompactTextString(m) }
+func (*MetaGetAtVersionRequest) ProtoMessage()    {}
+func (*MetaGetAtVersionRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b5ea8fe65782bcc, []int{64}
 }
 
-func (m *MetaCreateEntityRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_MetaCreateEntityRequest.Unmarshal(m, b)
+func (m *MetaGetAtVersionRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MetaGetAtVersionRequest.Unmarshal(m, b)
 }
-func (m *MetaCreateEntityRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_MetaCreateEntityRequest.Marshal(b, m, deterministic)
+func (m *MetaGetAtVersionRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MetaGetAtVersionRequest.Marshal(b, m, deterministic)
 }
-func (m *MetaCreateEntityRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_MetaCreateEntityRequest.Merge(m, src)
+func (m *MetaGetAtVersionRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MetaGetAtVersionRequest.Merge(m, src)
 }
-func (m *MetaCreateEntityRequest) XXX_Size() int {
-	return xxx_messageInfo_MetaCreateEntityRequest.Size(m)
+func (m *MetaGetAtVersionRequest) XXX_Size() int {
+	return xxx_messageInfo_MetaGetAtVersionRequest.Size(m)
 }
-func (m *MetaCreateEntityRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_MetaCreateEntityRequest.DiscardUnknown(m)
+func (m *MetaGetAtVersionRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_MetaGetAtVersionRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_MetaCreateEntityRequest proto.InternalMessageInfo
+var xxx_messageInfo_MetaGetAtVersionRequest proto.InternalMessageInfo
 
-func (m *MetaCreateEntityRequest) GetEntity() *MetaEntity {
+func (m *MetaGetAtVersionRequest) GetKey() *Key {
 	if m != nil {
-		return m.Entity
+		return m.Key
 	}
 	return nil
 }
 
-func (m *MetaCreateEntityRequest) GetKindName() string {
+func (m *MetaGetAtVersionRequest) GetVersion() int64 {
 	if m != nil {
-		return m.KindName
+		return m.Version
 	}
-	return ""
+	return 0
 }
 
-type MetaCreateEntityResponse struct {
-	Entity               *MetaEntity `protobuf:"bytes,1,opt,name=entity,proto3" json:"entity,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
-	XXX_unrecognized     []byte      `json:"-"`
-	XXX_sizecache        int32       `json:"-"`
+type MetaGetAtVersionResponse struct {
+	EntityVersion        *MetaEntityVersion `protobuf:"bytes,1,opt,name=entityVersion,proto3" json:"entityVersion,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
 }
 
-func (m *MetaCreateEntityResponse) Reset()         { *m = MetaCreateEntityResponse{} }
-func (m *MetaCreateEntityResponse) String() string { return proto.CompactTextString(m) }
-func (*MetaCreateEntityResponse) ProtoMessage()    {}
-func (*MetaCreateEntityResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3b5ea8fe65782bcc, []int{33}
+func (m *MetaGetAtVersionResponse) Reset()         { *m = MetaGetAtVersionResponse{} }
+func (m *MetaGetAtVersionResponse) String() string { return proto.CompactTextString(m) }
+func (*MetaGetAtVersionResponse) ProtoMessage()    {}
+func (*MetaGetAtVersionResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b5ea8fe65782bcc, []int{65}
 }
 
-func (m *MetaCreateEntityResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_MetaCreateEntityResponse.Unmarshal(m, b)
+func (m *MetaGetAtVersionResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MetaGetAtVersionResponse.Unmarshal(m, b)
 }
-func (m *MetaCreateEntityResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_MetaCreateEntityResponse.Marshal(b, m, deterministic)
+func (m *MetaGetAtVersionResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MetaGetAtVersionResponse.Marshal(b, m, deterministic)
 }
-func (m *MetaCreateEntityResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_MetaCreateEntityResponse.Merge(m, src)
+func (m *MetaGetAtVersionResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MetaGetAtVersionResponse.Merge(m, src)
 }
-func (m *MetaCreateEntityResponse) XXX_Size() int {
-	return xxx_messageInfo_MetaCreateEntityResponse.Size(m)
+func (m *MetaGetAtVersionResponse) XXX_Size() int {
+	return xxx_messageInfo_MetaGetAtVersionResponse.Size(m)
 }
-func (m *MetaCreateEntityResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_MetaCreateEntityResponse.DiscardUnknown(m)
+func (m *MetaGetAtVersionResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_MetaGetAtVersionResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_MetaCreateEntityResponse proto.InternalMessageInfo
+var xxx_messageInfo_MetaGetAtVersionResponse proto.InternalMessageInfo
 
-func (m *MetaCreateEntityResponse) GetEntity() *MetaEntity {
+func (m *MetaGetAtVersionResponse) GetEntityVersion() *MetaEntityVersion {
 	if m != nil {
-		return m.Entity
+		return m.EntityVersion
 	}
 	return nil
 }
 
-type MetaDeleteEntityRequest struct {
+type FindReferencingRequest struct {
 	Key                  *Key     `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
-	KindName             string   `protobuf:"bytes,2,opt,name=kindName,proto3" json:"kindName,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *MetaDeleteEntityRequest) Reset()         { *m = MetaDeleteEntityRequest{} }
-func (m *MetaDeleteEntityRequest) String() string { return proto.CompactTextString(m) }
-func (*MetaDeleteEntityRequest) ProtoMessage()    {}
-func (*MetaDeleteEntityRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3b5ea8fe65782bcc, []int{34}
-}
-
-func (m *MetaDeleteEntityRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_MetaDeleteEntityRequest.Unmarshal(m, b)
+func (m *FindReferencingRequest) Reset()         { *m = FindReferencingRequest{} }
+func (m *FindReferencingRequest) String() string { return proto.CompactTextString(m) }
+func (*FindReferencingRequest) ProtoMessage()    {}
+func (*FindReferencingRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b5ea8fe65782bcc, []int{66}
 }
-func (m *MetaDeleteEntityRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_MetaDeleteEntityRequest.Marshal(b, m, deterministic)
+
+func (m *FindReferencingRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FindReferencingRequest.Unmarshal(m, b)
 }
-func (m *MetaDeleteEntityRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_MetaDeleteEntityRequest.Merge(m, src)
+func (m *FindReferencingRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FindReferencingRequest.Marshal(b, m, deterministic)
 }
-func (m *MetaDeleteEntityRequest) XXX_Size() int {
-	return xxx_messageInfo_MetaDeleteEntityRequest.Size(m)
+func (m *FindReferencingRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FindReferencingRequest.Merge(m, src)
 }
-func (m *MetaDeleteEntityRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_MetaDeleteEntityRequest.DiscardUnknown(m)
+func (m *FindReferencingRequest) XXX_Size() int {
+	return xxx_messageInfo_FindReferencingRequest.Size(m)
+}
+func (m *FindReferencingRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_FindReferencingRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_MetaDeleteEntityRequest proto.InternalMessageInfo
+var xxx_messageInfo_FindReferencingRequest proto.InternalMessageInfo
 
-func (m *MetaDeleteEntityRequest) GetKey() *Key {
+func (m *FindReferencingRequest) GetKey() *Key {
 	if m != nil {
 		return m.Key
 	}
 	return nil
 }
 
-func (m *MetaDeleteEntityRequest) GetKindName() string {
-	if m != nil {
-		return m.KindName
-	}
-	return ""
-}
-
-type MetaDeleteEntityResponse struct {
-	Entity               *MetaEntity `protobuf:"bytes,1,opt,name=entity,proto3" json:"entity,omitempty"`
+type MetaReferencingEntity struct {
+	KindName string `protobuf:"bytes,1,opt,name=kindName,proto3" json:"kindName,omitempty"`
+	// the field of the entity that refers to the requested key, which can
+	// hold it in a struct or map value
+	FieldName            string      `protobuf:"bytes,2,opt,name=fieldName,proto3" json:"fieldName,omitempty"`
+	Entity               *MetaEntity `protobuf:"bytes,3,opt,name=entity,proto3" json:"entity,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
 	XXX_unrecognized     []byte      `json:"-"`
 	XXX_sizecache        int32       `json:"-"`
 }
 
-func (m *MetaDeleteEntityResponse) Reset()         { *m = MetaDeleteEntityResponse{} }
-func (m *MetaDeleteEntityResponse) String() string { return proto.CompactTextString(m) }
-func (*MetaDeleteEntityResponse) ProtoMessage()    {}
-func (*MetaDeleteEntityResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3b5ea8fe65782bcc, []int{35}
+func (m *MetaReferencingEntity) Reset()         { *m = MetaReferencingEntity{} }
+func (m *MetaReferencingEntity) String() string { return proto.CompactTextString(m) }
+func (*MetaReferencingEntity) ProtoMessage()    {}
+func (*MetaReferencingEntity) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b5ea8fe65782bcc, []int{67}
 }
 
-func (m *MetaDeleteEntityResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_MetaDeleteEntityResponse.Unmarshal(m, b)
+func (m *MetaReferencingEntity) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MetaReferencingEntity.Unmarshal(m, b)
 }
-func (m *MetaDeleteEntityResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_MetaDeleteEntityResponse.Marshal(b, m, deterministic)
+func (m *MetaReferencingEntity) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MetaReferencingEntity.Marshal(b, m, deterministic)
 }
-func (m *MetaDeleteEntityResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_MetaDeleteEntityResponse.Merge(m, src)
+func (m *MetaReferencingEntity) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MetaReferencingEntity.Merge(m, src)
 }
-func (m *MetaDeleteEntityResponse) XXX_Size() int {
-	return xxx_messageInfo_MetaDeleteEntityResponse.Size(m)
+func (m *MetaReferencingEntity) XXX_Size() int {
+	return xxx_messageInfo_MetaReferencingEntity.Size(m)
 }
-func (m *MetaDeleteEntityResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_MetaDeleteEntityResponse.DiscardUnknown(m)
+func (m *MetaReferencingEntity) XXX_DiscardUnknown() {
+	xxx_messageInfo_MetaReferencingEntity.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_MetaDeleteEntityResponse proto.InternalMessageInfo
+var xxx_messageInfo_MetaReferencingEntity proto.InternalMessageInfo
 
-func (m *MetaDeleteEntityResponse) GetEntity() *MetaEntity {
+func (m *MetaReferencingEntity) GetKindName() string {
 	if m != nil {
-		return m.Entity
+		return m.KindName
 	}
-	return nil
-}
-
-type GetTransactionQueueCountRequest struct {
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	return ""
 }
 
-func (m *GetTransactionQueueCountRequest) Reset()         { *m = GetTransactionQueueCountRequest{} }
-func (m *GetTransactionQueueCountRequest) String() string { return proto.CompactTextString(m) }
-func (*GetTransactionQueueCountRequest) ProtoMessage()    {}
-func (*GetTransactionQueueCountRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3b5ea8fe65782bcc, []int{36}
+func (m *MetaReferencingEntity) GetFieldName() string {
+	if m != nil {
+		return m.FieldName
+	}
+	return ""
 }
 
-func (m *GetTransactionQueueCountRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_GetTransactionQueueCountRequest.Unmarshal(m, b)
-}
-func (m *GetTransactionQueueCountRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_GetTransactionQueueCountRequest.Marshal(b, m, deterministic)
-}
-func (m *GetTransactionQueueCountRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_GetTransactionQueueCountRequest.Merge(m, src)
-}
-func (m *GetTransactionQueueCountRequest) XXX_Size() int {
-	return xxx_messageInfo_GetTransactionQueueCountRequest.Size(m)
-}
-func (m *GetTransactionQueueCountRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_GetTransactionQueueCountRequest.DiscardUnknown(m)
+func (m *MetaReferencingEntity) GetEntity() *MetaEntity {
+	if m != nil {
+		return m.Entity
+	}
+	return nil
 }
 
-var xxx_messageInfo_GetTransactionQueueCountRequest proto.InternalMessageInfo
-
-type GetTransactionQueueCountResponse struct {
-	TransactionQueueCount uint32   `protobuf:"varint,1,opt,name=transactionQueueCount,proto3" json:"transactionQueueCount,omitempty"`
-	XXX_NoUnkeyedLiteral  struct{} `json:"-"`
-	XXX_unrecognized      []byte   `json:"-"`
-	XXX_sizecache         int32    `json:"-"`
+type FindReferencingResponse struct {
+	Entities             []*MetaReferencingEntity `protobuf:"bytes,1,rep,name=entities,proto3" json:"entities,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                 `json:"-"`
+	XXX_unrecognized     []byte                   `json:"-"`
+	XXX_sizecache        int32                    `json:"-"`
 }
 
-func (m *GetTransactionQueueCountResponse) Reset()         { *m = GetTransactionQueueCountResponse{} }
-func (m *GetTransactionQueueCountResponse) String() string { return proto.CompactTextString(m) }
-func (*GetTransactionQueueCountResponse) ProtoMessage()    {}
-func (*GetTransactionQueueCountResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3b5ea8fe65782bcc, []int{37}
+func (m *FindReferencingResponse) Reset()         { *m = FindReferencingResponse{} }
+func (m *FindReferencingResponse) String() string { return proto.CompactTextString(m) }
+func (*FindReferencingResponse) ProtoMessage()    {}
+func (*FindReferencingResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b5ea8fe65782bcc, []int{68}
 }
 
-func (m *GetTransactionQueueCountResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_GetTransactionQueueCountResponse.Unmarshal(m, b)
+func (m *FindReferencingResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FindReferencingResponse.Unmarshal(m, b)
 }
-func (m *GetTransactionQueueCountResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_GetTransactionQueueCountResponse.Marshal(b, m, deterministic)
+func (m *FindReferencingResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FindReferencingResponse.Marshal(b, m, deterministic)
 }
-func (m *GetTransactionQueueCountResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_GetTransactionQueueCountResponse.Merge(m, src)
+func (m *FindReferencingResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FindReferencingResponse.Merge(m, src)
 }
-func (m *GetTransactionQueueCountResponse) XXX_Size() int {
-	return xxx_messageInfo_GetTransactionQueueCountResponse.Size(m)
+func (m *FindReferencingResponse) XXX_Size() int {
+	return xxx_messageInfo_FindReferencingResponse.Size(m)
 }
-func (m *GetTransactionQueueCountResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_GetTransactionQueueCountResponse.DiscardUnknown(m)
+func (m *FindReferencingResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_FindReferencingResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_GetTransactionQueueCountResponse proto.InternalMessageInfo
+var xxx_messageInfo_FindReferencingResponse proto.InternalMessageInfo
 
-func (m *GetTransactionQueueCountResponse) GetTransactionQueueCount() uint32 {
+func (m *FindReferencingResponse) GetEntities() []*MetaReferencingEntity {
 	if m != nil {
-		return m.TransactionQueueCount
+		return m.Entities
 	}
-	return 0
+	return nil
 }
 
 type MetaTransaction struct {
@@ -2275,7 +4164,7 @@ func (m *MetaTransaction) Reset()         { *m = MetaTransaction{} }
 func (m *MetaTransaction) String() string { return proto.CompactTextString(m) }
 func (*MetaTransaction) ProtoMessage()    {}
 func (*MetaTransaction) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3b5ea8fe65782bcc, []int{38}
+	return fileDescriptor_3b5ea8fe65782bcc, []int{69}
 }
 
 func (m *MetaTransaction) XXX_Unmarshal(b []byte) error {
@@ -2327,7 +4216,7 @@ func (m *MetaOperation) Reset()         { *m = MetaOperation{} }
 func (m *MetaOperation) String() string { return proto.CompactTextString(m) }
 func (*MetaOperation) ProtoMessage()    {}
 func (*MetaOperation) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3b5ea8fe65782bcc, []int{39}
+	return fileDescriptor_3b5ea8fe65782bcc, []int{70}
 }
 
 func (m *MetaOperation) XXX_Unmarshal(b []byte) error {
@@ -2446,7 +4335,7 @@ func (m *MetaTransactionResult) Reset()         { *m = MetaTransactionResult{} }
 func (m *MetaTransactionResult) String() string { return proto.CompactTextString(m) }
 func (*MetaTransactionResult) ProtoMessage()    {}
 func (*MetaTransactionResult) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3b5ea8fe65782bcc, []int{40}
+	return fileDescriptor_3b5ea8fe65782bcc, []int{71}
 }
 
 func (m *MetaTransactionResult) XXX_Unmarshal(b []byte) error {
@@ -2475,17 +4364,20 @@ func (m *MetaTransactionResult) GetOperationResults() []*MetaOperationResult {
 }
 
 type MetaOperationResultError struct {
-	ErrorMessage         string   `protobuf:"bytes,1,opt,name=errorMessage,proto3" json:"errorMessage,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	ErrorMessage string `protobuf:"bytes,1,opt,name=errorMessage,proto3" json:"errorMessage,omitempty"`
+	// the gRPC status code of the error
+	Code                 uint32                `protobuf:"varint,2,opt,name=code,proto3" json:"code,omitempty"`
+	FieldViolations      []*MetaFieldViolation `protobuf:"bytes,3,rep,name=fieldViolations,proto3" json:"fieldViolations,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
+	XXX_unrecognized     []byte                `json:"-"`
+	XXX_sizecache        int32                 `json:"-"`
 }
 
 func (m *MetaOperationResultError) Reset()         { *m = MetaOperationResultError{} }
 func (m *MetaOperationResultError) String() string { return proto.CompactTextString(m) }
 func (*MetaOperationResultError) ProtoMessage()    {}
 func (*MetaOperationResultError) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3b5ea8fe65782bcc, []int{41}
+	return fileDescriptor_3b5ea8fe65782bcc, []int{72}
 }
 
 func (m *MetaOperationResultError) XXX_Unmarshal(b []byte) error {
@@ -2513,6 +4405,77 @@ func (m *MetaOperationResultError) GetErrorMessage() string {
 	return ""
 }
 
+func (m *MetaOperationResultError) GetCode() uint32 {
+	if m != nil {
+		return m.Code
+	}
+	return 0
+}
+
+func (m *MetaOperationResultError) GetFieldViolations() []*MetaFieldViolation {
+	if m != nil {
+		return m.FieldViolations
+	}
+	return nil
+}
+
+// MetaFieldViolation is attached to the details of errors caused by the
+// value of a specific field
+type MetaFieldViolation struct {
+	KindName             string   `protobuf:"bytes,1,opt,name=kindName,proto3" json:"kindName,omitempty"`
+	FieldName            string   `protobuf:"bytes,2,opt,name=fieldName,proto3" json:"fieldName,omitempty"`
+	Description          string   `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *MetaFieldViolation) Reset()         { *m = MetaFieldViolation{} }
+func (m *MetaFieldViolation) String() string { return proto.CompactTextString(m) }
+func (*MetaFieldViolation) ProtoMessage()    {}
+func (*MetaFieldViolation) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b5ea8fe65782bcc, []int{73}
+}
+
+func (m *MetaFieldViolation) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MetaFieldViolation.Unmarshal(m, b)
+}
+func (m *MetaFieldViolation) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MetaFieldViolation.Marshal(b, m, deterministic)
+}
+func (m *MetaFieldViolation) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MetaFieldViolation.Merge(m, src)
+}
+func (m *MetaFieldViolation) XXX_Size() int {
+	return xxx_messageInfo_MetaFieldViolation.Size(m)
+}
+func (m *MetaFieldViolation) XXX_DiscardUnknown() {
+	xxx_messageInfo_MetaFieldViolation.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MetaFieldViolation proto.InternalMessageInfo
+
+func (m *MetaFieldViolation) GetKindName() string {
+	if m != nil {
+		return m.KindName
+	}
+	return ""
+}
+
+func (m *MetaFieldViolation) GetFieldName() string {
+	if m != nil {
+		return m.FieldName
+	}
+	return ""
+}
+
+func (m *MetaFieldViolation) GetDescription() string {
+	if m != nil {
+		return m.Description
+	}
+	return ""
+}
+
 type MetaOperationResult struct {
 	Error *MetaOperationResultError `protobuf:"bytes,1,opt,name=error,proto3" json:"error,omitempty"`
 	// Types that are valid to be assigned to Operation:
@@ -2531,7 +4494,7 @@ func (m *MetaOperationResult) Reset()         { *m = MetaOperationResult{} }
 func (m *MetaOperationResult) String() string { return proto.CompactTextString(m) }
 func (*MetaOperationResult) ProtoMessage()    {}
 func (*MetaOperationResult) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3b5ea8fe65782bcc, []int{42}
+	return fileDescriptor_3b5ea8fe65782bcc, []int{74}
 }
 
 func (m *MetaOperationResult) XXX_Unmarshal(b []byte) error {
@@ -2647,16 +4610,18 @@ func (*MetaOperationResult) XXX_OneofWrappers() []interface{} {
 }
 
 type WatchTransactionsRequest struct {
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	// if omitted, transactions are watched in the default namespace
+	PartitionId          *PartitionId `protobuf:"bytes,1,opt,name=partitionId,proto3" json:"partitionId,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
 }
 
 func (m *WatchTransactionsRequest) Reset()         { *m = WatchTransactionsRequest{} }
 func (m *WatchTransactionsRequest) String() string { return proto.CompactTextString(m) }
 func (*WatchTransactionsRequest) ProtoMessage()    {}
 func (*WatchTransactionsRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3b5ea8fe65782bcc, []int{43}
+	return fileDescriptor_3b5ea8fe65782bcc, []int{75}
 }
 
 func (m *WatchTransactionsRequest) XXX_Unmarshal(b []byte) error {
@@ -2677,6 +4642,13 @@ func (m *WatchTransactionsRequest) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_WatchTransactionsRequest proto.InternalMessageInfo
 
+func (m *WatchTransactionsRequest) GetPartitionId() *PartitionId {
+	if m != nil {
+		return m.PartitionId
+	}
+	return nil
+}
+
 type WatchTransactionsResponse struct {
 	// Types that are valid to be assigned to Response:
 	//	*WatchTransactionsResponse_Batch
@@ -2691,7 +4663,7 @@ func (m *WatchTransactionsResponse) Reset()         { *m = WatchTransactionsResp
 func (m *WatchTransactionsResponse) String() string { return proto.CompactTextString(m) }
 func (*WatchTransactionsResponse) ProtoMessage()    {}
 func (*WatchTransactionsResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3b5ea8fe65782bcc, []int{44}
+	return fileDescriptor_3b5ea8fe65782bcc, []int{76}
 }
 
 func (m *WatchTransactionsResponse) XXX_Unmarshal(b []byte) error {
@@ -2773,7 +4745,7 @@ func (m *MetaTransactionRecord) Reset()         { *m = MetaTransactionRecord{} }
 func (m *MetaTransactionRecord) String() string { return proto.CompactTextString(m) }
 func (*MetaTransactionRecord) ProtoMessage()    {}
 func (*MetaTransactionRecord) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3b5ea8fe65782bcc, []int{45}
+	return fileDescriptor_3b5ea8fe65782bcc, []int{77}
 }
 
 func (m *MetaTransactionRecord) XXX_Unmarshal(b []byte) error {
@@ -2850,7 +4822,7 @@ func (m *MetaTransactionBatch) Reset()         { *m = MetaTransactionBatch{} }
 func (m *MetaTransactionBatch) String() string { return proto.CompactTextString(m) }
 func (*MetaTransactionBatch) ProtoMessage()    {}
 func (*MetaTransactionBatch) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3b5ea8fe65782bcc, []int{46}
+	return fileDescriptor_3b5ea8fe65782bcc, []int{78}
 }
 
 func (m *MetaTransactionBatch) XXX_Unmarshal(b []byte) error {
@@ -2910,7 +4882,7 @@ func (m *MetaTransactionInitialState) Reset()         { *m = MetaTransactionInit
 func (m *MetaTransactionInitialState) String() string { return proto.CompactTextString(m) }
 func (*MetaTransactionInitialState) ProtoMessage()    {}
 func (*MetaTransactionInitialState) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3b5ea8fe65782bcc, []int{47}
+	return fileDescriptor_3b5ea8fe65782bcc, []int{79}
 }
 
 func (m *MetaTransactionInitialState) XXX_Unmarshal(b []byte) error {
@@ -2957,7 +4929,7 @@ func (m *ConfigstoreTraceEntry) Reset()         { *m = ConfigstoreTraceEntry{} }
 func (m *ConfigstoreTraceEntry) String() string { return proto.CompactTextString(m) }
 func (*ConfigstoreTraceEntry) ProtoMessage()    {}
 func (*ConfigstoreTraceEntry) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3b5ea8fe65782bcc, []int{48}
+	return fileDescriptor_3b5ea8fe65782bcc, []int{80}
 }
 
 func (m *ConfigstoreTraceEntry) XXX_Unmarshal(b []byte) error {
@@ -3043,6 +5015,7 @@ func (m *ConfigstoreTraceEntry) GetPort() int32 {
 
 func init() {
 	proto.RegisterEnum("meta.ValueType", ValueType_name, ValueType_value)
+	proto.RegisterEnum("meta.SchemaFieldOnDelete", SchemaFieldOnDelete_name, SchemaFieldOnDelete_value)
 	proto.RegisterEnum("meta.SchemaFieldEditorInfoType", SchemaFieldEditorInfoType_name, SchemaFieldEditorInfoType_value)
 	proto.RegisterEnum("meta.SchemaIndexType", SchemaIndexType_name, SchemaIndexType_value)
 	proto.RegisterEnum("meta.ConfigstoreTraceEntry_ConfigstoreTraceEntryType", ConfigstoreTraceEntry_ConfigstoreTraceEntryType_name, ConfigstoreTraceEntry_ConfigstoreTraceEntryType_value)
@@ -3050,6 +5023,10 @@ func init() {
 	proto.RegisterType((*PathElement)(nil), "meta.PathElement")
 	proto.RegisterType((*Key)(nil), "meta.Key")
 	proto.RegisterType((*Value)(nil), "meta.Value")
+	proto.RegisterMapType((map[string]*Value)(nil), "meta.Value.MapValueEntry")
+	proto.RegisterType((*DecimalValue)(nil), "meta.DecimalValue")
+	proto.RegisterType((*GeoPoint)(nil), "meta.GeoPoint")
+	proto.RegisterType((*StructValue)(nil), "meta.StructValue")
 	proto.RegisterType((*SchemaField)(nil), "meta.SchemaField")
 	proto.RegisterType((*SchemaFieldEditorInfo)(nil), "meta.SchemaFieldEditorInfo")
 	proto.RegisterType((*SchemaFieldEditorValidator)(nil), "meta.SchemaFieldEditorValidator")
@@ -3058,6 +5035,21 @@ func init() {
 	proto.RegisterType((*SchemaFieldEditorValidatorDefault)(nil), "meta.SchemaFieldEditorValidatorDefault")
 	proto.RegisterType((*SchemaFieldEditorValidatorFormatIPAddress)(nil), "meta.SchemaFieldEditorValidatorFormatIPAddress")
 	proto.RegisterType((*SchemaFieldEditorValidatorFormatIPAddressPort)(nil), "meta.SchemaFieldEditorValidatorFormatIPAddressPort")
+	proto.RegisterType((*SchemaFieldEditorValidatorPattern)(nil), "meta.SchemaFieldEditorValidatorPattern")
+	proto.RegisterType((*SchemaFieldEditorValidatorInt64Minimum)(nil), "meta.SchemaFieldEditorValidatorInt64Minimum")
+	proto.RegisterType((*SchemaFieldEditorValidatorInt64Maximum)(nil), "meta.SchemaFieldEditorValidatorInt64Maximum")
+	proto.RegisterType((*SchemaFieldEditorValidatorUint64Minimum)(nil), "meta.SchemaFieldEditorValidatorUint64Minimum")
+	proto.RegisterType((*SchemaFieldEditorValidatorUint64Maximum)(nil), "meta.SchemaFieldEditorValidatorUint64Maximum")
+	proto.RegisterType((*SchemaFieldEditorValidatorDoubleMinimum)(nil), "meta.SchemaFieldEditorValidatorDoubleMinimum")
+	proto.RegisterType((*SchemaFieldEditorValidatorDoubleMaximum)(nil), "meta.SchemaFieldEditorValidatorDoubleMaximum")
+	proto.RegisterType((*SchemaFieldEditorValidatorMinimumLength)(nil), "meta.SchemaFieldEditorValidatorMinimumLength")
+	proto.RegisterType((*SchemaFieldEditorValidatorMaximumLength)(nil), "meta.SchemaFieldEditorValidatorMaximumLength")
+	proto.RegisterType((*SchemaFieldEditorValidatorAllowedValues)(nil), "meta.SchemaFieldEditorValidatorAllowedValues")
+	proto.RegisterType((*SchemaFieldEditorValidatorFormatEmail)(nil), "meta.SchemaFieldEditorValidatorFormatEmail")
+	proto.RegisterType((*SchemaFieldEditorValidatorFormatURL)(nil), "meta.SchemaFieldEditorValidatorFormatURL")
+	proto.RegisterType((*SchemaFieldEditorValidatorFormatURI)(nil), "meta.SchemaFieldEditorValidatorFormatURI")
+	proto.RegisterType((*SchemaFieldEditorValidatorTimestampMinimum)(nil), "meta.SchemaFieldEditorValidatorTimestampMinimum")
+	proto.RegisterType((*SchemaFieldEditorValidatorTimestampMaximum)(nil), "meta.SchemaFieldEditorValidatorTimestampMaximum")
 	proto.RegisterType((*SchemaKindEditor)(nil), "meta.SchemaKindEditor")
 	proto.RegisterType((*SchemaKind)(nil), "meta.SchemaKind")
 	proto.RegisterType((*SchemaIndex)(nil), "meta.SchemaIndex")
@@ -3067,7 +5059,12 @@ func init() {
 	proto.RegisterType((*SchemaComputedIndexFnv32A)(nil), "meta.SchemaComputedIndexFnv32a")
 	proto.RegisterType((*SchemaComputedIndexFnv32APair)(nil), "meta.SchemaComputedIndexFnv32aPair")
 	proto.RegisterType((*Schema)(nil), "meta.Schema")
+	proto.RegisterMapType((map[string]*SchemaEnum)(nil), "meta.Schema.EnumsEntry")
 	proto.RegisterMapType((map[string]*SchemaKind)(nil), "meta.Schema.KindsEntry")
+	proto.RegisterMapType((map[string]*SchemaStruct)(nil), "meta.Schema.StructsEntry")
+	proto.RegisterType((*SchemaStruct)(nil), "meta.SchemaStruct")
+	proto.RegisterType((*SchemaEnum)(nil), "meta.SchemaEnum")
+	proto.RegisterType((*SchemaEnumValue)(nil), "meta.SchemaEnumValue")
 	proto.RegisterType((*GetSchemaRequest)(nil), "meta.GetSchemaRequest")
 	proto.RegisterType((*GetSchemaResponse)(nil), "meta.GetSchemaResponse")
 	proto.RegisterType((*MetaListEntitiesRequest)(nil), "meta.MetaListEntitiesRequest")
@@ -3085,10 +5082,21 @@ func init() {
 	proto.RegisterType((*MetaDeleteEntityResponse)(nil), "meta.MetaDeleteEntityResponse")
 	proto.RegisterType((*GetTransactionQueueCountRequest)(nil), "meta.GetTransactionQueueCountRequest")
 	proto.RegisterType((*GetTransactionQueueCountResponse)(nil), "meta.GetTransactionQueueCountResponse")
+	proto.RegisterType((*RestoreToTimeRequest)(nil), "meta.RestoreToTimeRequest")
+	proto.RegisterType((*RestoreToTimeResponse)(nil), "meta.RestoreToTimeResponse")
+	proto.RegisterType((*MetaEntityVersion)(nil), "meta.MetaEntityVersion")
+	proto.RegisterType((*MetaGetHistoryRequest)(nil), "meta.MetaGetHistoryRequest")
+	proto.RegisterType((*MetaGetHistoryResponse)(nil), "meta.MetaGetHistoryResponse")
+	proto.RegisterType((*MetaGetAtVersionRequest)(nil), "meta.MetaGetAtVersionRequest")
+	proto.RegisterType((*MetaGetAtVersionResponse)(nil), "meta.MetaGetAtVersionResponse")
+	proto.RegisterType((*FindReferencingRequest)(nil), "meta.FindReferencingRequest")
+	proto.RegisterType((*MetaReferencingEntity)(nil), "meta.MetaReferencingEntity")
+	proto.RegisterType((*FindReferencingResponse)(nil), "meta.FindReferencingResponse")
 	proto.RegisterType((*MetaTransaction)(nil), "meta.MetaTransaction")
 	proto.RegisterType((*MetaOperation)(nil), "meta.MetaOperation")
 	proto.RegisterType((*MetaTransactionResult)(nil), "meta.MetaTransactionResult")
 	proto.RegisterType((*MetaOperationResultError)(nil), "meta.MetaOperationResultError")
+	proto.RegisterType((*MetaFieldViolation)(nil), "meta.MetaFieldViolation")
 	proto.RegisterType((*MetaOperationResult)(nil), "meta.MetaOperationResult")
 	proto.RegisterType((*WatchTransactionsRequest)(nil), "meta.WatchTransactionsRequest")
 	proto.RegisterType((*WatchTransactionsResponse)(nil), "meta.WatchTransactionsResponse")
@@ -3101,190 +5109,276 @@ func init() {
 func init() { proto.RegisterFile("meta.proto", fileDescriptor_3b5ea8fe65782bcc) }
 
 var fileDescriptor_3b5ea8fe65782bcc = []byte{
-	// 2925 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x59, 0x4f, 0x73, 0xdb, 0xc8,
-	0xb1, 0xe7, 0x1f, 0x91, 0x22, 0x9b, 0x92, 0x0d, 0x8f, 0x2d, 0x9b, 0xa6, 0x6d, 0x49, 0x86, 0xd7,
-	0x7e, 0xb2, 0xbd, 0x96, 0x77, 0x25, 0x3f, 0xbf, 0xb7, 0x5b, 0xfb, 0xde, 0x2e, 0x45, 0x42, 0x22,
-	0x62, 0x99, 0x54, 0x86, 0x90, 0x77, 0x5d, 0xa9, 0x0a, 0x03, 0x11, 0x23, 0x19, 0x25, 0x12, 0x60,
-	0x00, 0xd0, 0x36, 0x2f, 0xf9, 0x1a, 0xfb, 0x01, 0x92, 0x4a, 0xe5, 0x9a, 0x63, 0x0e, 0xa9, 0x54,
-	0x72, 0x4f, 0x3e, 0x4a, 0x0e, 0xb9, 0xe5, 0x96, 0x9a, 0x3f, 0x00, 0x07, 0x10, 0x48, 0x5a, 0x7b,
-	0xc3, 0xf4, 0xfc, 0xfa, 0x37, 0x3d, 0xdd, 0x3d, 0x3d, 0x7f, 0x00, 0x30, 0x24, 0x81, 0xb9, 0x3d,
-	0xf2, 0xdc, 0xc0, 0x45, 0x4b, 0xf4, 0xbb, 0xb6, 0x71, 0xe6, 0xba, 0x67, 0x03, 0xf2, 0x9c, 0xc9,
-	0x4e, 0xc6, 0xa7, 0xcf, 0x03, 0x7b, 0x48, 0xfc, 0xc0, 0x1c, 0x8e, 0x38, 0x4c, 0x7d, 0x0a, 0x95,
-	0x23, 0xd3, 0x0b, 0xec, 0xc0, 0x76, 0x1d, 0xdd, 0x42, 0x77, 0xa1, 0xec, 0x98, 0x43, 0xe2, 0x8f,
-	0xcc, 0x3e, 0xa9, 0x66, 0x37, 0xb3, 0x5b, 0x65, 0x3c, 0x15, 0xa8, 0x5d, 0x0a, 0x0e, 0xde, 0x69,
-	0x03, 0x32, 0x24, 0x4e, 0x80, 0x10, 0x2c, 0x9d, 0xdb, 0x8e, 0x25, 0x70, 0xec, 0x1b, 0x29, 0x90,
-	0xb3, 0xad, 0x6a, 0x6e, 0x33, 0xbb, 0x95, 0x6f, 0x65, 0x70, 0xce, 0xb6, 0xd0, 0x0d, 0x58, 0xa2,
-	0x0c, 0xd5, 0x3c, 0x45, 0xb5, 0x32, 0x98, 0xb5, 0xf6, 0x4a, 0x50, 0xb4, 0x2d, 0x63, 0x32, 0x22,
-	0xaa, 0x09, 0xf9, 0x57, 0x64, 0x82, 0x76, 0xa1, 0x32, 0x9a, 0x1a, 0xc2, 0x38, 0x2b, 0x3b, 0xd7,
-	0xb6, 0xd9, 0x8c, 0x24, 0x0b, 0xb1, 0x8c, 0x42, 0x0f, 0x61, 0x69, 0x64, 0x06, 0xef, 0xaa, 0xb9,
-	0xcd, 0xbc, 0x8c, 0x8e, 0x4c, 0xc4, 0xac, 0x5b, 0xfd, 0x77, 0x0e, 0x0a, 0x6f, 0xcc, 0xc1, 0x98,
-	0xa0, 0x2b, 0xcc, 0x3c, 0x4a, 0x5e, 0x60, 0xc6, 0x3d, 0x80, 0xa5, 0x60, 0x32, 0x22, 0xcc, 0xe0,
-	0x2b, 0x3b, 0x57, 0x39, 0x01, 0x83, 0x52, 0xdb, 0x30, 0xeb, 0x44, 0x9b, 0x50, 0xb1, 0xdc, 0xf1,
-	0xc9, 0x80, 0xb0, 0x0e, 0x36, 0x91, 0x2c, 0x96, 0x45, 0x48, 0x05, 0xb0, 0x9d, 0xe0, 0xe5, 0x0b,
-	0x0e, 0x58, 0xa2, 0xb3, 0xdf, 0xcb, 0x7d, 0x91, 0xc5, 0x92, 0x94, 0xb2, 0xf8, 0x81, 0x67, 0x3b,
-	0x67, 0x1c, 0x54, 0x60, 0x4e, 0x93, 0x45, 0x68, 0x0f, 0xae, 0x44, 0xe1, 0xe1, 0xa0, 0x22, 0xf3,
-	0x42, 0x6d, 0x9b, 0x47, 0x71, 0x3b, 0x8c, 0xe2, 0xb6, 0x11, 0xc2, 0x70, 0x42, 0x03, 0xa9, 0xb0,
-	0x72, 0xe2, 0xba, 0x03, 0x62, 0x3a, 0x9c, 0x61, 0x79, 0x33, 0xbb, 0x55, 0xc2, 0x31, 0x19, 0x5a,
-	0x07, 0x38, 0x99, 0x04, 0xc4, 0xe7, 0x88, 0xd2, 0x66, 0x76, 0x6b, 0x05, 0x4b, 0x12, 0xf4, 0x10,
-	0x4a, 0xe7, 0x64, 0xc2, 0x7b, 0xcb, 0xcc, 0x82, 0x32, 0x77, 0xcc, 0x2b, 0x32, 0xc1, 0x51, 0x17,
-	0xfa, 0x0c, 0x2a, 0x63, 0x69, 0xd6, 0xb0, 0x99, 0xdd, 0x5a, 0x62, 0xb3, 0x96, 0xc5, 0xea, 0x5f,
-	0xb3, 0x50, 0xe9, 0xf6, 0xdf, 0x91, 0xa1, 0xb9, 0x6f, 0x93, 0x81, 0x75, 0x21, 0x02, 0x48, 0xa4,
-	0x47, 0x8e, 0x27, 0x11, 0xfd, 0x8e, 0xa2, 0x92, 0x9f, 0x17, 0x95, 0x2a, 0x2c, 0xf7, 0xdd, 0x21,
-	0x8d, 0x32, 0x73, 0x78, 0x19, 0x87, 0x4d, 0xb4, 0x0b, 0x45, 0x62, 0xd9, 0x81, 0xeb, 0x31, 0x27,
-	0x57, 0x76, 0xee, 0x70, 0x02, 0xc9, 0x0a, 0x8d, 0x75, 0xeb, 0xce, 0xa9, 0x8b, 0x05, 0x14, 0xd5,
-	0xa0, 0xe4, 0x11, 0xd3, 0x72, 0x9d, 0xc1, 0x84, 0xb9, 0xbd, 0x84, 0xa3, 0xb6, 0xfa, 0xcf, 0x1c,
-	0xac, 0xa5, 0x6a, 0xb3, 0xd4, 0xb0, 0xfd, 0xd1, 0xc0, 0x9c, 0xb4, 0xe9, 0x24, 0xf8, 0x4a, 0x90,
-	0x45, 0x68, 0x37, 0x96, 0x61, 0x1b, 0x73, 0x4c, 0x91, 0xe6, 0xf6, 0x08, 0xae, 0x70, 0xb3, 0x70,
-	0x68, 0x52, 0x9e, 0x99, 0x94, 0x90, 0xd2, 0x68, 0x9b, 0x83, 0x81, 0xfb, 0x81, 0x58, 0xaf, 0x6c,
-	0xc7, 0xf2, 0xab, 0x4b, 0x9b, 0xf9, 0xad, 0x32, 0x8e, 0xc9, 0x90, 0x01, 0x0f, 0xc7, 0x3e, 0xd9,
-	0xb7, 0x1d, 0xd3, 0xe9, 0xdb, 0xe6, 0x80, 0xbb, 0xd1, 0x6d, 0xdb, 0x27, 0x27, 0x03, 0xdb, 0xf1,
-	0x1b, 0xae, 0xf3, 0x9e, 0x78, 0xbe, 0xed, 0x3a, 0xcc, 0x59, 0x25, 0xfc, 0x69, 0x60, 0xf4, 0x1d,
-	0xc0, 0x7b, 0x73, 0x60, 0x5b, 0x66, 0xe0, 0x7a, 0x7e, 0xb5, 0xc8, 0xd6, 0xdf, 0xe6, 0x8c, 0xc9,
-	0xbd, 0x09, 0x81, 0x58, 0xd2, 0xa1, 0x0e, 0x0f, 0xc8, 0xc7, 0xa0, 0xee, 0x11, 0x53, 0x64, 0x69,
-	0xd4, 0x56, 0xff, 0x9e, 0x87, 0xda, 0x6c, 0x1a, 0xb4, 0x4f, 0x63, 0xf5, 0xeb, 0xb1, 0xed, 0x91,
-	0xb0, 0x50, 0x6c, 0x2d, 0x1c, 0x5a, 0xe0, 0x5b, 0x19, 0x1c, 0xe9, 0xa2, 0x0e, 0x54, 0x4e, 0xed,
-	0x8f, 0xc4, 0x3a, 0x24, 0xce, 0x19, 0xab, 0x22, 0x94, 0xea, 0xe9, 0x22, 0xaa, 0xfd, 0xa9, 0x4a,
-	0x2b, 0x83, 0x65, 0x06, 0xd4, 0x80, 0x65, 0x8b, 0x9c, 0x9a, 0xe3, 0x41, 0xc0, 0x02, 0x56, 0xd9,
-	0xf9, 0xaf, 0x45, 0x64, 0x4d, 0x0e, 0x6f, 0x65, 0x70, 0xa8, 0x89, 0x7e, 0x01, 0x57, 0x4f, 0x5d,
-	0x6f, 0x68, 0x06, 0xfa, 0x51, 0xdd, 0xb2, 0x3c, 0xe2, 0xfb, 0x2c, 0xc1, 0x2b, 0x3b, 0xcf, 0x17,
-	0x5a, 0x16, 0x57, 0x6b, 0x65, 0x70, 0x92, 0x09, 0x9d, 0xc1, 0xf5, 0x84, 0xe8, 0xc8, 0xf5, 0x02,
-	0xb1, 0x50, 0x76, 0x2f, 0x39, 0x00, 0x55, 0x6d, 0x65, 0x70, 0x1a, 0xe3, 0x5e, 0x05, 0xca, 0x51,
-	0xb0, 0xd5, 0xcf, 0x40, 0x5d, 0x1c, 0x1a, 0xf5, 0x5b, 0x78, 0xf8, 0x49, 0x5e, 0x47, 0x37, 0xa1,
-	0x38, 0xe0, 0x21, 0xa3, 0xd1, 0x5f, 0xc5, 0xa2, 0xa5, 0xee, 0xc3, 0xfd, 0x85, 0x9e, 0x46, 0xf7,
-	0xa1, 0xf0, 0x9e, 0x15, 0x2c, 0x9e, 0x39, 0x15, 0xa9, 0xba, 0x60, 0xde, 0xa3, 0x3e, 0x85, 0xc7,
-	0x9f, 0xec, 0x03, 0xf5, 0x39, 0x3c, 0xbb, 0x94, 0xc3, 0xd4, 0x7f, 0x64, 0x41, 0xe1, 0x1a, 0x74,
-	0x81, 0x6a, 0x51, 0xf9, 0xf1, 0x6d, 0xe7, 0x6c, 0x3c, 0x30, 0x3d, 0x51, 0x45, 0xa2, 0x36, 0x9d,
-	0xee, 0x68, 0x30, 0xf6, 0xcc, 0x81, 0x28, 0x92, 0xa2, 0x85, 0x9a, 0x70, 0xcf, 0x23, 0x8e, 0x45,
-	0x3c, 0xce, 0xd1, 0xf4, 0xdc, 0x91, 0xe5, 0x7e, 0x70, 0xbe, 0xb7, 0x83, 0x77, 0xcc, 0x16, 0xbe,
-	0xe5, 0xe2, 0xf9, 0x20, 0xba, 0x1b, 0x9c, 0x93, 0x49, 0x23, 0x56, 0x4a, 0x25, 0x09, 0xdb, 0xb7,
-	0x68, 0x40, 0x27, 0x9c, 0x33, 0xdc, 0xb7, 0xa6, 0x22, 0xf5, 0x6f, 0x59, 0x80, 0xe9, 0x84, 0xd0,
-	0x63, 0x28, 0x9e, 0x52, 0xb9, 0x1f, 0xdf, 0x96, 0x25, 0x27, 0x61, 0x01, 0x40, 0xdb, 0x51, 0xa5,
-	0xe6, 0xcb, 0xe5, 0xa6, 0x0c, 0x9d, 0x7a, 0x27, 0x2a, 0xd2, 0x4f, 0x61, 0xd9, 0x76, 0x2c, 0xf2,
-	0x91, 0xf0, 0x52, 0x97, 0xe0, 0xd6, 0x69, 0x17, 0x0e, 0x11, 0xf4, 0x2c, 0x63, 0x3a, 0x7d, 0xe2,
-	0xb3, 0x0a, 0x55, 0x60, 0x95, 0x71, 0x2a, 0x10, 0xfb, 0x50, 0x31, 0xdc, 0x87, 0xd4, 0xdf, 0x47,
-	0xfb, 0x14, 0xa3, 0x89, 0xf6, 0xa5, 0xac, 0xb4, 0x2f, 0x3d, 0x8e, 0xd5, 0xf2, 0xb5, 0x0b, 0x63,
-	0x4b, 0x15, 0xfc, 0x7f, 0xa0, 0xd4, 0x77, 0x87, 0xa3, 0x71, 0x40, 0x2c, 0x31, 0xb7, 0xdb, 0x32,
-	0xbc, 0x21, 0xfa, 0x98, 0x1a, 0xad, 0x49, 0x21, 0x18, 0xdd, 0x84, 0x02, 0x73, 0x0e, 0x8f, 0x44,
-	0x2b, 0x83, 0x79, 0x73, 0x6f, 0x59, 0xa4, 0xad, 0xfa, 0x87, 0x1c, 0x5c, 0x4f, 0x21, 0x41, 0x5f,
-	0x41, 0xf1, 0xd4, 0x79, 0xff, 0xf2, 0x85, 0x29, 0x12, 0x7b, 0x63, 0xe6, 0x78, 0xfb, 0x0c, 0xd6,
-	0xca, 0x60, 0xa1, 0x80, 0xf6, 0xa1, 0xc2, 0xbf, 0x7a, 0x23, 0xd3, 0xf6, 0x44, 0x1d, 0x7c, 0xb0,
-	0x40, 0xff, 0xc8, 0xb4, 0xbd, 0x56, 0x06, 0xc3, 0x69, 0xd4, 0x12, 0x26, 0xec, 0xee, 0x98, 0x62,
-	0xca, 0x73, 0x4d, 0xd8, 0xdd, 0x09, 0x4d, 0xd8, 0xdd, 0x09, 0x4d, 0xd8, 0xdd, 0x11, 0x26, 0x2c,
-	0x2d, 0x36, 0x61, 0x77, 0x47, 0x36, 0x41, 0xb4, 0x68, 0xd9, 0x31, 0x07, 0x67, 0xae, 0x67, 0x07,
-	0xef, 0x86, 0xea, 0x97, 0x70, 0x7b, 0xa6, 0xf9, 0xe8, 0x46, 0xe8, 0x68, 0x1e, 0x61, 0xde, 0x50,
-	0x3b, 0x70, 0x6f, 0xee, 0x8c, 0xe9, 0x62, 0x64, 0xc8, 0x2f, 0x85, 0x9e, 0x68, 0x45, 0xf2, 0x9d,
-	0x70, 0x91, 0xf2, 0xd6, 0x6c, 0x1b, 0xe8, 0xac, 0x2f, 0x69, 0x83, 0x98, 0xe4, 0xa5, 0x6d, 0xf8,
-	0x31, 0x0b, 0x45, 0xce, 0x98, 0x9a, 0xd6, 0xcf, 0xa0, 0x70, 0xce, 0x8e, 0x0f, 0x7c, 0xbd, 0xde,
-	0x92, 0xbd, 0xbe, 0xcd, 0x0e, 0x11, 0x9a, 0x13, 0x78, 0x13, 0xcc, 0x51, 0xb5, 0x9f, 0x01, 0x4c,
-	0x85, 0x48, 0x81, 0xfc, 0x39, 0x99, 0x08, 0x3e, 0xfa, 0x89, 0x1e, 0x85, 0x05, 0x96, 0xe7, 0x91,
-	0x92, 0x5c, 0xd3, 0xa2, 0xca, 0x7e, 0x9d, 0xfb, 0xdf, 0xac, 0x8a, 0x40, 0x39, 0x20, 0x01, 0xef,
-	0xa3, 0xfb, 0x00, 0xf1, 0x03, 0xf5, 0x2b, 0xb8, 0x26, 0xc9, 0xfc, 0x91, 0xeb, 0xf8, 0xf4, 0xb0,
-	0x59, 0xf4, 0x99, 0x44, 0x64, 0xf7, 0x8a, 0xcc, 0x8a, 0x45, 0x9f, 0x6a, 0xc2, 0xad, 0xd7, 0x24,
-	0x30, 0x0f, 0x6d, 0x3f, 0xd0, 0x1c, 0x7a, 0x4b, 0x20, 0xbe, 0x60, 0xa5, 0xae, 0xf6, 0x03, 0xd3,
-	0x0b, 0x98, 0xfe, 0x0a, 0xe6, 0x0d, 0x2a, 0x1d, 0xd8, 0x43, 0x3b, 0x60, 0xb6, 0xae, 0x62, 0xde,
-	0xa0, 0xc5, 0x98, 0x4e, 0xb5, 0x1d, 0x5d, 0x5b, 0x70, 0xd4, 0x56, 0x7f, 0x03, 0xd5, 0x8b, 0x43,
-	0x08, 0x23, 0xa9, 0x73, 0xc9, 0xc7, 0x70, 0x08, 0xf6, 0x4d, 0xcb, 0xe7, 0xd0, 0xf5, 0x08, 0x26,
-	0xfe, 0x78, 0x10, 0xf8, 0x6c, 0x9c, 0x12, 0x96, 0x45, 0xe8, 0x73, 0x28, 0x11, 0xc1, 0x54, 0xcd,
-	0xb3, 0x08, 0x08, 0x97, 0xd1, 0x71, 0xd8, 0x18, 0x13, 0x1c, 0x21, 0xd4, 0x36, 0xc0, 0x54, 0x8e,
-	0xee, 0x4c, 0xbd, 0x1f, 0x3b, 0xa5, 0xb3, 0x40, 0x3c, 0x80, 0x22, 0xf3, 0x74, 0x18, 0xd8, 0xd8,
-	0x56, 0x27, 0xba, 0xd4, 0x75, 0xb8, 0x7b, 0x40, 0x02, 0xb1, 0x39, 0xca, 0x17, 0x2d, 0x11, 0x8d,
-	0xff, 0x83, 0x7b, 0x33, 0xfa, 0xc5, 0xa4, 0xe7, 0x5f, 0x19, 0x3b, 0x70, 0x83, 0x9a, 0x7b, 0x40,
-	0x02, 0x31, 0x13, 0x11, 0x8e, 0xb9, 0x86, 0xcb, 0xfe, 0xcf, 0x25, 0xfc, 0x5f, 0x87, 0xb5, 0x04,
-	0xa1, 0xb0, 0x63, 0x0b, 0x8a, 0xcc, 0x49, 0x21, 0xe9, 0x45, 0x27, 0x8a, 0x7e, 0xb5, 0xc1, 0xb3,
-	0xe4, 0x78, 0x64, 0x99, 0x01, 0x89, 0x9b, 0xf5, 0xe9, 0x24, 0x4d, 0x9e, 0x07, 0x71, 0x92, 0x4b,
-	0x9b, 0xd2, 0xe3, 0xa6, 0x34, 0x3c, 0xf2, 0xd3, 0x4d, 0x99, 0xeb, 0x2e, 0x61, 0x66, 0x7c, 0x80,
-	0x4b, 0x9b, 0x89, 0xb9, 0x99, 0x4d, 0x32, 0x20, 0x49, 0x33, 0x7f, 0x72, 0x20, 0x85, 0x65, 0x71,
-	0xce, 0x4b, 0x5b, 0x76, 0x1f, 0x36, 0x0e, 0x48, 0x60, 0x78, 0xa6, 0xe3, 0x9b, 0x7d, 0x9a, 0x99,
-	0x3f, 0x1f, 0x93, 0x31, 0x69, 0xb8, 0x63, 0x27, 0x08, 0x33, 0xf8, 0x07, 0xd8, 0x9c, 0x0d, 0x11,
-	0x03, 0xbe, 0x80, 0xb5, 0x20, 0x0d, 0x20, 0x0e, 0x98, 0xe9, 0x9d, 0xea, 0x3b, 0xb8, 0x4a, 0x4d,
-	0x92, 0xa8, 0xd1, 0x2e, 0x80, 0x3b, 0x22, 0x9e, 0x49, 0x1b, 0x7e, 0x35, 0xcb, 0xd6, 0xdd, 0xf5,
-	0xa9, 0xf5, 0x9d, 0xb0, 0x0f, 0x4b, 0x30, 0x76, 0x8b, 0x24, 0x7e, 0xdf, 0xb3, 0x47, 0xb4, 0x2d,
-	0x3c, 0x25, 0x8b, 0xd4, 0x7f, 0xe5, 0x60, 0x35, 0xa6, 0x8f, 0xea, 0x50, 0x19, 0xd8, 0x7e, 0x38,
-	0x49, 0xe1, 0xa7, 0x7b, 0xd3, 0x91, 0x52, 0x6a, 0x20, 0xbd, 0xad, 0x48, 0x3a, 0xe8, 0x1b, 0x80,
-	0x33, 0x12, 0x31, 0xe4, 0xc4, 0x5b, 0x43, 0xc4, 0x90, 0x5c, 0xb3, 0x74, 0xa7, 0x9d, 0xe2, 0x91,
-	0x06, 0xab, 0x63, 0x96, 0xfc, 0x21, 0x41, 0x3e, 0x69, 0x42, 0xca, 0x02, 0x6b, 0x65, 0x70, 0x5c,
-	0x8b, 0xd2, 0xf4, 0x59, 0x72, 0x86, 0x34, 0x4b, 0x49, 0x9a, 0x94, 0xc5, 0x41, 0x69, 0x62, 0x5a,
-	0x94, 0xc6, 0x62, 0x99, 0x14, 0xd2, 0x14, 0x92, 0x34, 0x29, 0xc9, 0x4b, 0x69, 0x62, 0x5a, 0xf4,
-	0xf8, 0x10, 0xc5, 0x45, 0xfd, 0x25, 0x2f, 0x35, 0x52, 0x78, 0x79, 0xc9, 0x46, 0x1a, 0x28, 0x11,
-	0x2a, 0x2c, 0xec, 0x3c, 0xd4, 0xb7, 0xd3, 0x42, 0xcd, 0x10, 0xf8, 0x82, 0x8a, 0xfa, 0xff, 0x7c,
-	0x05, 0x24, 0x80, 0x9a, 0xe7, 0xb9, 0x1e, 0xbd, 0xd9, 0x13, 0xfa, 0xf1, 0x9a, 0xf8, 0xbe, 0x79,
-	0x16, 0x16, 0xd6, 0x98, 0x4c, 0xfd, 0x73, 0x1e, 0xae, 0xa7, 0x10, 0xa0, 0x17, 0x50, 0x60, 0x38,
-	0x91, 0x14, 0xeb, 0x33, 0x6d, 0x62, 0x43, 0x61, 0x0e, 0x46, 0x4d, 0x58, 0xe1, 0xc9, 0xc1, 0x97,
-	0x84, 0xc8, 0x87, 0xf5, 0x59, 0x19, 0xc5, 0x51, 0xad, 0x0c, 0x8e, 0x69, 0xa1, 0x6f, 0xa1, 0xc2,
-	0x72, 0x44, 0x90, 0xe4, 0xe5, 0x07, 0x98, 0xd4, 0xba, 0x4d, 0x93, 0x52, 0xd2, 0x40, 0x2d, 0xb8,
-	0x12, 0x26, 0x88, 0xe0, 0x58, 0x4a, 0x1a, 0x92, 0x56, 0x73, 0x5b, 0x19, 0x9c, 0xd0, 0xa3, 0x4c,
-	0x61, 0x8e, 0x08, 0xa6, 0x42, 0x92, 0x29, 0xad, 0x2c, 0x52, 0xa6, 0xb8, 0x1e, 0x65, 0x0a, 0xd3,
-	0x44, 0x30, 0x15, 0x93, 0x4c, 0x69, 0x65, 0x8c, 0x32, 0xc5, 0xf5, 0xe2, 0xf9, 0x55, 0x83, 0xea,
-	0xf7, 0x66, 0xd0, 0x7f, 0x27, 0x25, 0x58, 0xb8, 0x54, 0xd5, 0xdf, 0x65, 0xe1, 0x76, 0x4a, 0xa7,
-	0x30, 0x68, 0x07, 0x0a, 0x27, 0xb4, 0x53, 0x44, 0x58, 0x5a, 0xb4, 0x12, 0x7c, 0x8f, 0x22, 0xe8,
-	0x05, 0x82, 0x41, 0xd1, 0x01, 0xac, 0xd8, 0x8e, 0x1d, 0xd8, 0xe6, 0xa0, 0x1b, 0x98, 0x41, 0x18,
-	0xdf, 0xfb, 0xa9, 0xaa, 0xba, 0x04, 0xa4, 0x21, 0x96, 0x15, 0xf7, 0x00, 0x4a, 0x9e, 0x30, 0x44,
-	0xfd, 0x6d, 0x2e, 0x65, 0x8d, 0xf4, 0x5d, 0xcf, 0x42, 0x4f, 0xa1, 0x32, 0x1c, 0x53, 0xbc, 0xf5,
-	0x8a, 0x4c, 0xc2, 0xe5, 0x21, 0xed, 0x0f, 0x72, 0x2f, 0x05, 0x73, 0x47, 0x71, 0x70, 0xee, 0x02,
-	0x58, 0xea, 0x45, 0xdf, 0xc1, 0x2a, 0x8d, 0x73, 0x77, 0x7c, 0x32, 0xb4, 0x83, 0xe9, 0xfd, 0x6a,
-	0xde, 0x2b, 0x69, 0x5c, 0x01, 0x7d, 0x03, 0x15, 0x2a, 0xe0, 0xd1, 0xb7, 0x44, 0x82, 0xcd, 0xd3,
-	0x97, 0xe1, 0xc9, 0x6a, 0x5d, 0xb8, 0x50, 0xad, 0xa5, 0xbb, 0x65, 0x99, 0xdd, 0x2d, 0xff, 0x98,
-	0xe5, 0xa7, 0xa0, 0x64, 0x70, 0xd0, 0xd7, 0x70, 0x55, 0xb8, 0x21, 0x5c, 0x58, 0xc2, 0x51, 0x17,
-	0x37, 0xbc, 0x24, 0xf0, 0x72, 0x3e, 0x4b, 0xd8, 0x9c, 0x9f, 0x65, 0xf3, 0x52, 0x64, 0xf3, 0x2b,
-	0xb8, 0x33, 0x27, 0x29, 0x62, 0x87, 0xd6, 0xec, 0xc2, 0x43, 0xeb, 0x5f, 0x56, 0x60, 0xad, 0xe1,
-	0x3a, 0xa7, 0xf6, 0x19, 0xbd, 0x7c, 0x13, 0xc3, 0x33, 0xfb, 0x84, 0x5f, 0x1f, 0x74, 0x71, 0xa5,
-	0xce, 0xb2, 0x2b, 0xf5, 0x7f, 0x73, 0x8e, 0x54, 0x68, 0xba, 0x54, 0xba, 0x72, 0x4f, 0x0f, 0x0d,
-	0xb9, 0x05, 0x07, 0x26, 0x71, 0x66, 0xc9, 0xa7, 0x9e, 0x59, 0xd6, 0xc3, 0x1d, 0xdc, 0xf5, 0xf4,
-	0x30, 0x88, 0x92, 0x04, 0x7d, 0x06, 0xab, 0xd2, 0x69, 0x40, 0xb7, 0xd8, 0xe3, 0x65, 0x19, 0xc7,
-	0x85, 0x68, 0x1f, 0xd6, 0x3d, 0x32, 0x34, 0x6d, 0xc7, 0x76, 0xce, 0x52, 0x8f, 0x1e, 0xec, 0xdd,
-	0xbd, 0x80, 0x17, 0xa0, 0xd0, 0x4b, 0xb8, 0xe9, 0x91, 0xbe, 0xeb, 0x38, 0x84, 0xf5, 0x34, 0x5c,
-	0x8b, 0x74, 0xd9, 0x2f, 0x03, 0xf6, 0x32, 0x5f, 0xc6, 0x33, 0x7a, 0x69, 0xc0, 0x59, 0x59, 0x17,
-	0x60, 0xe0, 0x01, 0x97, 0x44, 0xf4, 0xb2, 0x32, 0x72, 0xbd, 0xa0, 0x5a, 0x61, 0x76, 0xb0, 0x6f,
-	0xf5, 0xc7, 0x32, 0xdc, 0x9e, 0xe9, 0x66, 0x74, 0x17, 0xaa, 0x7a, 0x5b, 0x37, 0xf4, 0xfa, 0x61,
-	0xaf, 0x6b, 0xd4, 0x0d, 0xad, 0xd7, 0xd5, 0xda, 0xcd, 0xde, 0x9e, 0x76, 0xa0, 0xb7, 0x95, 0x0c,
-	0xba, 0x07, 0xb7, 0x53, 0x7a, 0xb5, 0xb6, 0xa1, 0x1b, 0x6f, 0x95, 0x2c, 0xaa, 0xc1, 0xcd, 0xd4,
-	0xee, 0xa6, 0x92, 0x43, 0x1b, 0x70, 0x27, 0xde, 0x87, 0xb5, 0x86, 0xa6, 0xbf, 0xd1, 0x04, 0x77,
-	0x1e, 0x6d, 0xc2, 0xdd, 0x74, 0x80, 0xa0, 0x5f, 0xba, 0x38, 0xfa, 0x14, 0xd1, 0x54, 0x0a, 0x94,
-	0xc0, 0xc0, 0xf5, 0x76, 0xb7, 0xde, 0x30, 0xf4, 0x4e, 0xbb, 0xb7, 0x57, 0x37, 0x1a, 0x2d, 0xd9,
-	0xfc, 0x22, 0x7a, 0x0c, 0x0f, 0x67, 0x20, 0x5e, 0x1f, 0x53, 0xc2, 0x68, 0x2a, 0xcb, 0xe8, 0x19,
-	0x3c, 0x9e, 0x01, 0x6d, 0x6a, 0x87, 0xda, 0x14, 0xda, 0x7b, 0xa5, 0xbd, 0x55, 0x4a, 0x68, 0x1d,
-	0x6a, 0x33, 0xe0, 0xd4, 0xb6, 0x32, 0x7a, 0x00, 0x1b, 0x17, 0xfb, 0xe3, 0x1e, 0x00, 0xf4, 0x39,
-	0x6c, 0xcd, 0x06, 0x25, 0x2c, 0xac, 0xa0, 0x2f, 0xe0, 0xf3, 0xd9, 0xe8, 0x14, 0x23, 0x57, 0xd0,
-	0x7d, 0xb8, 0x37, 0x5b, 0x83, 0xda, 0xb9, 0xca, 0x23, 0xd8, 0x7b, 0xad, 0xbd, 0xee, 0xe0, 0xb7,
-	0xbd, 0xae, 0xd1, 0xc1, 0x91, 0xfb, 0xaf, 0xa0, 0x3b, 0x70, 0x6b, 0xda, 0xc7, 0x07, 0x08, 0x3b,
-	0xaf, 0xa2, 0x5b, 0x70, 0x5d, 0xe6, 0xae, 0x63, 0xac, 0xbf, 0xd1, 0x9a, 0x8a, 0x92, 0x9c, 0xf9,
-	0xbe, 0xde, 0xd6, 0xbb, 0x2d, 0xad, 0xd9, 0x3b, 0xc2, 0x9d, 0x86, 0xd6, 0xed, 0xea, 0xed, 0x03,
-	0xe5, 0x5a, 0x52, 0xbb, 0x6b, 0xd4, 0x0f, 0x0f, 0xb5, 0xa6, 0x82, 0xa8, 0x3d, 0x8d, 0x4e, 0x7b,
-	0x5f, 0x3f, 0xe0, 0xb6, 0x34, 0x3a, 0xed, 0xae, 0xde, 0x35, 0xb4, 0xb6, 0xa1, 0x5c, 0x47, 0x2a,
-	0xac, 0xcb, 0x4a, 0x71, 0x07, 0xb1, 0x29, 0xdf, 0x48, 0x62, 0x52, 0xdc, 0xb2, 0x86, 0xbe, 0x84,
-	0x67, 0x32, 0x06, 0x6b, 0x74, 0x14, 0x03, 0x1f, 0x37, 0x8c, 0x5e, 0xfd, 0xe8, 0x28, 0x25, 0x3b,
-	0x6e, 0xa2, 0x97, 0xb0, 0xd3, 0x38, 0xd4, 0xb5, 0xb6, 0xd1, 0x6b, 0x1c, 0x63, 0xac, 0xb5, 0x8d,
-	0xc3, 0xb7, 0xbd, 0xa6, 0xde, 0x6d, 0x74, 0xda, 0x6d, 0xad, 0x41, 0x91, 0x75, 0xc3, 0xd0, 0x5e,
-	0x1f, 0x19, 0x7a, 0xfb, 0x80, 0xf3, 0x51, 0xb1, 0x72, 0x0b, 0x3d, 0x81, 0x47, 0x42, 0xef, 0xa0,
-	0x63, 0xf4, 0xb4, 0xce, 0x7e, 0x2a, 0x90, 0xfa, 0xa4, 0x4a, 0x17, 0x8c, 0x84, 0x6d, 0xeb, 0x87,
-	0xbd, 0xbd, 0xe3, 0x83, 0x9e, 0x7e, 0xd0, 0xee, 0x60, 0x0a, 0xb8, 0x4d, 0xe3, 0x21, 0x00, 0xfb,
-	0x75, 0xfd, 0x50, 0x6b, 0x4a, 0x23, 0xd5, 0xa8, 0xdb, 0x43, 0x0b, 0x05, 0x29, 0x9b, 0x9a, 0xd6,
-	0x35, 0xea, 0x7b, 0x87, 0x2c, 0x02, 0xca, 0x1d, 0xb4, 0x0b, 0xcf, 0xa5, 0x21, 0x8e, 0xdb, 0xda,
-	0x0f, 0x47, 0xdc, 0xfc, 0x46, 0xa7, 0xa9, 0xa5, 0xcf, 0xe1, 0x2e, 0xad, 0x10, 0x5d, 0x0d, 0xbf,
-	0xd1, 0x30, 0x0d, 0x13, 0x36, 0x8e, 0x8f, 0x7a, 0x07, 0xf8, 0xa8, 0xd1, 0x3b, 0xea, 0x60, 0x43,
-	0xb9, 0x97, 0xd2, 0xdb, 0x32, 0x8c, 0x23, 0xde, 0xbb, 0x2e, 0xf5, 0x1e, 0xe0, 0x7a, 0x43, 0xdb,
-	0x3f, 0x3e, 0xec, 0x75, 0x5b, 0xc7, 0x46, 0xb3, 0xf3, 0x7d, 0x5b, 0xd9, 0x78, 0xf2, 0x01, 0xca,
-	0xd1, 0x0f, 0x40, 0x54, 0x81, 0xe5, 0xb1, 0x73, 0xee, 0xb8, 0x1f, 0x1c, 0x25, 0x83, 0x00, 0x8a,
-	0xfc, 0x57, 0xac, 0x92, 0x45, 0x65, 0x28, 0xb0, 0x5f, 0x8f, 0x4a, 0x8e, 0x8a, 0xf9, 0xbf, 0x55,
-	0x25, 0x8f, 0x56, 0xa1, 0x1c, 0xfd, 0x26, 0x55, 0x96, 0xa8, 0xba, 0xf8, 0x1f, 0xaa, 0x14, 0xa8,
-	0x0a, 0xfb, 0xf5, 0xa9, 0x14, 0xd1, 0x32, 0xdb, 0x16, 0x94, 0x65, 0xaa, 0xcb, 0x7f, 0x61, 0x2a,
-	0xa5, 0x27, 0x7b, 0xe1, 0xfb, 0x5d, 0xca, 0xdf, 0x3a, 0xca, 0x24, 0xfe, 0xda, 0x28, 0x19, 0xb4,
-	0x02, 0xa5, 0x91, 0xe9, 0xfb, 0x1f, 0x5c, 0xcf, 0x52, 0xb2, 0x94, 0x63, 0xe0, 0xba, 0xe7, 0xe3,
-	0x91, 0x92, 0x7b, 0xb2, 0x0d, 0x57, 0x13, 0xaf, 0xc4, 0xe8, 0x2a, 0x54, 0xc6, 0x8e, 0x3f, 0x22,
-	0x7d, 0xfb, 0xd4, 0x26, 0x16, 0x9f, 0xc6, 0x90, 0x0c, 0x5d, 0x6f, 0xa2, 0x64, 0x77, 0xfe, 0x54,
-	0x84, 0x9b, 0x52, 0x19, 0xa6, 0x3b, 0x58, 0x97, 0x78, 0xef, 0xed, 0x3e, 0x41, 0xdf, 0x40, 0x39,
-	0x7a, 0x1c, 0x43, 0xe2, 0xb9, 0x3c, 0xf9, 0x82, 0x56, 0xbb, 0x75, 0x41, 0x2e, 0xce, 0x8d, 0x3a,
-	0x94, 0xc2, 0x93, 0x3c, 0x9a, 0x7f, 0x57, 0xac, 0x2d, 0x38, 0xf8, 0xa3, 0x3d, 0x58, 0x16, 0xe7,
-	0x79, 0x34, 0xe7, 0xce, 0x58, 0x9b, 0x77, 0xf4, 0x47, 0xaf, 0xf8, 0x5b, 0x16, 0x3f, 0xcf, 0xa3,
-	0xf9, 0x37, 0xc7, 0xda, 0x82, 0x0b, 0x40, 0x48, 0xc6, 0x4f, 0x69, 0x68, 0xfe, 0xfd, 0xb1, 0xb6,
-	0xe0, 0x0e, 0x10, 0x92, 0xf1, 0x53, 0x3d, 0x9a, 0x7f, 0x8b, 0xac, 0x2d, 0xb8, 0x06, 0xa0, 0x5f,
-	0xc1, 0x5a, 0xea, 0x13, 0x1a, 0x52, 0xa3, 0x38, 0xcd, 0x7c, 0x7f, 0xab, 0x3d, 0x98, 0x8b, 0x11,
-	0x23, 0xec, 0x83, 0x52, 0x1f, 0x8d, 0x06, 0x13, 0xf9, 0x25, 0x62, 0x2d, 0xf5, 0x64, 0x2f, 0x07,
-	0xe4, 0xe2, 0xc5, 0xf6, 0x0d, 0x5c, 0xbb, 0x70, 0xe9, 0x40, 0x62, 0x7a, 0xb3, 0xae, 0x2a, 0xb5,
-	0x8d, 0x99, 0xfd, 0xdc, 0xba, 0x2f, 0xb2, 0xc8, 0x86, 0xea, 0xac, 0x27, 0x18, 0xf4, 0x30, 0x9a,
-	0xe0, 0xbc, 0x57, 0x9c, 0xda, 0xa3, 0x45, 0x30, 0x3e, 0xd8, 0x49, 0x91, 0x1d, 0xdf, 0x77, 0xff,
-	0x13, 0x00, 0x00, 0xff, 0xff, 0x9d, 0x0c, 0xf0, 0xdb, 0x0c, 0x23, 0x00, 0x00,
+	// 4296 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x5b, 0xcd, 0x72, 0x1b, 0x49,
+	0x72, 0x26, 0x00, 0x02, 0x04, 0x12, 0xfc, 0x69, 0x95, 0x44, 0x0a, 0x02, 0x25, 0x8a, 0x6a, 0x8d,
+	0x24, 0x4a, 0x1a, 0xfd, 0x0c, 0xa9, 0xd1, 0xee, 0xac, 0x67, 0xac, 0x05, 0x81, 0x26, 0x81, 0x15,
+	0x09, 0x70, 0x0b, 0x20, 0x67, 0x15, 0x8e, 0x18, 0xb8, 0x09, 0x14, 0xa9, 0xb6, 0x80, 0x6e, 0x6c,
+	0x77, 0x43, 0x43, 0x86, 0x1d, 0xbe, 0xf9, 0xe4, 0xbb, 0xf7, 0x01, 0xec, 0x70, 0xf8, 0xe0, 0x8b,
+	0x1f, 0x60, 0xc3, 0x11, 0x7b, 0xf3, 0xc5, 0x6f, 0xe0, 0x08, 0x3f, 0x81, 0x0f, 0xde, 0x17, 0x70,
+	0xd4, 0x4f, 0x77, 0x57, 0x37, 0x1a, 0x7f, 0x3b, 0xbe, 0xa1, 0x2a, 0x33, 0xbf, 0xca, 0xca, 0xca,
+	0xaa, 0xca, 0xcc, 0x6a, 0x00, 0xf4, 0x89, 0xab, 0xbf, 0x1c, 0xd8, 0x96, 0x6b, 0xa1, 0x45, 0xfa,
+	0xbb, 0xb8, 0x75, 0x69, 0x59, 0x97, 0x3d, 0xf2, 0x8a, 0xf5, 0x9d, 0x0f, 0x2f, 0x5e, 0x75, 0x87,
+	0xb6, 0xee, 0x1a, 0x96, 0xc9, 0xb9, 0x8a, 0x77, 0xa3, 0x74, 0xc7, 0xb5, 0x87, 0x1d, 0x57, 0x50,
+	0xef, 0x47, 0xa9, 0xae, 0xd1, 0x27, 0x8e, 0xab, 0xf7, 0x07, 0x9c, 0x41, 0x7d, 0x0e, 0xf9, 0x13,
+	0xdd, 0x76, 0x0d, 0x8a, 0x58, 0xeb, 0xa2, 0xbb, 0x90, 0x33, 0xf5, 0x3e, 0x71, 0x06, 0x7a, 0x87,
+	0x14, 0x12, 0xdb, 0x89, 0x9d, 0x1c, 0x0e, 0x3a, 0xd4, 0x26, 0x65, 0x76, 0x3f, 0x6a, 0x3d, 0xd2,
+	0x27, 0xa6, 0x8b, 0x10, 0x2c, 0x7e, 0x32, 0xcc, 0xae, 0xe0, 0x63, 0xbf, 0x91, 0x02, 0x49, 0xa3,
+	0x5b, 0x48, 0x6e, 0x27, 0x76, 0x52, 0xd5, 0x05, 0x9c, 0x34, 0xba, 0xe8, 0x16, 0x2c, 0x52, 0x84,
+	0x42, 0x8a, 0x72, 0x55, 0x17, 0x30, 0x6b, 0xed, 0x67, 0x21, 0x63, 0x74, 0x5b, 0xd7, 0x03, 0xa2,
+	0xea, 0x90, 0x7a, 0x4f, 0xae, 0xd1, 0x1e, 0xe4, 0x07, 0x81, 0x22, 0x0c, 0x33, 0xbf, 0x7b, 0xe3,
+	0x25, 0xb3, 0x87, 0xa4, 0x21, 0x96, 0xb9, 0xd0, 0x23, 0x58, 0x1c, 0xe8, 0xee, 0xc7, 0x42, 0x72,
+	0x3b, 0x25, 0x73, 0xfb, 0x2a, 0x62, 0x46, 0x56, 0xff, 0x3b, 0x03, 0xe9, 0x33, 0xbd, 0x37, 0x24,
+	0x68, 0x95, 0xa9, 0x47, 0xc1, 0xd3, 0x4c, 0xb9, 0x87, 0xb0, 0xe8, 0x5e, 0x0f, 0x08, 0x53, 0x78,
+	0x75, 0x77, 0x8d, 0x03, 0x30, 0x56, 0xaa, 0x1b, 0x66, 0x44, 0xb4, 0x0d, 0xf9, 0xae, 0x35, 0x3c,
+	0xef, 0x11, 0x46, 0x60, 0x13, 0x49, 0x60, 0xb9, 0x0b, 0xa9, 0x00, 0x86, 0xe9, 0xbe, 0x7d, 0xc3,
+	0x19, 0x16, 0xe9, 0xec, 0xf7, 0x93, 0xaf, 0x13, 0x58, 0xea, 0xa5, 0x28, 0x8e, 0x6b, 0x1b, 0xe6,
+	0x25, 0x67, 0x4a, 0x33, 0xa3, 0xc9, 0x5d, 0x68, 0x1f, 0x56, 0xfd, 0xe5, 0xe1, 0x4c, 0x19, 0x66,
+	0x85, 0xe2, 0x4b, 0xbe, 0x8a, 0x2f, 0xbd, 0x55, 0x7c, 0xd9, 0xf2, 0xd8, 0x70, 0x44, 0x02, 0xa9,
+	0xb0, 0x7c, 0x6e, 0x59, 0x3d, 0xa2, 0x9b, 0x1c, 0x61, 0x69, 0x3b, 0xb1, 0x93, 0xc5, 0xa1, 0x3e,
+	0xb4, 0x05, 0x70, 0x7e, 0xed, 0x12, 0x87, 0x73, 0x64, 0xb7, 0x13, 0x3b, 0xcb, 0x58, 0xea, 0x41,
+	0x8f, 0x20, 0xfb, 0x89, 0x5c, 0x73, 0x6a, 0x8e, 0x69, 0x90, 0xe3, 0x86, 0x79, 0x4f, 0xae, 0xb1,
+	0x4f, 0x42, 0x5f, 0x40, 0x7e, 0x28, 0xcd, 0x1a, 0xb6, 0x13, 0x3b, 0x8b, 0x6c, 0xd6, 0x72, 0x37,
+	0x7a, 0x0e, 0xa0, 0xdb, 0xb6, 0x2e, 0xe0, 0xf2, 0x6c, 0xa1, 0xf2, 0x92, 0x9d, 0xb1, 0x44, 0xa6,
+	0x4e, 0xc0, 0xdd, 0x97, 0x73, 0x2f, 0xcb, 0x4e, 0xd0, 0x0c, 0x08, 0x58, 0xe6, 0x42, 0x5f, 0x43,
+	0xb6, 0xaf, 0x0b, 0x83, 0xad, 0x30, 0xfc, 0x3b, 0x12, 0xfe, 0xcb, 0x63, 0x41, 0xd3, 0x4c, 0xd7,
+	0xbe, 0xc6, 0x3e, 0x2b, 0x7a, 0x0b, 0xcb, 0x5d, 0xd2, 0x31, 0xfa, 0x7a, 0x8f, 0x8b, 0xae, 0xb2,
+	0xc1, 0x10, 0x17, 0xad, 0x48, 0x14, 0x1c, 0xe2, 0x43, 0xef, 0x60, 0xc5, 0xdb, 0x82, 0x5c, 0x70,
+	0x8d, 0x09, 0xde, 0x19, 0x59, 0xa4, 0x8a, 0xe0, 0xc2, 0x61, 0x7e, 0xf4, 0x06, 0x56, 0x2e, 0x89,
+	0x35, 0xb0, 0x0c, 0x53, 0x4c, 0x53, 0x61, 0x00, 0xab, 0x7c, 0xe4, 0x43, 0x62, 0x9d, 0x50, 0x12,
+	0x0e, 0x33, 0xa1, 0xaf, 0x21, 0xf7, 0x57, 0x8e, 0x37, 0xe4, 0x0d, 0x26, 0x71, 0x7b, 0x64, 0x48,
+	0x6e, 0x23, 0x1c, 0x70, 0xb2, 0x0d, 0x3d, 0xec, 0x89, 0x29, 0x22, 0xe6, 0x0c, 0x41, 0x47, 0xb1,
+	0x0a, 0x2b, 0x21, 0xf3, 0x20, 0x05, 0x52, 0x9f, 0xc8, 0xb5, 0xd8, 0xd1, 0xf4, 0x27, 0x7a, 0x00,
+	0xe9, 0xcf, 0x4c, 0x38, 0xb9, 0x9d, 0x88, 0x2e, 0x1d, 0xa7, 0xfc, 0x22, 0xf9, 0xf3, 0x84, 0xfa,
+	0xe7, 0xb0, 0x2c, 0xdb, 0x0c, 0x15, 0x20, 0x3d, 0x34, 0x0d, 0xd7, 0x29, 0x24, 0xfc, 0xcd, 0xc0,
+	0x3b, 0xd0, 0x2d, 0x48, 0x9b, 0xba, 0x69, 0x39, 0x0c, 0x30, 0x8d, 0x79, 0x43, 0xad, 0x40, 0xd6,
+	0x9b, 0x39, 0x2a, 0x42, 0xb6, 0xa7, 0xbb, 0x86, 0x3b, 0xec, 0xf2, 0x33, 0x28, 0x81, 0xfd, 0x36,
+	0x9d, 0x4f, 0xcf, 0x32, 0x2f, 0x39, 0x31, 0xc9, 0x88, 0x41, 0x87, 0xba, 0x0b, 0x79, 0xc9, 0x4d,
+	0xd0, 0x43, 0xc8, 0x30, 0x0d, 0xa9, 0x16, 0x23, 0x7e, 0x27, 0x48, 0xea, 0x7f, 0xa5, 0x20, 0xdf,
+	0xec, 0x7c, 0x24, 0x7d, 0xfd, 0xc0, 0x20, 0xbd, 0xee, 0xc8, 0x11, 0x81, 0xc4, 0xf9, 0x95, 0xe4,
+	0xa7, 0x1c, 0xfd, 0xed, 0x1f, 0x1b, 0xa9, 0x49, 0xc7, 0x46, 0x01, 0x96, 0x3a, 0x56, 0x9f, 0x1e,
+	0x43, 0xec, 0x44, 0xc8, 0x61, 0xaf, 0x89, 0xf6, 0x20, 0x43, 0xba, 0x86, 0x6b, 0xd9, 0xec, 0x14,
+	0xc8, 0xef, 0x6e, 0x0a, 0x0f, 0x0f, 0xb4, 0xd0, 0x18, 0xb9, 0x66, 0x5e, 0x58, 0x58, 0xb0, 0x52,
+	0xab, 0xd8, 0x44, 0xef, 0x5a, 0x66, 0xef, 0x9a, 0x9d, 0x0b, 0x59, 0xec, 0xb7, 0xd1, 0x06, 0x64,
+	0x86, 0xa6, 0xf1, 0x5b, 0x7f, 0xbf, 0x8b, 0x16, 0xdd, 0x1a, 0x96, 0x59, 0x21, 0x3d, 0xe2, 0xf2,
+	0x7d, 0xbe, 0xba, 0x7b, 0x67, 0x64, 0xa8, 0x86, 0x60, 0xc0, 0x3e, 0x2b, 0x1f, 0x6a, 0x40, 0x74,
+	0x97, 0x74, 0x0b, 0x39, 0x6f, 0x28, 0xde, 0xa6, 0x87, 0x07, 0xdf, 0x7c, 0x75, 0xbd, 0xcf, 0x37,
+	0x7d, 0x0e, 0x4b, 0x3d, 0x68, 0x0f, 0x96, 0xbd, 0x2d, 0x46, 0x6d, 0x51, 0xc8, 0xc7, 0x9b, 0x28,
+	0xc4, 0x44, 0x07, 0x24, 0xe6, 0xb0, 0xcf, 0x20, 0x97, 0x19, 0xa4, 0xdf, 0xa6, 0x27, 0x9a, 0xd8,
+	0x7f, 0xcd, 0x8e, 0xde, 0xa3, 0x5b, 0x9c, 0xae, 0x4c, 0xa8, 0x8f, 0xca, 0x53, 0xa7, 0xd6, 0xcf,
+	0x7b, 0x7c, 0x1f, 0x67, 0xb1, 0xdf, 0x56, 0xff, 0x27, 0x09, 0xeb, 0xb1, 0x96, 0x65, 0xe7, 0xba,
+	0xe1, 0x0c, 0x7a, 0xfa, 0x35, 0x1b, 0x98, 0x3b, 0xbd, 0xdc, 0x85, 0xf6, 0x42, 0xd7, 0xc3, 0xfd,
+	0x09, 0xcb, 0x24, 0xad, 0xfb, 0x63, 0x58, 0xe5, 0x4b, 0x86, 0xbd, 0xe5, 0x4a, 0x31, 0x95, 0x22,
+	0xbd, 0x74, 0x62, 0x7a, 0xaf, 0x67, 0xfd, 0x48, 0xba, 0xef, 0x0d, 0xb3, 0xeb, 0x14, 0x16, 0xb7,
+	0x53, 0x3b, 0x39, 0x1c, 0xea, 0x43, 0x2d, 0x78, 0x34, 0x74, 0xc8, 0x81, 0x61, 0xea, 0x66, 0xc7,
+	0x10, 0x7b, 0xab, 0x65, 0xd5, 0x8d, 0xf3, 0xf3, 0x9e, 0x61, 0x3a, 0x65, 0xcb, 0xfc, 0x4c, 0x6c,
+	0xc7, 0xb0, 0x4c, 0xe6, 0x48, 0x59, 0x3c, 0x1b, 0x33, 0xfa, 0x25, 0xc0, 0x67, 0xbd, 0x67, 0x74,
+	0x75, 0xd7, 0xb2, 0x9d, 0x42, 0x86, 0xed, 0x8d, 0xed, 0x31, 0x93, 0x3b, 0xf3, 0x18, 0xb1, 0x24,
+	0x43, 0x0d, 0xee, 0x92, 0x2b, 0xb7, 0x64, 0x13, 0x5d, 0xb8, 0x9c, 0xdf, 0x56, 0xff, 0xb0, 0x02,
+	0xc5, 0xf1, 0x30, 0xe8, 0x80, 0x3a, 0xd7, 0x6f, 0x87, 0x86, 0x4d, 0xbc, 0x5b, 0x7e, 0x67, 0xea,
+	0xd0, 0x82, 0xbf, 0xba, 0x80, 0x7d, 0x59, 0xd4, 0x80, 0xfc, 0x85, 0x71, 0x45, 0xba, 0x47, 0xc4,
+	0xbc, 0x64, 0x21, 0x00, 0x85, 0x7a, 0x3e, 0x0d, 0xea, 0x20, 0x10, 0xa9, 0x2e, 0x60, 0x19, 0x01,
+	0x95, 0x61, 0xa9, 0x4b, 0x2e, 0xf4, 0x61, 0xcf, 0x65, 0x0b, 0x96, 0xdf, 0x7d, 0x32, 0x0d, 0xac,
+	0xc2, 0xd9, 0xab, 0x0b, 0xd8, 0x93, 0x44, 0x7f, 0x01, 0x6b, 0x17, 0x96, 0xdd, 0xd7, 0xdd, 0xda,
+	0x49, 0xa9, 0xdb, 0xb5, 0x89, 0xe3, 0xb0, 0xcd, 0x9f, 0xdf, 0x7d, 0x35, 0x55, 0xb3, 0xb0, 0x58,
+	0x75, 0x01, 0x47, 0x91, 0xd0, 0x25, 0xdc, 0x8c, 0x74, 0x9d, 0x58, 0xb6, 0x2b, 0x0e, 0x91, 0xbd,
+	0x39, 0x07, 0xa0, 0xa2, 0xd5, 0x05, 0x1c, 0x87, 0x48, 0x4d, 0x31, 0xd0, 0x5d, 0x97, 0xd8, 0x66,
+	0x21, 0x33, 0x9b, 0x29, 0x4e, 0x38, 0x3b, 0x35, 0x85, 0x90, 0x44, 0x18, 0x96, 0x59, 0x1c, 0x70,
+	0x6c, 0x98, 0x46, 0x7f, 0xd8, 0x67, 0x7e, 0x92, 0xdf, 0xfd, 0x72, 0x1a, 0x52, 0x4d, 0x92, 0xa9,
+	0x2e, 0xe0, 0x10, 0x46, 0x80, 0xa9, 0x5f, 0x31, 0xcc, 0xec, 0x3c, 0x98, 0xfa, 0x55, 0x18, 0x93,
+	0xb7, 0xd1, 0x29, 0xac, 0x0c, 0x43, 0x8a, 0xf2, 0x98, 0xe7, 0xc5, 0x34, 0xd0, 0x53, 0x23, 0xac,
+	0x69, 0x18, 0x45, 0x82, 0x15, 0xba, 0xc2, 0x5c, 0xb0, 0xbe, 0xb2, 0x61, 0x14, 0x0a, 0xcb, 0x23,
+	0x4f, 0x4f, 0xdb, 0xfc, 0x6c, 0xb0, 0x15, 0x59, 0x88, 0xc2, 0x86, 0x50, 0x24, 0x58, 0xa1, 0xed,
+	0xf2, 0x5c, 0xb0, 0x81, 0xb6, 0x21, 0x14, 0x0a, 0xdb, 0xe7, 0x23, 0x88, 0x6d, 0xba, 0x32, 0x1b,
+	0xec, 0xb1, 0x2c, 0x44, 0x61, 0x43, 0x28, 0x0c, 0x56, 0xbf, 0x0a, 0x3a, 0x0a, 0xab, 0x33, 0xc2,
+	0xea, 0x57, 0x11, 0x58, 0xfd, 0x2a, 0x0c, 0x2b, 0x4e, 0xdf, 0x33, 0x1e, 0x36, 0xac, 0xcd, 0x06,
+	0x5b, 0x92, 0x85, 0x28, 0x6c, 0x08, 0x85, 0x9d, 0x54, 0x6c, 0x93, 0x69, 0x7d, 0xdd, 0xe8, 0x15,
+	0x94, 0x19, 0x4f, 0xaa, 0x40, 0x84, 0x9d, 0x54, 0x41, 0x13, 0xd5, 0x20, 0xc7, 0x9b, 0xa7, 0xf8,
+	0x48, 0xc4, 0x82, 0x4f, 0x67, 0x83, 0x3b, 0xc5, 0x47, 0xd5, 0x05, 0x1c, 0x48, 0xcb, 0x50, 0xb5,
+	0x02, 0x9a, 0x0f, 0xaa, 0x26, 0x43, 0xd5, 0xd0, 0x0f, 0xa0, 0xf8, 0xc9, 0x88, 0xe7, 0x9c, 0x37,
+	0x19, 0xe2, 0xeb, 0x69, 0x88, 0xad, 0x88, 0x5c, 0x75, 0x01, 0x8f, 0x60, 0x85, 0xf1, 0x85, 0x97,
+	0xde, 0x9a, 0x17, 0xdf, 0x77, 0xd4, 0x11, 0xac, 0xfd, 0x3c, 0xe4, 0xfc, 0x1b, 0x4e, 0xfd, 0x02,
+	0xd4, 0xe9, 0xf7, 0x91, 0xfa, 0x0e, 0x1e, 0xcd, 0x74, 0xd5, 0xd0, 0x00, 0xad, 0xc7, 0x7e, 0xb1,
+	0x2b, 0x6f, 0x05, 0x8b, 0x96, 0x7a, 0x00, 0x0f, 0xa6, 0x5e, 0x2f, 0x41, 0x08, 0x9e, 0x18, 0x17,
+	0x82, 0xab, 0xcf, 0xe1, 0xe9, 0xcc, 0x07, 0xbf, 0xfa, 0x0a, 0x5e, 0xcc, 0x75, 0x4b, 0xa8, 0xdf,
+	0xc1, 0x83, 0xa9, 0x27, 0x3f, 0x0d, 0x77, 0xbd, 0x3b, 0x83, 0x47, 0x52, 0x5e, 0x53, 0xdd, 0x87,
+	0xc7, 0xb3, 0x1d, 0xf7, 0x34, 0x6b, 0x08, 0x66, 0x2a, 0xb2, 0x06, 0x3e, 0xc1, 0x19, 0x30, 0xf4,
+	0xab, 0x29, 0x18, 0x65, 0x78, 0x32, 0xe3, 0x69, 0x1e, 0x06, 0x59, 0x9c, 0x17, 0x44, 0xbf, 0x9a,
+	0x02, 0xf2, 0x0e, 0x9e, 0x4c, 0x3d, 0x52, 0x85, 0x26, 0xb7, 0x64, 0x90, 0xb9, 0x00, 0xf4, 0xab,
+	0x09, 0x00, 0xa5, 0x49, 0x00, 0xa1, 0xd3, 0x77, 0xac, 0xef, 0x4e, 0x86, 0xd0, 0xaf, 0x66, 0x80,
+	0xa8, 0xc3, 0x93, 0x19, 0x4f, 0xd5, 0xd9, 0x72, 0xb9, 0x27, 0xf0, 0x68, 0x9a, 0x67, 0xb3, 0x13,
+	0x54, 0x7d, 0x07, 0x0f, 0x67, 0x38, 0x2a, 0xa9, 0x4f, 0x3b, 0x94, 0x4d, 0x8c, 0x9a, 0xc3, 0x5e,
+	0x53, 0x7d, 0x34, 0x0b, 0x40, 0x4d, 0xfd, 0x01, 0x9e, 0xcd, 0x7e, 0xea, 0xa1, 0xd7, 0xe1, 0x8d,
+	0x3e, 0xa9, 0xee, 0x23, 0x96, 0x71, 0x46, 0x7c, 0xfd, 0xea, 0x4f, 0xc4, 0xff, 0xcf, 0x04, 0x28,
+	0x7c, 0x00, 0x9a, 0x8f, 0x68, 0x7e, 0x26, 0xea, 0x18, 0xe6, 0xe5, 0xb0, 0xa7, 0xdb, 0x62, 0xab,
+	0xfb, 0x6d, 0xba, 0xd2, 0x83, 0xde, 0xd0, 0xd6, 0x7b, 0x22, 0x5f, 0x16, 0x2d, 0x54, 0x81, 0x7b,
+	0x36, 0x31, 0xbb, 0xc4, 0xe6, 0x18, 0x15, 0xdb, 0x1a, 0x74, 0xad, 0x1f, 0xcd, 0xef, 0x0d, 0xf7,
+	0x23, 0x53, 0x9d, 0x97, 0x07, 0xf1, 0x64, 0x26, 0x9a, 0x7c, 0x7e, 0x22, 0xd7, 0xe5, 0x50, 0x56,
+	0x2d, 0xf5, 0xb0, 0x1a, 0x9b, 0x65, 0xbb, 0xfb, 0xd7, 0x1c, 0xd3, 0xab, 0xb1, 0x05, 0x5d, 0xea,
+	0x1f, 0x12, 0x00, 0xc1, 0x84, 0xd0, 0x53, 0xc8, 0x5c, 0xd0, 0x7e, 0x27, 0x5c, 0x42, 0x94, 0x6c,
+	0x8a, 0x05, 0x03, 0x7a, 0xe9, 0x27, 0xed, 0x3c, 0x3b, 0xd8, 0x90, 0x59, 0x03, 0xeb, 0xf8, 0xf9,
+	0xfa, 0x73, 0x58, 0x32, 0xcc, 0x2e, 0xb9, 0x22, 0x3c, 0xb3, 0x8b, 0x60, 0xd7, 0x28, 0x09, 0x7b,
+	0x1c, 0xb4, 0xac, 0xa1, 0x9b, 0x1d, 0xe2, 0xb0, 0x84, 0x2c, 0xcd, 0x5c, 0x2d, 0xe8, 0x10, 0x25,
+	0x89, 0x8c, 0x57, 0x92, 0x50, 0xff, 0x39, 0x01, 0x79, 0x09, 0xc6, 0x2f, 0x51, 0x24, 0xa4, 0x12,
+	0xc5, 0xd3, 0x50, 0xea, 0xba, 0x3e, 0x32, 0xb6, 0x94, 0xb0, 0xfe, 0x0c, 0xb2, 0x1d, 0xab, 0x3f,
+	0x18, 0xd2, 0x74, 0x3f, 0x25, 0x8a, 0x59, 0x12, 0x7b, 0x59, 0xd0, 0x98, 0x18, 0x4d, 0xc1, 0x3c,
+	0x66, 0xb4, 0x01, 0x69, 0x66, 0x1c, 0xbe, 0x12, 0xd5, 0x05, 0xcc, 0x9b, 0xfb, 0x4b, 0xc2, 0xcf,
+	0xd4, 0x7f, 0x49, 0xc2, 0xcd, 0x18, 0x10, 0xf4, 0x0d, 0x64, 0x2e, 0xcc, 0xcf, 0x6f, 0xdf, 0xe8,
+	0xc2, 0x13, 0xef, 0x8f, 0x1d, 0xef, 0x80, 0xb1, 0x55, 0x17, 0xb0, 0x10, 0x40, 0x07, 0x90, 0xe7,
+	0xbf, 0xda, 0x03, 0xdd, 0xb0, 0x45, 0xda, 0xf7, 0x70, 0x8a, 0xfc, 0x89, 0x6e, 0xd8, 0xd5, 0x05,
+	0x0c, 0x17, 0x7e, 0x4b, 0xa8, 0xb0, 0xb7, 0xab, 0x17, 0x52, 0xd3, 0x55, 0xd8, 0xdb, 0xf5, 0x54,
+	0xd8, 0xdb, 0xf5, 0x54, 0xd8, 0xdb, 0x15, 0x2a, 0x2c, 0x4e, 0x57, 0x61, 0x6f, 0x57, 0x56, 0x41,
+	0xb4, 0x68, 0xc0, 0xa1, 0xf7, 0x2e, 0x2d, 0xdb, 0x70, 0x3f, 0xf6, 0xd5, 0xaf, 0xe0, 0xce, 0x58,
+	0xf5, 0xe9, 0x19, 0xce, 0x0d, 0xcd, 0x57, 0x98, 0x37, 0xd4, 0x06, 0xdc, 0x9b, 0x38, 0x63, 0xba,
+	0x19, 0x19, 0xe7, 0x57, 0x42, 0x4e, 0xb4, 0xfc, 0xfe, 0x5d, 0x6f, 0x93, 0xf2, 0xd6, 0x78, 0x1d,
+	0xf6, 0x76, 0xe7, 0xd6, 0x41, 0x4c, 0x72, 0x6e, 0x1d, 0x7e, 0x97, 0x82, 0x0c, 0x47, 0x8c, 0x75,
+	0xeb, 0x17, 0x90, 0xfe, 0x64, 0x98, 0xfe, 0x7e, 0xbd, 0x2d, 0x5b, 0xfd, 0x25, 0xab, 0x99, 0xf0,
+	0x3a, 0x2f, 0xe7, 0x42, 0x7b, 0xb0, 0xc4, 0x6b, 0x53, 0x4e, 0x21, 0x25, 0x97, 0x86, 0x85, 0x00,
+	0x2f, 0x16, 0x0a, 0x11, 0x8f, 0x93, 0x8e, 0x41, 0xab, 0x4f, 0xde, 0xbe, 0x0d, 0x8f, 0xa1, 0x51,
+	0x8a, 0x18, 0x83, 0x71, 0x15, 0x7f, 0x05, 0x10, 0x0c, 0x1c, 0x53, 0x41, 0x7d, 0x1c, 0xae, 0xa0,
+	0x2a, 0xd1, 0x73, 0x43, 0x2a, 0xa3, 0x16, 0xeb, 0xb0, 0x2c, 0xeb, 0x14, 0x83, 0xb6, 0x13, 0x46,
+	0x43, 0x32, 0x1a, 0x17, 0x95, 0xf1, 0x7e, 0x05, 0x10, 0x28, 0x3c, 0xa7, 0x6e, 0x54, 0x50, 0x2e,
+	0xf1, 0x7e, 0x03, 0xcb, 0xf2, 0x30, 0xd2, 0xd9, 0x99, 0x98, 0x72, 0x76, 0xaa, 0x7f, 0x06, 0x10,
+	0x60, 0xa2, 0x17, 0x91, 0xab, 0x7c, 0x3d, 0x3a, 0x6a, 0xf8, 0x52, 0xff, 0x0e, 0xd6, 0x22, 0xa4,
+	0x58, 0xcf, 0xd8, 0x80, 0x8c, 0x39, 0xec, 0x9f, 0x13, 0x5b, 0x14, 0x96, 0x45, 0x4b, 0x45, 0xa0,
+	0x1c, 0x12, 0x97, 0x23, 0xd0, 0xc0, 0x9d, 0x38, 0xae, 0xfa, 0x0d, 0xdc, 0x90, 0xfa, 0x9c, 0x81,
+	0x65, 0x3a, 0xf4, 0x3d, 0x23, 0xc3, 0x6e, 0x77, 0xef, 0x50, 0x5a, 0x96, 0xd5, 0xc2, 0x82, 0xa6,
+	0xfe, 0x3e, 0x01, 0xb7, 0x8f, 0x89, 0xab, 0x1f, 0x19, 0x8e, 0xab, 0x99, 0xae, 0xe1, 0x1a, 0xc4,
+	0x11, 0xb0, 0x74, 0x8b, 0x38, 0xae, 0x6e, 0xbb, 0x0c, 0x60, 0x19, 0xf3, 0x06, 0xed, 0xed, 0x19,
+	0x7d, 0xc3, 0x65, 0x7a, 0xad, 0x60, 0xde, 0xa0, 0x97, 0x28, 0x75, 0xd1, 0xba, 0xff, 0x34, 0x86,
+	0xfd, 0x76, 0xf4, 0x2d, 0x6c, 0x71, 0xc6, 0xb7, 0xb0, 0xac, 0x77, 0x63, 0x14, 0xd2, 0x23, 0xaf,
+	0x36, 0x1e, 0x49, 0xfd, 0x5b, 0x28, 0x8c, 0xaa, 0x2f, 0x2c, 0x40, 0xcd, 0x4a, 0xae, 0x3c, 0xf5,
+	0xd9, 0x6f, 0x7a, 0xa5, 0xf6, 0x2d, 0x9b, 0x60, 0xe2, 0x0c, 0x7b, 0x2e, 0x2f, 0xda, 0x67, 0xb1,
+	0xdc, 0x85, 0xbe, 0xa4, 0xc5, 0x5b, 0x8e, 0x24, 0x36, 0x99, 0x70, 0x23, 0x3a, 0x0e, 0x1b, 0xe3,
+	0x1a, 0xfb, 0x1c, 0xea, 0xbf, 0x26, 0x00, 0x02, 0x02, 0xda, 0x0c, 0x5c, 0x32, 0xa4, 0x30, 0xf3,
+	0xce, 0x20, 0xe6, 0x4b, 0x8e, 0x8d, 0xf9, 0x68, 0x8c, 0xe6, 0x15, 0x41, 0xa9, 0x1d, 0x53, 0xd8,
+	0x6b, 0xa2, 0x7d, 0x58, 0x63, 0xfe, 0x77, 0x66, 0x58, 0x3d, 0xf6, 0xfe, 0xe2, 0xed, 0xe8, 0x42,
+	0xa0, 0xdf, 0x41, 0x88, 0x01, 0x47, 0x05, 0xd4, 0x2d, 0xb8, 0x7b, 0x48, 0x5c, 0x91, 0x89, 0xc9,
+	0xb6, 0x17, 0x9e, 0xf4, 0x1d, 0xdc, 0x1b, 0x43, 0x17, 0x36, 0x9d, 0xfc, 0xa2, 0xda, 0x80, 0x5b,
+	0x54, 0x8b, 0x43, 0xe2, 0x0a, 0x43, 0x09, 0x4f, 0x9a, 0x68, 0x16, 0xd9, 0x75, 0x92, 0x61, 0xd7,
+	0x51, 0x4b, 0xb0, 0x1e, 0x01, 0x14, 0x7a, 0xec, 0x40, 0x86, 0xad, 0x81, 0x07, 0x3a, 0xba, 0x46,
+	0x82, 0xae, 0xfe, 0xbd, 0xf0, 0xf0, 0xd3, 0x41, 0x57, 0x77, 0x49, 0x58, 0xaf, 0x99, 0x51, 0xd0,
+	0x0e, 0xac, 0x91, 0xab, 0x01, 0xe9, 0xb8, 0xa4, 0x7b, 0x26, 0x96, 0x87, 0xbd, 0x0a, 0xe3, 0x68,
+	0x37, 0xb5, 0x10, 0xb3, 0xfa, 0xb1, 0xee, 0x7c, 0x62, 0x0e, 0x94, 0xc3, 0x41, 0x87, 0x5a, 0x81,
+	0xc2, 0xa8, 0x32, 0x73, 0xcf, 0xa9, 0xcd, 0xa7, 0x54, 0xb6, 0xc9, 0x4f, 0x98, 0xd2, 0x24, 0xbb,
+	0x0b, 0x35, 0xc3, 0x03, 0xcc, 0xad, 0xe6, 0xdf, 0x70, 0x35, 0xf9, 0x33, 0xcc, 0xff, 0x8f, 0x47,
+	0xc4, 0x2d, 0x44, 0x2a, 0x76, 0x21, 0xbc, 0x39, 0x84, 0x47, 0x9f, 0x7b, 0x0e, 0x67, 0x70, 0xff,
+	0x90, 0xb8, 0x2d, 0x5b, 0x37, 0x1d, 0xbd, 0x43, 0x37, 0xc3, 0xaf, 0x87, 0x64, 0x48, 0xca, 0xd6,
+	0xd0, 0x74, 0xbd, 0xb9, 0xfc, 0x29, 0x6f, 0xfd, 0xea, 0x6f, 0x60, 0x7b, 0x3c, 0xae, 0xd0, 0xf2,
+	0x0d, 0xac, 0xbb, 0x71, 0x0c, 0x22, 0xed, 0x8c, 0x27, 0xaa, 0x7f, 0x97, 0x80, 0x5b, 0x98, 0x9d,
+	0x8e, 0xa4, 0x65, 0xd1, 0x14, 0xc8, 0xd3, 0xf3, 0xe7, 0x90, 0xf3, 0xab, 0x44, 0x33, 0xe4, 0x4c,
+	0x01, 0x73, 0x74, 0x86, 0xc9, 0x99, 0x66, 0xe8, 0xc0, 0x7a, 0x44, 0x0d, 0x31, 0xad, 0x17, 0xb0,
+	0x6c, 0x73, 0x42, 0xf7, 0x3d, 0xb9, 0xf6, 0xae, 0x4d, 0xc9, 0x09, 0x42, 0x64, 0xf4, 0x1c, 0xf2,
+	0x5d, 0xb6, 0x86, 0x9c, 0x3b, 0x19, 0xe5, 0x96, 0xa9, 0xea, 0x1f, 0x13, 0x70, 0x23, 0x58, 0x45,
+	0x6f, 0x4f, 0x4a, 0x87, 0x6a, 0x22, 0x7c, 0xa8, 0x7e, 0x01, 0x2b, 0x92, 0x15, 0xc5, 0xdc, 0x72,
+	0x38, 0xdc, 0x49, 0xe5, 0xf5, 0xa1, 0xfb, 0xb1, 0x39, 0x3c, 0x17, 0x97, 0x9b, 0xd7, 0x44, 0xbf,
+	0x84, 0x15, 0xba, 0x93, 0x9b, 0xc3, 0xf3, 0xbe, 0xe1, 0xba, 0xc4, 0xbb, 0xdd, 0x26, 0xd9, 0x35,
+	0x2c, 0x40, 0xb1, 0xc5, 0x04, 0xc4, 0xab, 0x97, 0xd7, 0x94, 0x9c, 0x34, 0x33, 0xc5, 0x49, 0xdf,
+	0xf8, 0xc7, 0x64, 0xd5, 0xa0, 0x96, 0x9b, 0x69, 0x9b, 0xa9, 0xc7, 0xb0, 0x11, 0x95, 0x12, 0x2b,
+	0xb4, 0x07, 0x59, 0x61, 0x20, 0x6f, 0x75, 0x6e, 0x47, 0xc7, 0x16, 0xa6, 0xc5, 0x3e, 0xa3, 0x7a,
+	0xc2, 0x77, 0xfb, 0x21, 0x71, 0x4b, 0xae, 0x47, 0x9d, 0x65, 0xb7, 0x4b, 0x8b, 0x93, 0x0c, 0x2d,
+	0x8e, 0xfa, 0x01, 0x0a, 0xa3, 0x88, 0x42, 0xc5, 0xef, 0x60, 0x85, 0xc8, 0x8a, 0x08, 0xf0, 0xb1,
+	0x7a, 0x86, 0xb9, 0xd5, 0xaf, 0x61, 0xe3, 0x80, 0x06, 0xab, 0xe4, 0x82, 0xd8, 0xc4, 0xec, 0x18,
+	0xe6, 0xe5, 0x4c, 0x26, 0xfb, 0x6b, 0x6e, 0x68, 0x49, 0x4c, 0x1b, 0x3d, 0x4c, 0x13, 0x91, 0x23,
+	0xcb, 0xbb, 0x11, 0xa4, 0xf3, 0x2c, 0xe8, 0x90, 0x56, 0x39, 0x35, 0x65, 0x95, 0x31, 0xdc, 0x1e,
+	0xd1, 0x59, 0x58, 0xe3, 0x67, 0x52, 0xd0, 0xc2, 0x17, 0x6c, 0x33, 0x80, 0x19, 0xd1, 0x56, 0x8a,
+	0x5f, 0x3e, 0xc2, 0x1a, 0x65, 0x91, 0xce, 0x21, 0xb4, 0x07, 0x60, 0x0d, 0x88, 0x2d, 0x42, 0x0c,
+	0x8e, 0x76, 0x33, 0x40, 0x6b, 0x78, 0x34, 0x2c, 0xb1, 0xb1, 0xc7, 0x67, 0xe2, 0x74, 0x6c, 0x63,
+	0xe0, 0x7a, 0x0b, 0x99, 0xc3, 0x72, 0x97, 0xfa, 0xbf, 0x49, 0x58, 0x09, 0xc9, 0xa3, 0x12, 0xe4,
+	0x7b, 0x86, 0xe3, 0x1d, 0xa3, 0xc2, 0xe2, 0xf7, 0x82, 0x91, 0x62, 0x62, 0x52, 0xfa, 0x74, 0x20,
+	0xc9, 0xa0, 0x6f, 0x01, 0x2e, 0x89, 0x8f, 0x90, 0x14, 0x7b, 0xcf, 0x47, 0x88, 0x06, 0x22, 0x34,
+	0x63, 0x0d, 0xf8, 0x91, 0x06, 0x2b, 0x43, 0x76, 0x11, 0x7b, 0x00, 0xa9, 0xa8, 0x0a, 0x31, 0x41,
+	0x03, 0x7b, 0xc3, 0x92, 0xa5, 0x28, 0x4c, 0xc7, 0x26, 0x41, 0x47, 0x61, 0x31, 0x0a, 0x13, 0x73,
+	0x51, 0x53, 0x98, 0x90, 0x14, 0x85, 0xe1, 0x3b, 0xdf, 0x83, 0x49, 0x47, 0x61, 0x62, 0x2e, 0x52,
+	0x0a, 0x13, 0x92, 0xa2, 0x69, 0xb8, 0xbf, 0x2e, 0xea, 0x0f, 0xb0, 0x1e, 0x59, 0x5e, 0x1e, 0xe6,
+	0x22, 0x0d, 0x14, 0x9f, 0xcb, 0x0b, 0x86, 0x13, 0x72, 0x4a, 0x19, 0x5e, 0x6a, 0xc6, 0x81, 0x47,
+	0x44, 0xd4, 0x7f, 0x48, 0x40, 0x21, 0x86, 0x53, 0xb3, 0x6d, 0xcb, 0xa6, 0x5f, 0x04, 0x10, 0xfa,
+	0xe3, 0x98, 0x38, 0x8e, 0x7e, 0xe9, 0xed, 0x8b, 0x50, 0x1f, 0x8d, 0xd1, 0x3b, 0x96, 0xf8, 0xf6,
+	0x65, 0x05, 0xb3, 0xdf, 0x71, 0x81, 0x6e, 0x6a, 0xde, 0x40, 0x77, 0x00, 0x68, 0x94, 0xed, 0x27,
+	0xec, 0xd2, 0x88, 0x7f, 0xa7, 0x46, 0xfd, 0xfb, 0xf7, 0x29, 0xb8, 0x19, 0x63, 0x0a, 0xf4, 0x06,
+	0xd2, 0x6c, 0xc6, 0xc2, 0xbf, 0xb7, 0xc6, 0x9a, 0x97, 0x19, 0x0d, 0x73, 0x66, 0x54, 0x81, 0x65,
+	0xee, 0xe7, 0x7c, 0x83, 0x17, 0x92, 0x51, 0xe1, 0xb8, 0x8c, 0x87, 0xbe, 0x05, 0xcb, 0x52, 0xe8,
+	0x1d, 0xe4, 0x2f, 0x89, 0xdf, 0x14, 0xee, 0xbd, 0x19, 0xbb, 0x3f, 0x7c, 0x04, 0x59, 0x02, 0x55,
+	0x61, 0xd5, 0xf3, 0x75, 0x81, 0xb1, 0x18, 0x55, 0x24, 0x2e, 0x94, 0xad, 0x2e, 0xe0, 0x88, 0x1c,
+	0x45, 0xf2, 0xdc, 0x5d, 0x20, 0xa5, 0xa3, 0x48, 0x71, 0xd1, 0x26, 0x45, 0x0a, 0xcb, 0x51, 0x24,
+	0xcf, 0xe3, 0x05, 0x52, 0x26, 0x8a, 0x14, 0x17, 0xf3, 0x51, 0xa4, 0xb0, 0x5c, 0x78, 0xab, 0x34,
+	0xa0, 0xf0, 0xbd, 0xee, 0x76, 0x3e, 0x4a, 0x7b, 0xc5, 0xf9, 0x49, 0x11, 0xde, 0x3f, 0x25, 0xe0,
+	0x4e, 0x0c, 0xa2, 0x98, 0xc5, 0x2e, 0xa4, 0xcf, 0x29, 0xd1, 0x0f, 0xc4, 0x7c, 0xe5, 0x25, 0xf6,
+	0x7d, 0xca, 0x41, 0x0b, 0x91, 0x8c, 0x15, 0x1d, 0xd2, 0xcf, 0x05, 0x0c, 0xd7, 0xd0, 0x7b, 0x4d,
+	0x57, 0x77, 0x3d, 0xa7, 0x78, 0x10, 0x2b, 0x5a, 0x93, 0x18, 0xf9, 0x37, 0x02, 0x41, 0x7b, 0x1f,
+	0xe8, 0x47, 0x2b, 0x5c, 0x11, 0xf5, 0x1f, 0x93, 0x31, 0x67, 0x44, 0xc7, 0xb2, 0xbb, 0x34, 0xf0,
+	0xea, 0x0f, 0x5d, 0x5d, 0x84, 0x56, 0xa3, 0x61, 0x9a, 0x4c, 0x9d, 0x2b, 0x4a, 0x1b, 0x8d, 0x9a,
+	0x52, 0xf3, 0x46, 0x4d, 0xdf, 0x42, 0x9e, 0x76, 0x70, 0x97, 0x99, 0x25, 0xea, 0x92, 0xd9, 0xa3,
+	0xbb, 0x39, 0x3d, 0xb2, 0x9b, 0xa5, 0x1a, 0x75, 0x8e, 0xd5, 0xa8, 0xff, 0x2d, 0x01, 0xb7, 0x22,
+	0x56, 0x62, 0x8b, 0x83, 0x7e, 0x01, 0x6b, 0xc2, 0x0c, 0x5a, 0xf8, 0x02, 0x1e, 0xbd, 0xc7, 0xa3,
+	0x8c, 0xf3, 0xd9, 0x6c, 0xea, 0x09, 0x24, 0x74, 0x5e, 0xf4, 0x75, 0x7e, 0x0f, 0x9b, 0x13, 0x9c,
+	0x22, 0x54, 0xe8, 0x48, 0x4c, 0x2d, 0x74, 0xfc, 0xfb, 0x32, 0xac, 0x97, 0x2d, 0xf3, 0xc2, 0xb8,
+	0xe4, 0x21, 0xbd, 0xad, 0x77, 0xc4, 0x47, 0x96, 0x35, 0x51, 0x9a, 0x4f, 0xb0, 0xd2, 0xfc, 0xd7,
+	0x1c, 0x23, 0x96, 0x35, 0xbe, 0x57, 0x2a, 0xdd, 0x07, 0xb1, 0x50, 0x72, 0x4a, 0xf2, 0x2a, 0xa2,
+	0xb4, 0x54, 0x6c, 0x44, 0xb9, 0xe5, 0x45, 0x30, 0x96, 0x5d, 0xf3, 0x16, 0x51, 0xea, 0x19, 0x0d,
+	0xfa, 0x97, 0xe2, 0x82, 0xfe, 0x03, 0xd8, 0xb2, 0x49, 0x5f, 0x37, 0x4c, 0xc3, 0xbc, 0x8c, 0xcd,
+	0xd3, 0xd8, 0xe7, 0x3a, 0x69, 0x3c, 0x85, 0x0b, 0xbd, 0x85, 0x0d, 0x9b, 0x74, 0x2c, 0xd3, 0x24,
+	0x8c, 0x52, 0xb6, 0xba, 0xa4, 0xc9, 0x3e, 0x93, 0x66, 0x5f, 0xe6, 0xe4, 0xf0, 0x18, 0x2a, 0x5d,
+	0x70, 0x76, 0x17, 0x08, 0x66, 0xfe, 0x6d, 0xa2, 0xdc, 0x45, 0x2f, 0xcf, 0x01, 0xfd, 0x62, 0x2a,
+	0xcf, 0xf4, 0x60, 0xbf, 0xd5, 0xdf, 0xe5, 0xe0, 0xce, 0x58, 0x33, 0xa3, 0xbb, 0x50, 0xa8, 0xd5,
+	0x6b, 0xad, 0x5a, 0xe9, 0xa8, 0xdd, 0x6c, 0x95, 0x5a, 0x5a, 0xbb, 0xa9, 0xd5, 0x2b, 0xed, 0x7d,
+	0xed, 0xb0, 0x56, 0x57, 0x16, 0xd0, 0x3d, 0xb8, 0x13, 0x43, 0xd5, 0xea, 0xad, 0x5a, 0xeb, 0x83,
+	0x92, 0x40, 0x45, 0xd8, 0x88, 0x25, 0x57, 0x94, 0x24, 0xba, 0x0f, 0x9b, 0x61, 0x1a, 0xd6, 0xca,
+	0x5a, 0xed, 0x4c, 0x13, 0xd8, 0x29, 0xb4, 0x0d, 0x77, 0xe3, 0x19, 0x04, 0xfc, 0xe2, 0xe8, 0xe8,
+	0x01, 0x47, 0x45, 0x49, 0x53, 0x80, 0x16, 0x2e, 0xd5, 0x9b, 0xa5, 0x72, 0xab, 0xd6, 0xa8, 0xb7,
+	0xf7, 0x4b, 0xad, 0x72, 0x55, 0x56, 0x3f, 0x83, 0x9e, 0xc2, 0xa3, 0x31, 0x1c, 0xc7, 0xa7, 0x14,
+	0xd0, 0x9f, 0xca, 0x12, 0x7a, 0x01, 0x4f, 0xc7, 0xb0, 0x56, 0xb4, 0x23, 0x2d, 0x60, 0x6d, 0xbf,
+	0xd7, 0x3e, 0x28, 0x59, 0xb4, 0x05, 0xc5, 0x31, 0xec, 0x54, 0xb7, 0x1c, 0x7a, 0x08, 0xf7, 0x47,
+	0xe9, 0x61, 0x0b, 0x00, 0xfa, 0x12, 0x76, 0xc6, 0x33, 0x45, 0x34, 0xcc, 0xa3, 0xd7, 0xf0, 0xe5,
+	0x78, 0xee, 0x18, 0x25, 0x97, 0xd1, 0x03, 0xb8, 0x37, 0x5e, 0x82, 0xea, 0xb9, 0xc2, 0x57, 0xb0,
+	0x7d, 0xac, 0x1d, 0x37, 0xf0, 0x87, 0x76, 0xb3, 0xd5, 0xc0, 0xbe, 0xf9, 0x57, 0xd1, 0x26, 0xdc,
+	0x0e, 0x68, 0x7c, 0x00, 0x8f, 0xb8, 0x86, 0x6e, 0xc3, 0x4d, 0x19, 0xbb, 0x84, 0x71, 0xed, 0x4c,
+	0xab, 0x28, 0x4a, 0x74, 0xe6, 0x07, 0xb5, 0x7a, 0xad, 0x59, 0xd5, 0x2a, 0xed, 0x13, 0xdc, 0x28,
+	0x6b, 0xcd, 0x66, 0xad, 0x7e, 0xa8, 0xdc, 0x88, 0x4a, 0x37, 0x5b, 0xa5, 0xa3, 0x23, 0xad, 0xa2,
+	0x20, 0xaa, 0x4f, 0xb9, 0x51, 0x3f, 0xa8, 0x1d, 0x72, 0x5d, 0xca, 0x8d, 0x7a, 0xb3, 0xd6, 0x6c,
+	0x69, 0xf5, 0x96, 0x72, 0x13, 0xa9, 0xb0, 0x25, 0x0b, 0x85, 0x0d, 0xc4, 0xa6, 0x7c, 0x2b, 0xca,
+	0x13, 0x63, 0x96, 0x75, 0xf4, 0x15, 0xbc, 0x90, 0x79, 0xb0, 0x46, 0x47, 0x69, 0xe1, 0xd3, 0x72,
+	0xab, 0x5d, 0x3a, 0x39, 0x89, 0xf1, 0x8e, 0x0d, 0xf4, 0x16, 0x76, 0xcb, 0x47, 0x35, 0xad, 0xde,
+	0x6a, 0x97, 0x4f, 0x31, 0xd6, 0xea, 0xad, 0xa3, 0x0f, 0xed, 0x4a, 0xad, 0x59, 0x6e, 0xd4, 0xeb,
+	0x5a, 0x99, 0x72, 0x96, 0x5a, 0x2d, 0xed, 0xf8, 0xa4, 0x55, 0xab, 0x1f, 0x72, 0x3c, 0xda, 0xad,
+	0xdc, 0x46, 0xcf, 0xe0, 0xb1, 0x90, 0x3b, 0x6c, 0xb4, 0xda, 0x5a, 0xe3, 0x20, 0x96, 0x91, 0xda,
+	0xa4, 0x40, 0x37, 0x8c, 0xc4, 0x5b, 0xaf, 0x1d, 0xb5, 0xf7, 0x4f, 0x0f, 0xdb, 0xb5, 0xc3, 0x7a,
+	0x03, 0x53, 0x86, 0x3b, 0x74, 0x3d, 0x04, 0xc3, 0x41, 0xa9, 0x76, 0xa4, 0x55, 0xa4, 0x91, 0x8a,
+	0xd4, 0xec, 0x9e, 0x86, 0x02, 0x94, 0x4d, 0x4d, 0x6b, 0xb6, 0x4a, 0xfb, 0x47, 0x6c, 0x05, 0x94,
+	0x4d, 0xb4, 0x07, 0xaf, 0xa4, 0x21, 0x4e, 0xeb, 0xda, 0x6f, 0x4e, 0xb8, 0xfa, 0xe5, 0x46, 0x45,
+	0x8b, 0x9f, 0xc3, 0x5d, 0x7a, 0x42, 0x34, 0x35, 0x7c, 0xa6, 0x61, 0xba, 0x4c, 0xb8, 0x75, 0x7a,
+	0xd2, 0x3e, 0xc4, 0x27, 0xe5, 0xf6, 0x49, 0x03, 0xb7, 0x94, 0x7b, 0x31, 0xd4, 0x6a, 0xab, 0x75,
+	0xc2, 0xa9, 0x5b, 0x12, 0xf5, 0x10, 0x97, 0xca, 0xda, 0xc1, 0xe9, 0x51, 0xbb, 0x59, 0x3d, 0x6d,
+	0x55, 0x1a, 0xdf, 0xd7, 0x95, 0xfb, 0xcf, 0xfe, 0x23, 0x01, 0xb9, 0xe0, 0x1b, 0xe9, 0x3c, 0x2c,
+	0x0d, 0xcd, 0x4f, 0xa6, 0xf5, 0xa3, 0xa9, 0x2c, 0x20, 0x80, 0x0c, 0xff, 0xd0, 0x4e, 0x49, 0xa0,
+	0x1c, 0xa4, 0xd9, 0x67, 0x27, 0x4a, 0x92, 0x76, 0xf3, 0x3f, 0x94, 0x28, 0x29, 0xb4, 0x22, 0x15,
+	0xaf, 0x94, 0x45, 0x2a, 0x2e, 0xfe, 0x04, 0xa2, 0xa4, 0xa9, 0x08, 0xfb, 0xbf, 0x87, 0x92, 0x41,
+	0x4b, 0xec, 0x5e, 0x50, 0x96, 0xa8, 0x2c, 0xff, 0xd2, 0x50, 0xc9, 0x0a, 0x9c, 0x61, 0xc7, 0x55,
+	0x72, 0x94, 0xa1, 0xaf, 0x0f, 0x14, 0x40, 0x59, 0x58, 0xa4, 0x0f, 0x5e, 0x4a, 0x9e, 0x62, 0x89,
+	0xcf, 0xaf, 0x95, 0x65, 0xb4, 0x0c, 0x59, 0xef, 0xff, 0x0d, 0xca, 0x0a, 0x6d, 0x79, 0xff, 0x5b,
+	0x50, 0x56, 0xa9, 0x08, 0xfd, 0x2b, 0x82, 0xb2, 0xf6, 0xec, 0xbd, 0xf7, 0x10, 0x1c, 0xfa, 0xe6,
+	0x9c, 0xb2, 0x9b, 0x56, 0x89, 0x1d, 0xe4, 0xca, 0x02, 0x6d, 0xd9, 0x84, 0x4e, 0xa0, 0xe3, 0x2a,
+	0x09, 0x3a, 0x4a, 0x47, 0x77, 0x3a, 0x7a, 0x97, 0x28, 0x49, 0xda, 0x70, 0x88, 0x5b, 0x1f, 0xf6,
+	0x7a, 0x4a, 0xea, 0xd9, 0x3e, 0xdc, 0x91, 0xc0, 0xc2, 0x1f, 0x61, 0x73, 0xe5, 0x58, 0x39, 0x9e,
+	0x23, 0x0e, 0x74, 0xc7, 0xf9, 0xd1, 0xb2, 0xbb, 0x4a, 0x82, 0x4e, 0xab, 0x67, 0x59, 0x9f, 0x86,
+	0x03, 0x25, 0xf9, 0xec, 0xa5, 0xf7, 0xaa, 0xe4, 0xbf, 0x86, 0xa3, 0x35, 0xc8, 0x0f, 0x4d, 0x67,
+	0x40, 0x3a, 0xc6, 0x85, 0x41, 0xba, 0xdc, 0xca, 0x7d, 0xd2, 0xb7, 0xec, 0x6b, 0x25, 0xb1, 0xfb,
+	0xc7, 0x2c, 0x6c, 0x48, 0xd7, 0x04, 0xbd, 0x61, 0x9b, 0xc4, 0xfe, 0x6c, 0x74, 0x08, 0xfa, 0x16,
+	0x72, 0xfe, 0x6b, 0x12, 0xda, 0xf0, 0xfe, 0xc6, 0x11, 0x7e, 0x72, 0x2a, 0xde, 0x1e, 0xe9, 0x17,
+	0x71, 0x6d, 0x0d, 0xb2, 0x5e, 0x7a, 0x82, 0x26, 0xe7, 0xf2, 0xc5, 0x29, 0xd9, 0x0c, 0xda, 0x87,
+	0x25, 0x91, 0xa4, 0xa0, 0x09, 0x39, 0x7d, 0x71, 0x52, 0x3e, 0x83, 0xde, 0x03, 0x04, 0x49, 0x0a,
+	0x9a, 0x9c, 0xd9, 0x17, 0xa7, 0x64, 0x35, 0x1e, 0x18, 0x8f, 0x22, 0xd1, 0xe4, 0xfc, 0xbe, 0x38,
+	0x25, 0xb1, 0xf1, 0xc0, 0x84, 0xe7, 0x4c, 0xce, 0xf2, 0x8b, 0x53, 0x72, 0x1b, 0xf4, 0x97, 0xb0,
+	0x1e, 0xfb, 0x6e, 0x83, 0x54, 0x7f, 0x9d, 0xc6, 0x3e, 0xfa, 0x14, 0x1f, 0x4e, 0xe4, 0x11, 0x23,
+	0x1c, 0x80, 0x52, 0x1a, 0x0c, 0x7a, 0xd7, 0x72, 0xa5, 0x68, 0x3d, 0x36, 0xf3, 0x28, 0x6e, 0xc6,
+	0x76, 0x8b, 0x74, 0xf8, 0x0c, 0x6e, 0x8c, 0x24, 0x45, 0x48, 0x4c, 0x6f, 0x5c, 0xfe, 0x55, 0xbc,
+	0x3f, 0x96, 0xce, 0xb5, 0x7b, 0x9d, 0x40, 0x06, 0x14, 0xc6, 0xd5, 0xd3, 0xd1, 0x23, 0x7f, 0x82,
+	0x93, 0xea, 0xf8, 0xc5, 0xc7, 0xd3, 0xd8, 0xfc, 0x04, 0x74, 0x25, 0x54, 0xd8, 0xf6, 0xbc, 0x33,
+	0xae, 0xe8, 0x5e, 0xdc, 0x8c, 0xa5, 0xf9, 0x3e, 0xb0, 0x1a, 0xae, 0xc0, 0xa2, 0xb0, 0x33, 0x87,
+	0xab, 0xb9, 0xc5, 0xbb, 0xf1, 0x44, 0x01, 0xf6, 0x6b, 0x50, 0xa2, 0xd5, 0x52, 0xd9, 0xad, 0x62,
+	0xea, 0xb2, 0xc5, 0xad, 0x71, 0x64, 0x01, 0x59, 0x87, 0xb5, 0x48, 0xc5, 0x11, 0x09, 0x1d, 0xe2,
+	0x8b, 0xa7, 0xc5, 0x7b, 0x63, 0xa8, 0x1c, 0xef, 0x3c, 0xc3, 0x12, 0xb3, 0xbd, 0xff, 0x1b, 0x00,
+	0xe9, 0xc9, 0xb9, 0xbc, 0x18, 0x3a, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -3309,6 +5403,10 @@ type ConfigstoreMetaServiceClient interface {
 	ApplyTransaction(ctx context.Context, in *MetaTransaction, opts ...grpc.CallOption) (*MetaTransactionResult, error)
 	WatchTransactions(ctx context.Context, in *WatchTransactionsRequest, opts ...grpc.CallOption) (ConfigstoreMetaService_WatchTransactionsClient, error)
 	GetTransactionQueueCount(ctx context.Context, in *GetTransactionQueueCountRequest, opts ...grpc.CallOption) (*GetTransactionQueueCountResponse, error)
+	RestoreToTime(ctx context.Context, in *RestoreToTimeRequest, opts ...grpc.CallOption) (*RestoreToTimeResponse, error)
+	MetaGetHistory(ctx context.Context, in *MetaGetHistoryRequest, opts ...grpc.CallOption) (*MetaGetHistoryResponse, error)
+	MetaGetAtVersion(ctx context.Context, in *MetaGetAtVersionRequest, opts ...grpc.CallOption) (*MetaGetAtVersionResponse, error)
+	FindReferencing(ctx context.Context, in *FindReferencingRequest, opts ...grpc.CallOption) (*FindReferencingResponse, error)
 }
 
 type configstoreMetaServiceClient struct {
@@ -3432,6 +5530,42 @@ func (c *configstoreMetaServiceClient) GetTransactionQueueCount(ctx context.Cont
 	return out, nil
 }
 
+func (c *configstoreMetaServiceClient) RestoreToTime(ctx context.Context, in *RestoreToTimeRequest, opts ...grpc.CallOption) (*RestoreToTimeResponse, error) {
+	out := new(RestoreToTimeResponse)
+	err := c.cc.Invoke(ctx, "/meta.ConfigstoreMetaService/RestoreToTime", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *configstoreMetaServiceClient) MetaGetHistory(ctx context.Context, in *MetaGetHistoryRequest, opts ...grpc.CallOption) (*MetaGetHistoryResponse, error) {
+	out := new(MetaGetHistoryResponse)
+	err := c.cc.Invoke(ctx, "/meta.ConfigstoreMetaService/MetaGetHistory", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *configstoreMetaServiceClient) MetaGetAtVersion(ctx context.Context, in *MetaGetAtVersionRequest, opts ...grpc.CallOption) (*MetaGetAtVersionResponse, error) {
+	out := new(MetaGetAtVersionResponse)
+	err := c.cc.Invoke(ctx, "/meta.ConfigstoreMetaService/MetaGetAtVersion", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *configstoreMetaServiceClient) FindReferencing(ctx context.Context, in *FindReferencingRequest, opts ...grpc.CallOption) (*FindReferencingResponse, error) {
+	out := new(FindReferencingResponse)
+	err := c.cc.Invoke(ctx, "/meta.ConfigstoreMetaService/FindReferencing", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ConfigstoreMetaServiceServer is the server API for ConfigstoreMetaService service.
 type ConfigstoreMetaServiceServer interface {
 	GetSchema(context.Context, *GetSchemaRequest) (*GetSchemaResponse, error)
@@ -3444,6 +5578,10 @@ type ConfigstoreMetaServiceServer interface {
 	ApplyTransaction(context.Context, *MetaTransaction) (*MetaTransactionResult, error)
 	WatchTransactions(*WatchTransactionsRequest, ConfigstoreMetaService_WatchTransactionsServer) error
 	GetTransactionQueueCount(context.Context, *GetTransactionQueueCountRequest) (*GetTransactionQueueCountResponse, error)
+	RestoreToTime(context.Context, *RestoreToTimeRequest) (*RestoreToTimeResponse, error)
+	MetaGetHistory(context.Context, *MetaGetHistoryRequest) (*MetaGetHistoryResponse, error)
+	MetaGetAtVersion(context.Context, *MetaGetAtVersionRequest) (*MetaGetAtVersionResponse, error)
+	FindReferencing(context.Context, *FindReferencingRequest) (*FindReferencingResponse, error)
 }
 
 // UnimplementedConfigstoreMetaServiceServer can be embedded to have forward compatible implementations.
@@ -3480,6 +5618,18 @@ func (*UnimplementedConfigstoreMetaServiceServer) WatchTransactions(req *WatchTr
 func (*UnimplementedConfigstoreMetaServiceServer) GetTransactionQueueCount(ctx context.Context, req *GetTransactionQueueCountRequest) (*GetTransactionQueueCountResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetTransactionQueueCount not implemented")
 }
+func (*UnimplementedConfigstoreMetaServiceServer) RestoreToTime(ctx context.Context, req *RestoreToTimeRequest) (*RestoreToTimeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RestoreToTime not implemented")
+}
+func (*UnimplementedConfigstoreMetaServiceServer) MetaGetHistory(ctx context.Context, req *MetaGetHistoryRequest) (*MetaGetHistoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MetaGetHistory not implemented")
+}
+func (*UnimplementedConfigstoreMetaServiceServer) MetaGetAtVersion(ctx context.Context, req *MetaGetAtVersionRequest) (*MetaGetAtVersionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MetaGetAtVersion not implemented")
+}
+func (*UnimplementedConfigstoreMetaServiceServer) FindReferencing(ctx context.Context, req *FindReferencingRequest) (*FindReferencingResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FindReferencing not implemented")
+}
 
 func RegisterConfigstoreMetaServiceServer(s *grpc.Server, srv ConfigstoreMetaServiceServer) {
 	s.RegisterService(&_ConfigstoreMetaService_serviceDesc, srv)
@@ -3668,6 +5818,78 @@ func _ConfigstoreMetaService_GetTransactionQueueCount_Handler(srv interface{}, c
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ConfigstoreMetaService_RestoreToTime_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreToTimeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ConfigstoreMetaServiceServer).RestoreToTime(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/meta.ConfigstoreMetaService/RestoreToTime",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ConfigstoreMetaServiceServer).RestoreToTime(ctx, req.(*RestoreToTimeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ConfigstoreMetaService_MetaGetHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MetaGetHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ConfigstoreMetaServiceServer).MetaGetHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/meta.ConfigstoreMetaService/MetaGetHistory",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ConfigstoreMetaServiceServer).MetaGetHistory(ctx, req.(*MetaGetHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ConfigstoreMetaService_MetaGetAtVersion_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MetaGetAtVersionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ConfigstoreMetaServiceServer).MetaGetAtVersion(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/meta.ConfigstoreMetaService/MetaGetAtVersion",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ConfigstoreMetaServiceServer).MetaGetAtVersion(ctx, req.(*MetaGetAtVersionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ConfigstoreMetaService_FindReferencing_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FindReferencingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ConfigstoreMetaServiceServer).FindReferencing(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/meta.ConfigstoreMetaService/FindReferencing",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ConfigstoreMetaServiceServer).FindReferencing(ctx, req.(*FindReferencingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _ConfigstoreMetaService_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "meta.ConfigstoreMetaService",
 	HandlerType: (*ConfigstoreMetaServiceServer)(nil),
@@ -3708,6 +5930,22 @@ var _ConfigstoreMetaService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "GetTransactionQueueCount",
 			Handler:    _ConfigstoreMetaService_GetTransactionQueueCount_Handler,
 		},
+		{
+			MethodName: "RestoreToTime",
+			Handler:    _ConfigstoreMetaService_RestoreToTime_Handler,
+		},
+		{
+			MethodName: "MetaGetHistory",
+			Handler:    _ConfigstoreMetaService_MetaGetHistory_Handler,
+		},
+		{
+			MethodName: "MetaGetAtVersion",
+			Handler:    _ConfigstoreMetaService_MetaGetAtVersion_Handler,
+		},
+		{
+			MethodName: "FindReferencing",
+			Handler:    _ConfigstoreMetaService_FindReferencing_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
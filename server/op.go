@@ -1,17 +1,37 @@
 package main
 
 import (
-	"cloud.google.com/go/firestore"
+	"time"
 )
 
 type operationProcessor struct {
-	client *firestore.Client
-	tx     *firestore.Transaction
+	storage       storageBackend
+	tx            storageTransaction
+	transactionID string
+	dateSubmitted time.Time
+	versions      map[string]int64
+	guards        map[string]string
+	updatedGuards map[string]map[string]bool
+	exists        map[string]bool
+	deletePlans   map[string]*referenceDeletePlan
+
+	// entities modified as a side effect of an operation, such as by an
+	// onDelete policy, for the transaction log
+	mutatedKeys []*Key
+	deletedKeys []*Key
+	changes     []interface{}
 }
 
-func createOperationProcessor(client *firestore.Client, tx *firestore.Transaction) *operationProcessor {
+func createOperationProcessor(storage storageBackend, tx storageTransaction, transactionID string, dateSubmitted time.Time) *operationProcessor {
 	return &operationProcessor{
-		client: client,
-		tx:     tx,
+		storage:       storage,
+		tx:            tx,
+		transactionID: transactionID,
+		dateSubmitted: dateSubmitted,
+		versions:      make(map[string]int64),
+		guards:        make(map[string]string),
+		updatedGuards: make(map[string]map[string]bool),
+		exists:        make(map[string]bool),
+		deletePlans:   make(map[string]*referenceDeletePlan),
 	}
 }
@@ -0,0 +1,112 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// checkKeyAncestors returns an InvalidArgument error unless every element
+// of the path of key is nested under a kind it allows as an ancestor, and
+// the path starts at a top-level kind.
+func checkKeyAncestors(schema *Schema, key *Key) error {
+	if key == nil || len(key.Path) == 0 {
+		return nil
+	}
+	for i, pathElement := range key.Path {
+		kindInfo, ok := schema.Kinds[pathElement.Kind]
+		if !ok {
+			return status.Error(codes.InvalidArgument, fmt.Sprintf("key %s has an element of kind '%s', which isn't in the schema", serializeKey(key), pathElement.Kind))
+		}
+		if i < len(key.Path)-1 && pathElement.IdType == nil {
+			return status.Error(codes.InvalidArgument, fmt.Sprintf("key %s has an incomplete ancestor of kind '%s'", serializeKey(key), pathElement.Kind))
+		}
+		if i == 0 {
+			if len(kindInfo.Ancestors) > 0 {
+				return status.Error(codes.InvalidArgument, fmt.Sprintf("entities of kind '%s' must be created under an entity of kind %s", pathElement.Kind, formatKindList(kindInfo.Ancestors)))
+			}
+		} else if !containsString(kindInfo.Ancestors, key.Path[i-1].Kind) {
+			if len(kindInfo.Ancestors) == 0 {
+				return status.Error(codes.InvalidArgument, fmt.Sprintf("entities of kind '%s' are top-level, so they can't be created under an entity of kind '%s'", pathElement.Kind, key.Path[i-1].Kind))
+			}
+			return status.Error(codes.InvalidArgument, fmt.Sprintf("entities of kind '%s' must be created under an entity of kind %s, not '%s'", pathElement.Kind, formatKindList(kindInfo.Ancestors), key.Path[i-1].Kind))
+		}
+	}
+	return nil
+}
+
+// readParentExists loads whether the parent of key exists. It must be
+// called during the read phase of the transaction.
+func (s *operationProcessor) readParentExists(ctx context.Context, key *Key) error {
+	parent := getKeyParent(key)
+	if parent == nil || isKeyIncomplete(parent) || !shouldCheckReferences(ctx) {
+		return nil
+	}
+	return s.readEntityExists(parent)
+}
+
+// checkParentExists returns a FailedPrecondition error if the parent of key
+// doesn't exist, including if it was deleted earlier in the transaction.
+func (s *operationProcessor) checkParentExists(ctx context.Context, key *Key) error {
+	parent := getKeyParent(key)
+	if parent == nil || !shouldCheckReferences(ctx) {
+		return nil
+	}
+	if exists, ok := s.exists[serializeKey(normalizeEntityKey(s.storage, parent))]; !ok || !exists {
+		return status.Error(codes.FailedPrecondition, fmt.Sprintf("parent %s of the entity does not exist", serializeKey(parent)))
+	}
+	return nil
+}
+
+// checkSchemaAncestors returns an error if a kind allows an ancestor that
+// isn't in the schema, or if there's no path to a kind from a top-level kind.
+func checkSchemaAncestors(schema *Schema) error {
+	for kindName, kind := range schema.Kinds {
+		for _, ancestor := range kind.Ancestors {
+			if _, ok := schema.Kinds[ancestor]; !ok {
+				return fmt.Errorf("kind '%s' has ancestor '%s', which isn't in the schema", kindName, ancestor)
+			}
+		}
+	}
+
+	reachable := make(map[string]bool)
+	for kindName, kind := range schema.Kinds {
+		if len(kind.Ancestors) == 0 {
+			reachable[kindName] = true
+		}
+	}
+	for changed := true; changed; {
+		changed = false
+		for kindName, kind := range schema.Kinds {
+			if reachable[kindName] {
+				continue
+			}
+			for _, ancestor := range kind.Ancestors {
+				if reachable[ancestor] {
+					reachable[kindName] = true
+					changed = true
+					break
+				}
+			}
+		}
+	}
+	for kindName := range schema.Kinds {
+		if !reachable[kindName] {
+			return fmt.Errorf("entities of kind '%s' can't be created, because none of its ancestors lead back to a top-level kind", kindName)
+		}
+	}
+	return nil
+}
+
+func formatKindList(kindNames []string) string {
+	result := ""
+	for i, kindName := range kindNames {
+		if i > 0 {
+			result += " or "
+		}
+		result += fmt.Sprintf("'%s'", kindName)
+	}
+	return result
+}
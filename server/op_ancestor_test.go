@@ -0,0 +1,100 @@
+package main
+
+import (
+	"context"
+
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"gotest.tools/assert"
+)
+
+func listAncestorTestEntities(t *testing.T, processor *transactionProcessor, schema *Schema, ancestor *Key, start []byte, limit uint32) *MetaListEntitiesResponse {
+	resp, err := processor.processTransaction(context.Background(), schema, &MetaTransaction{
+		Operations: []*MetaOperation{
+			&MetaOperation{
+				Operation: &MetaOperation_ListRequest{
+					ListRequest: &MetaListEntitiesRequest{
+						KindName: "ProjectEnvironment",
+						Ancestor: ancestor,
+						Start:    start,
+						Limit:    limit,
+					},
+				},
+			},
+		},
+	})
+	assert.NilError(t, err)
+	assert.Equal(t, getOperationResultCode(resp.OperationResults[0]), codes.OK)
+	return resp.OperationResults[0].GetListResponse()
+}
+
+func TestAncestors(t *testing.T) {
+	ctx := context.Background()
+
+	genResult, err := generate("./schema.json")
+	assert.NilError(t, err)
+
+	storage := createMemoryStorage()
+	defer storage.Close()
+	processor := createTransactionProcessor(storage)
+
+	p1 := createTestKey("Project", "p1")
+	p2 := createTestKey("Project", "p2")
+	resp, err := processor.processTransaction(ctx, genResult.Schema, &MetaTransaction{
+		Operations: []*MetaOperation{
+			createTestOperation(createTestKey("Project", "p1")),
+			createTestOperation(createTestKey("Project", "p2")),
+			createTestOperation(createChildTestKey(p1, "ProjectEnvironment", "production")),
+			createTestOperation(createChildTestKey(p1, "ProjectEnvironment", "staging")),
+			createTestOperation(createChildTestKey(p2, "ProjectEnvironment", "production")),
+		},
+	})
+	assert.NilError(t, err)
+	for _, result := range resp.OperationResults {
+		assert.Equal(t, getOperationResultCode(result), codes.OK)
+	}
+
+	resp, err = processor.processTransaction(ctx, genResult.Schema, &MetaTransaction{
+		Operations: []*MetaOperation{
+			createTestOperation(createChildTestKey(nil, "ProjectEnvironment", "top")),
+			createTestOperation(createChildTestKey(createTestKey("User", "u1"), "ProjectEnvironment", "user")),
+			createTestOperation(createChildTestKey(createTestKey("Project", "missing"), "ProjectEnvironment", "missing")),
+		},
+	})
+	assert.NilError(t, err)
+	assert.Equal(t, getOperationResultCode(resp.OperationResults[0]), codes.InvalidArgument)
+	assert.Equal(t, getOperationResultCode(resp.OperationResults[1]), codes.InvalidArgument)
+	assert.Equal(t, getOperationResultCode(resp.OperationResults[2]), codes.FailedPrecondition)
+
+	// updates of entities that don't exist create them, so they're checked
+	// the same way
+	resp, err = processor.processTransaction(ctx, genResult.Schema, &MetaTransaction{
+		Operations: []*MetaOperation{
+			updateTestOperation(createChildTestKey(nil, "ProjectEnvironment", "top")),
+			updateTestOperation(createChildTestKey(createTestKey("User", "u1"), "ProjectEnvironment", "user")),
+			updateTestOperation(createChildTestKey(createTestKey("Project", "missing"), "ProjectEnvironment", "missing")),
+			updateTestOperation(createChildTestKey(p2, "ProjectEnvironment", "staging")),
+		},
+	})
+	assert.NilError(t, err)
+	assert.Equal(t, getOperationResultCode(resp.OperationResults[0]), codes.InvalidArgument)
+	assert.Equal(t, getOperationResultCode(resp.OperationResults[1]), codes.InvalidArgument)
+	assert.Equal(t, getOperationResultCode(resp.OperationResults[2]), codes.FailedPrecondition)
+	assert.Equal(t, getOperationResultCode(resp.OperationResults[3]), codes.OK)
+
+	list := listAncestorTestEntities(t, processor, genResult.Schema, p1, nil, 0)
+	assert.Equal(t, len(list.Entities), 2)
+	assert.Equal(t, getKeyIDString(list.Entities[0].Key), "production")
+	assert.Equal(t, getKeyIDString(list.Entities[1].Key), "staging")
+
+	// without an ancestor, entities under every parent are listed, and
+	// entities with the same name under different parents are paged through
+	list = listAncestorTestEntities(t, processor, genResult.Schema, nil, nil, 2)
+	assert.Equal(t, len(list.Entities), 2)
+	assert.Equal(t, list.MoreResults, true)
+	list = listAncestorTestEntities(t, processor, genResult.Schema, nil, list.Next, 2)
+	assert.Equal(t, len(list.Entities), 2)
+	assert.Equal(t, getKeyIDString(getKeyParent(list.Entities[0].Key)), "p2")
+	assert.Equal(t, getKeyIDString(getKeyParent(list.Entities[1].Key)), "p2")
+}
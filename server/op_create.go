@@ -2,9 +2,42 @@ package main
 
 import (
 	"context"
+	"fmt"
 )
 
 func (s *operationProcessor) operationCreateRead(ctx context.Context, schema *Schema, req *MetaCreateEntityRequest) (interface{}, error) {
+	if req != nil && req.Entity != nil && req.Entity.Key != nil && !isKeyIncomplete(req.Entity.Key) {
+		// the entity may have existed before, in which case its history
+		// continues from the last version
+		err := s.readEntityVersion(req.Entity.Key)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if req != nil && req.Entity != nil {
+		err := s.readParentExists(ctx, req.Entity.Key)
+		if err != nil {
+			return nil, err
+		}
+
+		kindInfo, err := findSchemaKindByName(schema, req.KindName)
+		if err != nil {
+			// reported by the write
+			return nil, nil
+		}
+		data := convertMetaValuesToDataMap(schema, kindInfo.Fields, req.Entity.Values)
+		applySchemaDefaults(kindInfo, data)
+		err = s.readUniqueGuards(getKeyNamespace(req.Entity.Key), req.KindName, kindInfo, data)
+		if err != nil {
+			return nil, err
+		}
+		err = s.readReferenceTargets(ctx, schema, kindInfo, data)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	return nil, nil
 }
 
@@ -16,44 +49,83 @@ func (s *operationProcessor) operationCreateWrite(ctx context.Context, schema *S
 
 	if req.Entity.Key == nil {
 		// we need to automatically generate a key for this entity
-		firestoreCollection := s.client.Collection(req.KindName)
-
-		newKey, err := convertDocumentRefToMetaKey(
-			firestoreCollection.NewDoc(),
+		req.Entity.Key = s.storage.NewKey("", nil, req.KindName)
+	} else if isKeyIncomplete(req.Entity.Key) {
+		req.Entity.Key = s.storage.NewKey(
+			getKeyNamespace(req.Entity.Key),
+			getKeyParent(req.Entity.Key),
+			getKeyKindName(req.Entity.Key),
 		)
-		if err != nil {
-			return nil, err
+	}
+	if req.Entity.Key == nil {
+		return nil, fmt.Errorf("unable to generate key for new entity")
+	}
+	if req.Entity.Key.PartitionId != nil && req.Entity.Key.PartitionId.Namespace == "" {
+		req.Entity.Key.PartitionId = &PartitionId{
+			Namespace: s.storage.DefaultNamespace(),
 		}
+	}
 
-		req.Entity.Key = newKey
+	err = checkKeyAncestors(schema, req.Entity.Key)
+	if err != nil {
+		return nil, err
 	}
 
-	ref, data, err := convertMetaEntityToRefAndDataMap(
-		s.client,
+	err = s.checkParentExists(ctx, req.Entity.Key)
+	if err != nil {
+		return nil, err
+	}
+
+	key, data, err := convertMetaEntityToKeyAndDataMap(
 		req.Entity,
+		schema,
 		kindInfo,
 	)
 	if err != nil {
 		return nil, err
 	}
 
-	if ref.ID == "" {
-		ref = ref.Parent.NewDoc()
+	if applySchemaDefaults(kindInfo, data) {
+		// respond with the defaults that were filled in
+		req.Entity, err = convertSnapshotToMetaEntity(schema, kindInfo, &storageSnapshot{
+			Key:  key,
+			Data: data,
+		})
+		if err != nil {
+			return nil, err
+		}
 	}
-	err = s.tx.Create(ref, data)
+
+	err = validateEntityData(ctx, schema, req.KindName, kindInfo, data)
 	if err != nil {
 		return nil, err
 	}
 
-	key, err := convertDocumentRefToMetaKey(
-		ref,
-	)
+	err = s.checkReferences(ctx, schema, req.KindName, kindInfo, data)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.checkUniqueValues(ctx, key, req.KindName, kindInfo, data)
 	if err != nil {
 		return nil, err
 	}
 
-	// set the key
-	req.Entity.Key = key
+	req.Entity.Version, err = s.appendEntityVersion(ctx, key, data)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.tx.Create(key, data)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.updateUniqueGuards(key, req.KindName, kindInfo, nil, data)
+	if err != nil {
+		return nil, err
+	}
+	s.setEntityExists(key, true)
 
 	return &MetaCreateEntityResponse{
 		Entity: req.Entity,
@@ -2,20 +2,28 @@ package main
 
 import (
 	"context"
-
-	"cloud.google.com/go/firestore"
 )
 
 func (s *operationProcessor) operationDeleteRead(ctx context.Context, schema *Schema, req *MetaDeleteEntityRequest) (interface{}, error) {
-	ref, err := convertMetaKeyToDocumentRef(
-		s.client,
-		req.Key,
-	)
+	snapshot, err := s.tx.Get(req.Key)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.readEntityVersion(req.Key)
 	if err != nil {
 		return nil, err
 	}
 
-	snapshot, err := s.tx.Get(ref)
+	kindInfo, err := findSchemaKindByName(schema, req.KindName)
+	if err == nil {
+		err = s.readUniqueGuards(getKeyNamespace(req.Key), req.KindName, kindInfo, snapshot.Data)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err = s.readReferenceDeletePlan(ctx, schema, snapshot.Key)
 	if err != nil {
 		return nil, err
 	}
@@ -29,22 +37,36 @@ func (s *operationProcessor) operationDeleteWrite(ctx context.Context, schema *S
 		return nil, err
 	}
 
-	ref, err := convertMetaKeyToDocumentRef(
-		s.client,
-		req.Key,
-	)
+	snapshot := readState.(*storageSnapshot)
+
+	entity, err := convertSnapshotToMetaEntity(schema, kindInfo, snapshot)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.checkEntityVersion(snapshot.Key, req.ExpectedVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	s.setEntityExists(snapshot.Key, false)
+	err = s.applyReferenceDeletePlan(ctx, snapshot.Key)
 	if err != nil {
+		s.setEntityExists(snapshot.Key, true)
 		return nil, err
 	}
 
-	snapshot := readState.(*firestore.DocumentSnapshot)
+	err = s.tx.Delete(snapshot.Key)
+	if err != nil {
+		return nil, err
+	}
 
-	entity, err := convertSnapshotToMetaEntity(kindInfo, snapshot)
+	_, err = s.appendEntityVersion(ctx, snapshot.Key, nil)
 	if err != nil {
 		return nil, err
 	}
 
-	err = s.tx.Delete(ref)
+	err = s.updateUniqueGuards(snapshot.Key, req.KindName, kindInfo, snapshot.Data, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -10,20 +10,12 @@ func (s *operationProcessor) operationGetRead(ctx context.Context, schema *Schem
 		return nil, err
 	}
 
-	ref, err := convertMetaKeyToDocumentRef(
-		s.client,
-		req.Key,
-	)
+	snapshot, err := s.tx.Get(req.Key)
 	if err != nil {
 		return nil, err
 	}
 
-	snapshot, err := s.tx.Get(ref)
-	if err != nil {
-		return nil, err
-	}
-
-	entity, err := convertSnapshotToMetaEntity(kindInfo, snapshot)
+	entity, err := convertSnapshotToMetaEntity(schema, kindInfo, snapshot)
 	if err != nil {
 		return nil, err
 	}
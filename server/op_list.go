@@ -2,13 +2,13 @@ package main
 
 import (
 	"context"
-	"fmt"
 
-	"cloud.google.com/go/firestore"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 func (s *operationProcessor) operationListRead(ctx context.Context, schema *Schema, req *MetaListEntitiesRequest) (interface{}, error) {
-	var start interface{}
+	var start string
 	if req.Start != nil {
 		if len(req.Start[:]) > 0 {
 			start = string(req.Start[:])
@@ -20,41 +20,34 @@ func (s *operationProcessor) operationListRead(ctx context.Context, schema *Sche
 		return nil, err
 	}
 
-	var snapshots []*firestore.DocumentSnapshot
-	if runWithoutFirestoreTransactionalQueries() {
-		if (req.Limit == 0) && start == nil {
-			snapshots, err = s.client.Collection(req.KindName).Documents(ctx).GetAll()
-		} else if req.Limit == 0 {
-			snapshots, err = s.client.Collection(req.KindName).OrderBy(firestore.DocumentID, firestore.Asc).StartAfter(start.(string)).Documents(ctx).GetAll()
-		} else if start == nil {
-			snapshots, err = s.client.Collection(req.KindName).Limit(int(req.Limit)).Documents(ctx).GetAll()
-		} else {
-			snapshots, err = s.client.Collection(req.KindName).OrderBy(firestore.DocumentID, firestore.Asc).StartAfter(start.(string)).Limit(int(req.Limit)).Documents(ctx).GetAll()
-		}
-	} else {
-		if (req.Limit == 0) && start == nil {
-			snapshots, err = s.tx.Documents(s.client.Collection(req.KindName)).GetAll()
-		} else if req.Limit == 0 {
-			snapshots, err = s.tx.Documents(s.client.Collection(req.KindName).OrderBy(firestore.DocumentID, firestore.Asc).StartAfter(start.(string))).GetAll()
-		} else if start == nil {
-			snapshots, err = s.tx.Documents(s.client.Collection(req.KindName).Limit(int(req.Limit))).GetAll()
-		} else {
-			snapshots, err = s.tx.Documents(s.client.Collection(req.KindName).OrderBy(firestore.DocumentID, firestore.Asc).StartAfter(start.(string)).Limit(int(req.Limit))).GetAll()
+	var namespace string
+	if req.PartitionId != nil {
+		namespace = req.PartitionId.Namespace
+	}
+
+	query := &storageQuery{
+		Namespace:      namespace,
+		KindName:       req.KindName,
+		AllDescendants: len(kindInfo.Ancestors) > 0,
+		StartAfter:     start,
+		Limit:          int(req.Limit),
+	}
+	if req.Ancestor != nil {
+		if isKeyIncomplete(req.Ancestor) {
+			return nil, status.Error(codes.InvalidArgument, "ancestor must be a complete key")
 		}
+		query.Parent = req.Ancestor
+		query.AllDescendants = true
 	}
 
+	snapshots, err := s.tx.Query(query)
 	if err != nil {
 		return nil, err
 	}
 
 	var entities []*MetaEntity
 	for _, snapshot := range snapshots {
-		entity, err := convertSnapshotToMetaEntity(kindInfo, snapshot)
-		if err != nil {
-			fmt.Printf("%v", err)
-			continue
-		}
-		entities = append(entities, entity)
+		entities = append(entities, convertSnapshotToReadableMetaEntity(schema, kindInfo, snapshot))
 	}
 
 	response := &MetaListEntitiesResponse{
@@ -68,7 +61,7 @@ func (s *operationProcessor) operationListRead(ctx context.Context, schema *Sche
 			// TODO: query to see if there really are more results, to make this behave like datastore
 			response.MoreResults = true
 			last := snapshots[len(snapshots)-1]
-			response.Next = []byte(last.Ref.ID)
+			response.Next = []byte(last.Cursor)
 		}
 	} else {
 		response.MoreResults = false
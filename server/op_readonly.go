@@ -0,0 +1,121 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"google.golang.org/genproto/googleapis/type/latlng"
+	"google.golang.org/grpc/codes"
+)
+
+// checkReadonlyFields returns a PermissionDenied error naming every
+// readonly field whose value in data differs from the stored entity.
+// Readonly fields can be set freely when the entity is first created, or
+// by callers with the readonly override scope.
+func checkReadonlyFields(ctx context.Context, kindName string, kindInfo *SchemaKind, stored *storageSnapshot, data map[string]interface{}) error {
+	if stored == nil || canOverrideReadonly(ctx) {
+		return nil
+	}
+
+	var violations []*MetaFieldViolation
+	var names []string
+	for _, field := range kindInfo.Fields {
+		if !field.Readonly {
+			continue
+		}
+		if !storageValuesEqual(stored.Data[field.Name], data[field.Name]) {
+			violations = append(violations, &MetaFieldViolation{
+				KindName:    kindName,
+				FieldName:   field.Name,
+				Description: "field is readonly and can't be modified",
+			})
+			names = append(names, field.Name)
+		}
+	}
+	if len(violations) > 0 {
+		return createFieldViolationError(
+			codes.PermissionDenied,
+			fmt.Sprintf("readonly fields can't be modified: %s", strings.Join(names, ", ")),
+			violations,
+		)
+	}
+	return nil
+}
+
+// storageValuesEqual compares two values from storage data maps, treating
+// unset values as equal to the zero value of the field type.
+func storageValuesEqual(a interface{}, b interface{}) bool {
+	if isZeroStorageValue(a) || isZeroStorageValue(b) {
+		return isZeroStorageValue(a) && isZeroStorageValue(b)
+	}
+	switch av := a.(type) {
+	case time.Time:
+		bv, ok := b.(time.Time)
+		return ok && av.Equal(bv)
+	case []byte:
+		bv, ok := b.([]byte)
+		return ok && bytes.Equal(av, bv)
+	case *Key:
+		bv, ok := b.(*Key)
+		return ok && proto.Equal(av, bv)
+	case *latlng.LatLng:
+		bv, ok := b.(*latlng.LatLng)
+		return ok && proto.Equal(av, bv)
+	case []interface{}:
+		bv, ok := b.([]interface{})
+		if !ok || len(av) != len(bv) {
+			return false
+		}
+		for i := range av {
+			if !storageValuesEqual(av[i], bv[i]) {
+				return false
+			}
+		}
+		return true
+	case map[string]interface{}:
+		bv, ok := b.(map[string]interface{})
+		if !ok || len(av) != len(bv) {
+			return false
+		}
+		for name, value := range av {
+			if !storageValuesEqual(value, bv[name]) {
+				return false
+			}
+		}
+		return true
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+func isZeroStorageValue(value interface{}) bool {
+	switch v := value.(type) {
+	case nil:
+		return true
+	case float64:
+		return v == 0
+	case int64:
+		return v == 0
+	case string:
+		return v == ""
+	case bool:
+		return !v
+	case []byte:
+		return len(v) == 0
+	case time.Time:
+		return v.IsZero()
+	case *Key:
+		return v == nil
+	case *latlng.LatLng:
+		return v == nil
+	case []interface{}:
+		return len(v) == 0
+	case map[string]interface{}:
+		return len(v) == 0
+	}
+	return false
+}
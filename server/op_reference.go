@@ -0,0 +1,451 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// contextSkipReferenceChecksKey is set when entities are written in an order
+// that doesn't follow their references, such as during an import, so that
+// references to entities that haven't been written yet are accepted.
+const contextSkipReferenceChecksKey c = "skipReferenceChecks"
+
+// isReferenceField returns whether field is a key field that refers to
+// entities of the kinds in its allowedKinds.
+func isReferenceField(field *SchemaField) bool {
+	return field.Type == ValueType_key && field.Editor != nil && len(field.Editor.AllowedKinds) > 0
+}
+
+func shouldCheckReferences(ctx context.Context) bool {
+	if skip, ok := ctx.Value(contextSkipValidationKey).(bool); ok && skip {
+		return false
+	}
+	if skip, ok := ctx.Value(contextSkipReferenceChecksKey).(bool); ok && skip {
+		return false
+	}
+	return true
+}
+
+type referenceDeleteEntry struct {
+	snapshot *storageSnapshot
+	kindName string
+	kindInfo *SchemaKind
+	field    *SchemaField
+	target   *Key
+}
+
+// referenceDeletePlan is what has to happen to the entities that refer to
+// an entity when it's deleted. It's worked out during the read phase of the
+// transaction, since the cascaded deletes can't be read after writing.
+type referenceDeletePlan struct {
+	restricted []*referenceDeleteEntry
+	cascade    []*referenceDeleteEntry
+	setNull    []*referenceDeleteEntry
+}
+
+// readReferenceTargets loads whether the entities referred to by data
+// exist. It must be called during the read phase of the transaction.
+func (s *operationProcessor) readReferenceTargets(ctx context.Context, schema *Schema, kindInfo *SchemaKind, data map[string]interface{}) error {
+	if !shouldCheckReferences(ctx) {
+		return nil
+	}
+	var targets []*Key
+	for _, field := range kindInfo.Fields {
+		forEachFieldKey(schema, field, data[field.Name], func(keyField *SchemaField, target *Key) {
+			if isReferenceField(keyField) && !isKeyIncomplete(target) {
+				targets = append(targets, target)
+			}
+		})
+	}
+	for _, target := range targets {
+		err := s.readEntityExists(target)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// forEachFieldKey calls f with every key in value, the stored value of
+// field, along with the key field that holds it. The elements of repeated
+// fields, the entries of map fields and the fields of struct values are
+// walked, so keys nested in them are included.
+func forEachFieldKey(schema *Schema, field *SchemaField, value interface{}, f func(keyField *SchemaField, key *Key)) {
+	if field.Type == ValueType_map {
+		entries, _ := value.(map[string]interface{})
+		var names []string
+		for name := range entries {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		valueField := getMapValueField(field)
+		for _, name := range names {
+			forEachElementKey(schema, valueField, entries[name], f)
+		}
+		return
+	}
+	if !field.Repeated {
+		forEachElementKey(schema, field, value, f)
+		return
+	}
+	elements, _ := value.([]interface{})
+	for _, element := range elements {
+		forEachElementKey(schema, field, element, f)
+	}
+}
+
+func forEachElementKey(schema *Schema, field *SchemaField, value interface{}, f func(keyField *SchemaField, key *Key)) {
+	switch field.Type {
+	case ValueType_key:
+		if key, ok := value.(*Key); ok && key != nil {
+			f(field, key)
+		}
+	case ValueType_struct:
+		structInfo, ok := schema.Structs[field.StructName]
+		data, isData := value.(map[string]interface{})
+		if !ok || !isData {
+			return
+		}
+		for _, structField := range structInfo.Fields {
+			forEachFieldKey(schema, structField, data[structField.Name], f)
+		}
+	}
+}
+
+func (s *operationProcessor) readEntityExists(key *Key) error {
+	id := serializeKey(normalizeEntityKey(s.storage, key))
+	if _, ok := s.exists[id]; ok {
+		return nil
+	}
+	_, err := s.tx.Get(key)
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			s.exists[id] = false
+			return nil
+		}
+		return err
+	}
+	s.exists[id] = true
+	return nil
+}
+
+// setEntityExists records that an entity was written or deleted earlier in
+// the transaction, so that later operations can refer to it or not.
+func (s *operationProcessor) setEntityExists(key *Key, exists bool) {
+	s.exists[serializeKey(normalizeEntityKey(s.storage, key))] = exists
+}
+
+// checkReferences returns an InvalidArgument error naming every reference
+// field in data that refers to an entity of a kind that isn't allowed, or
+// to an entity that doesn't exist.
+func (s *operationProcessor) checkReferences(ctx context.Context, schema *Schema, kindName string, kindInfo *SchemaKind, data map[string]interface{}) error {
+	if !shouldCheckReferences(ctx) {
+		return nil
+	}
+
+	var violations []*MetaFieldViolation
+	var descriptions []string
+	for _, field := range kindInfo.Fields {
+		// keys nested in struct and map values are reported against the
+		// field of the kind that holds them
+		description := ""
+		forEachFieldKey(schema, field, data[field.Name], func(keyField *SchemaField, target *Key) {
+			if description != "" || !isReferenceField(keyField) {
+				return
+			}
+			if !containsString(keyField.Editor.AllowedKinds, getKeyKindName(target)) {
+				description = fmt.Sprintf("must refer to an entity of kind %s", strings.Join(keyField.Editor.AllowedKinds, " or "))
+			} else if exists, ok := s.exists[serializeKey(normalizeEntityKey(s.storage, target))]; !ok || !exists {
+				description = fmt.Sprintf("refers to %s, which does not exist", serializeKey(target))
+			}
+		})
+		if description != "" {
+			violations = append(violations, &MetaFieldViolation{
+				KindName:    kindName,
+				FieldName:   field.Name,
+				Description: description,
+			})
+			descriptions = append(descriptions, fmt.Sprintf("%s: %s", field.Name, description))
+		}
+	}
+	if len(violations) > 0 {
+		return createFieldViolationError(
+			codes.InvalidArgument,
+			fmt.Sprintf("entity of kind '%s' has invalid references: %s", kindName, strings.Join(descriptions, "; ")),
+			violations,
+		)
+	}
+	return nil
+}
+
+// readReferenceDeletePlan finds the entities that refer to the entity being
+// deleted, and the entities that refer to those that will be deleted in
+// turn, and loads everything needed to carry out their onDelete policies.
+// It must be called during the read phase of the transaction.
+func (s *operationProcessor) readReferenceDeletePlan(ctx context.Context, schema *Schema, key *Key) error {
+	if !shouldCheckReferences(ctx) {
+		return nil
+	}
+
+	var kindNames []string
+	for kindName := range schema.Kinds {
+		kindNames = append(kindNames, kindName)
+	}
+	sort.Strings(kindNames)
+
+	root := serializeKey(normalizeEntityKey(s.storage, key))
+	plan := &referenceDeletePlan{}
+	visited := map[string]bool{root: true}
+	queue := []*Key{key}
+	for len(queue) > 0 {
+		target := queue[0]
+		queue = queue[1:]
+		for _, kindName := range kindNames {
+			kindInfo := schema.Kinds[kindName]
+			for _, field := range kindInfo.Fields {
+				if !isReferenceField(field) || field.OnDelete == SchemaFieldOnDelete_noAction || !containsString(field.Editor.AllowedKinds, getKeyKindName(target)) {
+					continue
+				}
+				op := "=="
+				if field.Repeated {
+					op = "array-contains"
+				}
+				referencing, err := s.tx.Query(&storageQuery{
+					Namespace:      getKeyNamespace(target),
+					KindName:       kindName,
+					AllDescendants: len(kindInfo.Ancestors) > 0,
+					Filters: []storageFilter{
+						{
+							Field: field.Name,
+							Op:    op,
+							Value: normalizeEntityKey(s.storage, target),
+						},
+					},
+				})
+				if err != nil {
+					return err
+				}
+				for _, snapshot := range referencing {
+					entry := &referenceDeleteEntry{
+						snapshot: snapshot,
+						kindName: kindName,
+						kindInfo: kindInfo,
+						field:    field,
+						target:   target,
+					}
+					switch field.OnDelete {
+					case SchemaFieldOnDelete_restrict:
+						plan.restricted = append(plan.restricted, entry)
+					case SchemaFieldOnDelete_cascade:
+						id := serializeKey(normalizeEntityKey(s.storage, snapshot.Key))
+						if visited[id] {
+							continue
+						}
+						visited[id] = true
+						err = s.readEntityVersion(snapshot.Key)
+						if err != nil {
+							return err
+						}
+						err = s.readUniqueGuards(getKeyNamespace(snapshot.Key), kindName, kindInfo, snapshot.Data)
+						if err != nil {
+							return err
+						}
+						plan.cascade = append(plan.cascade, entry)
+						queue = append(queue, snapshot.Key)
+					case SchemaFieldOnDelete_setNull:
+						err = s.readEntityVersion(snapshot.Key)
+						if err != nil {
+							return err
+						}
+						plan.setNull = append(plan.setNull, entry)
+					}
+				}
+			}
+		}
+	}
+	s.deletePlans[root] = plan
+	return nil
+}
+
+// applyReferenceDeletePlan carries out the onDelete policies of the entities
+// that refer to the deleted entity. It returns a FailedPrecondition error if
+// any of them restrict the delete.
+func (s *operationProcessor) applyReferenceDeletePlan(ctx context.Context, key *Key) error {
+	plan, ok := s.deletePlans[serializeKey(normalizeEntityKey(s.storage, key))]
+	if !ok {
+		return nil
+	}
+
+	cascaded := make(map[string]bool)
+	for _, entry := range plan.cascade {
+		cascaded[serializeKey(normalizeEntityKey(s.storage, entry.snapshot.Key))] = true
+	}
+
+	var violations []*MetaFieldViolation
+	var descriptions []string
+	for _, entry := range plan.restricted {
+		id := serializeKey(normalizeEntityKey(s.storage, entry.snapshot.Key))
+		if exists, ok := s.exists[id]; (ok && !exists) || cascaded[id] {
+			// deleted earlier in this transaction, or about to be
+			continue
+		}
+		description := fmt.Sprintf("%s refers to this entity and restricts it from being deleted", id)
+		violations = append(violations, &MetaFieldViolation{
+			KindName:    entry.kindName,
+			FieldName:   entry.field.Name,
+			Description: description,
+		})
+		descriptions = append(descriptions, description)
+	}
+	if len(violations) > 0 {
+		return createFieldViolationError(
+			codes.FailedPrecondition,
+			fmt.Sprintf("entity %s can't be deleted while other entities refer to it: %s", serializeKey(key), strings.Join(descriptions, "; ")),
+			violations,
+		)
+	}
+
+	for _, entry := range plan.cascade {
+		id := serializeKey(normalizeEntityKey(s.storage, entry.snapshot.Key))
+		if exists, ok := s.exists[id]; ok && !exists {
+			continue
+		}
+		err := s.tx.Delete(entry.snapshot.Key)
+		if err != nil {
+			return err
+		}
+		_, err = s.appendEntityVersion(ctx, entry.snapshot.Key, nil)
+		if err != nil {
+			return err
+		}
+		err = s.updateUniqueGuards(entry.snapshot.Key, entry.kindName, entry.kindInfo, entry.snapshot.Data, nil)
+		if err != nil {
+			return err
+		}
+		s.setEntityExists(entry.snapshot.Key, false)
+		s.deletedKeys = append(s.deletedKeys, entry.snapshot.Key)
+		s.changes = append(s.changes, map[string]interface{}{
+			"key":    entry.snapshot.Key,
+			"before": entry.snapshot.Data,
+			"after":  nil,
+		})
+	}
+
+	// an entity can refer to the deleted entity from more than one field
+	var setNullOrder []string
+	setNullEntries := make(map[string][]*referenceDeleteEntry)
+	for _, entry := range plan.setNull {
+		id := serializeKey(normalizeEntityKey(s.storage, entry.snapshot.Key))
+		if exists, ok := s.exists[id]; (ok && !exists) || cascaded[id] {
+			continue
+		}
+		if _, ok := setNullEntries[id]; !ok {
+			setNullOrder = append(setNullOrder, id)
+		}
+		setNullEntries[id] = append(setNullEntries[id], entry)
+	}
+	for _, id := range setNullOrder {
+		entries := setNullEntries[id]
+		snapshot := entries[0].snapshot
+		data := make(map[string]interface{})
+		for name, value := range snapshot.Data {
+			data[name] = value
+		}
+		for _, entry := range entries {
+			if entry.field.Repeated {
+				data[entry.field.Name] = s.removeReferenceTarget(data[entry.field.Name], entry.target)
+			} else {
+				data[entry.field.Name] = nil
+			}
+		}
+		u, err := uuid.NewRandom()
+		if err != nil {
+			return err
+		}
+		data["_forceFirestoreSnapshotGeneration"] = u.String()
+
+		_, err = s.appendEntityVersion(ctx, snapshot.Key, data)
+		if err != nil {
+			return err
+		}
+		err = s.tx.Set(snapshot.Key, data)
+		if err != nil {
+			return err
+		}
+		s.mutatedKeys = append(s.mutatedKeys, snapshot.Key)
+		s.changes = append(s.changes, map[string]interface{}{
+			"key":    snapshot.Key,
+			"before": snapshot.Data,
+			"after":  data,
+		})
+	}
+
+	return nil
+}
+
+// removeReferenceTarget returns the elements of a repeated reference field
+// that don't refer to target.
+func (s *operationProcessor) removeReferenceTarget(value interface{}, target *Key) []interface{} {
+	id := serializeKey(normalizeEntityKey(s.storage, target))
+	elements, _ := value.([]interface{})
+	remaining := make([]interface{}, 0, len(elements))
+	for _, element := range elements {
+		if key, ok := element.(*Key); ok && key != nil && serializeKey(normalizeEntityKey(s.storage, key)) == id {
+			continue
+		}
+		remaining = append(remaining, element)
+	}
+	return remaining
+}
+
+// checkSchemaReferences returns an error if an onDelete policy is set on a
+// field that isn't a reference, or can't be carried out. Policies are only
+// carried out for the fields of kinds, so the fields of structs can't have
+// one.
+func checkSchemaReferences(schema *Schema) error {
+	for structName, structInfo := range schema.Structs {
+		for _, field := range structInfo.Fields {
+			if field.OnDelete != SchemaFieldOnDelete_noAction {
+				return fmt.Errorf("field '%s' of struct '%s' has an onDelete policy, but only the fields of kinds can have one", field.Name, structName)
+			}
+		}
+	}
+	for kindName, kind := range schema.Kinds {
+		for _, field := range kind.Fields {
+			if field.OnDelete == SchemaFieldOnDelete_noAction {
+				continue
+			}
+			if !isReferenceField(field) {
+				return fmt.Errorf("field '%s' of kind '%s' has an onDelete policy, but only key fields with allowedKinds can have one", field.Name, kindName)
+			}
+			for _, allowedKind := range field.Editor.AllowedKinds {
+				if _, ok := schema.Kinds[allowedKind]; !ok {
+					return fmt.Errorf("field '%s' of kind '%s' allows references to kind '%s', which isn't in the schema", field.Name, kindName, allowedKind)
+				}
+			}
+			if field.OnDelete == SchemaFieldOnDelete_setNull {
+				for _, validator := range field.Editor.Validators {
+					if validator.GetRequired() != nil {
+						return fmt.Errorf("field '%s' of kind '%s' is required, so its onDelete policy can't be setNull", field.Name, kindName)
+					}
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,259 @@
+package main
+
+import (
+	"context"
+
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"gotest.tools/assert"
+)
+
+func createReferenceTestValue(id int32, kindName string, name string) *Value {
+	return &Value{
+		Id:       id,
+		Type:     ValueType_key,
+		KeyValue: createTestKey(kindName, name),
+	}
+}
+
+func TestReferences(t *testing.T) {
+	ctx := context.Background()
+
+	genResult, err := generate("./schema.json")
+	assert.NilError(t, err)
+
+	storage := createMemoryStorage()
+	defer storage.Close()
+	processor := createTransactionProcessor(storage)
+
+	resp, err := processor.processTransaction(ctx, genResult.Schema, &MetaTransaction{
+		Operations: []*MetaOperation{
+			createTestOperation(createTestKey("Project", "p1")),
+			createTestOperation(createTestKey("Project", "p2")),
+			createTestOperation(createTestKey("Project", "p3")),
+		},
+	})
+	assert.NilError(t, err)
+	for _, result := range resp.OperationResults {
+		assert.Equal(t, getOperationResultCode(result), codes.OK)
+	}
+
+	// references must be to existing entities of an allowed kind
+	resp, err = processor.processTransaction(ctx, genResult.Schema, &MetaTransaction{
+		Operations: []*MetaOperation{
+			createTestOperation(createTestKey("ReferenceTest", "missing"), createReferenceTestValue(2, "Project", "missing")),
+			createTestOperation(createTestKey("ReferenceTest", "kind"), createReferenceTestValue(2, "User", "p1")),
+		},
+	})
+	assert.NilError(t, err)
+	assert.Equal(t, getOperationResultCode(resp.OperationResults[0]), codes.InvalidArgument)
+	assert.Equal(t, getOperationResultCode(resp.OperationResults[1]), codes.InvalidArgument)
+	assert.Equal(t, resp.OperationResults[1].Error.FieldViolations[0].FieldName, "restrictProject")
+
+	// including entities created earlier in the same transaction
+	resp, err = processor.processTransaction(ctx, genResult.Schema, &MetaTransaction{
+		Operations: []*MetaOperation{
+			createTestOperation(createTestKey("ReferenceTest", "r1"), createReferenceTestValue(2, "Project", "p1")),
+			createTestOperation(createTestKey("ReferenceTest", "r2"), createReferenceTestValue(3, "Project", "p2")),
+			createTestOperation(createTestKey("ReferenceTest", "r3"), createReferenceTestValue(4, "Project", "p3")),
+			createTestOperation(createTestKey("Project", "p4")),
+			createTestOperation(createTestKey("ReferenceTest", "r4"), createReferenceTestValue(3, "Project", "p4")),
+		},
+	})
+	assert.NilError(t, err)
+	for _, result := range resp.OperationResults {
+		assert.Equal(t, getOperationResultCode(result), codes.OK)
+	}
+
+	resp, err = processor.processTransaction(ctx, genResult.Schema, &MetaTransaction{
+		Operations: []*MetaOperation{
+			deleteTestOperation(createTestKey("Project", "p1")),
+		},
+	})
+	assert.NilError(t, err)
+	assert.Equal(t, getOperationResultCode(resp.OperationResults[0]), codes.FailedPrecondition)
+
+	resp, err = processor.processTransaction(ctx, genResult.Schema, &MetaTransaction{
+		Operations: []*MetaOperation{
+			deleteTestOperation(createTestKey("Project", "p2")),
+			deleteTestOperation(createTestKey("Project", "p3")),
+		},
+	})
+	assert.NilError(t, err)
+	for _, result := range resp.OperationResults {
+		assert.Equal(t, getOperationResultCode(result), codes.OK)
+	}
+
+	// r2 was deleted with p2, and r3's reference to p3 was cleared
+	remaining, err := storage.Query(ctx, &storageQuery{KindName: "ReferenceTest"})
+	assert.NilError(t, err)
+	assert.Equal(t, len(remaining), 3)
+	for _, snapshot := range remaining {
+		assert.Assert(t, getKeyIDString(snapshot.Key) != "r2")
+		if getKeyIDString(snapshot.Key) == "r3" {
+			assert.Assert(t, snapshot.Data["setNullProject"] == nil)
+		}
+	}
+
+	// the side effects are recorded in the transaction log
+	transactions, err := storage.Query(ctx, &storageQuery{
+		KindName:   "Transaction",
+		OrderBy:    "dateSubmitted",
+		Descending: true,
+		Limit:      1,
+	})
+	assert.NilError(t, err)
+	assert.Equal(t, len(transactions[0].Data["deletedKeys"].([]interface{})), 3)
+	assert.Equal(t, len(transactions[0].Data["mutatedKeys"].([]interface{})), 1)
+
+	// restricting references don't count if they're deleted first
+	resp, err = processor.processTransaction(ctx, genResult.Schema, &MetaTransaction{
+		Operations: []*MetaOperation{
+			deleteTestOperation(createTestKey("ReferenceTest", "r1")),
+			deleteTestOperation(createTestKey("Project", "p1")),
+		},
+	})
+	assert.NilError(t, err)
+	for _, result := range resp.OperationResults {
+		assert.Equal(t, getOperationResultCode(result), codes.OK)
+	}
+}
+
+func TestRepeatedReferences(t *testing.T) {
+	ctx := context.Background()
+
+	genResult, err := generate("./schema.json")
+	assert.NilError(t, err)
+
+	storage := createMemoryStorage()
+	defer storage.Close()
+	processor := createTransactionProcessor(storage)
+
+	allowedUsers := func(names ...string) *Value {
+		value := &Value{
+			Id:   3,
+			Type: ValueType_key,
+		}
+		for _, name := range names {
+			value.ArrayValue = append(value.ArrayValue, &Value{
+				KeyValue: createTestKey("User", name),
+			})
+		}
+		return value
+	}
+
+	resp, err := processor.processTransaction(ctx, genResult.Schema, &MetaTransaction{
+		Operations: []*MetaOperation{
+			createTestOperation(createTestKey("User", "u1")),
+			createTestOperation(createTestKey("User", "u2")),
+			createTestOperation(createTestKey("Project", "p1"), allowedUsers("u1", "u2")),
+			createTestOperation(createTestKey("Project", "p2"), allowedUsers("u1", "missing")),
+		},
+	})
+	assert.NilError(t, err)
+	assert.Equal(t, getOperationResultCode(resp.OperationResults[2]), codes.OK)
+	assert.Equal(t, getOperationResultCode(resp.OperationResults[3]), codes.InvalidArgument)
+	assert.Equal(t, resp.OperationResults[3].Error.FieldViolations[0].FieldName, "allowedUsers")
+
+	resp, err = processor.processTransaction(ctx, genResult.Schema, &MetaTransaction{
+		Operations: []*MetaOperation{
+			deleteTestOperation(createTestKey("User", "u1")),
+		},
+	})
+	assert.NilError(t, err)
+	assert.Equal(t, getOperationResultCode(resp.OperationResults[0]), codes.OK)
+
+	// only the deleted user is removed from the list
+	projects, err := storage.Query(ctx, &storageQuery{KindName: "Project"})
+	assert.NilError(t, err)
+	assert.Equal(t, len(projects), 1)
+	entity, err := convertSnapshotToMetaEntity(genResult.Schema, genResult.Schema.Kinds["Project"], projects[0])
+	assert.NilError(t, err)
+	assert.Equal(t, len(entity.Values), 1)
+	assert.Equal(t, len(entity.Values[0].ArrayValue), 1)
+	assert.Equal(t, getKeyIDString(entity.Values[0].ArrayValue[0].KeyValue), "u2")
+}
+
+func TestNestedReferences(t *testing.T) {
+	ctx := context.Background()
+
+	genResult, err := generate("./schema.json")
+	assert.NilError(t, err)
+
+	storage := createMemoryStorage()
+	defer storage.Close()
+	processor := createTransactionProcessor(storage)
+
+	address := func(kindName string, name string) *Value {
+		return &Value{
+			Type: ValueType_struct,
+			StructValue: &StructValue{
+				Values: []*Value{
+					&Value{Id: 2, Type: ValueType_string, StringValue: "Sydney"},
+					createReferenceTestValue(4, kindName, name),
+				},
+			},
+		}
+	}
+	withID := func(id int32, value *Value) *Value {
+		value.Id = id
+		return value
+	}
+
+	resp, err := processor.processTransaction(ctx, genResult.Schema, &MetaTransaction{
+		Operations: []*MetaOperation{
+			createTestOperation(createTestKey("User", "u1")),
+			createTestOperation(createTestKey("StructTest", "s1"), withID(2, address("User", "u1"))),
+			createTestOperation(createTestKey("StructTest", "s2"), withID(2, address("User", "u1")), &Value{
+				Id:         3,
+				Type:       ValueType_struct,
+				ArrayValue: []*Value{address("User", "missing")},
+			}),
+			createTestOperation(createTestKey("StructTest", "s3"), withID(2, address("Project", "u1"))),
+			createTestOperation(createTestKey("MapTest", "m1"), &Value{
+				Id:   3,
+				Type: ValueType_map,
+				MapValue: map[string]*Value{
+					"home": address("User", "missing"),
+				},
+			}),
+		},
+	})
+	assert.NilError(t, err)
+	assert.Equal(t, getOperationResultCode(resp.OperationResults[1]), codes.OK)
+	assert.Equal(t, getOperationResultCode(resp.OperationResults[2]), codes.InvalidArgument)
+	assert.Equal(t, resp.OperationResults[2].Error.FieldViolations[0].FieldName, "previousAddresses")
+	assert.Equal(t, getOperationResultCode(resp.OperationResults[3]), codes.InvalidArgument)
+	assert.Equal(t, resp.OperationResults[3].Error.FieldViolations[0].FieldName, "address")
+	assert.Equal(t, getOperationResultCode(resp.OperationResults[4]), codes.InvalidArgument)
+	assert.Equal(t, resp.OperationResults[4].Error.FieldViolations[0].FieldName, "addresses")
+}
+
+func TestSchemaReferencesRejectNestedOnDelete(t *testing.T) {
+	schema := &Schema{
+		Kinds: map[string]*SchemaKind{
+			"User": &SchemaKind{},
+		},
+		Structs: map[string]*SchemaStruct{
+			"Address": &SchemaStruct{
+				Fields: []*SchemaField{
+					&SchemaField{
+						Id:       1,
+						Name:     "landlord",
+						Type:     ValueType_key,
+						OnDelete: SchemaFieldOnDelete_cascade,
+						Editor: &SchemaFieldEditorInfo{
+							AllowedKinds: []string{"User"},
+						},
+					},
+				},
+			},
+		},
+	}
+	err := checkSchemaReferences(schema)
+	assert.ErrorContains(t, err, "field 'landlord' of struct 'Address' has an onDelete policy")
+
+	schema.Structs["Address"].Fields[0].OnDelete = SchemaFieldOnDelete_noAction
+	assert.NilError(t, checkSchemaReferences(schema))
+}
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"google.golang.org/grpc/codes"
+)
+
+func createTestKey(kindName string, name string) *Key {
+	return &Key{
+		PartitionId: &PartitionId{},
+		Path: []*PathElement{
+			&PathElement{
+				Kind: kindName,
+				IdType: &PathElement_Name{
+					Name: name,
+				},
+			},
+		},
+	}
+}
+
+// createChildTestKey returns the key of an entity nested under parent, or a
+// top-level key if parent is nil.
+func createChildTestKey(parent *Key, kindName string, name string) *Key {
+	key := createTestKey(kindName, name)
+	if parent != nil {
+		key.Path = append(append([]*PathElement{}, parent.Path...), key.Path...)
+	}
+	return key
+}
+
+func createTestOperation(key *Key, values ...*Value) *MetaOperation {
+	return &MetaOperation{
+		Operation: &MetaOperation_CreateRequest{
+			CreateRequest: &MetaCreateEntityRequest{
+				KindName: getKeyKindName(key),
+				Entity: &MetaEntity{
+					Key:    key,
+					Values: values,
+				},
+			},
+		},
+	}
+}
+
+func updateTestOperation(key *Key, values ...*Value) *MetaOperation {
+	return &MetaOperation{
+		Operation: &MetaOperation_UpdateRequest{
+			UpdateRequest: &MetaUpdateEntityRequest{
+				Entity: &MetaEntity{
+					Key:    key,
+					Values: values,
+				},
+			},
+		},
+	}
+}
+
+func deleteTestOperation(key *Key) *MetaOperation {
+	return &MetaOperation{
+		Operation: &MetaOperation_DeleteRequest{
+			DeleteRequest: &MetaDeleteEntityRequest{
+				KindName: getKeyKindName(key),
+				Key:      key,
+			},
+		},
+	}
+}
+
+func getOperationResultCode(result *MetaOperationResult) codes.Code {
+	if result.Error == nil {
+		return codes.OK
+	}
+	return codes.Code(result.Error.Code)
+}
@@ -0,0 +1,364 @@
+package main
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// uniqueGuardKind is the kind of the guard documents that enforce unique
+// fields. There's one guard for each unique value in use, named after the
+// kind, field and value, and it records the entity that holds the value.
+// Because the guard is read and written in the same transaction as the
+// entity, two transactions can't both claim a value. It isn't part of the
+// schema.
+const uniqueGuardKind = "ConfigstoreUnique"
+
+func (s *operationProcessor) getUniqueGuardKey(namespace string, kindName string, field *SchemaField, value interface{}) *Key {
+	if isZeroStorageValue(value) {
+		return nil
+	}
+
+	var serialized string
+	switch v := value.(type) {
+	case string:
+		serialized = v
+	case int64:
+		serialized = strconv.FormatInt(v, 10)
+	case float64:
+		serialized = strconv.FormatFloat(v, 'g', -1, 64)
+	case []byte:
+		serialized = hex.EncodeToString(v)
+	case time.Time:
+		serialized = strconv.FormatInt(v.UnixNano(), 10)
+	case *Key:
+		serialized = serializeKey(normalizeEntityKey(s.storage, v))
+	default:
+		serialized = fmt.Sprintf("%v", v)
+	}
+	hash := sha256.Sum256([]byte(serialized))
+
+	return &Key{
+		PartitionId: &PartitionId{
+			Namespace: normalizeNamespace(s.storage, namespace),
+		},
+		Path: []*PathElement{
+			&PathElement{
+				Kind: uniqueGuardKind,
+				IdType: &PathElement_Name{
+					Name: fmt.Sprintf("%s:%s:%s", kindName, field.Name, hex.EncodeToString(hash[:])),
+				},
+			},
+		},
+	}
+}
+
+// readUniqueGuards loads the guards for the unique values in data, so that
+// checkUniqueValues and updateUniqueGuards can use them. It must be called
+// during the read phase of the transaction.
+func (s *operationProcessor) readUniqueGuards(namespace string, kindName string, kindInfo *SchemaKind, data map[string]interface{}) error {
+	for _, field := range kindInfo.Fields {
+		if !field.Unique {
+			continue
+		}
+		guardKey := s.getUniqueGuardKey(namespace, kindName, field, data[field.Name])
+		if guardKey == nil {
+			continue
+		}
+		id := serializeKey(guardKey)
+		if _, ok := s.guards[id]; ok {
+			continue
+		}
+
+		snapshot, err := s.tx.Get(guardKey)
+		if err != nil {
+			if status.Code(err) == codes.NotFound {
+				s.guards[id] = ""
+				continue
+			}
+			return err
+		}
+		owner, _ := snapshot.Data["owner"].(*Key)
+		if owner == nil {
+			s.guards[id] = ""
+		} else {
+			s.guards[id] = serializeKey(normalizeEntityKey(s.storage, owner))
+		}
+	}
+	return nil
+}
+
+// setUpdatedUniqueGuards records the guards that an entity will hold after
+// it's updated, so that values it releases can be claimed by operations
+// that come before its update in a transaction that's applied in full or
+// not at all.
+func (s *operationProcessor) setUpdatedUniqueGuards(key *Key, kindName string, kindInfo *SchemaKind, data map[string]interface{}) {
+	guards := make(map[string]bool)
+	for _, field := range kindInfo.Fields {
+		if !field.Unique {
+			continue
+		}
+		guardKey := s.getUniqueGuardKey(getKeyNamespace(key), kindName, field, data[field.Name])
+		if guardKey != nil {
+			guards[serializeKey(guardKey)] = true
+		}
+	}
+	s.updatedGuards[serializeKey(normalizeEntityKey(s.storage, key))] = guards
+}
+
+// isReleasedLater returns whether owner will release the guard when it's
+// updated later in the transaction.
+func (s *operationProcessor) isReleasedLater(ctx context.Context, owner string, guardID string) bool {
+	if abort, ok := ctx.Value(contextAbortOnErrorKey).(bool); !ok || !abort {
+		return false
+	}
+	guards, ok := s.updatedGuards[owner]
+	return ok && !guards[guardID]
+}
+
+// checkUniqueValues returns an AlreadyExists error naming every unique field
+// whose value in data is held by another entity, including entities written
+// earlier in the same transaction. It's checked even when validation is
+// skipped, since a guard can only have one owner.
+func (s *operationProcessor) checkUniqueValues(ctx context.Context, key *Key, kindName string, kindInfo *SchemaKind, data map[string]interface{}) error {
+	self := serializeKey(normalizeEntityKey(s.storage, key))
+	var violations []*MetaFieldViolation
+	var descriptions []string
+	for _, field := range kindInfo.Fields {
+		if !field.Unique {
+			continue
+		}
+		guardKey := s.getUniqueGuardKey(getKeyNamespace(key), kindName, field, data[field.Name])
+		if guardKey == nil {
+			continue
+		}
+		guardID := serializeKey(guardKey)
+		owner, ok := s.guards[guardID]
+		if !ok {
+			return fmt.Errorf("unique guard for field '%s' was not read before the entity was written", field.Name)
+		}
+		if owner != "" && owner != self && !s.isReleasedLater(ctx, owner, guardID) {
+			description := fmt.Sprintf("value must be unique, but is already used by %s", owner)
+			violations = append(violations, &MetaFieldViolation{
+				KindName:    kindName,
+				FieldName:   field.Name,
+				Description: description,
+			})
+			descriptions = append(descriptions, fmt.Sprintf("%s: %s", field.Name, description))
+		}
+	}
+	if len(violations) > 0 {
+		return createFieldViolationError(
+			codes.AlreadyExists,
+			fmt.Sprintf("entity of kind '%s' has duplicate values: %s", kindName, strings.Join(descriptions, "; ")),
+			violations,
+		)
+	}
+	return nil
+}
+
+// updateUniqueGuards releases the guards held by the entity for values in
+// before that aren't in after, and claims the guards for the values in
+// after. Either may be nil if the entity is being created or deleted.
+func (s *operationProcessor) updateUniqueGuards(key *Key, kindName string, kindInfo *SchemaKind, before map[string]interface{}, after map[string]interface{}) error {
+	normalizedKey := normalizeEntityKey(s.storage, key)
+	self := serializeKey(normalizedKey)
+	for _, field := range kindInfo.Fields {
+		if !field.Unique {
+			continue
+		}
+
+		var beforeKey *Key
+		var afterKey *Key
+		if before != nil {
+			beforeKey = s.getUniqueGuardKey(getKeyNamespace(key), kindName, field, before[field.Name])
+		}
+		if after != nil {
+			afterKey = s.getUniqueGuardKey(getKeyNamespace(key), kindName, field, after[field.Name])
+		}
+
+		if beforeKey != nil && (afterKey == nil || serializeKey(beforeKey) != serializeKey(afterKey)) {
+			id := serializeKey(beforeKey)
+			if s.guards[id] == self {
+				err := s.tx.Delete(beforeKey)
+				if err != nil {
+					return err
+				}
+				s.guards[id] = ""
+			}
+		}
+
+		if afterKey != nil {
+			id := serializeKey(afterKey)
+			if s.guards[id] == self {
+				continue
+			}
+			err := s.tx.Set(afterKey, map[string]interface{}{
+				"kind":  kindName,
+				"field": field.Name,
+				"owner": normalizedKey,
+			})
+			if err != nil {
+				return err
+			}
+			s.guards[id] = self
+		}
+	}
+	return nil
+}
+
+// backfillUniqueGuards creates the missing guards of entities that were
+// written before their fields were marked unique. Only one of the entities
+// that share a unique value can hold its guard, so the others are logged
+// and left unguarded; they can't be updated until the value is changed.
+func backfillUniqueGuards(ctx context.Context, storage storageBackend, schema *Schema) error {
+	var kindNames []string
+	for kindName, kindInfo := range schema.Kinds {
+		for _, field := range kindInfo.Fields {
+			if field.Unique {
+				kindNames = append(kindNames, kindName)
+				break
+			}
+		}
+	}
+	if len(kindNames) == 0 {
+		return nil
+	}
+	sort.Strings(kindNames)
+
+	namespaces, err := storage.Namespaces(ctx)
+	if err != nil {
+		return err
+	}
+	keys := createOperationProcessor(storage, nil, "", time.Time{})
+	for _, namespace := range namespaces {
+		// most entities already have their guards, so they're checked
+		// without a transaction first
+		guards, err := storage.Query(ctx, &storageQuery{
+			Namespace: namespace,
+			KindName:  uniqueGuardKind,
+		})
+		if err != nil {
+			return err
+		}
+		owners := make(map[string]string)
+		for _, guard := range guards {
+			if owner, _ := guard.Data["owner"].(*Key); owner != nil {
+				owners[serializeKey(normalizeEntityKey(storage, guard.Key))] = serializeKey(normalizeEntityKey(storage, owner))
+			}
+		}
+
+		for _, kindName := range kindNames {
+			kindInfo := schema.Kinds[kindName]
+			snapshots, err := storage.Query(ctx, &storageQuery{
+				Namespace:      namespace,
+				KindName:       kindName,
+				AllDescendants: len(kindInfo.Ancestors) > 0,
+			})
+			if err != nil {
+				return err
+			}
+			for _, snapshot := range snapshots {
+				self := serializeKey(normalizeEntityKey(storage, snapshot.Key))
+				guarded := true
+				for _, field := range kindInfo.Fields {
+					if !field.Unique {
+						continue
+					}
+					guardKey := keys.getUniqueGuardKey(namespace, kindName, field, snapshot.Data[field.Name])
+					if guardKey != nil && owners[serializeKey(guardKey)] != self {
+						guarded = false
+					}
+				}
+				if guarded {
+					continue
+				}
+
+				var duplicates []string
+				err = storage.RunTransaction(ctx, func(ctx context.Context, tx storageTransaction) error {
+					duplicates = nil
+					s := createOperationProcessor(storage, tx, "", time.Now())
+					current, err := tx.Get(snapshot.Key)
+					if err != nil {
+						if status.Code(err) == codes.NotFound {
+							// deleted since it was queried
+							return nil
+						}
+						return err
+					}
+					err = s.readUniqueGuards(namespace, kindName, kindInfo, current.Data)
+					if err != nil {
+						return err
+					}
+					for _, field := range kindInfo.Fields {
+						if !field.Unique {
+							continue
+						}
+						guardKey := s.getUniqueGuardKey(namespace, kindName, field, current.Data[field.Name])
+						if guardKey == nil {
+							continue
+						}
+						owner := s.guards[serializeKey(guardKey)]
+						if owner == self {
+							continue
+						}
+						if owner != "" {
+							duplicates = append(duplicates, fmt.Sprintf("%s has the same value of unique field '%s' of kind '%s' as %s", self, field.Name, kindName, owner))
+							continue
+						}
+						err = tx.Set(guardKey, map[string]interface{}{
+							"kind":  kindName,
+							"field": field.Name,
+							"owner": normalizeEntityKey(storage, snapshot.Key),
+						})
+						if err != nil {
+							return err
+						}
+					}
+					return nil
+				})
+				if err != nil {
+					return err
+				}
+				for _, duplicate := range duplicates {
+					log.Printf("%s; the value will be unguarded until one of them is changed", duplicate)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// checkSchemaUniqueFields returns an error if a field that can't hold
+// distinct values is marked as unique.
+func checkSchemaUniqueFields(schema *Schema) error {
+	for kindName, kind := range schema.Kinds {
+		for _, field := range kind.Fields {
+			if field.Unique && field.Type == ValueType_boolean {
+				return fmt.Errorf("field '%s' of kind '%s' is a boolean, so it can't be unique", field.Name, kindName)
+			}
+			if field.Unique && field.Type == ValueType_struct {
+				return fmt.Errorf("field '%s' of kind '%s' is a struct, so it can't be unique", field.Name, kindName)
+			}
+			if field.Unique && field.Type == ValueType_map {
+				return fmt.Errorf("field '%s' of kind '%s' is a map, so it can't be unique", field.Name, kindName)
+			}
+			if field.Unique && field.Type == ValueType_json {
+				return fmt.Errorf("field '%s' of kind '%s' holds JSON, so it can't be unique", field.Name, kindName)
+			}
+			if field.Unique && field.Repeated {
+				return fmt.Errorf("field '%s' of kind '%s' is repeated, so it can't be unique", field.Name, kindName)
+			}
+		}
+	}
+	return nil
+}
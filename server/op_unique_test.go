@@ -0,0 +1,173 @@
+package main
+
+import (
+	"context"
+
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"gotest.tools/assert"
+)
+
+func createUniqueTestValue(code string) *Value {
+	return &Value{
+		Id:          2,
+		Type:        ValueType_string,
+		StringValue: code,
+	}
+}
+
+func TestUniqueFields(t *testing.T) {
+	ctx := context.Background()
+
+	genResult, err := generate("./schema.json")
+	assert.NilError(t, err)
+
+	storage := createMemoryStorage()
+	defer storage.Close()
+	processor := createTransactionProcessor(storage)
+
+	resp, err := processor.processTransaction(ctx, genResult.Schema, &MetaTransaction{
+		Operations: []*MetaOperation{
+			createTestOperation(createTestKey("UniqueTest", "a"), createUniqueTestValue("x")),
+		},
+	})
+	assert.NilError(t, err)
+	assert.Equal(t, getOperationResultCode(resp.OperationResults[0]), codes.OK)
+
+	resp, err = processor.processTransaction(ctx, genResult.Schema, &MetaTransaction{
+		Operations: []*MetaOperation{
+			createTestOperation(createTestKey("UniqueTest", "b"), createUniqueTestValue("x")),
+		},
+	})
+	assert.NilError(t, err)
+	assert.Equal(t, getOperationResultCode(resp.OperationResults[0]), codes.AlreadyExists)
+	assert.Equal(t, len(resp.OperationResults[0].Error.FieldViolations), 1)
+	assert.Equal(t, resp.OperationResults[0].Error.FieldViolations[0].FieldName, "code")
+
+	// entities can keep their own value, and empty values aren't unique
+	resp, err = processor.processTransaction(ctx, genResult.Schema, &MetaTransaction{
+		Operations: []*MetaOperation{
+			updateTestOperation(createTestKey("UniqueTest", "a"), createUniqueTestValue("x")),
+			createTestOperation(createTestKey("UniqueTest", "c"), createUniqueTestValue("")),
+			createTestOperation(createTestKey("UniqueTest", "d"), createUniqueTestValue("")),
+		},
+	})
+	assert.NilError(t, err)
+	for _, result := range resp.OperationResults {
+		assert.Equal(t, getOperationResultCode(result), codes.OK)
+	}
+
+	// a value released earlier in a transaction can be claimed later in it
+	resp, err = processor.processTransaction(ctx, genResult.Schema, &MetaTransaction{
+		Operations: []*MetaOperation{
+			updateTestOperation(createTestKey("UniqueTest", "a"), createUniqueTestValue("y")),
+			createTestOperation(createTestKey("UniqueTest", "b"), createUniqueTestValue("x")),
+		},
+	})
+	assert.NilError(t, err)
+	assert.Equal(t, getOperationResultCode(resp.OperationResults[0]), codes.OK)
+	assert.Equal(t, getOperationResultCode(resp.OperationResults[1]), codes.OK)
+
+	// but a value claimed earlier in a transaction can't be claimed again
+	resp, err = processor.processTransaction(ctx, genResult.Schema, &MetaTransaction{
+		Operations: []*MetaOperation{
+			createTestOperation(createTestKey("UniqueTest", "e"), createUniqueTestValue("z")),
+			updateTestOperation(createTestKey("UniqueTest", "c"), createUniqueTestValue("z")),
+		},
+	})
+	assert.NilError(t, err)
+	assert.Equal(t, getOperationResultCode(resp.OperationResults[0]), codes.OK)
+	assert.Equal(t, getOperationResultCode(resp.OperationResults[1]), codes.AlreadyExists)
+
+	// deleting an entity releases its values
+	resp, err = processor.processTransaction(ctx, genResult.Schema, &MetaTransaction{
+		Operations: []*MetaOperation{
+			deleteTestOperation(createTestKey("UniqueTest", "a")),
+		},
+	})
+	assert.NilError(t, err)
+	assert.Equal(t, getOperationResultCode(resp.OperationResults[0]), codes.OK)
+
+	resp, err = processor.processTransaction(ctx, genResult.Schema, &MetaTransaction{
+		Operations: []*MetaOperation{
+			createTestOperation(createTestKey("UniqueTest", "f"), createUniqueTestValue("y")),
+		},
+	})
+	assert.NilError(t, err)
+	assert.Equal(t, getOperationResultCode(resp.OperationResults[0]), codes.OK)
+
+	guards, err := storage.Query(ctx, &storageQuery{KindName: uniqueGuardKind})
+	assert.NilError(t, err)
+	assert.Equal(t, len(guards), 3)
+}
+
+func TestBackfillUniqueGuards(t *testing.T) {
+	ctx := context.Background()
+
+	genResult, err := generate("./schema.json")
+	assert.NilError(t, err)
+
+	storage := createMemoryStorage()
+	defer storage.Close()
+	processor := createTransactionProcessor(storage)
+
+	// entities written before the field was marked unique have no guards
+	write := func(namespace string, name string, code string) {
+		key := createTestKey("UniqueTest", name)
+		key.PartitionId.Namespace = namespace
+		err := storage.RunTransaction(ctx, func(ctx context.Context, tx storageTransaction) error {
+			return tx.Create(key, map[string]interface{}{
+				"code": code,
+			})
+		})
+		assert.NilError(t, err)
+	}
+	write("", "a", "x")
+	write("", "b", "y")
+	write("", "c", "")
+	write("tenant-a", "a", "x")
+
+	err = backfillUniqueGuards(ctx, storage, genResult.Schema)
+	assert.NilError(t, err)
+	guards, err := storage.Query(ctx, &storageQuery{KindName: uniqueGuardKind})
+	assert.NilError(t, err)
+	assert.Equal(t, len(guards), 2)
+	guards, err = storage.Query(ctx, &storageQuery{Namespace: "tenant-a", KindName: uniqueGuardKind})
+	assert.NilError(t, err)
+	assert.Equal(t, len(guards), 1)
+
+	resp, err := processor.processTransaction(ctx, genResult.Schema, &MetaTransaction{
+		Operations: []*MetaOperation{
+			createTestOperation(createTestKey("UniqueTest", "d"), createUniqueTestValue("x")),
+		},
+	})
+	assert.NilError(t, err)
+	assert.Equal(t, getOperationResultCode(resp.OperationResults[0]), codes.AlreadyExists)
+
+	// backfilling again doesn't change anything
+	err = backfillUniqueGuards(ctx, storage, genResult.Schema)
+	assert.NilError(t, err)
+
+	// duplicates are left unguarded, and can't be updated until their
+	// value is changed
+	write("", "e", "y")
+	err = backfillUniqueGuards(ctx, storage, genResult.Schema)
+	assert.NilError(t, err)
+	guards, err = storage.Query(ctx, &storageQuery{KindName: uniqueGuardKind})
+	assert.NilError(t, err)
+	assert.Equal(t, len(guards), 2)
+	for _, guard := range guards {
+		assert.Assert(t, getKeyIDString(guard.Data["owner"].(*Key)) != "e")
+	}
+
+	resp, err = processor.processTransaction(ctx, genResult.Schema, &MetaTransaction{
+		Operations: []*MetaOperation{
+			updateTestOperation(createTestKey("UniqueTest", "e"), createUniqueTestValue("y")),
+			updateTestOperation(createTestKey("UniqueTest", "a"), createUniqueTestValue("v")),
+		},
+	})
+	assert.NilError(t, err)
+	assert.Equal(t, getOperationResultCode(resp.OperationResults[0]), codes.AlreadyExists)
+	assert.Equal(t, getOperationResultCode(resp.OperationResults[1]), codes.OK)
+}
@@ -3,10 +3,79 @@ package main
 import (
 	"context"
 	"fmt"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 func (s *operationProcessor) operationUpdateRead(ctx context.Context, schema *Schema, req *MetaUpdateEntityRequest) (interface{}, error) {
-	return nil, nil
+	if req == nil || req.Entity == nil || isKeyIncomplete(req.Entity.Key) {
+		return (*storageSnapshot)(nil), nil
+	}
+
+	// read the current version of the entity, so the transaction log can
+	// record what it looked like before the update
+	err := s.readEntityVersion(req.Entity.Key)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot, err := s.tx.Get(req.Entity.Key)
+	if err != nil {
+		if status.Code(err) != codes.NotFound {
+			return nil, err
+		}
+		snapshot = nil
+	}
+
+	err = s.readUpdateTargets(ctx, schema, req, snapshot)
+	if err != nil {
+		return nil, err
+	}
+
+	if snapshot == nil {
+		// updating an entity that doesn't exist creates it
+		err = s.readParentExists(ctx, req.Entity.Key)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return snapshot, nil
+}
+
+// readUpdateTargets loads the guards for the unique values the entity has
+// now and will have after the update, and the entities it will refer to.
+func (s *operationProcessor) readUpdateTargets(ctx context.Context, schema *Schema, req *MetaUpdateEntityRequest, snapshot *storageSnapshot) error {
+	kindName := getKeyKindName(req.Entity.Key)
+	kindInfo, err := findSchemaKindByName(schema, kindName)
+	if err != nil {
+		// reported by the write
+		return nil
+	}
+	namespace := getKeyNamespace(req.Entity.Key)
+
+	data := convertMetaValuesToDataMap(schema, kindInfo.Fields, req.Entity.Values)
+	if snapshot != nil {
+		err = s.readUniqueGuards(namespace, kindName, kindInfo, snapshot.Data)
+		if err != nil {
+			return err
+		}
+		if len(req.FieldMask) > 0 {
+			data, err = applyUpdateFieldMask(kindInfo, snapshot.Data, data, req.FieldMask)
+			if err != nil {
+				// reported by the write
+				return nil
+			}
+		}
+	}
+	err = s.readUniqueGuards(namespace, kindName, kindInfo, data)
+	if err != nil {
+		return err
+	}
+	s.setUpdatedUniqueGuards(req.Entity.Key, kindName, kindInfo, data)
+	return s.readReferenceTargets(ctx, schema, kindInfo, data)
 }
 
 func (s *operationProcessor) operationUpdateWrite(ctx context.Context, schema *Schema, req *MetaUpdateEntityRequest, readState interface{}) (*MetaUpdateEntityResponse, error) {
@@ -22,25 +91,142 @@ func (s *operationProcessor) operationUpdateWrite(ctx context.Context, schema *S
 		return nil, err
 	}
 
-	ref, data, err := convertMetaEntityToRefAndDataMap(
-		s.client,
+	key, data, err := convertMetaEntityToKeyAndDataMap(
 		req.Entity,
+		schema,
 		kindInfo,
 	)
 	if err != nil {
-		return nil, fmt.Errorf("can't convert meta entity to ref and map: %v", err)
+		return nil, fmt.Errorf("can't convert meta entity to key and map: %v", err)
 	}
 
-	if ref == nil {
+	if key == nil {
 		return nil, fmt.Errorf("entity must be set")
 	}
 
-	err = s.tx.Set(ref, data)
+	err = s.checkEntityVersion(key, req.ExpectedVersion)
 	if err != nil {
-		return nil, fmt.Errorf("can't set data against entity (Firestore): %v", err)
+		return nil, err
+	}
+
+	stored, _ := readState.(*storageSnapshot)
+	if stored == nil {
+		// the entity is being created, so it's checked like a create
+		err = checkKeyAncestors(schema, key)
+		if err != nil {
+			return nil, err
+		}
+		err = s.checkParentExists(ctx, key)
+		if err != nil {
+			return nil, err
+		}
 	}
 
+	if len(req.FieldMask) > 0 {
+		if stored == nil {
+			return nil, status.Error(codes.NotFound, fmt.Sprintf("entity %s does not exist, so it can't be partially updated", serializeKey(key)))
+		}
+		data, err = applyUpdateFieldMask(kindInfo, stored.Data, data, req.FieldMask)
+		if err != nil {
+			return nil, err
+		}
+
+		// respond with the whole entity, not just the fields that changed
+		req.Entity, err = convertSnapshotToMetaEntity(schema, kindInfo, &storageSnapshot{
+			Key:  key,
+			Data: data,
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err = checkReadonlyFields(ctx, lastKind, kindInfo, stored, data)
+	if err != nil {
+		return nil, err
+	}
+
+	err = validateEntityData(ctx, schema, lastKind, kindInfo, data)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.checkReferences(ctx, schema, lastKind, kindInfo, data)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.checkUniqueValues(ctx, key, lastKind, kindInfo, data)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Entity.Version, err = s.appendEntityVersion(ctx, key, data)
+	if err != nil {
+		return nil, fmt.Errorf("can't record entity version: %v", err)
+	}
+
+	err = s.tx.Set(key, data)
+	if err != nil {
+		return nil, fmt.Errorf("can't set data against entity: %v", err)
+	}
+
+	var before map[string]interface{}
+	if stored != nil {
+		before = stored.Data
+	}
+	err = s.updateUniqueGuards(key, lastKind, kindInfo, before, data)
+	if err != nil {
+		return nil, fmt.Errorf("can't update unique guards: %v", err)
+	}
+	s.setEntityExists(key, true)
+
 	return &MetaUpdateEntityResponse{
 		Entity: req.Entity,
 	}, nil
 }
+
+// applyUpdateFieldMask returns the stored data of an entity with the fields
+// named in fieldMask replaced by their values in updated. Fields in the mask
+// that aren't set in updated are cleared. A single entry of a map field is
+// named as "field.key", and is removed if it isn't in the updated map.
+func applyUpdateFieldMask(kindInfo *SchemaKind, stored map[string]interface{}, updated map[string]interface{}, fieldMask []string) (map[string]interface{}, error) {
+	merged := make(map[string]interface{})
+	for name, value := range stored {
+		merged[name] = value
+	}
+	merged["_forceFirestoreSnapshotGeneration"] = updated["_forceFirestoreSnapshotGeneration"]
+
+	for _, name := range fieldMask {
+		if findSchemaFieldByName(kindInfo, name) == nil && strings.Contains(name, ".") {
+			parts := strings.SplitN(name, ".", 2)
+			field := findSchemaFieldByName(kindInfo, parts[0])
+			if field == nil || field.Type != ValueType_map {
+				return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("field mask contains '%s', but '%s' is not a map field of this kind", name, parts[0]))
+			}
+			entries := make(map[string]interface{})
+			if storedEntries, ok := merged[parts[0]].(map[string]interface{}); ok {
+				for entryKey, entry := range storedEntries {
+					entries[entryKey] = entry
+				}
+			}
+			updatedEntries, _ := updated[parts[0]].(map[string]interface{})
+			if entry, ok := updatedEntries[parts[1]]; ok {
+				entries[parts[1]] = entry
+			} else {
+				delete(entries, parts[1])
+			}
+			merged[parts[0]] = entries
+			continue
+		}
+		if findSchemaFieldByName(kindInfo, name) == nil {
+			return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("field mask contains '%s', which is not a field of this kind", name))
+		}
+		if value, ok := updated[name]; ok {
+			merged[name] = value
+		} else {
+			delete(merged, name)
+		}
+	}
+	return merged, nil
+}
@@ -0,0 +1,503 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net"
+	"net/mail"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"google.golang.org/grpc/codes"
+)
+
+// contextSkipValidationKey is set when operations are replaying data that
+// was already accepted, such as a restore to an earlier point in time, so
+// that validators added since then don't reject it.
+const contextSkipValidationKey c = "skipValidation"
+
+// applySchemaDefaults sets every unset field of data that has a default
+// validator to the default value, and returns whether any were set. Like the
+// editor, defaults only apply to number and string fields, since an unset
+// boolean can't be told apart from false, and not to repeated fields.
+// Nullable fields are only unset when they're null, so an explicit zero
+// value is kept.
+func applySchemaDefaults(kindInfo *SchemaKind, data map[string]interface{}) bool {
+	applied := false
+	for _, field := range kindInfo.Fields {
+		if field.Editor == nil || field.Repeated || !isZeroStorageValue(data[field.Name]) {
+			continue
+		}
+		if field.Nullable && data[field.Name] != nil {
+			continue
+		}
+		switch field.Type {
+		case ValueType_double, ValueType_int64, ValueType_uint64, ValueType_string:
+		default:
+			continue
+		}
+		for _, validator := range field.Editor.Validators {
+			def := validator.GetDefault()
+			if def == nil || def.Value == nil || def.Value.Type != field.Type {
+				continue
+			}
+			if value, ok := convertValueToDataMapValue(def.Value); ok {
+				data[field.Name] = value
+				applied = true
+			}
+		}
+	}
+	return applied
+}
+
+// validateEntityData checks data against the validators of each field in
+// the kind, and the fields of any structs in it, and returns an
+// InvalidArgument error listing every violation.
+func validateEntityData(ctx context.Context, schema *Schema, kindName string, kindInfo *SchemaKind, data map[string]interface{}) error {
+	if skip, ok := ctx.Value(contextSkipValidationKey).(bool); ok && skip {
+		return nil
+	}
+
+	violations := validateFieldsData(schema, kindName, "", kindInfo.Fields, data)
+	if len(violations) > 0 {
+		return createValidationError(kindName, violations)
+	}
+	return nil
+}
+
+// createValidationError returns an InvalidArgument error for an entity of
+// the kind with the violations.
+func createValidationError(kindName string, violations []*MetaFieldViolation) error {
+	var descriptions []string
+	for _, violation := range violations {
+		descriptions = append(descriptions, fmt.Sprintf("%s: %s", violation.FieldName, violation.Description))
+	}
+	return createFieldViolationError(
+		codes.InvalidArgument,
+		fmt.Sprintf("entity of kind '%s' failed validation: %s", kindName, strings.Join(descriptions, "; ")),
+		violations,
+	)
+}
+
+// validateFieldsData checks data, which is an entity's data map or the map
+// of a struct value, against the validators of fields. The fields of structs
+// are named by their path from the entity, like "address.city",
+// "addresses.0.city" for an element of a repeated struct field, or
+// "addresses.home.city" for an entry of a map field.
+func validateFieldsData(schema *Schema, kindName string, prefix string, fields []*SchemaField, data map[string]interface{}) []*MetaFieldViolation {
+	var violations []*MetaFieldViolation
+	for _, field := range fields {
+		if field.Editor != nil {
+			for _, validator := range field.Editor.Validators {
+				var description string
+				if field.Type == ValueType_map {
+					description = validateMapFieldValue(field, validator, data[field.Name])
+				} else if field.Repeated {
+					description = validateRepeatedFieldValue(field, validator, data[field.Name])
+				} else {
+					description = validateFieldValue(field, validator, data[field.Name])
+				}
+				if description == "" {
+					continue
+				}
+				violations = append(violations, &MetaFieldViolation{
+					KindName:    kindName,
+					FieldName:   prefix + field.Name,
+					Description: description,
+				})
+			}
+		}
+
+		if description := validateEnumFieldValue(schema, field, data[field.Name]); description != "" {
+			violations = append(violations, &MetaFieldViolation{
+				KindName:    kindName,
+				FieldName:   prefix + field.Name,
+				Description: description,
+			})
+		}
+
+		if field.Type == ValueType_map {
+			entries, _ := data[field.Name].(map[string]interface{})
+			if _, ok := entries[""]; ok {
+				violations = append(violations, &MetaFieldViolation{
+					KindName:    kindName,
+					FieldName:   prefix + field.Name,
+					Description: "entry keys must not be empty",
+				})
+			}
+			structInfo, ok := schema.Structs[field.StructName]
+			if field.MapValueType != ValueType_struct || !ok {
+				continue
+			}
+			for _, entryKey := range getSortedMapKeys(entries) {
+				if value, ok := entries[entryKey].(map[string]interface{}); ok {
+					violations = append(violations, validateFieldsData(schema, kindName, fmt.Sprintf("%s%s.%s.", prefix, field.Name, entryKey), structInfo.Fields, value)...)
+				}
+			}
+			continue
+		}
+
+		structInfo, ok := schema.Structs[field.StructName]
+		if field.Type != ValueType_struct || !ok {
+			continue
+		}
+		if field.Repeated {
+			elements, _ := data[field.Name].([]interface{})
+			for i, element := range elements {
+				if value, ok := element.(map[string]interface{}); ok {
+					violations = append(violations, validateFieldsData(schema, kindName, fmt.Sprintf("%s%s.%d.", prefix, field.Name, i), structInfo.Fields, value)...)
+				}
+			}
+		} else if value, ok := data[field.Name].(map[string]interface{}); ok {
+			violations = append(violations, validateFieldsData(schema, kindName, fmt.Sprintf("%s%s.", prefix, field.Name), structInfo.Fields, value)...)
+		}
+	}
+	return violations
+}
+
+// validateFieldValue returns a description of why value doesn't pass the
+// validator, or an empty string if it does. Format and length validators
+// only apply to values that are set; use the required validator to reject
+// empty values.
+func validateFieldValue(field *SchemaField, validator *SchemaFieldEditorValidator, value interface{}) string {
+	switch v := validator.Validator.(type) {
+	case *SchemaFieldEditorValidator_Required:
+		if f, ok := value.(float64); ok && math.IsNaN(f) {
+			return "a non-zero value is required"
+		}
+		if s, ok := value.(string); ok && strings.TrimSpace(s) == "" {
+			return "a non-empty value is required"
+		}
+		if isZeroStorageValue(value) {
+			switch field.Type {
+			case ValueType_double, ValueType_int64, ValueType_uint64, ValueType_decimal, ValueType_duration:
+				return "a non-zero value is required"
+			case ValueType_key:
+				return "a key is required"
+			}
+			return "a non-empty value is required"
+		}
+	case *SchemaFieldEditorValidator_FixedLength:
+		if v.FixedLength == nil {
+			return ""
+		}
+		length := v.FixedLength.Length
+		switch value := value.(type) {
+		case string:
+			if value != "" && utf8.RuneCountInString(value) != int(length) {
+				return fmt.Sprintf("must be exactly %d characters long", length)
+			}
+		case []byte:
+			if len(value) > 0 && len(value) != int(length) {
+				return fmt.Sprintf("must be exactly %d bytes long", length)
+			}
+		}
+	case *SchemaFieldEditorValidator_FormatIPAddress:
+		if s, ok := value.(string); ok && s != "" {
+			if net.ParseIP(s) == nil {
+				return "must be an IPv4 or IPv6 address"
+			}
+		}
+	case *SchemaFieldEditorValidator_FormatIPAddressPort:
+		if s, ok := value.(string); ok && s != "" {
+			if !isValidIPAddressPort(s) {
+				return "must be an IPv4 or IPv6 address, with a port number specified; use brackets [] around an IPv6 address"
+			}
+		}
+	case *SchemaFieldEditorValidator_Pattern:
+		if s, ok := value.(string); ok && s != "" && v.Pattern != nil {
+			re, err := compileValidatorPattern(v.Pattern.Pattern)
+			if err != nil || !re.MatchString(s) {
+				return fmt.Sprintf("must match the pattern %s", v.Pattern.Pattern)
+			}
+		}
+	case *SchemaFieldEditorValidator_Int64Minimum:
+		if i, ok := value.(int64); ok && field.Type == ValueType_int64 && i < v.Int64Minimum.GetValue() {
+			return fmt.Sprintf("must be at least %d", v.Int64Minimum.GetValue())
+		}
+	case *SchemaFieldEditorValidator_Int64Maximum:
+		if i, ok := value.(int64); ok && field.Type == ValueType_int64 && i > v.Int64Maximum.GetValue() {
+			return fmt.Sprintf("must be at most %d", v.Int64Maximum.GetValue())
+		}
+	case *SchemaFieldEditorValidator_Uint64Minimum:
+		if i, ok := value.(int64); ok && field.Type == ValueType_uint64 && uint64(i) < v.Uint64Minimum.GetValue() {
+			return fmt.Sprintf("must be at least %d", v.Uint64Minimum.GetValue())
+		}
+	case *SchemaFieldEditorValidator_Uint64Maximum:
+		if i, ok := value.(int64); ok && field.Type == ValueType_uint64 && uint64(i) > v.Uint64Maximum.GetValue() {
+			return fmt.Sprintf("must be at most %d", v.Uint64Maximum.GetValue())
+		}
+	case *SchemaFieldEditorValidator_DoubleMinimum:
+		if f, ok := value.(float64); ok && (math.IsNaN(f) || f < v.DoubleMinimum.GetValue()) {
+			return fmt.Sprintf("must be at least %v", v.DoubleMinimum.GetValue())
+		}
+	case *SchemaFieldEditorValidator_DoubleMaximum:
+		if f, ok := value.(float64); ok && (math.IsNaN(f) || f > v.DoubleMaximum.GetValue()) {
+			return fmt.Sprintf("must be at most %v", v.DoubleMaximum.GetValue())
+		}
+	case *SchemaFieldEditorValidator_MinimumLength:
+		length := v.MinimumLength.GetLength()
+		switch value := value.(type) {
+		case string:
+			if value != "" && utf8.RuneCountInString(value) < int(length) {
+				return fmt.Sprintf("must be at least %d characters long", length)
+			}
+		case []byte:
+			if len(value) > 0 && len(value) < int(length) {
+				return fmt.Sprintf("must be at least %d bytes long", length)
+			}
+		}
+	case *SchemaFieldEditorValidator_MaximumLength:
+		length := v.MaximumLength.GetLength()
+		switch value := value.(type) {
+		case string:
+			if utf8.RuneCountInString(value) > int(length) {
+				return fmt.Sprintf("must be at most %d characters long", length)
+			}
+		case []byte:
+			if len(value) > int(length) {
+				return fmt.Sprintf("must be at most %d bytes long", length)
+			}
+		}
+	case *SchemaFieldEditorValidator_AllowedValues:
+		if s, ok := value.(string); value == nil || (ok && s == "") || v.AllowedValues == nil {
+			return ""
+		}
+		var allowed []string
+		for _, allowedValue := range v.AllowedValues.Values {
+			if allowedValue.Type != field.Type {
+				continue
+			}
+			converted, ok := convertValueToDataMapValue(allowedValue)
+			if !ok {
+				continue
+			}
+			if storageValuesEqual(value, converted) {
+				return ""
+			}
+			if field.Type == ValueType_uint64 {
+				allowed = append(allowed, strconv.FormatUint(allowedValue.Uint64Value, 10))
+			} else {
+				allowed = append(allowed, fmt.Sprintf("%v", converted))
+			}
+		}
+		return fmt.Sprintf("must be one of: %s", strings.Join(allowed, ", "))
+	case *SchemaFieldEditorValidator_FormatEmail:
+		if s, ok := value.(string); ok && s != "" {
+			address, err := mail.ParseAddress(s)
+			if err != nil || address.Address != s {
+				return "must be an email address"
+			}
+		}
+	case *SchemaFieldEditorValidator_FormatURL:
+		if s, ok := value.(string); ok && s != "" {
+			if !isValidURL(s, v.FormatURL.GetSchemes()) {
+				if len(v.FormatURL.GetSchemes()) > 0 {
+					return fmt.Sprintf("must be an absolute URL using one of these schemes: %s", strings.Join(v.FormatURL.GetSchemes(), ", "))
+				}
+				return "must be an absolute URL"
+			}
+		}
+	case *SchemaFieldEditorValidator_FormatURI:
+		if s, ok := value.(string); ok && s != "" {
+			u, err := url.Parse(s)
+			if err != nil || !u.IsAbs() {
+				return "must be an absolute URI"
+			}
+		}
+	case *SchemaFieldEditorValidator_TimestampMinimum:
+		if t, ok := value.(time.Time); ok && !t.IsZero() && v.TimestampMinimum.GetValue() != nil {
+			minimum := convertTimestampToTime(v.TimestampMinimum.GetValue())
+			if t.Before(minimum) {
+				return fmt.Sprintf("must not be before %s", minimum.UTC().Format(time.RFC3339Nano))
+			}
+		}
+	case *SchemaFieldEditorValidator_TimestampMaximum:
+		if t, ok := value.(time.Time); ok && !t.IsZero() && v.TimestampMaximum.GetValue() != nil {
+			maximum := convertTimestampToTime(v.TimestampMaximum.GetValue())
+			if t.After(maximum) {
+				return fmt.Sprintf("must not be after %s", maximum.UTC().Format(time.RFC3339Nano))
+			}
+		}
+	}
+	return ""
+}
+
+// validateRepeatedFieldValue applies the validator to each element of a
+// repeated field, except for the required validator, which requires the list
+// to have at least one element.
+func validateRepeatedFieldValue(field *SchemaField, validator *SchemaFieldEditorValidator, value interface{}) string {
+	elements, _ := value.([]interface{})
+	if validator.GetRequired() != nil {
+		if len(elements) == 0 {
+			return "at least one value is required"
+		}
+		return ""
+	}
+	for i, element := range elements {
+		if description := validateFieldValue(field, validator, element); description != "" {
+			return fmt.Sprintf("element %d %s", i, description)
+		}
+	}
+	return ""
+}
+
+// validateMapFieldValue applies the validator to the value of each entry of
+// a map field, except for the required validator, which requires the map to
+// have at least one entry.
+func validateMapFieldValue(field *SchemaField, validator *SchemaFieldEditorValidator, value interface{}) string {
+	entries, _ := value.(map[string]interface{})
+	if validator.GetRequired() != nil {
+		if len(entries) == 0 {
+			return "at least one entry is required"
+		}
+		return ""
+	}
+	valueField := getMapValueField(field)
+	for _, entryKey := range getSortedMapKeys(entries) {
+		if description := validateFieldValue(valueField, validator, entries[entryKey]); description != "" {
+			return fmt.Sprintf("entry '%s' %s", entryKey, description)
+		}
+	}
+	return ""
+}
+
+// validateEnumFieldValue returns a description of why the value of an enum
+// field, or of each of its elements or entries, isn't a value of the field's
+// enum. Empty values are left to the required validator.
+func validateEnumFieldValue(schema *Schema, field *SchemaField, value interface{}) string {
+	enumInfo, ok := schema.Enums[field.EnumName]
+	if !ok {
+		return ""
+	}
+	isValid := func(value interface{}) bool {
+		name, _ := value.(string)
+		return name == "" || findSchemaEnumValueByName(enumInfo, name) != nil
+	}
+	var names []string
+	for _, enumValue := range enumInfo.Values {
+		names = append(names, enumValue.Name)
+	}
+	description := fmt.Sprintf("must be one of: %s", strings.Join(names, ", "))
+
+	switch {
+	case field.Type == ValueType_map:
+		entries, _ := value.(map[string]interface{})
+		for _, entryKey := range getSortedMapKeys(entries) {
+			if !isValid(entries[entryKey]) {
+				return fmt.Sprintf("entry '%s' %s", entryKey, description)
+			}
+		}
+	case field.Repeated:
+		elements, _ := value.([]interface{})
+		for i, element := range elements {
+			if !isValid(element) {
+				return fmt.Sprintf("element %d %s", i, description)
+			}
+		}
+	case !isValid(value):
+		return description
+	}
+	return ""
+}
+
+func getSortedMapKeys(entries map[string]interface{}) []string {
+	keys := make([]string, 0, len(entries))
+	for key := range entries {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+var validatorPatterns sync.Map
+
+func compileValidatorPattern(pattern string) (*regexp.Regexp, error) {
+	if re, ok := validatorPatterns.Load(pattern); ok {
+		return re.(*regexp.Regexp), nil
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, err
+	}
+	validatorPatterns.Store(pattern, re)
+	return re, nil
+}
+
+// checkSchemaValidators returns an error if any validator in the schema
+// can't be applied to the field it's on.
+func checkSchemaValidators(schema *Schema) error {
+	for kindName, kind := range schema.Kinds {
+		for _, field := range kind.Fields {
+			if field.Editor == nil {
+				continue
+			}
+			for _, validator := range field.Editor.Validators {
+				if validator.GetDefault() != nil && field.Repeated {
+					return fmt.Errorf("field '%s' of kind '%s' is repeated, so it can't have a default value", field.Name, kindName)
+				}
+				if validator.GetDefault() != nil && field.Type == ValueType_map {
+					return fmt.Errorf("field '%s' of kind '%s' is a map, so it can't have a default value", field.Name, kindName)
+				}
+				if pattern := validator.GetPattern(); pattern != nil {
+					_, err := compileValidatorPattern(pattern.Pattern)
+					if err != nil {
+						return fmt.Errorf("field '%s' of kind '%s' has an invalid pattern: %v", field.Name, kindName, err)
+					}
+				}
+				if allowedValues := validator.GetAllowedValues(); allowedValues != nil {
+					valueType := field.Type
+					if field.Type == ValueType_map {
+						valueType = field.MapValueType
+					}
+					switch valueType {
+					case ValueType_string, ValueType_int64, ValueType_uint64, ValueType_double:
+					default:
+						return fmt.Errorf("field '%s' of kind '%s' has allowed values, which aren't supported for fields of type '%s'", field.Name, kindName, valueType)
+					}
+					for _, allowedValue := range allowedValues.Values {
+						if allowedValue.Type != valueType {
+							return fmt.Errorf("field '%s' of kind '%s' has an allowed value of type '%s', but the field is of type '%s'", field.Name, kindName, allowedValue.Type, valueType)
+						}
+					}
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func isValidIPAddressPort(value string) bool {
+	host, port, err := net.SplitHostPort(value)
+	if err != nil {
+		return false
+	}
+	if net.ParseIP(host) == nil {
+		return false
+	}
+	portNumber, err := strconv.ParseUint(port, 10, 16)
+	return err == nil && portNumber > 0
+}
+
+func isValidURL(value string, schemes []string) bool {
+	u, err := url.Parse(value)
+	if err != nil || !u.IsAbs() || u.Host == "" {
+		return false
+	}
+	if len(schemes) == 0 {
+		return true
+	}
+	for _, scheme := range schemes {
+		if strings.EqualFold(u.Scheme, scheme) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,119 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// entityVersionKind is the kind of the version records stored underneath
+// each entity. It isn't part of the schema.
+const entityVersionKind = "ConfigstoreVersion"
+
+// entityVersionDataField is the document field that holds the current
+// version of each entity, so that reads can return it without loading the
+// version records.
+const entityVersionDataField = "_version"
+
+func normalizeEntityKey(storage storageBackend, key *Key) *Key {
+	return &Key{
+		PartitionId: &PartitionId{
+			Namespace: normalizeNamespace(storage, getKeyNamespace(key)),
+		},
+		Path: key.Path,
+	}
+}
+
+func getEntityVersionKey(key *Key, version int64) *Key {
+	path := append([]*PathElement{}, key.Path...)
+	path = append(path, &PathElement{
+		Kind: entityVersionKind,
+		IdType: &PathElement_Name{
+			Name: strconv.FormatInt(version, 10),
+		},
+	})
+	return &Key{
+		PartitionId: key.PartitionId,
+		Path:        path,
+	}
+}
+
+// readEntityVersion loads the latest version number of the entity, so that
+// appendEntityVersion can record the next one. It must be called during the
+// read phase of the transaction.
+func (s *operationProcessor) readEntityVersion(key *Key) error {
+	key = normalizeEntityKey(s.storage, key)
+	id := serializeKey(key)
+	if _, ok := s.versions[id]; ok {
+		return nil
+	}
+
+	records, err := s.tx.Query(&storageQuery{
+		Parent:     key,
+		KindName:   entityVersionKind,
+		OrderBy:    "version",
+		Descending: true,
+		Limit:      1,
+	})
+	if err != nil {
+		return err
+	}
+	if len(records) == 0 {
+		s.versions[id] = 0
+	} else {
+		version, _ := records[0].Data["version"].(int64)
+		s.versions[id] = version
+	}
+	return nil
+}
+
+// checkEntityVersion returns an Aborted error if expectedVersion is set and
+// the entity is at a different version.
+func (s *operationProcessor) checkEntityVersion(key *Key, expectedVersion int64) error {
+	if expectedVersion == 0 {
+		return nil
+	}
+	key = normalizeEntityKey(s.storage, key)
+	current := s.versions[serializeKey(key)]
+	if current != expectedVersion {
+		return status.Error(codes.Aborted, fmt.Sprintf("entity %s is at version %d, but version %d was expected; read it again and retry", serializeKey(key), current, expectedVersion))
+	}
+	return nil
+}
+
+// appendEntityVersion records a new immutable version of the entity with
+// the given data, or a deletion if data is nil, and returns the new version
+// number. The version number is also stored in data.
+func (s *operationProcessor) appendEntityVersion(ctx context.Context, key *Key, data map[string]interface{}) (int64, error) {
+	key = normalizeEntityKey(s.storage, key)
+	id := serializeKey(key)
+	version := s.versions[id] + 1
+	s.versions[id] = version
+	if data != nil {
+		data[entityVersionDataField] = version
+	}
+
+	record := map[string]interface{}{
+		"version":       version,
+		"transactionId": s.transactionID,
+		"dateSubmitted": s.dateSubmitted,
+		"deleted":       data == nil,
+		"values":        nil,
+		"authSub":       nil,
+	}
+	if data != nil {
+		record["values"] = data
+	}
+	if authSub, ok := ctx.Value(contextSubjectKey).(string); ok {
+		record["authSub"] = authSub
+	}
+
+	err := s.tx.Create(getEntityVersionKey(key, version), record)
+	if err != nil {
+		return 0, err
+	}
+	return version, nil
+}
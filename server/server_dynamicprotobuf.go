@@ -8,45 +8,45 @@ import (
 	"github.com/jhump/protoreflect/dynamic"
 
 	"google.golang.org/grpc"
-
-	"cloud.google.com/go/firestore"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 type configstoreDynamicProtobufService struct {
-	firestoreClient      *firestore.Client
+	storage              storageBackend
 	genResult            *generatorResult
 	service              *builder.ServiceBuilder
 	kindName             string
 	schema               *Schema
 	transactionProcessor *transactionProcessor
-	transactionWatcher   *transactionWatcher
+	transactionWatchers  *transactionWatcherSet
 }
 
 func createConfigstoreDynamicProtobufServer(
-	firestoreClient *firestore.Client,
+	storage storageBackend,
 	genResult *generatorResult,
 	service *builder.ServiceBuilder,
 	kindName string,
 	schema *Schema,
-	transactionWatcher *transactionWatcher,
+	transactionWatchers *transactionWatcherSet,
 ) *configstoreDynamicProtobufService {
 	return &configstoreDynamicProtobufService{
-		firestoreClient:      firestoreClient,
+		storage:              storage,
 		genResult:            genResult,
 		service:              service,
 		kindName:             kindName,
 		schema:               schema,
-		transactionProcessor: createTransactionProcessor(firestoreClient),
-		transactionWatcher:   transactionWatcher,
+		transactionProcessor: createTransactionProcessor(storage),
+		transactionWatchers:  transactionWatchers,
 	}
 }
 
 func (s *configstoreDynamicProtobufService) getMetaServiceServer() *configstoreMetaServiceServer {
 	return createConfigstoreMetaServiceServer(
-		s.firestoreClient,
+		s.storage,
 		s.schema,
 		s.transactionProcessor,
-		s.transactionWatcher,
+		s.transactionWatchers,
 	)
 }
 
@@ -68,6 +68,15 @@ func (s *configstoreDynamicProtobufService) dynamicProtobufList(srv interface{},
 		return nil, err
 	}
 
+	partitionID, err := getPartitionIdFromDynamicMessage(in)
+	if err != nil {
+		return nil, err
+	}
+	ancestor, err := getAncestorFromDynamicMessage(in)
+	if err != nil {
+		return nil, err
+	}
+
 	var start []byte
 	if startBytes != nil {
 		start = startBytes.([]byte)
@@ -79,9 +88,11 @@ func (s *configstoreDynamicProtobufService) dynamicProtobufList(srv interface{},
 
 	metaServer := s.getMetaServiceServer()
 	resp, err := metaServer.MetaList(ctx, &MetaListEntitiesRequest{
-		Start:    start,
-		Limit:    limit,
-		KindName: s.kindName,
+		Start:       start,
+		Limit:       limit,
+		KindName:    s.kindName,
+		PartitionId: partitionID,
+		Ancestor:    ancestor,
 	})
 	if err != nil {
 		return nil, err
@@ -94,6 +105,7 @@ func (s *configstoreDynamicProtobufService) dynamicProtobufList(srv interface{},
 			s.genResult.MessageMap[s.kindName],
 			snapshot,
 			s.genResult.CommonMessageDescriptors,
+			s.genResult.Schema,
 			s.genResult.KindMap[s.service],
 		)
 		if err != nil {
@@ -112,8 +124,7 @@ func (s *configstoreDynamicProtobufService) dynamicProtobufList(srv interface{},
 		} else {
 			// TODO: query to see if there really are more results, to make this behave like datastore
 			out.SetFieldByName("moreResults", true)
-			last := resp.Entities[len(resp.Entities)-1]
-			out.SetFieldByName("next", []byte(last.Key.Path[len(last.Key.Path)-1].GetName()))
+			out.SetFieldByName("next", resp.Next)
 		}
 	} else {
 		out.SetFieldByName("moreResults", false)
@@ -155,6 +166,7 @@ func (s *configstoreDynamicProtobufService) dynamicProtobufGet(srv interface{},
 		s.genResult.MessageMap[s.kindName],
 		resp.Entity,
 		s.genResult.CommonMessageDescriptors,
+		s.genResult.Schema,
 		s.genResult.KindMap[s.service],
 	)
 	if err != nil {
@@ -186,11 +198,28 @@ func (s *configstoreDynamicProtobufService) dynamicProtobufUpdate(srv interface{
 		return nil, fmt.Errorf("entity must not be nil")
 	}
 
+	rawExpectedVersion, err := in.TryGetFieldByName("expectedVersion")
+	if err != nil {
+		return nil, err
+	}
+	expectedVersion, _ := rawExpectedVersion.(int64)
+
+	rawFieldMask, err := in.TryGetFieldByName("fieldMask")
+	if err != nil {
+		return nil, err
+	}
+	var fieldMask []string
+	if rawFieldMaskValues, ok := rawFieldMask.([]interface{}); ok {
+		for _, rawFieldMaskValue := range rawFieldMaskValues {
+			fieldMask = append(fieldMask, rawFieldMaskValue.(string))
+		}
+	}
+
 	entity, err := convertDynamicMessageIntoMetaEntity(
-		s.firestoreClient,
 		messageFactory,
 		s.genResult.MessageMap[s.kindName],
 		rawEntity.(*dynamic.Message),
+		s.genResult.Schema,
 		s.genResult.Schema.Kinds[s.kindName],
 	)
 	if err != nil {
@@ -199,7 +228,9 @@ func (s *configstoreDynamicProtobufService) dynamicProtobufUpdate(srv interface{
 
 	metaServer := s.getMetaServiceServer()
 	resp, err := metaServer.MetaUpdate(ctx, &MetaUpdateEntityRequest{
-		Entity: entity,
+		Entity:          entity,
+		ExpectedVersion: expectedVersion,
+		FieldMask:       fieldMask,
 	})
 	if err != nil {
 		return nil, err
@@ -210,6 +241,7 @@ func (s *configstoreDynamicProtobufService) dynamicProtobufUpdate(srv interface{
 		s.genResult.MessageMap[s.kindName],
 		resp.Entity,
 		s.genResult.CommonMessageDescriptors,
+		s.genResult.Schema,
 		s.genResult.KindMap[s.service],
 	)
 	if err != nil {
@@ -242,10 +274,10 @@ func (s *configstoreDynamicProtobufService) dynamicProtobufCreate(srv interface{
 	}
 
 	entity, err := convertDynamicMessageIntoMetaEntity(
-		s.firestoreClient,
 		messageFactory,
 		s.genResult.MessageMap[s.kindName],
 		rawEntity.(*dynamic.Message),
+		s.genResult.Schema,
 		s.genResult.Schema.Kinds[s.kindName],
 	)
 	if err != nil {
@@ -266,6 +298,7 @@ func (s *configstoreDynamicProtobufService) dynamicProtobufCreate(srv interface{
 		s.genResult.MessageMap[s.kindName],
 		resp.Entity,
 		s.genResult.CommonMessageDescriptors,
+		s.genResult.Schema,
 		s.genResult.KindMap[s.service],
 	)
 	if err != nil {
@@ -298,10 +331,17 @@ func (s *configstoreDynamicProtobufService) dynamicProtobufDelete(srv interface{
 		return nil, fmt.Errorf("unable to read key")
 	}
 
+	rawExpectedVersion, err := in.TryGetFieldByName("expectedVersion")
+	if err != nil {
+		return nil, err
+	}
+	expectedVersion, _ := rawExpectedVersion.(int64)
+
 	metaServer := s.getMetaServiceServer()
 	resp, err := metaServer.MetaDelete(ctx, &MetaDeleteEntityRequest{
-		Key:      key,
-		KindName: s.kindName,
+		Key:             key,
+		KindName:        s.kindName,
+		ExpectedVersion: expectedVersion,
 	})
 	if err != nil {
 		return nil, err
@@ -312,6 +352,7 @@ func (s *configstoreDynamicProtobufService) dynamicProtobufDelete(srv interface{
 		s.genResult.MessageMap[s.kindName],
 		resp.Entity,
 		s.genResult.CommonMessageDescriptors,
+		s.genResult.Schema,
 		s.genResult.KindMap[s.service],
 	)
 	if err != nil {
@@ -325,28 +366,186 @@ func (s *configstoreDynamicProtobufService) dynamicProtobufDelete(srv interface{
 	return out, nil
 }
 
+func (s *configstoreDynamicProtobufService) convertMetaEntityVersionToDynamicMessage(messageFactory *dynamic.MessageFactory, version *MetaEntityVersion) (*dynamic.Message, error) {
+	out := messageFactory.NewDynamicMessage(s.genResult.MessageMap[fmt.Sprintf("%sVersion", s.kindName)])
+	out.SetFieldByName("version", version.Version)
+	out.SetFieldByName("transactionId", version.TransactionId)
+	out.SetFieldByName("authSub", version.AuthSub)
+	out.SetFieldByName("dateSubmitted", version.DateSubmitted)
+	out.SetFieldByName("deleted", version.Deleted)
+	if version.Entity != nil {
+		entity, err := convertMetaEntityToDynamicMessage(
+			messageFactory,
+			s.genResult.MessageMap[s.kindName],
+			version.Entity,
+			s.genResult.CommonMessageDescriptors,
+			s.genResult.Schema,
+			s.genResult.KindMap[s.service],
+		)
+		if err != nil {
+			return nil, err
+		}
+		out.SetFieldByName("entity", entity)
+	}
+	return out, nil
+}
+
+func (s *configstoreDynamicProtobufService) dynamicProtobufGetHistory(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	messageFactory := dynamic.NewMessageFactoryWithDefaults()
+
+	requestMessageDescriptor := s.genResult.MessageMap[fmt.Sprintf("GetHistory%sRequest", s.kindName)]
+	in := messageFactory.NewDynamicMessage(requestMessageDescriptor)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+
+	rawKey, err := in.TryGetFieldByName("key")
+	if err != nil {
+		return nil, err
+	}
+
+	key, ok := rawKey.(*Key)
+	if !ok {
+		return nil, fmt.Errorf("unable to read key")
+	}
+	if getKeyKindName(key) != s.kindName {
+		return nil, fmt.Errorf("key is not a %s key", s.kindName)
+	}
+
+	metaServer := s.getMetaServiceServer()
+	resp, err := metaServer.MetaGetHistory(ctx, &MetaGetHistoryRequest{
+		Key: key,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var versions []*dynamic.Message
+	for _, version := range resp.Versions {
+		message, err := s.convertMetaEntityVersionToDynamicMessage(messageFactory, version)
+		if err != nil {
+			return nil, err
+		}
+		versions = append(versions, message)
+	}
+
+	responseMessageDescriptor := s.genResult.MessageMap[fmt.Sprintf("GetHistory%sResponse", s.kindName)]
+	out := messageFactory.NewDynamicMessage(responseMessageDescriptor)
+	out.SetFieldByName("versions", versions)
+
+	return out, nil
+}
+
+func (s *configstoreDynamicProtobufService) dynamicProtobufGetAtVersion(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	messageFactory := dynamic.NewMessageFactoryWithDefaults()
+
+	requestMessageDescriptor := s.genResult.MessageMap[fmt.Sprintf("GetAtVersion%sRequest", s.kindName)]
+	in := messageFactory.NewDynamicMessage(requestMessageDescriptor)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+
+	rawKey, err := in.TryGetFieldByName("key")
+	if err != nil {
+		return nil, err
+	}
+	rawVersion, err := in.TryGetFieldByName("version")
+	if err != nil {
+		return nil, err
+	}
+
+	key, ok := rawKey.(*Key)
+	if !ok {
+		return nil, fmt.Errorf("unable to read key")
+	}
+	if getKeyKindName(key) != s.kindName {
+		return nil, fmt.Errorf("key is not a %s key", s.kindName)
+	}
+	version, _ := rawVersion.(int64)
+
+	metaServer := s.getMetaServiceServer()
+	resp, err := metaServer.MetaGetAtVersion(ctx, &MetaGetAtVersionRequest{
+		Key:     key,
+		Version: version,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	message, err := s.convertMetaEntityVersionToDynamicMessage(messageFactory, resp.EntityVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	responseMessageDescriptor := s.genResult.MessageMap[fmt.Sprintf("GetAtVersion%sResponse", s.kindName)]
+	out := messageFactory.NewDynamicMessage(responseMessageDescriptor)
+	out.SetFieldByName("entityVersion", message)
+
+	return out, nil
+}
+
 func (s *configstoreDynamicProtobufService) dynamicProtobufWatch(srv interface{}, ctx context.Context, stream grpc.ServerStream) error {
 	messageFactory := dynamic.NewMessageFactoryWithDefaults()
 
-	snapshots := s.firestoreClient.Collection(s.kindName).Snapshots(ctx)
+	requestMessageDescriptor := s.genResult.MessageMap[fmt.Sprintf("Watch%sRequest", s.kindName)]
+	in := messageFactory.NewDynamicMessage(requestMessageDescriptor)
+	if err := stream.RecvMsg(in); err != nil {
+		return err
+	}
+	partitionID, err := getPartitionIdFromDynamicMessage(in)
+	if err != nil {
+		return err
+	}
+	partitionID, err = resolveTenantPartitionId(ctx, partitionID)
+	if err != nil {
+		return err
+	}
+	var namespace string
+	if partitionID != nil {
+		namespace = partitionID.Namespace
+	}
+	ancestor, err := getAncestorFromDynamicMessage(in)
+	if err != nil {
+		return err
+	}
+
+	query := &storageQuery{
+		Namespace:      namespace,
+		KindName:       s.kindName,
+		AllDescendants: len(s.genResult.KindMap[s.service].Ancestors) > 0,
+	}
+	if ancestor != nil {
+		if isKeyIncomplete(ancestor) {
+			return status.Error(codes.InvalidArgument, "ancestor must be a complete key")
+		}
+		if tenant, ok := getContextTenant(ctx); ok {
+			err = enforceTenantKey(tenant, ancestor)
+			if err != nil {
+				return err
+			}
+		}
+		query.Parent = ancestor
+		query.AllDescendants = true
+	}
+
+	snapshots := s.storage.Snapshots(ctx, query)
 	for true {
 		snapshot, err := snapshots.Next()
 		if err != nil {
 			return err
 		}
 		for _, change := range snapshot.Changes {
-			metaEntity, err := convertSnapshotToMetaEntity(
+			metaEntity := convertSnapshotToReadableMetaEntity(
+				s.genResult.Schema,
 				s.genResult.KindMap[s.service],
-				change.Doc,
+				change.Snapshot,
 			)
-			if err != nil {
-				return err
-			}
 			message, err := convertMetaEntityToDynamicMessage(
 				messageFactory,
 				s.genResult.MessageMap[s.kindName],
 				metaEntity,
 				s.genResult.CommonMessageDescriptors,
+				s.genResult.Schema,
 				s.genResult.KindMap[s.service],
 			)
 			if err != nil {
@@ -356,11 +555,11 @@ func (s *configstoreDynamicProtobufService) dynamicProtobufWatch(srv interface{}
 			responseMessageDescriptor := s.genResult.MessageMap[fmt.Sprintf("Watch%sEvent", s.kindName)]
 			out := messageFactory.NewDynamicMessage(responseMessageDescriptor)
 			switch change.Kind {
-			case firestore.DocumentAdded:
+			case storageDocumentAdded:
 				out.SetFieldByName("type", s.genResult.WatchTypeEnumValues.Created.GetNumber())
-			case firestore.DocumentModified:
+			case storageDocumentModified:
 				out.SetFieldByName("type", s.genResult.WatchTypeEnumValues.Updated.GetNumber())
-			case firestore.DocumentRemoved:
+			case storageDocumentRemoved:
 				out.SetFieldByName("type", s.genResult.WatchTypeEnumValues.Deleted.GetNumber())
 			}
 			out.SetFieldByName("entity", message)
@@ -373,3 +572,53 @@ func (s *configstoreDynamicProtobufService) dynamicProtobufWatch(srv interface{}
 
 	return nil
 }
+
+func getPartitionIdFromDynamicMessage(in *dynamic.Message) (*PartitionId, error) {
+	partitionIDRaw, err := in.TryGetFieldByName("partitionId")
+	if err != nil {
+		return nil, err
+	}
+	if partitionIDRaw == nil {
+		return nil, nil
+	}
+	switch partitionID := partitionIDRaw.(type) {
+	case *PartitionId:
+		return partitionID, nil
+	case *dynamic.Message:
+		result := &PartitionId{}
+		err = partitionID.ConvertTo(result)
+		if err != nil {
+			return nil, err
+		}
+		return result, nil
+	}
+	return nil, fmt.Errorf("partitionId of unexpected type")
+}
+
+func getAncestorFromDynamicMessage(in *dynamic.Message) (*Key, error) {
+	ancestorRaw, err := in.TryGetFieldByName("ancestor")
+	if err != nil {
+		return nil, err
+	}
+	if ancestorRaw == nil {
+		return nil, nil
+	}
+	switch ancestor := ancestorRaw.(type) {
+	case *Key:
+		if ancestor == nil || len(ancestor.Path) == 0 {
+			return nil, nil
+		}
+		return ancestor, nil
+	case *dynamic.Message:
+		result := &Key{}
+		err = ancestor.ConvertTo(result)
+		if err != nil {
+			return nil, err
+		}
+		if len(result.Path) == 0 {
+			return nil, nil
+		}
+		return result, nil
+	}
+	return nil, fmt.Errorf("ancestor of unexpected type")
+}
@@ -9,59 +9,75 @@ import (
 	"github.com/jhump/protoreflect/dynamic"
 
 	"google.golang.org/grpc"
-
-	"cloud.google.com/go/firestore"
 )
 
 type configstoreDynamicProtobufTransactionService struct {
-	firestoreClient      *firestore.Client
+	storage              storageBackend
 	genResult            *generatorResult
 	service              *builder.ServiceBuilder
 	schema               *Schema
 	transactionProcessor *transactionProcessor
-	transactionWatcher   *transactionWatcher
+	transactionWatchers  *transactionWatcherSet
 }
 
 func createConfigstoreDynamicProtobufTransactionServer(
-	firestoreClient *firestore.Client,
+	storage storageBackend,
 	genResult *generatorResult,
 	service *builder.ServiceBuilder,
 	schema *Schema,
-	transactionWatcher *transactionWatcher,
+	transactionWatchers *transactionWatcherSet,
 ) *configstoreDynamicProtobufTransactionService {
 	return &configstoreDynamicProtobufTransactionService{
-		firestoreClient:      firestoreClient,
+		storage:              storage,
 		genResult:            genResult,
 		service:              service,
 		schema:               schema,
-		transactionProcessor: createTransactionProcessor(firestoreClient),
-		transactionWatcher:   transactionWatcher,
+		transactionProcessor: createTransactionProcessor(storage),
+		transactionWatchers:  transactionWatchers,
 	}
 }
 
 func (s *configstoreDynamicProtobufTransactionService) getMetaServiceServer() *configstoreMetaServiceServer {
 	return createConfigstoreMetaServiceServer(
-		s.firestoreClient,
+		s.storage,
 		s.schema,
 		s.transactionProcessor,
-		s.transactionWatcher,
+		s.transactionWatchers,
 	)
 }
 
 func (s *configstoreDynamicProtobufTransactionService) dynamicProtobufTransactionWatch(ctx context.Context, srv interface{}, stream grpc.ServerStream) error {
 	messageFactory := dynamic.NewMessageFactoryWithDefaults()
 
-	if !s.transactionWatcher.isConsistent {
+	requestMessageDescriptor := s.genResult.MessageMap["TypedWatchTransactionsRequest"]
+	in := messageFactory.NewDynamicMessage(requestMessageDescriptor)
+	if err := stream.RecvMsg(in); err != nil {
+		return err
+	}
+	partitionID, err := getPartitionIdFromDynamicMessage(in)
+	if err != nil {
+		return err
+	}
+	partitionID, err = resolveTenantPartitionId(ctx, partitionID)
+	if err != nil {
+		return err
+	}
+	transactionWatcher, err := s.transactionWatchers.Get(partitionID)
+	if err != nil {
+		return err
+	}
+
+	if !transactionWatcher.isConsistent {
 		return fmt.Errorf("configstore is not yet transactionally consistent because it is starting up, please try again in a moment")
 	}
 
 	// lock before registering for notifications, so we don't miss any transactions
 	// that get applied to the entity state
-	s.transactionWatcher.CurrentEntitiesTakeReadLock()
+	transactionWatcher.CurrentEntitiesTakeReadLock()
 	hasReadLock := true
 	releaseLockIfHeld := func() {
 		if hasReadLock {
-			s.transactionWatcher.CurrentEntitiesReleaseReadLock()
+			transactionWatcher.CurrentEntitiesReleaseReadLock()
 			hasReadLock = false
 		}
 	}
@@ -69,8 +85,8 @@ func (s *configstoreDynamicProtobufTransactionService) dynamicProtobufTransactio
 
 	// register for new transaction notifications
 	ch := make(chan *MetaTransactionBatch)
-	s.transactionWatcher.RegisterChannel(ch)
-	defer s.transactionWatcher.DeregisterChannel(ch)
+	transactionWatcher.RegisterChannel(ch)
+	defer transactionWatcher.DeregisterChannel(ch)
 
 	// send down the initial state of the database
 	initialState := messageFactory.NewDynamicMessage(s.genResult.MessageMap["TypedTransactionInitialState"])
@@ -79,25 +95,21 @@ func (s *configstoreDynamicProtobufTransactionService) dynamicProtobufTransactio
 		Type:       ConfigstoreTraceEntry_INITIAL_STATE_SEND_BEGIN,
 	})
 	var transactionEntities []*dynamic.Message
-	for _, snapshot := range s.transactionWatcher.currentEntities {
-		key, err := convertDocumentRefToMetaKey(snapshot.Ref)
-		if err != nil {
-			return err
-		}
+	for _, snapshot := range transactionWatcher.currentEntities {
+		key := snapshot.Key
 		kindName := key.Path[len(key.Path)-1].Kind
-		kind := s.transactionWatcher.schema.Kinds[kindName]
-		metaEntity, err := convertSnapshotToMetaEntity(
+		kind := transactionWatcher.schema.Kinds[kindName]
+		metaEntity := convertSnapshotToReadableMetaEntity(
+			s.genResult.Schema,
 			kind,
 			snapshot,
 		)
-		if err != nil {
-			return err
-		}
 		entityMessage, err := convertMetaEntityToDynamicMessage(
 			messageFactory,
 			s.genResult.MessageMap[kindName],
 			metaEntity,
 			s.genResult.CommonMessageDescriptors,
+			s.genResult.Schema,
 			kind,
 		)
 		if err != nil {
@@ -159,12 +171,13 @@ func (s *configstoreDynamicProtobufTransactionService) dynamicProtobufTransactio
 			var mutatedEntities []*dynamic.Message
 			for _, mutatedEntity := range msg.MutatedEntities {
 				kindName := mutatedEntity.Key.Path[len(mutatedEntity.Key.Path)-1].Kind
-				kind := s.transactionWatcher.schema.Kinds[kindName]
+				kind := transactionWatcher.schema.Kinds[kindName]
 				typedMutatedEntity, err := convertMetaEntityToDynamicMessage(
 					messageFactory,
 					s.genResult.MessageMap[kindName],
 					mutatedEntity,
 					s.genResult.CommonMessageDescriptors,
+					s.genResult.Schema,
 					kind,
 				)
 				if err != nil {
@@ -3,29 +3,31 @@ package main
 import (
 	"context"
 	"fmt"
+	"sort"
 	"time"
 
-	"cloud.google.com/go/firestore"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 type configstoreMetaServiceServer struct {
-	firestoreClient      *firestore.Client
+	storage              storageBackend
 	schema               *Schema
 	transactionProcessor *transactionProcessor
-	transactionWatcher   *transactionWatcher
+	transactionWatchers  *transactionWatcherSet
 }
 
 func createConfigstoreMetaServiceServer(
-	firestoreClient *firestore.Client,
+	storage storageBackend,
 	schema *Schema,
 	transactionProcessor *transactionProcessor,
-	transactionWatcher *transactionWatcher,
+	transactionWatchers *transactionWatcherSet,
 ) *configstoreMetaServiceServer {
 	return &configstoreMetaServiceServer{
-		firestoreClient:      firestoreClient,
+		storage:              storage,
 		schema:               schema,
 		transactionProcessor: transactionProcessor,
-		transactionWatcher:   transactionWatcher,
+		transactionWatchers:  transactionWatchers,
 	}
 }
 
@@ -36,11 +38,13 @@ func (s *configstoreMetaServiceServer) GetSchema(ctx context.Context, req *GetSc
 }
 
 func (s *configstoreMetaServiceServer) GetDefaultPartitionId(ctx context.Context, req *GetDefaultPartitionIdRequest) (*GetDefaultPartitionIdResponse, error) {
-	firestoreTestCollection := s.firestoreClient.Collection("Test")
-	firestoreNamespace := firestoreTestCollection.Path[0:(len(firestoreTestCollection.Path) - len(firestoreTestCollection.ID) - 1)]
-
+	if tenant, ok := getContextTenant(ctx); ok {
+		return &GetDefaultPartitionIdResponse{
+			Namespace: tenant,
+		}, nil
+	}
 	return &GetDefaultPartitionIdResponse{
-		Namespace: firestoreNamespace,
+		Namespace: s.storage.DefaultNamespace(),
 	}, nil
 }
 
@@ -62,7 +66,7 @@ func (s *configstoreMetaServiceServer) MetaList(ctx context.Context, req *MetaLi
 		return nil, err
 	}
 	if resp.OperationResults[0].Error != nil {
-		return nil, fmt.Errorf("%s", resp.OperationResults[0].Error.ErrorMessage)
+		return nil, convertOperationResultError(resp.OperationResults[0].Error)
 	}
 	return resp.OperationResults[0].GetListResponse(), nil
 }
@@ -85,7 +89,7 @@ func (s *configstoreMetaServiceServer) MetaGet(ctx context.Context, req *MetaGet
 		return nil, err
 	}
 	if resp.OperationResults[0].Error != nil {
-		return nil, fmt.Errorf("%s", resp.OperationResults[0].Error.ErrorMessage)
+		return nil, convertOperationResultError(resp.OperationResults[0].Error)
 	}
 	return resp.OperationResults[0].GetGetResponse(), nil
 }
@@ -108,7 +112,7 @@ func (s *configstoreMetaServiceServer) MetaUpdate(ctx context.Context, req *Meta
 		return nil, err
 	}
 	if resp.OperationResults[0].Error != nil {
-		return nil, fmt.Errorf("%s", resp.OperationResults[0].Error.ErrorMessage)
+		return nil, convertOperationResultError(resp.OperationResults[0].Error)
 	}
 	return resp.OperationResults[0].GetUpdateResponse(), nil
 }
@@ -131,7 +135,7 @@ func (s *configstoreMetaServiceServer) MetaDelete(ctx context.Context, req *Meta
 		return nil, err
 	}
 	if resp.OperationResults[0].Error != nil {
-		return nil, fmt.Errorf("%s", resp.OperationResults[0].Error.ErrorMessage)
+		return nil, convertOperationResultError(resp.OperationResults[0].Error)
 	}
 	return resp.OperationResults[0].GetDeleteResponse(), nil
 }
@@ -154,7 +158,7 @@ func (s *configstoreMetaServiceServer) MetaCreate(ctx context.Context, req *Meta
 		return nil, err
 	}
 	if resp.OperationResults[0].Error != nil {
-		return nil, fmt.Errorf("%s", resp.OperationResults[0].Error.ErrorMessage)
+		return nil, convertOperationResultError(resp.OperationResults[0].Error)
 	}
 	return resp.OperationResults[0].GetCreateResponse(), nil
 }
@@ -169,25 +173,206 @@ func (s *configstoreMetaServiceServer) ApplyTransaction(ctx context.Context, req
 }
 
 func (s *configstoreMetaServiceServer) GetTransactionQueueCount(ctx context.Context, req *GetTransactionQueueCountRequest) (*GetTransactionQueueCountResponse, error) {
-	s.transactionWatcher.transactionsLock.RLock()
-	defer s.transactionWatcher.transactionsLock.RUnlock()
+	partitionID, err := resolveTenantPartitionId(ctx, req.PartitionId)
+	if err != nil {
+		return nil, err
+	}
+	transactionWatcher, err := s.transactionWatchers.Get(partitionID)
+	if err != nil {
+		return nil, err
+	}
+
+	transactionWatcher.transactionsLock.RLock()
+	defer transactionWatcher.transactionsLock.RUnlock()
 	return &GetTransactionQueueCountResponse{
-		TransactionQueueCount: uint32(len(s.transactionWatcher.transactions)),
+		TransactionQueueCount: uint32(len(transactionWatcher.transactions)),
+	}, nil
+}
+
+func (s *configstoreMetaServiceServer) RestoreToTime(ctx context.Context, req *RestoreToTimeRequest) (*RestoreToTimeResponse, error) {
+	if !isAdmin(ctx) {
+		return nil, status.Error(codes.PermissionDenied, "restoring requires the admin scope")
+	}
+	if req.Timestamp == nil {
+		return nil, status.Error(codes.InvalidArgument, "timestamp must be set")
+	}
+	partitionID, err := resolveTenantPartitionId(ctx, req.PartitionId)
+	if err != nil {
+		return nil, err
+	}
+	var namespace string
+	if partitionID != nil {
+		namespace = partitionID.Namespace
+	}
+	return s.transactionProcessor.restoreToTime(
+		ctx,
+		s.schema,
+		normalizeNamespace(s.storage, namespace),
+		convertTimestampToTime(req.Timestamp),
+	)
+}
+
+// getHistoryKey validates the key of an entity whose history is being
+// read, returning it with the namespace resolved along with its kind.
+func (s *configstoreMetaServiceServer) getHistoryKey(ctx context.Context, key *Key) (*Key, *SchemaKind, error) {
+	if key == nil || len(key.Path) == 0 || isKeyIncomplete(key) {
+		return nil, nil, status.Error(codes.InvalidArgument, "key must be set and complete")
+	}
+	if tenant, ok := getContextTenant(ctx); ok {
+		err := enforceTenantKey(tenant, key)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+	kindInfo, err := findSchemaKindByName(s.schema, getKeyKindName(key))
+	if err != nil {
+		return nil, nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return normalizeEntityKey(s.storage, key), kindInfo, nil
+}
+
+func (s *configstoreMetaServiceServer) MetaGetHistory(ctx context.Context, req *MetaGetHistoryRequest) (*MetaGetHistoryResponse, error) {
+	key, kindInfo, err := s.getHistoryKey(ctx, req.Key)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshots, err := s.storage.Query(ctx, &storageQuery{
+		Parent:   key,
+		KindName: entityVersionKind,
+		OrderBy:  "version",
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &MetaGetHistoryResponse{}
+	for _, snapshot := range snapshots {
+		version, err := convertSnapshotToMetaEntityVersion(s.schema, kindInfo, key, snapshot)
+		if err != nil {
+			return nil, err
+		}
+		resp.Versions = append(resp.Versions, version)
+	}
+	return resp, nil
+}
+
+func (s *configstoreMetaServiceServer) MetaGetAtVersion(ctx context.Context, req *MetaGetAtVersionRequest) (*MetaGetAtVersionResponse, error) {
+	key, kindInfo, err := s.getHistoryKey(ctx, req.Key)
+	if err != nil {
+		return nil, err
+	}
+	if req.Version <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "version must be 1 or greater")
+	}
+
+	var snapshot *storageSnapshot
+	err = s.storage.RunTransaction(ctx, func(ctx context.Context, tx storageTransaction) error {
+		var err error
+		snapshot, err = tx.Get(getEntityVersionKey(key, req.Version))
+		return err
+	})
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return nil, status.Error(codes.NotFound, fmt.Sprintf("version %d of %s does not exist", req.Version, serializeKey(key)))
+		}
+		return nil, err
+	}
+
+	version, err := convertSnapshotToMetaEntityVersion(s.schema, kindInfo, key, snapshot)
+	if err != nil {
+		return nil, err
+	}
+	return &MetaGetAtVersionResponse{
+		EntityVersion: version,
 	}, nil
 }
 
+func (s *configstoreMetaServiceServer) FindReferencing(ctx context.Context, req *FindReferencingRequest) (*FindReferencingResponse, error) {
+	if req.Key == nil || len(req.Key.Path) == 0 || isKeyIncomplete(req.Key) {
+		return nil, status.Error(codes.InvalidArgument, "key must be set and complete")
+	}
+	if tenant, ok := getContextTenant(ctx); ok {
+		err := enforceTenantKey(tenant, req.Key)
+		if err != nil {
+			return nil, err
+		}
+	}
+	transactionWatcher, err := s.transactionWatchers.Get(req.Key.PartitionId)
+	if err != nil {
+		return nil, err
+	}
+
+	if !transactionWatcher.isConsistent {
+		return nil, fmt.Errorf("configstore is not yet transactionally consistent because it is starting up, please try again in a moment")
+	}
+
+	target := serializeKey(normalizeEntityKey(s.storage, req.Key))
+
+	transactionWatcher.CurrentEntitiesTakeReadLock()
+	defer transactionWatcher.CurrentEntitiesReleaseReadLock()
+
+	resp := &FindReferencingResponse{}
+	for _, snapshot := range transactionWatcher.currentEntities {
+		kindName := getKeyKindName(snapshot.Key)
+		kindInfo, ok := transactionWatcher.schema.Kinds[kindName]
+		if !ok {
+			continue
+		}
+		var entity *MetaEntity
+		for _, field := range kindInfo.Fields {
+			found := false
+			forEachFieldKey(transactionWatcher.schema, field, snapshot.Data[field.Name], func(keyField *SchemaField, value *Key) {
+				if serializeKey(normalizeEntityKey(s.storage, value)) == target {
+					found = true
+				}
+			})
+			if !found {
+				continue
+			}
+			if entity == nil {
+				entity = convertSnapshotToReadableMetaEntity(transactionWatcher.schema, kindInfo, snapshot)
+			}
+			resp.Entities = append(resp.Entities, &MetaReferencingEntity{
+				KindName:  kindName,
+				FieldName: field.Name,
+				Entity:    entity,
+			})
+		}
+	}
+
+	sort.Slice(resp.Entities, func(i, j int) bool {
+		a := serializeKey(resp.Entities[i].Entity.Key)
+		b := serializeKey(resp.Entities[j].Entity.Key)
+		if a != b {
+			return a < b
+		}
+		return resp.Entities[i].FieldName < resp.Entities[j].FieldName
+	})
+	return resp, nil
+}
+
 func (s *configstoreMetaServiceServer) WatchTransactions(req *WatchTransactionsRequest, srv ConfigstoreMetaService_WatchTransactionsServer) error {
-	if !s.transactionWatcher.isConsistent {
+	partitionID, err := resolveTenantPartitionId(srv.Context(), req.PartitionId)
+	if err != nil {
+		return err
+	}
+	transactionWatcher, err := s.transactionWatchers.Get(partitionID)
+	if err != nil {
+		return err
+	}
+
+	if !transactionWatcher.isConsistent {
 		return fmt.Errorf("configstore is not yet transactionally consistent because it is starting up, please try again in a moment")
 	}
 
 	// lock before registering for notifications, so we don't miss any transactions
 	// that get applied to the entity state
-	s.transactionWatcher.CurrentEntitiesTakeReadLock()
+	transactionWatcher.CurrentEntitiesTakeReadLock()
 	hasReadLock := true
 	releaseLockIfHeld := func() {
 		if hasReadLock {
-			s.transactionWatcher.CurrentEntitiesReleaseReadLock()
+			transactionWatcher.CurrentEntitiesReleaseReadLock()
 			hasReadLock = false
 		}
 	}
@@ -195,8 +380,8 @@ func (s *configstoreMetaServiceServer) WatchTransactions(req *WatchTransactionsR
 
 	// register for new transaction notifications
 	ch := make(chan *MetaTransactionBatch)
-	s.transactionWatcher.RegisterChannel(ch)
-	defer s.transactionWatcher.DeregisterChannel(ch)
+	transactionWatcher.RegisterChannel(ch)
+	defer transactionWatcher.DeregisterChannel(ch)
 
 	// send down the initial state of the database
 	initialState := &MetaTransactionInitialState{}
@@ -204,18 +389,13 @@ func (s *configstoreMetaServiceServer) WatchTransactions(req *WatchTransactionsR
 		OperatorId: getTraceServiceName(),
 		Type:       ConfigstoreTraceEntry_INITIAL_STATE_SEND_BEGIN,
 	})
-	for _, snapshot := range s.transactionWatcher.currentEntities {
-		key, err := convertDocumentRefToMetaKey(snapshot.Ref)
-		if err != nil {
-			return err
-		}
-		entity, err := convertSnapshotToMetaEntity(
-			s.transactionWatcher.schema.Kinds[key.Path[len(key.Path)-1].Kind],
+	for _, snapshot := range transactionWatcher.currentEntities {
+		key := snapshot.Key
+		entity := convertSnapshotToReadableMetaEntity(
+			transactionWatcher.schema,
+			transactionWatcher.schema.Kinds[key.Path[len(key.Path)-1].Kind],
 			snapshot,
 		)
-		if err != nil {
-			return err
-		}
 		RecordTrace(&ConfigstoreTraceEntry{
 			OperatorId: getTraceServiceName(),
 			Type:       ConfigstoreTraceEntry_INITIAL_STATE_SEND_ENTITY,
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"context"
+	"time"
+
+	"testing"
+
+	"github.com/dgrijalva/jwt-go"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"gotest.tools/assert"
+)
+
+func TestRestoreToTimeRequiresAdmin(t *testing.T) {
+	genResult, err := generate("./schema.json")
+	assert.NilError(t, err)
+
+	storage := createMemoryStorage()
+	defer storage.Close()
+	server := createConfigstoreMetaServiceServer(storage, genResult.Schema, createTransactionProcessor(storage), nil)
+
+	secret := []byte("secret")
+	authEnabled, _ := authGrpcInterceptors(secret, nil, "iss", "aud", "", "", "configstore:admin")
+	authDisabled, _ := authDisabledGrpcInterceptors()
+	restore := func(interceptor grpc.UnaryServerInterceptor, scope string) error {
+		ctx := context.Background()
+		if scope != "" {
+			token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+				"sub":   "service",
+				"scope": scope,
+				"iss":   "iss",
+				"aud":   "aud",
+			}).SignedString(secret)
+			assert.NilError(t, err)
+			ctx = metadata.NewIncomingContext(ctx, metadata.Pairs("authorization", token))
+		}
+		_, err := interceptor(ctx, nil, &grpc.UnaryServerInfo{}, func(ctx context.Context, req interface{}) (interface{}, error) {
+			return server.RestoreToTime(ctx, &RestoreToTimeRequest{
+				Timestamp: convertTimeToTimestamp(time.Now()),
+			})
+		})
+		return err
+	}
+
+	assert.Equal(t, status.Code(restore(authEnabled, "")), codes.PermissionDenied)
+	assert.Equal(t, status.Code(restore(authEnabled, "configstore")), codes.PermissionDenied)
+	assert.NilError(t, restore(authEnabled, "configstore configstore:admin"))
+
+	// every caller is trusted when authentication is disabled
+	assert.NilError(t, restore(authDisabled, ""))
+}
@@ -0,0 +1,201 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"time"
+)
+
+// storageSnapshot is a point-in-time read of a single document from a
+// storage backend.
+//
+// Values inside Data are backend-neutral: references to other entities are
+// always *Key (or []interface{} of *Key), timestamps are time.Time,
+// locations are *latlng.LatLng and unsigned integers are stored as int64.
+type storageSnapshot struct {
+	Key        *Key
+	Data       map[string]interface{}
+	CreateTime time.Time
+	UpdateTime time.Time
+	ReadTime   time.Time
+
+	// Cursor is the value to pass as storageQuery.StartAfter to resume a
+	// listing immediately after this document.
+	Cursor string
+}
+
+type storageChangeKind int
+
+const (
+	storageDocumentAdded storageChangeKind = iota
+	storageDocumentModified
+	storageDocumentRemoved
+)
+
+// storageChange describes a single document changing in the result set of
+// a watched query. For removals, Snapshot is the last known version of the
+// document.
+type storageChange struct {
+	Kind     storageChangeKind
+	Snapshot *storageSnapshot
+	OldIndex int
+	NewIndex int
+}
+
+type storageQuerySnapshot struct {
+	Changes  []storageChange
+	ReadTime time.Time
+}
+
+// storageSnapshotIterator delivers the changes to a watched query. The first
+// call to Next returns every matching document as an addition.
+type storageSnapshotIterator interface {
+	Next() (*storageQuerySnapshot, error)
+	Stop()
+}
+
+type storageFilter struct {
+	Field string
+	// Op is one of "==", "<", "<=", ">", ">=" or "array-contains", which
+	// matches list values with an element equal to Value.
+	Op    string
+	Value interface{}
+}
+
+// storageQuery selects documents of a single kind. If Parent is nil, the
+// query targets top-level entities of that kind in Namespace; otherwise it
+// targets the direct children of Parent.
+type storageQuery struct {
+	// Namespace is ignored when Parent is set. An empty namespace selects
+	// the default namespace.
+	Namespace string
+	Parent    *Key
+	// AllDescendants widens the query to entities of the kind at any depth
+	// below Parent, or anywhere in Namespace if Parent is nil.
+	AllDescendants bool
+	KindName       string
+	Filters        []storageFilter
+	OrderBy        string
+	Descending     bool
+	// StartAfter is a cursor from storageSnapshot.Cursor. Setting it orders
+	// the results by document ID.
+	StartAfter string
+	// A limit of 0 or lower indicates no limit.
+	Limit int
+}
+
+// storageTransaction is the set of operations that can be performed inside
+// storageBackend.RunTransaction. All reads must happen before any writes.
+type storageTransaction interface {
+	// Get returns a gRPC NotFound error if the document does not exist.
+	Get(key *Key) (*storageSnapshot, error)
+	Query(query *storageQuery) ([]*storageSnapshot, error)
+	// Create returns a gRPC AlreadyExists error if the document exists.
+	Create(key *Key, data map[string]interface{}) error
+	Set(key *Key, data map[string]interface{}) error
+	Delete(key *Key) error
+}
+
+// storageBackend is the interface configstore uses to persist entities and
+// the transaction log. All writes made within a single transaction must be
+// reported with an identical UpdateTime, and the CreateTime of the
+// transaction log record must match it; the transaction watcher relies on
+// this to reassemble transactions from change notifications.
+type storageBackend interface {
+	RunTransaction(ctx context.Context, f func(ctx context.Context, tx storageTransaction) error) error
+	Query(ctx context.Context, query *storageQuery) ([]*storageSnapshot, error)
+	Snapshots(ctx context.Context, query *storageQuery) storageSnapshotIterator
+
+	// NewKey returns a complete key with an automatically generated name
+	// for a new entity of the given kind under parent. If parent is nil,
+	// the key is a top-level key in namespace (where an empty namespace is
+	// the default namespace).
+	NewKey(namespace string, parent *Key, kindName string) *Key
+
+	DefaultNamespace() string
+
+	// Namespaces returns the default namespace and every other namespace
+	// that has entities stored in it.
+	Namespaces(ctx context.Context) ([]string, error)
+
+	Close() error
+}
+
+var namespaceNameRegexp = regexp.MustCompile("^[A-Za-z0-9_.-]{1,100}$")
+
+// isValidNamespaceName returns whether namespace can be used as a
+// non-default namespace. The default namespace of each backend is always
+// valid, in addition to names accepted by this function.
+func isValidNamespaceName(namespace string) bool {
+	return namespaceNameRegexp.MatchString(namespace)
+}
+
+// normalizeNamespace returns the default namespace of storage if namespace
+// is empty.
+func normalizeNamespace(storage storageBackend, namespace string) string {
+	if namespace == "" {
+		return storage.DefaultNamespace()
+	}
+	return namespace
+}
+
+func getKeyNamespace(key *Key) string {
+	if key == nil || key.PartitionId == nil {
+		return ""
+	}
+	return key.PartitionId.Namespace
+}
+
+func getKeyParent(key *Key) *Key {
+	if key == nil || len(key.Path) <= 1 {
+		return nil
+	}
+	return &Key{
+		PartitionId: key.PartitionId,
+		Path:        key.Path[:len(key.Path)-1],
+	}
+}
+
+// isKeyDescendantOf returns whether key is nested, at any depth, under
+// ancestor. Namespaces aren't compared.
+func isKeyDescendantOf(key *Key, ancestor *Key) bool {
+	if key == nil || ancestor == nil || len(key.Path) <= len(ancestor.Path) {
+		return false
+	}
+	for i, pathElement := range ancestor.Path {
+		if key.Path[i].Kind != pathElement.Kind ||
+			key.Path[i].GetId() != pathElement.GetId() ||
+			key.Path[i].GetName() != pathElement.GetName() {
+			return false
+		}
+	}
+	return true
+}
+
+func getKeyKindName(key *Key) string {
+	if key == nil || len(key.Path) == 0 {
+		return ""
+	}
+	return key.Path[len(key.Path)-1].Kind
+}
+
+func isKeyIncomplete(key *Key) bool {
+	return key == nil || len(key.Path) == 0 || key.Path[len(key.Path)-1].IdType == nil
+}
+
+// getKeyIDString returns the name or numeric ID of the last path element of
+// key as a string.
+func getKeyIDString(key *Key) string {
+	if key == nil || len(key.Path) == 0 {
+		return ""
+	}
+	last := key.Path[len(key.Path)-1]
+	switch last.IdType.(type) {
+	case *PathElement_Id:
+		return fmt.Sprintf("%d", last.GetId())
+	case *PathElement_Name:
+		return last.GetName()
+	}
+	return ""
+}
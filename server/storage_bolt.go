@@ -0,0 +1,302 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	bolt "go.etcd.io/bbolt"
+	"google.golang.org/genproto/googleapis/type/latlng"
+)
+
+const boltStorageNamespace = "local"
+
+var (
+	boltDocumentsBucket = []byte("documents")
+	boltMetadataBucket  = []byte("metadata")
+	boltLastCommitKey   = []byte("lastCommitTime")
+)
+
+// boltStorage persists entities and the transaction log to a single bbolt
+// file. All documents are also held in memory, so queries and watches are
+// served exactly like the memory backend; every transaction is written to
+// the file before it becomes visible.
+type boltStorage struct {
+	*memoryStorage
+	db *bolt.DB
+}
+
+func createBoltStorage(path string) (*boltStorage, error) {
+	db, err := bolt.Open(path, 0600, &bolt.Options{Timeout: time.Second * 10})
+	if err != nil {
+		return nil, fmt.Errorf("can't open bolt database '%s': %v", path, err)
+	}
+
+	s := &boltStorage{
+		memoryStorage: createMemoryStorageWithNamespace(boltStorageNamespace),
+		db:            db,
+	}
+
+	err = db.Update(func(tx *bolt.Tx) error {
+		documents, err := tx.CreateBucketIfNotExists(boltDocumentsBucket)
+		if err != nil {
+			return err
+		}
+		metadata, err := tx.CreateBucketIfNotExists(boltMetadataBucket)
+		if err != nil {
+			return err
+		}
+
+		if raw := metadata.Get(boltLastCommitKey); raw != nil {
+			var lastCommitTime time.Time
+			err = lastCommitTime.UnmarshalBinary(raw)
+			if err != nil {
+				return err
+			}
+			s.memoryStorage.lastCommitTime = lastCommitTime
+		}
+
+		return documents.ForEach(func(k []byte, v []byte) error {
+			document, err := decodeBoltDocument(v)
+			if err != nil {
+				return fmt.Errorf("can't decode document '%s': %v", string(k), err)
+			}
+			s.memoryStorage.documents[string(k)] = document
+			return nil
+		})
+	})
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	s.memoryStorage.persist = s.persist
+	return s, nil
+}
+
+func (s *boltStorage) Close() error {
+	err := s.memoryStorage.Close()
+	if err != nil {
+		return err
+	}
+	return s.db.Close()
+}
+
+func (s *boltStorage) persist(ids []string, documents []*memoryDocument, commitTime time.Time) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(boltDocumentsBucket)
+		for i, id := range ids {
+			if documents[i] == nil {
+				err := bucket.Delete([]byte(id))
+				if err != nil {
+					return err
+				}
+				continue
+			}
+			encoded, err := encodeBoltDocument(documents[i])
+			if err != nil {
+				return err
+			}
+			err = bucket.Put([]byte(id), encoded)
+			if err != nil {
+				return err
+			}
+		}
+
+		lastCommitTime, err := commitTime.MarshalBinary()
+		if err != nil {
+			return err
+		}
+		return tx.Bucket(boltMetadataBucket).Put(boltLastCommitKey, lastCommitTime)
+	})
+}
+
+type boltDocument struct {
+	Key        []byte               `json:"key"`
+	Data       map[string]boltValue `json:"data"`
+	CreateTime time.Time            `json:"createTime"`
+	UpdateTime time.Time            `json:"updateTime"`
+}
+
+// boltValue is a tagged representation of a storage value, so that types
+// survive the round trip through JSON.
+type boltValue struct {
+	Type   string               `json:"t"`
+	Bool   bool                 `json:"b,omitempty"`
+	Int    int64                `json:"i,omitempty"`
+	Double uint64               `json:"d,omitempty"`
+	String string               `json:"s,omitempty"`
+	Bytes  []byte               `json:"y,omitempty"`
+	Time   *time.Time           `json:"ts,omitempty"`
+	Key    []byte               `json:"k,omitempty"`
+	Array  []boltValue          `json:"a,omitempty"`
+	Map    map[string]boltValue `json:"m,omitempty"`
+}
+
+func encodeBoltDocument(document *memoryDocument) ([]byte, error) {
+	key, err := proto.Marshal(document.key)
+	if err != nil {
+		return nil, err
+	}
+	data := make(map[string]boltValue)
+	for name, value := range document.data {
+		encoded, err := encodeBoltValue(value)
+		if err != nil {
+			return nil, fmt.Errorf("can't encode field '%s': %v", name, err)
+		}
+		data[name] = encoded
+	}
+	return json.Marshal(&boltDocument{
+		Key:        key,
+		Data:       data,
+		CreateTime: document.createTime,
+		UpdateTime: document.updateTime,
+	})
+}
+
+func decodeBoltDocument(raw []byte) (*memoryDocument, error) {
+	var document boltDocument
+	err := json.Unmarshal(raw, &document)
+	if err != nil {
+		return nil, err
+	}
+	key := &Key{}
+	err = proto.Unmarshal(document.Key, key)
+	if err != nil {
+		return nil, err
+	}
+	data := make(map[string]interface{})
+	for name, value := range document.Data {
+		decoded, err := decodeBoltValue(value)
+		if err != nil {
+			return nil, fmt.Errorf("can't decode field '%s': %v", name, err)
+		}
+		data[name] = decoded
+	}
+	return &memoryDocument{
+		key:        key,
+		data:       data,
+		createTime: document.CreateTime,
+		updateTime: document.UpdateTime,
+	}, nil
+}
+
+func encodeBoltValue(value interface{}) (boltValue, error) {
+	switch v := value.(type) {
+	case nil:
+		return boltValue{Type: "null"}, nil
+	case bool:
+		return boltValue{Type: "bool", Bool: v}, nil
+	case int64:
+		return boltValue{Type: "int64", Int: v}, nil
+	case float64:
+		return boltValue{Type: "double", Double: math.Float64bits(v)}, nil
+	case string:
+		return boltValue{Type: "string", String: v}, nil
+	case []byte:
+		return boltValue{Type: "bytes", Bytes: v}, nil
+	case time.Time:
+		return boltValue{Type: "timestamp", Time: &v}, nil
+	case *Key:
+		if v == nil {
+			return boltValue{Type: "null"}, nil
+		}
+		key, err := proto.Marshal(v)
+		if err != nil {
+			return boltValue{}, err
+		}
+		return boltValue{Type: "key", Key: key}, nil
+	case *latlng.LatLng:
+		if v == nil {
+			return boltValue{Type: "null"}, nil
+		}
+		return boltValue{Type: "geopoint", Array: []boltValue{
+			{Type: "double", Double: math.Float64bits(v.Latitude)},
+			{Type: "double", Double: math.Float64bits(v.Longitude)},
+		}}, nil
+	case []interface{}:
+		result := boltValue{Type: "array", Array: make([]boltValue, len(v))}
+		for i, elem := range v {
+			encoded, err := encodeBoltValue(elem)
+			if err != nil {
+				return boltValue{}, err
+			}
+			result.Array[i] = encoded
+		}
+		return result, nil
+	case map[string]interface{}:
+		result := boltValue{Type: "map", Map: make(map[string]boltValue)}
+		for k, elem := range v {
+			encoded, err := encodeBoltValue(elem)
+			if err != nil {
+				return boltValue{}, err
+			}
+			result.Map[k] = encoded
+		}
+		return result, nil
+	}
+	return boltValue{}, fmt.Errorf("unsupported value type %T", value)
+}
+
+func decodeBoltValue(value boltValue) (interface{}, error) {
+	switch value.Type {
+	case "null":
+		return nil, nil
+	case "bool":
+		return value.Bool, nil
+	case "int64":
+		return value.Int, nil
+	case "double":
+		return math.Float64frombits(value.Double), nil
+	case "string":
+		return value.String, nil
+	case "bytes":
+		if value.Bytes == nil {
+			return []byte{}, nil
+		}
+		return value.Bytes, nil
+	case "timestamp":
+		if value.Time == nil {
+			return time.Time{}, nil
+		}
+		return *value.Time, nil
+	case "key":
+		key := &Key{}
+		err := proto.Unmarshal(value.Key, key)
+		if err != nil {
+			return nil, err
+		}
+		return key, nil
+	case "geopoint":
+		if len(value.Array) != 2 {
+			return nil, fmt.Errorf("geopoint has %d coordinates", len(value.Array))
+		}
+		return &latlng.LatLng{
+			Latitude:  math.Float64frombits(value.Array[0].Double),
+			Longitude: math.Float64frombits(value.Array[1].Double),
+		}, nil
+	case "array":
+		result := make([]interface{}, len(value.Array))
+		for i, elem := range value.Array {
+			decoded, err := decodeBoltValue(elem)
+			if err != nil {
+				return nil, err
+			}
+			result[i] = decoded
+		}
+		return result, nil
+	case "map":
+		result := make(map[string]interface{})
+		for k, elem := range value.Map {
+			decoded, err := decodeBoltValue(elem)
+			if err != nil {
+				return nil, err
+			}
+			result[k] = decoded
+		}
+		return result, nil
+	}
+	return nil, fmt.Errorf("unsupported value type '%s'", value.Type)
+}
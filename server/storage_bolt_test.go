@@ -0,0 +1,75 @@
+package main
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path"
+
+	"testing"
+
+	"gotest.tools/assert"
+)
+
+func TestBoltStoragePersistsAcrossRestarts(t *testing.T) {
+	ctx := context.Background()
+
+	genResult, err := generate("./schema.json")
+	assert.NilError(t, err)
+
+	dir, err := ioutil.TempDir("", "configstore")
+	assert.NilError(t, err)
+	defer os.RemoveAll(dir)
+	dbPath := path.Join(dir, "configstore.db")
+
+	storage, err := createBoltStorage(dbPath)
+	assert.NilError(t, err)
+	resp, err := createTransactionProcessor(storage).processTransaction(ctx, genResult.Schema, &MetaTransaction{
+		Operations: []*MetaOperation{
+			&MetaOperation{
+				Operation: &MetaOperation_CreateRequest{
+					CreateRequest: &MetaCreateEntityRequest{
+						KindName: "IntegerTest",
+						Entity:   createMemoryTestEntity("a", 5),
+					},
+				},
+			},
+		},
+	})
+	assert.NilError(t, err)
+	key := resp.OperationResults[0].GetCreateResponse().Entity.Key
+	assert.NilError(t, storage.Close())
+
+	storage, err = createBoltStorage(dbPath)
+	assert.NilError(t, err)
+	defer storage.Close()
+
+	resp, err = createTransactionProcessor(storage).processTransaction(ctx, genResult.Schema, &MetaTransaction{
+		Operations: []*MetaOperation{
+			&MetaOperation{
+				Operation: &MetaOperation_GetRequest{
+					GetRequest: &MetaGetEntityRequest{
+						KindName: "IntegerTest",
+						Key:      key,
+					},
+				},
+			},
+		},
+	})
+	assert.NilError(t, err)
+	assert.Assert(t, resp.OperationResults[0].Error == nil)
+	entity := resp.OperationResults[0].GetGetResponse().Entity
+	assert.Equal(t, entity.Values[0].Uint64Value, uint64(5))
+
+	// the transaction log is persisted alongside the entities, with the
+	// same commit time
+	transactions, err := storage.Query(ctx, &storageQuery{KindName: "Transaction"})
+	assert.NilError(t, err)
+	assert.Equal(t, len(transactions), 1)
+	entities, err := storage.Query(ctx, &storageQuery{KindName: "IntegerTest"})
+	assert.NilError(t, err)
+	assert.Equal(t, len(entities), 1)
+	assert.Assert(t, entities[0].UpdateTime.Equal(transactions[0].CreateTime))
+	mutatedKeys := transactions[0].Data["mutatedKeys"].([]interface{})
+	assert.Equal(t, serializeKey(mutatedKeys[0].(*Key)), serializeKey(key))
+}
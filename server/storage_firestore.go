@@ -0,0 +1,419 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"cloud.google.com/go/firestore"
+)
+
+type firestoreStorage struct {
+	client *firestore.Client
+}
+
+func createFirestoreStorage(client *firestore.Client) *firestoreStorage {
+	return &firestoreStorage{
+		client: client,
+	}
+}
+
+func (s *firestoreStorage) DefaultNamespace() string {
+	return getFirestoreDefaultNamespace(s.client)
+}
+
+func (s *firestoreStorage) NewKey(namespace string, parent *Key, kindName string) *Key {
+	var collection *firestore.CollectionRef
+	if parent == nil {
+		namespaceRef, err := getFirestoreNamespaceRef(s.client, namespace)
+		if err != nil {
+			return nil
+		}
+		collection = getFirestoreCollectionRef(s.client, namespaceRef, kindName)
+	} else {
+		parentRef, err := convertMetaKeyToDocumentRef(s.client, parent)
+		if err != nil {
+			return nil
+		}
+		collection = parentRef.Collection(kindName)
+	}
+	key, err := convertDocumentRefToMetaKey(collection.NewDoc())
+	if err != nil {
+		return nil
+	}
+	return key
+}
+
+func (s *firestoreStorage) Namespaces(ctx context.Context) ([]string, error) {
+	namespaces := []string{s.DefaultNamespace()}
+	refs, err := s.client.Collection(firestoreNamespaceCollection).DocumentRefs(ctx).GetAll()
+	if err != nil {
+		return nil, err
+	}
+	for _, ref := range refs {
+		namespaces = append(namespaces, ref.ID)
+	}
+	return namespaces, nil
+}
+
+func (s *firestoreStorage) Close() error {
+	return s.client.Close()
+}
+
+func (s *firestoreStorage) RunTransaction(ctx context.Context, f func(ctx context.Context, tx storageTransaction) error) error {
+	return s.client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		return f(ctx, &firestoreStorageTransaction{
+			ctx:     ctx,
+			storage: s,
+			tx:      tx,
+		})
+	})
+}
+
+func (s *firestoreStorage) Query(ctx context.Context, query *storageQuery) ([]*storageSnapshot, error) {
+	queries, err := s.buildQueries(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	var documents []*firestore.DocumentSnapshot
+	for _, q := range queries {
+		results, err := q.Documents(ctx).GetAll()
+		if err != nil {
+			return nil, err
+		}
+		documents = append(documents, results...)
+	}
+	return convertFirestoreQueryResults(query, documents)
+}
+
+func (s *firestoreStorage) Snapshots(ctx context.Context, query *storageQuery) storageSnapshotIterator {
+	if query.AllDescendants {
+		return createFirestoreDescendantSnapshotIterator(ctx, s, query)
+	}
+	q, err := s.buildQuery(query)
+	if err != nil {
+		return &firestoreStorageSnapshotIterator{err: err}
+	}
+	return &firestoreStorageSnapshotIterator{
+		it: q.Snapshots(ctx),
+	}
+}
+
+// buildQueries returns the Firestore queries that together select the
+// documents matching query. Firestore can only query a single collection at
+// a time, so descendant queries become one query per collection of the kind
+// found below the parent.
+func (s *firestoreStorage) buildQueries(ctx context.Context, query *storageQuery) ([]firestore.Query, error) {
+	if !query.AllDescendants {
+		q, err := s.buildQuery(query)
+		if err != nil {
+			return nil, err
+		}
+		return []firestore.Query{q}, nil
+	}
+
+	collections, err := s.getDescendantCollections(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	queries := make([]firestore.Query, len(collections))
+	for i, collection := range collections {
+		queries[i], err = s.buildCollectionQuery(collection, query)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return queries, nil
+}
+
+// buildQuery builds the query for a query that isn't a descendant query.
+func (s *firestoreStorage) buildQuery(query *storageQuery) (firestore.Query, error) {
+	var collection *firestore.CollectionRef
+	if query.Parent == nil {
+		namespaceRef, err := getFirestoreNamespaceRef(s.client, query.Namespace)
+		if err != nil {
+			return firestore.Query{}, err
+		}
+		collection = getFirestoreCollectionRef(s.client, namespaceRef, query.KindName)
+	} else {
+		parentRef, err := convertMetaKeyToDocumentRef(s.client, query.Parent)
+		if err != nil {
+			return firestore.Query{}, err
+		}
+		collection = parentRef.Collection(query.KindName)
+	}
+	return s.buildCollectionQuery(collection, query)
+}
+
+// buildCollectionQuery applies the filters, ordering, cursor and limit of
+// query to collection. Descendant queries are merged across collections by
+// mergeFirestoreDescendantSnapshots, so the cursor and limit are left for it
+// to apply.
+func (s *firestoreStorage) buildCollectionQuery(collection *firestore.CollectionRef, query *storageQuery) (firestore.Query, error) {
+	q := collection.Query
+	for _, filter := range query.Filters {
+		value, err := convertStorageValueToFirestore(s.client, filter.Value)
+		if err != nil {
+			return firestore.Query{}, err
+		}
+		q = q.Where(filter.Field, filter.Op, value)
+	}
+	if query.OrderBy != "" {
+		if query.Descending {
+			q = q.OrderBy(query.OrderBy, firestore.Desc)
+		} else {
+			q = q.OrderBy(query.OrderBy, firestore.Asc)
+		}
+	}
+	if query.AllDescendants {
+		return q, nil
+	}
+	if query.StartAfter != "" {
+		q = q.OrderBy(firestore.DocumentID, firestore.Asc).StartAfter(query.StartAfter)
+	}
+	if query.Limit > 0 {
+		q = q.Limit(query.Limit)
+	}
+	return q, nil
+}
+
+type firestoreStorageTransaction struct {
+	ctx     context.Context
+	storage *firestoreStorage
+	tx      *firestore.Transaction
+}
+
+func (t *firestoreStorageTransaction) Get(key *Key) (*storageSnapshot, error) {
+	ref, err := convertMetaKeyToDocumentRef(t.storage.client, key)
+	if err != nil {
+		return nil, err
+	}
+	document, err := t.tx.Get(ref)
+	if err != nil {
+		return nil, err
+	}
+	return convertFirestoreSnapshot(document)
+}
+
+func (t *firestoreStorageTransaction) Query(query *storageQuery) ([]*storageSnapshot, error) {
+	if runWithoutFirestoreTransactionalQueries() {
+		return t.storage.Query(t.ctx, query)
+	}
+
+	// collections are discovered outside of the transaction, since
+	// Firestore transactions can only read documents
+	queries, err := t.storage.buildQueries(t.ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	var documents []*firestore.DocumentSnapshot
+	for _, q := range queries {
+		results, err := t.tx.Documents(q).GetAll()
+		if err != nil {
+			return nil, err
+		}
+		documents = append(documents, results...)
+	}
+	return convertFirestoreQueryResults(query, documents)
+}
+
+func (t *firestoreStorageTransaction) Create(key *Key, data map[string]interface{}) error {
+	ref, firestoreData, err := t.convertWrite(key, data)
+	if err != nil {
+		return err
+	}
+	return t.tx.Create(ref, firestoreData)
+}
+
+func (t *firestoreStorageTransaction) Set(key *Key, data map[string]interface{}) error {
+	ref, firestoreData, err := t.convertWrite(key, data)
+	if err != nil {
+		return err
+	}
+	return t.tx.Set(ref, firestoreData)
+}
+
+func (t *firestoreStorageTransaction) Delete(key *Key) error {
+	ref, err := convertMetaKeyToDocumentRef(t.storage.client, key)
+	if err != nil {
+		return err
+	}
+	return t.tx.Delete(ref)
+}
+
+func (t *firestoreStorageTransaction) convertWrite(key *Key, data map[string]interface{}) (*firestore.DocumentRef, map[string]interface{}, error) {
+	if isKeyIncomplete(key) {
+		return nil, nil, fmt.Errorf("key must be complete when writing to Firestore")
+	}
+	ref, err := convertMetaKeyToDocumentRef(t.storage.client, key)
+	if err != nil {
+		return nil, nil, err
+	}
+	firestoreData, err := convertStorageValueToFirestore(t.storage.client, data)
+	if err != nil {
+		return nil, nil, err
+	}
+	return ref, firestoreData.(map[string]interface{}), nil
+}
+
+type firestoreStorageSnapshotIterator struct {
+	it  *firestore.QuerySnapshotIterator
+	err error
+}
+
+func (i *firestoreStorageSnapshotIterator) Next() (*storageQuerySnapshot, error) {
+	if i.err != nil {
+		return nil, i.err
+	}
+	snapshot, err := i.it.Next()
+	if err != nil {
+		return nil, err
+	}
+	result := &storageQuerySnapshot{
+		ReadTime: snapshot.ReadTime,
+	}
+	for _, change := range snapshot.Changes {
+		converted, err := convertFirestoreSnapshot(change.Doc)
+		if err != nil {
+			return nil, err
+		}
+		var kind storageChangeKind
+		switch change.Kind {
+		case firestore.DocumentAdded:
+			kind = storageDocumentAdded
+		case firestore.DocumentModified:
+			kind = storageDocumentModified
+		case firestore.DocumentRemoved:
+			kind = storageDocumentRemoved
+		}
+		result.Changes = append(result.Changes, storageChange{
+			Kind:     kind,
+			Snapshot: converted,
+			OldIndex: change.OldIndex,
+			NewIndex: change.NewIndex,
+		})
+	}
+	return result, nil
+}
+
+func (i *firestoreStorageSnapshotIterator) Stop() {
+	if i.it != nil {
+		i.it.Stop()
+	}
+}
+
+// convertFirestoreQueryResults converts the documents returned by the
+// queries from buildQueries into the results of query.
+func convertFirestoreQueryResults(query *storageQuery, documents []*firestore.DocumentSnapshot) ([]*storageSnapshot, error) {
+	snapshots, err := convertFirestoreSnapshots(documents)
+	if err != nil {
+		return nil, err
+	}
+	if query.AllDescendants {
+		return mergeFirestoreDescendantSnapshots(query, snapshots), nil
+	}
+	return snapshots, nil
+}
+
+func convertFirestoreSnapshots(documents []*firestore.DocumentSnapshot) ([]*storageSnapshot, error) {
+	var snapshots []*storageSnapshot
+	for _, document := range documents {
+		snapshot, err := convertFirestoreSnapshot(document)
+		if err != nil {
+			return nil, err
+		}
+		snapshots = append(snapshots, snapshot)
+	}
+	return snapshots, nil
+}
+
+func convertFirestoreSnapshot(document *firestore.DocumentSnapshot) (*storageSnapshot, error) {
+	key, err := convertDocumentRefToMetaKey(document.Ref)
+	if err != nil {
+		return nil, fmt.Errorf("error while converting firestore ref to meta key: %v", err)
+	}
+	data, _ := convertFirestoreValueToStorage(document.Data()).(map[string]interface{})
+	return &storageSnapshot{
+		Key:        key,
+		Data:       data,
+		CreateTime: document.CreateTime,
+		UpdateTime: document.UpdateTime,
+		ReadTime:   document.ReadTime,
+		Cursor:     document.Ref.ID,
+	}, nil
+}
+
+// convertStorageValueToFirestore converts *Key values (including those nested
+// in slices and maps) into Firestore document references.
+func convertStorageValueToFirestore(client *firestore.Client, value interface{}) (interface{}, error) {
+	switch v := value.(type) {
+	case *Key:
+		if v == nil {
+			return nil, nil
+		}
+		return convertMetaKeyToDocumentRef(client, v)
+	case []*Key:
+		var refs []*firestore.DocumentRef
+		for _, key := range v {
+			ref, err := convertMetaKeyToDocumentRef(client, key)
+			if err != nil {
+				return nil, err
+			}
+			refs = append(refs, ref)
+		}
+		return refs, nil
+	case []interface{}:
+		result := make([]interface{}, len(v))
+		for i, elem := range v {
+			converted, err := convertStorageValueToFirestore(client, elem)
+			if err != nil {
+				return nil, err
+			}
+			result[i] = converted
+		}
+		return result, nil
+	case map[string]interface{}:
+		result := make(map[string]interface{})
+		for k, elem := range v {
+			converted, err := convertStorageValueToFirestore(client, elem)
+			if err != nil {
+				return nil, err
+			}
+			result[k] = converted
+		}
+		return result, nil
+	default:
+		return value, nil
+	}
+}
+
+// convertFirestoreValueToStorage converts Firestore document references
+// (including those nested in arrays and maps) back into *Key values.
+func convertFirestoreValueToStorage(value interface{}) interface{} {
+	switch v := value.(type) {
+	case *firestore.DocumentRef:
+		if v == nil {
+			return (*Key)(nil)
+		}
+		key, err := convertDocumentRefToMetaKey(v)
+		if err != nil {
+			log.Printf("error while converting firestore ref to meta key: %v", err)
+			return (*Key)(nil)
+		}
+		return key
+	case []interface{}:
+		result := make([]interface{}, len(v))
+		for i, elem := range v {
+			result[i] = convertFirestoreValueToStorage(elem)
+		}
+		return result
+	case map[string]interface{}:
+		result := make(map[string]interface{})
+		for k, elem := range v {
+			result[k] = convertFirestoreValueToStorage(elem)
+		}
+		return result
+	default:
+		return value
+	}
+}
@@ -0,0 +1,301 @@
+package main
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"cloud.google.com/go/firestore"
+)
+
+// The version of the Firestore client we use predates collection group
+// queries, so descendant queries are run against every collection of the
+// kind below the parent, and the results are merged here.
+
+// firestoreDescendantDiscoveryInterval is how often a descendant snapshot
+// iterator looks for new collections of its kind to watch.
+const firestoreDescendantDiscoveryInterval = 5 * time.Second
+
+// getDescendantCollections finds every collection of the kind of a
+// descendant query below its parent (or anywhere in its namespace), by
+// listing the subcollections of every document below it.
+func (s *firestoreStorage) getDescendantCollections(ctx context.Context, query *storageQuery) ([]*firestore.CollectionRef, error) {
+	var root *firestore.DocumentRef
+	var err error
+	if query.Parent != nil {
+		root, err = convertMetaKeyToDocumentRef(s.client, query.Parent)
+	} else {
+		root, err = getFirestoreNamespaceRef(s.client, query.Namespace)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var collections []*firestore.CollectionRef
+	var walk func(it *firestore.CollectionIterator) error
+	walk = func(it *firestore.CollectionIterator) error {
+		children, err := it.GetAll()
+		if err != nil {
+			return err
+		}
+		for _, collection := range children {
+			if collection.Parent == nil && collection.ID == firestoreNamespaceCollection {
+				// entities of other namespaces are stored below this
+				// collection
+				continue
+			}
+			if collection.ID == query.KindName {
+				collections = append(collections, collection)
+			}
+			// documents that only have subcollections are included here
+			refs, err := collection.DocumentRefs(ctx).GetAll()
+			if err != nil {
+				return err
+			}
+			for _, ref := range refs {
+				if err := walk(ref.Collections(ctx)); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	}
+	if root == nil {
+		err = walk(s.client.Collections(ctx))
+	} else {
+		err = walk(root.Collections(ctx))
+	}
+	if err != nil {
+		return nil, err
+	}
+	return collections, nil
+}
+
+// mergeFirestoreDescendantSnapshots orders the combined results of a
+// descendant query the same way the memory backend does, and then applies
+// its cursor and limit. The cursors of descendant results contain the whole
+// path of the document, since document IDs are only unique within a
+// collection.
+func mergeFirestoreDescendantSnapshots(query *storageQuery, snapshots []*storageSnapshot) []*storageSnapshot {
+	for _, snapshot := range snapshots {
+		snapshot.Cursor = formatMemoryCursor(snapshot.Key.Path)
+	}
+	sort.SliceStable(snapshots, func(i, j int) bool {
+		if query.OrderBy != "" {
+			c := compareMemoryValues(snapshots[i].Data[query.OrderBy], snapshots[j].Data[query.OrderBy])
+			if query.Descending {
+				c = -c
+			}
+			if c != 0 {
+				return c < 0
+			}
+		}
+		return compareMemoryPaths(snapshots[i].Key.Path, snapshots[j].Key.Path) < 0
+	})
+
+	if query.StartAfter != "" {
+		start := parseMemoryCursor(query.StartAfter, query.KindName)
+		var filtered []*storageSnapshot
+		for _, snapshot := range snapshots {
+			if compareMemoryPaths(snapshot.Key.Path, start) > 0 {
+				filtered = append(filtered, snapshot)
+			}
+		}
+		snapshots = filtered
+	}
+
+	if query.Limit > 0 && len(snapshots) > query.Limit {
+		snapshots = snapshots[:query.Limit]
+	}
+	return snapshots
+}
+
+// firestoreDescendantSnapshotIterator watches every collection of the kind
+// of a descendant query, and periodically looks for new collections to
+// watch. The first snapshot from a newly discovered collection reports its
+// documents as additions. The cursor and limit of the query are ignored.
+//
+// Indexes within a single collection don't mean anything across the merged
+// results, so every change is reported with an OldIndex and NewIndex of -1.
+type firestoreDescendantSnapshotIterator struct {
+	ctx       context.Context
+	cancel    context.CancelFunc
+	storage   *firestoreStorage
+	query     *storageQuery
+	snapshots chan *storageQuerySnapshot
+	errs      chan error
+	started   bool
+
+	mu      sync.Mutex
+	watched map[string]*firestoreStorageSnapshotIterator
+}
+
+func createFirestoreDescendantSnapshotIterator(ctx context.Context, storage *firestoreStorage, query *storageQuery) *firestoreDescendantSnapshotIterator {
+	ctx, cancel := context.WithCancel(ctx)
+	return &firestoreDescendantSnapshotIterator{
+		ctx:       ctx,
+		cancel:    cancel,
+		storage:   storage,
+		query:     query,
+		snapshots: make(chan *storageQuerySnapshot),
+		errs:      make(chan error, 1),
+		watched:   make(map[string]*firestoreStorageSnapshotIterator),
+	}
+}
+
+func (i *firestoreDescendantSnapshotIterator) Next() (*storageQuerySnapshot, error) {
+	if !i.started {
+		i.started = true
+		initial, err := i.watchNewCollections()
+		if err != nil {
+			return nil, err
+		}
+		go i.discover()
+		return initial, nil
+	}
+
+	select {
+	case snapshot := <-i.snapshots:
+		return snapshot, nil
+	case err := <-i.errs:
+		return nil, err
+	case <-i.ctx.Done():
+		return nil, i.ctx.Err()
+	}
+}
+
+func (i *firestoreDescendantSnapshotIterator) Stop() {
+	i.cancel()
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	for _, it := range i.watched {
+		it.Stop()
+	}
+}
+
+// watchNewCollections starts watching the collections that aren't watched
+// yet, and returns their current documents as a single snapshot.
+func (i *firestoreDescendantSnapshotIterator) watchNewCollections() (*storageQuerySnapshot, error) {
+	collections, err := i.storage.getDescendantCollections(i.ctx, i.query)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &storageQuerySnapshot{
+		ReadTime: time.Now(),
+	}
+	var added []*storageSnapshot
+	for _, collection := range collections {
+		i.mu.Lock()
+		_, ok := i.watched[collection.Path]
+		i.mu.Unlock()
+		if ok {
+			continue
+		}
+
+		q, err := i.storage.buildCollectionQuery(collection, i.query)
+		if err != nil {
+			return nil, err
+		}
+		it := &firestoreStorageSnapshotIterator{
+			it: q.Snapshots(i.ctx),
+		}
+		initial, err := it.Next()
+		if err != nil {
+			it.Stop()
+			return nil, err
+		}
+
+		i.mu.Lock()
+		if i.ctx.Err() != nil {
+			i.mu.Unlock()
+			it.Stop()
+			return nil, i.ctx.Err()
+		}
+		i.watched[collection.Path] = it
+		i.mu.Unlock()
+
+		for _, change := range initial.Changes {
+			added = append(added, change.Snapshot)
+		}
+		if initial.ReadTime.After(result.ReadTime) {
+			result.ReadTime = initial.ReadTime
+		}
+		go i.forward(it)
+	}
+
+	unlimited := *i.query
+	unlimited.StartAfter = ""
+	unlimited.Limit = 0
+	for _, snapshot := range mergeFirestoreDescendantSnapshots(&unlimited, added) {
+		result.Changes = append(result.Changes, storageChange{
+			Kind:     storageDocumentAdded,
+			Snapshot: snapshot,
+			OldIndex: -1,
+			NewIndex: -1,
+		})
+	}
+	return result, nil
+}
+
+// forward delivers the snapshots of a single watched collection after its
+// initial one.
+func (i *firestoreDescendantSnapshotIterator) forward(it *firestoreStorageSnapshotIterator) {
+	for {
+		snapshot, err := it.Next()
+		if err != nil {
+			i.fail(err)
+			return
+		}
+		for j := range snapshot.Changes {
+			change := &snapshot.Changes[j]
+			change.Snapshot.Cursor = formatMemoryCursor(change.Snapshot.Key.Path)
+			change.OldIndex = -1
+			change.NewIndex = -1
+		}
+		select {
+		case i.snapshots <- snapshot:
+		case <-i.ctx.Done():
+			return
+		}
+	}
+}
+
+func (i *firestoreDescendantSnapshotIterator) discover() {
+	ticker := time.NewTicker(firestoreDescendantDiscoveryInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+		case <-i.ctx.Done():
+			return
+		}
+		snapshot, err := i.watchNewCollections()
+		if err != nil {
+			i.fail(err)
+			return
+		}
+		if len(snapshot.Changes) == 0 {
+			continue
+		}
+		select {
+		case i.snapshots <- snapshot:
+		case <-i.ctx.Done():
+			return
+		}
+	}
+}
+
+// fail reports err from the next call to Next, unless the iterator has been
+// stopped or another error is already waiting to be reported.
+func (i *firestoreDescendantSnapshotIterator) fail(err error) {
+	if i.ctx.Err() != nil {
+		return
+	}
+	select {
+	case i.errs <- err:
+	default:
+	}
+}
@@ -0,0 +1,162 @@
+package main
+
+import (
+	"context"
+	"reflect"
+
+	"testing"
+
+	"cloud.google.com/go/firestore"
+	"google.golang.org/api/option"
+	"google.golang.org/grpc"
+	"gotest.tools/assert"
+)
+
+// createFirestoreQueryTestStorage returns Firestore storage for tests that
+// only build queries; the client never connects to anything.
+func createFirestoreQueryTestStorage(t *testing.T) *firestoreStorage {
+	conn, err := grpc.Dial("localhost:1", grpc.WithInsecure())
+	assert.NilError(t, err)
+	client, err := firestore.NewClient(
+		context.Background(),
+		"configstore-test-001",
+		option.WithGRPCConn(conn),
+	)
+	assert.NilError(t, err)
+	return createFirestoreStorage(client)
+}
+
+func createFirestoreQueryTestKey(namespace string, path ...*PathElement) *Key {
+	return &Key{
+		PartitionId: &PartitionId{
+			Namespace: namespace,
+		},
+		Path: path,
+	}
+}
+
+func createFirestoreQueryTestSnapshot(name string, path ...*PathElement) *storageSnapshot {
+	return &storageSnapshot{
+		Key: createFirestoreQueryTestKey("", path...),
+		Data: map[string]interface{}{
+			"name": name,
+		},
+		Cursor: path[len(path)-1].GetName(),
+	}
+}
+
+func TestFirestoreQueryBuilder(t *testing.T) {
+	storage := createFirestoreQueryTestStorage(t)
+	defer storage.Close()
+	client := storage.client
+
+	q, err := storage.buildQuery(&storageQuery{
+		Namespace: "tenant-a",
+		KindName:  "Project",
+		Filters: []storageFilter{
+			{Field: "name", Op: "==", Value: "test"},
+		},
+		OrderBy:    "name",
+		Descending: true,
+		StartAfter: "abc",
+		Limit:      10,
+	})
+	assert.NilError(t, err)
+	expected := client.Collection(firestoreNamespaceCollection).Doc("tenant-a").Collection("Project").
+		Where("name", "==", "test").
+		OrderBy("name", firestore.Desc).
+		OrderBy(firestore.DocumentID, firestore.Asc).
+		StartAfter("abc").
+		Limit(10)
+	assert.Assert(t, reflect.DeepEqual(q, expected))
+
+	parent := createFirestoreQueryTestKey("", &PathElement{Kind: "Project", IdType: &PathElement_Id{Id: 5}})
+	q, err = storage.buildQuery(&storageQuery{
+		Parent:   parent,
+		KindName: "ProjectEnvironment",
+	})
+	assert.NilError(t, err)
+	expected = client.Collection("Project").Doc("__datastore_id_polyfill=5").Collection("ProjectEnvironment").Query
+	assert.Assert(t, reflect.DeepEqual(q, expected))
+}
+
+func TestFirestoreDescendantQueryBuilder(t *testing.T) {
+	storage := createFirestoreQueryTestStorage(t)
+	defer storage.Close()
+	client := storage.client
+
+	target := createFirestoreQueryTestKey("", &PathElement{Kind: "Project", IdType: &PathElement_Name{Name: "a"}})
+	query := &storageQuery{
+		AllDescendants: true,
+		KindName:       "ProjectEnvironment",
+		Filters: []storageFilter{
+			{Field: "project", Op: "==", Value: target},
+		},
+		OrderBy:    "name",
+		StartAfter: "Project:name=a/name=prod",
+		Limit:      1,
+	}
+
+	// the cursor and limit are applied after the results of every
+	// collection have been merged
+	collection := client.Collection("Project").Doc("a").Collection("ProjectEnvironment")
+	q, err := storage.buildCollectionQuery(collection, query)
+	assert.NilError(t, err)
+	targetRef, err := convertMetaKeyToDocumentRef(client, target)
+	assert.NilError(t, err)
+	expected := collection.Where("project", "==", targetRef).OrderBy("name", firestore.Asc)
+	assert.Assert(t, reflect.DeepEqual(q, expected))
+}
+
+func TestMergeFirestoreDescendantSnapshots(t *testing.T) {
+	snapshots := func() []*storageSnapshot {
+		return []*storageSnapshot{
+			createFirestoreQueryTestSnapshot(
+				"b-prod",
+				&PathElement{Kind: "Project", IdType: &PathElement_Name{Name: "b"}},
+				&PathElement{Kind: "ProjectEnvironment", IdType: &PathElement_Name{Name: "prod"}},
+			),
+			createFirestoreQueryTestSnapshot(
+				"a-test",
+				&PathElement{Kind: "Project", IdType: &PathElement_Name{Name: "a"}},
+				&PathElement{Kind: "ProjectEnvironment", IdType: &PathElement_Name{Name: "test"}},
+			),
+			createFirestoreQueryTestSnapshot(
+				"a-prod",
+				&PathElement{Kind: "Project", IdType: &PathElement_Name{Name: "a"}},
+				&PathElement{Kind: "ProjectEnvironment", IdType: &PathElement_Name{Name: "prod"}},
+			),
+		}
+	}
+	names := func(results []*storageSnapshot) []string {
+		var result []string
+		for _, snapshot := range results {
+			result = append(result, snapshot.Data["name"].(string))
+		}
+		return result
+	}
+
+	// ordered by path, with cursors that hold the whole path
+	results := mergeFirestoreDescendantSnapshots(&storageQuery{KindName: "ProjectEnvironment"}, snapshots())
+	assert.DeepEqual(t, names(results), []string{"a-prod", "a-test", "b-prod"})
+	assert.Equal(t, results[0].Cursor, "Project:name=a/name=prod")
+
+	// ordered by a field
+	results = mergeFirestoreDescendantSnapshots(&storageQuery{KindName: "ProjectEnvironment", OrderBy: "name", Descending: true}, snapshots())
+	assert.DeepEqual(t, names(results), []string{"b-prod", "a-test", "a-prod"})
+
+	// paging with the cursors visits every entity once, even though
+	// entities in different collections have the same ID
+	var paged []string
+	cursor := ""
+	for {
+		results = mergeFirestoreDescendantSnapshots(&storageQuery{KindName: "ProjectEnvironment", StartAfter: cursor, Limit: 1}, snapshots())
+		if len(results) == 0 {
+			break
+		}
+		assert.Equal(t, len(results), 1)
+		paged = append(paged, results[0].Data["name"].(string))
+		cursor = results[0].Cursor
+	}
+	assert.DeepEqual(t, paged, []string{"a-prod", "a-test", "b-prod"})
+}
@@ -0,0 +1,948 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	timestamp "github.com/golang/protobuf/ptypes/timestamp"
+	"github.com/google/uuid"
+	"google.golang.org/genproto/googleapis/type/latlng"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const memoryStorageNamespace = "memory"
+
+type memoryDocument struct {
+	key        *Key
+	data       map[string]interface{}
+	createTime time.Time
+	updateTime time.Time
+}
+
+// memoryStorage keeps every document in process. Transactions are
+// serialized, so every transaction sees a consistent view of the data
+// and commits atomically.
+type memoryStorage struct {
+	mu             sync.Mutex
+	txMu           sync.Mutex
+	namespace      string
+	documents      map[string]*memoryDocument
+	iterators      map[*memoryStorageSnapshotIterator]bool
+	lastCommitTime time.Time
+	closed         bool
+
+	// persist is called with the documents a transaction is about to write
+	// (nil for deletions) before they become visible. If it returns an
+	// error, the transaction fails and nothing is applied.
+	persist func(ids []string, documents []*memoryDocument, commitTime time.Time) error
+}
+
+func createMemoryStorage() *memoryStorage {
+	return createMemoryStorageWithNamespace(memoryStorageNamespace)
+}
+
+func createMemoryStorageWithNamespace(namespace string) *memoryStorage {
+	return &memoryStorage{
+		namespace: namespace,
+		documents: make(map[string]*memoryDocument),
+		iterators: make(map[*memoryStorageSnapshotIterator]bool),
+	}
+}
+
+func (s *memoryStorage) DefaultNamespace() string {
+	return s.namespace
+}
+
+func (s *memoryStorage) NewKey(namespace string, parent *Key, kindName string) *Key {
+	u, err := uuid.NewRandom()
+	if err != nil {
+		return nil
+	}
+	name := strings.Replace(u.String(), "-", "", -1)
+	var path []*PathElement
+	if parent != nil {
+		namespace = getKeyNamespace(parent)
+		path = append(path, parent.Path...)
+	}
+	path = append(path, &PathElement{
+		Kind: kindName,
+		IdType: &PathElement_Name{
+			Name: name,
+		},
+	})
+	key, err := s.normalizeKey(&Key{
+		PartitionId: &PartitionId{
+			Namespace: namespace,
+		},
+		Path: path,
+	})
+	if err != nil {
+		return nil
+	}
+	return key
+}
+
+func (s *memoryStorage) Namespaces(ctx context.Context) ([]string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	seen := map[string]bool{
+		s.namespace: true,
+	}
+	namespaces := []string{s.namespace}
+	for _, document := range s.documents {
+		namespace := document.key.PartitionId.Namespace
+		if !seen[namespace] {
+			seen[namespace] = true
+			namespaces = append(namespaces, namespace)
+		}
+	}
+	sort.Strings(namespaces[1:])
+	return namespaces, nil
+}
+
+func (s *memoryStorage) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.closed = true
+	for it := range s.iterators {
+		it.stopLocked()
+	}
+	s.iterators = make(map[*memoryStorageSnapshotIterator]bool)
+	return nil
+}
+
+func (s *memoryStorage) RunTransaction(ctx context.Context, f func(ctx context.Context, tx storageTransaction) error) error {
+	s.txMu.Lock()
+	defer s.txMu.Unlock()
+
+	tx := &memoryStorageTransaction{
+		storage: s,
+		writes:  make(map[string]*memoryWrite),
+	}
+	err := f(ctx, tx)
+	if err != nil {
+		return err
+	}
+	return s.commit(tx)
+}
+
+func (s *memoryStorage) Query(ctx context.Context, query *storageQuery) ([]*storageSnapshot, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return nil, fmt.Errorf("memory storage is closed")
+	}
+	documents, err := s.queryLocked(query)
+	if err != nil {
+		return nil, err
+	}
+	readTime := time.Now()
+	var snapshots []*storageSnapshot
+	for _, document := range documents {
+		snapshots = append(snapshots, document.toSnapshot(readTime))
+	}
+	return snapshots, nil
+}
+
+func (s *memoryStorage) Snapshots(ctx context.Context, query *storageQuery) storageSnapshotIterator {
+	it := &memoryStorageSnapshotIterator{
+		ctx:     ctx,
+		storage: s,
+		query:   query,
+		notify:  make(chan struct{}, 1),
+		current: nil,
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		it.err = fmt.Errorf("memory storage is closed")
+		return it
+	}
+	documents, err := s.queryLocked(query)
+	if err != nil {
+		it.err = err
+		return it
+	}
+	readTime := time.Now()
+	initial := &storageQuerySnapshot{
+		ReadTime: readTime,
+	}
+	for i, document := range documents {
+		initial.Changes = append(initial.Changes, storageChange{
+			Kind:     storageDocumentAdded,
+			Snapshot: document.toSnapshot(readTime),
+			OldIndex: -1,
+			NewIndex: i,
+		})
+	}
+	it.current = documents
+	it.pending = append(it.pending, initial)
+	it.signal()
+	s.iterators[it] = true
+	return it
+}
+
+// normalizeKey returns a copy of key with the namespace filled in, or an
+// error if the key has an invalid namespace.
+func (s *memoryStorage) normalizeKey(key *Key) (*Key, error) {
+	if key == nil || key.PartitionId == nil {
+		return nil, fmt.Errorf("key or key partition ID is nil; if the caller wants to allow nil keys, it must check to see if the input key is nil first")
+	}
+	if len(key.Path) == 0 {
+		return nil, fmt.Errorf("inbound key did not contain any path components: namespace '%s'", key.PartitionId.Namespace)
+	}
+	namespace, err := s.normalizeNamespace(key.PartitionId.Namespace)
+	if err != nil {
+		return nil, err
+	}
+	result := proto.Clone(key).(*Key)
+	result.PartitionId.Namespace = namespace
+	return result, nil
+}
+
+func (s *memoryStorage) normalizeNamespace(namespace string) (string, error) {
+	if namespace == "" {
+		return s.namespace, nil
+	}
+	if namespace != s.namespace && !isValidNamespaceName(namespace) {
+		return "", fmt.Errorf("namespace '%s' is not a valid namespace name; namespaces must be omitted, match '%s', or consist of up to 100 letters, digits, '_', '-' or '.'", namespace, s.namespace)
+	}
+	return namespace, nil
+}
+
+func (s *memoryStorage) normalizeValue(value interface{}) (interface{}, error) {
+	switch v := value.(type) {
+	case *Key:
+		if v == nil {
+			return nil, nil
+		}
+		return s.normalizeKey(v)
+	case []*Key:
+		result := make([]interface{}, len(v))
+		for i, key := range v {
+			normalized, err := s.normalizeValue(key)
+			if err != nil {
+				return nil, err
+			}
+			result[i] = normalized
+		}
+		return result, nil
+	case []interface{}:
+		result := make([]interface{}, len(v))
+		for i, elem := range v {
+			normalized, err := s.normalizeValue(elem)
+			if err != nil {
+				return nil, err
+			}
+			result[i] = normalized
+		}
+		return result, nil
+	case map[string]interface{}:
+		result := make(map[string]interface{})
+		for k, elem := range v {
+			normalized, err := s.normalizeValue(elem)
+			if err != nil {
+				return nil, err
+			}
+			result[k] = normalized
+		}
+		return result, nil
+	case *timestamp.Timestamp:
+		if v == nil {
+			return nil, nil
+		}
+		return convertTimestampToTime(v), nil
+	case *latlng.LatLng:
+		if v == nil {
+			return nil, nil
+		}
+		return proto.Clone(v).(*latlng.LatLng), nil
+	case int:
+		return int64(v), nil
+	case int32:
+		return int64(v), nil
+	case uint32:
+		return int64(v), nil
+	case uint64:
+		return int64(v), nil
+	case float32:
+		return float64(v), nil
+	case []byte:
+		if v == nil {
+			return nil, nil
+		}
+		return append([]byte(nil), v...), nil
+	default:
+		return value, nil
+	}
+}
+
+func (s *memoryStorage) commit(tx *memoryStorageTransaction) error {
+	if len(tx.writes) == 0 {
+		return nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return fmt.Errorf("memory storage is closed")
+	}
+
+	// like Firestore, creating a document that already exists fails the
+	// whole transaction at commit time
+	for _, id := range tx.order {
+		if tx.writes[id].create {
+			if _, ok := s.documents[id]; ok {
+				return status.Errorf(codes.AlreadyExists, "entity already exists: %s", id)
+			}
+		}
+	}
+
+	// every write in a transaction shares the same commit time, which is
+	// what the transaction watcher uses to reassemble transactions
+	commitTime := time.Now().UTC()
+	if !commitTime.After(s.lastCommitTime) {
+		commitTime = s.lastCommitTime.Add(time.Nanosecond)
+	}
+
+	documents := make([]*memoryDocument, len(tx.order))
+	for i, id := range tx.order {
+		write := tx.writes[id]
+		if write.data == nil {
+			continue
+		}
+		createTime := commitTime
+		if existing, ok := s.documents[id]; ok {
+			createTime = existing.createTime
+		}
+		documents[i] = &memoryDocument{
+			key:        write.key,
+			data:       write.data,
+			createTime: createTime,
+			updateTime: commitTime,
+		}
+	}
+
+	if s.persist != nil {
+		err := s.persist(tx.order, documents, commitTime)
+		if err != nil {
+			return err
+		}
+	}
+
+	s.lastCommitTime = commitTime
+	changed := make(map[string]bool)
+	for i, id := range tx.order {
+		changed[id] = true
+		if documents[i] == nil {
+			delete(s.documents, id)
+		} else {
+			s.documents[id] = documents[i]
+		}
+	}
+
+	for it := range s.iterators {
+		it.update(changed, commitTime)
+	}
+
+	return nil
+}
+
+func (s *memoryStorage) queryLocked(query *storageQuery) ([]*memoryDocument, error) {
+	var parent *Key
+	var namespace string
+	if query.Parent != nil {
+		var err error
+		parent, err = s.normalizeKey(query.Parent)
+		if err != nil {
+			return nil, err
+		}
+		namespace = parent.PartitionId.Namespace
+	} else {
+		var err error
+		namespace, err = s.normalizeNamespace(query.Namespace)
+		if err != nil {
+			return nil, err
+		}
+	}
+	filterValues := make([]interface{}, len(query.Filters))
+	for i, filter := range query.Filters {
+		normalized, err := s.normalizeValue(filter.Value)
+		if err != nil {
+			return nil, err
+		}
+		filterValues[i] = normalized
+	}
+
+	var results []*memoryDocument
+	for _, document := range s.documents {
+		if !isMemoryDocumentInCollection(document.key, namespace, parent, query.KindName, query.AllDescendants) {
+			continue
+		}
+		matches := true
+		for i, filter := range query.Filters {
+			ok, err := matchMemoryFilter(document.data[filter.Field], filter.Op, filterValues[i])
+			if err != nil {
+				return nil, err
+			}
+			if !ok {
+				matches = false
+				break
+			}
+		}
+		if matches {
+			results = append(results, document)
+		}
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		if query.OrderBy != "" {
+			c := compareMemoryValues(results[i].data[query.OrderBy], results[j].data[query.OrderBy])
+			if query.Descending {
+				c = -c
+			}
+			if c != 0 {
+				return c < 0
+			}
+		}
+		return compareMemoryPaths(results[i].key.Path, results[j].key.Path) < 0
+	})
+
+	if query.StartAfter != "" {
+		start := parseMemoryCursor(query.StartAfter, query.KindName)
+		var filtered []*memoryDocument
+		for _, document := range results {
+			if compareMemoryPaths(document.key.Path, start) > 0 {
+				filtered = append(filtered, document)
+			}
+		}
+		results = filtered
+	}
+
+	if query.Limit > 0 && len(results) > query.Limit {
+		results = results[:query.Limit]
+	}
+
+	return results, nil
+}
+
+func (d *memoryDocument) toSnapshot(readTime time.Time) *storageSnapshot {
+	data, _ := cloneMemoryValue(d.data).(map[string]interface{})
+	return &storageSnapshot{
+		Key:        proto.Clone(d.key).(*Key),
+		Data:       data,
+		CreateTime: d.createTime,
+		UpdateTime: d.updateTime,
+		ReadTime:   readTime,
+		Cursor:     formatMemoryCursor(d.key.Path),
+	}
+}
+
+type memoryWrite struct {
+	key *Key
+	// data is nil for deletes.
+	data map[string]interface{}
+	// create indicates the document must not exist when the transaction
+	// commits.
+	create bool
+}
+
+type memoryStorageTransaction struct {
+	storage *memoryStorage
+	writes  map[string]*memoryWrite
+	order   []string
+}
+
+func (t *memoryStorageTransaction) Get(key *Key) (*storageSnapshot, error) {
+	if len(t.writes) > 0 {
+		return nil, fmt.Errorf("read after write in transaction")
+	}
+	normalized, err := t.storage.normalizeKey(key)
+	if err != nil {
+		return nil, err
+	}
+	if isKeyIncomplete(normalized) {
+		return nil, fmt.Errorf("key must be complete when reading from memory storage")
+	}
+
+	t.storage.mu.Lock()
+	defer t.storage.mu.Unlock()
+
+	document, ok := t.storage.documents[serializeKey(normalized)]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "entity not found: %s", serializeKey(normalized))
+	}
+	return document.toSnapshot(time.Now()), nil
+}
+
+func (t *memoryStorageTransaction) Query(query *storageQuery) ([]*storageSnapshot, error) {
+	if len(t.writes) > 0 {
+		return nil, fmt.Errorf("read after write in transaction")
+	}
+	return t.storage.Query(context.Background(), query)
+}
+
+func (t *memoryStorageTransaction) Create(key *Key, data map[string]interface{}) error {
+	normalized, err := t.storage.normalizeKey(key)
+	if err != nil {
+		return err
+	}
+	return t.write(normalized, data, true)
+}
+
+func (t *memoryStorageTransaction) Set(key *Key, data map[string]interface{}) error {
+	normalized, err := t.storage.normalizeKey(key)
+	if err != nil {
+		return err
+	}
+	return t.write(normalized, data, false)
+}
+
+func (t *memoryStorageTransaction) Delete(key *Key) error {
+	normalized, err := t.storage.normalizeKey(key)
+	if err != nil {
+		return err
+	}
+	if isKeyIncomplete(normalized) {
+		return fmt.Errorf("key must be complete when writing to memory storage")
+	}
+	id := serializeKey(normalized)
+	if _, ok := t.writes[id]; !ok {
+		t.order = append(t.order, id)
+	}
+	t.writes[id] = &memoryWrite{
+		key:  normalized,
+		data: nil,
+	}
+	return nil
+}
+
+func (t *memoryStorageTransaction) write(key *Key, data map[string]interface{}, create bool) error {
+	if isKeyIncomplete(key) {
+		return fmt.Errorf("key must be complete when writing to memory storage")
+	}
+	normalized, err := t.storage.normalizeValue(data)
+	if err != nil {
+		return err
+	}
+	normalizedData, _ := normalized.(map[string]interface{})
+	if normalizedData == nil {
+		normalizedData = make(map[string]interface{})
+	}
+	id := serializeKey(key)
+	if existing, ok := t.writes[id]; !ok {
+		t.order = append(t.order, id)
+	} else if create && existing.data != nil {
+		return status.Errorf(codes.AlreadyExists, "entity already exists: %s", id)
+	}
+	t.writes[id] = &memoryWrite{
+		key:    key,
+		data:   normalizedData,
+		create: create,
+	}
+	return nil
+}
+
+type memoryStorageSnapshotIterator struct {
+	ctx     context.Context
+	storage *memoryStorage
+	query   *storageQuery
+	notify  chan struct{}
+
+	// the fields below are protected by storage.mu
+	current []*memoryDocument
+	pending []*storageQuerySnapshot
+	stopped bool
+	err     error
+}
+
+func (i *memoryStorageSnapshotIterator) Next() (*storageQuerySnapshot, error) {
+	for {
+		i.storage.mu.Lock()
+		if len(i.pending) > 0 {
+			snapshot := i.pending[0]
+			i.pending = i.pending[1:]
+			i.storage.mu.Unlock()
+			return snapshot, nil
+		}
+		if i.err != nil {
+			err := i.err
+			i.storage.mu.Unlock()
+			return nil, err
+		}
+		if i.stopped {
+			i.storage.mu.Unlock()
+			return nil, fmt.Errorf("snapshot iterator stopped")
+		}
+		i.storage.mu.Unlock()
+
+		select {
+		case <-i.notify:
+		case <-i.ctx.Done():
+			i.Stop()
+			return nil, i.ctx.Err()
+		}
+	}
+}
+
+func (i *memoryStorageSnapshotIterator) Stop() {
+	i.storage.mu.Lock()
+	defer i.storage.mu.Unlock()
+
+	i.stopLocked()
+	delete(i.storage.iterators, i)
+}
+
+func (i *memoryStorageSnapshotIterator) stopLocked() {
+	i.stopped = true
+	i.signal()
+}
+
+func (i *memoryStorageSnapshotIterator) signal() {
+	select {
+	case i.notify <- struct{}{}:
+	default:
+	}
+}
+
+// update recomputes the query after a commit and queues the differences
+// as a new snapshot. It must be called with storage.mu held.
+func (i *memoryStorageSnapshotIterator) update(changed map[string]bool, commitTime time.Time) {
+	if i.stopped || i.err != nil {
+		return
+	}
+	documents, err := i.storage.queryLocked(i.query)
+	if err != nil {
+		i.err = err
+		i.signal()
+		return
+	}
+
+	oldIndexes := make(map[string]int)
+	for idx, document := range i.current {
+		oldIndexes[serializeKey(document.key)] = idx
+	}
+	newIndexes := make(map[string]int)
+	for idx, document := range documents {
+		newIndexes[serializeKey(document.key)] = idx
+	}
+
+	snapshot := &storageQuerySnapshot{
+		ReadTime: commitTime,
+	}
+	for idx, document := range i.current {
+		id := serializeKey(document.key)
+		if _, ok := newIndexes[id]; !ok {
+			snapshot.Changes = append(snapshot.Changes, storageChange{
+				Kind:     storageDocumentRemoved,
+				Snapshot: document.toSnapshot(commitTime),
+				OldIndex: idx,
+				NewIndex: -1,
+			})
+		}
+	}
+	for idx, document := range documents {
+		id := serializeKey(document.key)
+		oldIdx, existed := oldIndexes[id]
+		if !existed {
+			snapshot.Changes = append(snapshot.Changes, storageChange{
+				Kind:     storageDocumentAdded,
+				Snapshot: document.toSnapshot(commitTime),
+				OldIndex: -1,
+				NewIndex: idx,
+			})
+		} else if changed[id] {
+			snapshot.Changes = append(snapshot.Changes, storageChange{
+				Kind:     storageDocumentModified,
+				Snapshot: document.toSnapshot(commitTime),
+				OldIndex: oldIdx,
+				NewIndex: idx,
+			})
+		}
+	}
+
+	i.current = documents
+	if len(snapshot.Changes) > 0 {
+		i.pending = append(i.pending, snapshot)
+		i.signal()
+	}
+}
+
+func isMemoryDocumentInCollection(key *Key, namespace string, parent *Key, kindName string, allDescendants bool) bool {
+	if key.PartitionId.Namespace != namespace {
+		return false
+	}
+	parentLength := 0
+	if parent != nil {
+		parentLength = len(parent.Path)
+	}
+	if len(key.Path) != parentLength+1 && !(allDescendants && len(key.Path) > parentLength) {
+		return false
+	}
+	if key.Path[len(key.Path)-1].Kind != kindName {
+		return false
+	}
+	for i := 0; i < parentLength; i++ {
+		if key.Path[i].Kind != parent.Path[i].Kind ||
+			comparePathElements(key.Path[i], parent.Path[i]) != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// comparePathElements orders numeric IDs before names, numerically and then
+// lexically.
+func comparePathElements(a *PathElement, b *PathElement) int {
+	_, aIsID := a.IdType.(*PathElement_Id)
+	_, bIsID := b.IdType.(*PathElement_Id)
+	switch {
+	case aIsID && !bIsID:
+		return -1
+	case !aIsID && bIsID:
+		return 1
+	case aIsID && bIsID:
+		if a.GetId() < b.GetId() {
+			return -1
+		} else if a.GetId() > b.GetId() {
+			return 1
+		}
+		return 0
+	default:
+		return strings.Compare(a.GetName(), b.GetName())
+	}
+}
+
+// compareMemoryPaths orders keys by their path elements from the top down,
+// with ancestors before their descendants.
+func compareMemoryPaths(a []*PathElement, b []*PathElement) int {
+	for i := 0; i < len(a) && i < len(b); i++ {
+		if c := strings.Compare(a[i].Kind, b[i].Kind); c != 0 {
+			return c
+		}
+		if c := comparePathElements(a[i], b[i]); c != 0 {
+			return c
+		}
+	}
+	return len(a) - len(b)
+}
+
+// formatMemoryCursor formats the cursor for a document at path. Ancestors
+// are written as "Kind:id=.../" or "Kind:name=.../", followed by the last
+// path element without its kind, since the query determines it. Cursors of
+// top-level documents are just "id=..." or "name=...".
+func formatMemoryCursor(path []*PathElement) string {
+	elements := make([]string, len(path))
+	for i, pathElement := range path {
+		if _, ok := pathElement.IdType.(*PathElement_Id); ok {
+			elements[i] = fmt.Sprintf("id=%d", pathElement.GetId())
+		} else {
+			elements[i] = fmt.Sprintf("name=%s", pathElement.GetName())
+		}
+		if i < len(path)-1 {
+			elements[i] = fmt.Sprintf("%s:%s", pathElement.Kind, elements[i])
+		}
+	}
+	return strings.Join(elements, "/")
+}
+
+var memoryCursorAncestorRegexp = regexp.MustCompile("^([^/:=]+):((?:id|name)=[^/]*)/")
+
+func parseMemoryCursor(cursor string, kindName string) []*PathElement {
+	var path []*PathElement
+	for {
+		match := memoryCursorAncestorRegexp.FindStringSubmatch(cursor)
+		if match == nil {
+			break
+		}
+		path = append(path, parseMemoryCursorElement(match[1], match[2]))
+		cursor = cursor[len(match[0]):]
+	}
+	return append(path, parseMemoryCursorElement(kindName, cursor))
+}
+
+func parseMemoryCursorElement(kindName string, element string) *PathElement {
+	if strings.HasPrefix(element, "id=") {
+		id, err := strconv.ParseInt(element[len("id="):], 10, 64)
+		if err == nil {
+			return &PathElement{
+				Kind: kindName,
+				IdType: &PathElement_Id{
+					Id: id,
+				},
+			}
+		}
+	}
+	return &PathElement{
+		Kind: kindName,
+		IdType: &PathElement_Name{
+			Name: strings.TrimPrefix(element, "name="),
+		},
+	}
+}
+
+func matchMemoryFilter(value interface{}, op string, expected interface{}) (bool, error) {
+	if op == "==" {
+		return compareMemoryValues(value, expected) == 0 && memoryValueTypeOrder(value) == memoryValueTypeOrder(expected), nil
+	}
+	if op == "array-contains" {
+		elements, _ := value.([]interface{})
+		for _, element := range elements {
+			if ok, _ := matchMemoryFilter(element, "==", expected); ok {
+				return true, nil
+			}
+		}
+		return false, nil
+	}
+	if memoryValueTypeOrder(value) != memoryValueTypeOrder(expected) {
+		// like Firestore, range filters only match values of the same type
+		return false, nil
+	}
+	c := compareMemoryValues(value, expected)
+	switch op {
+	case "<":
+		return c < 0, nil
+	case "<=":
+		return c <= 0, nil
+	case ">":
+		return c > 0, nil
+	case ">=":
+		return c >= 0, nil
+	}
+	return false, fmt.Errorf("unsupported filter operator '%s'", op)
+}
+
+func memoryValueTypeOrder(value interface{}) int {
+	switch value.(type) {
+	case nil:
+		return 0
+	case bool:
+		return 1
+	case int64, float64:
+		return 2
+	case time.Time:
+		return 3
+	case string:
+		return 4
+	case []byte:
+		return 5
+	case *Key:
+		return 6
+	case *latlng.LatLng:
+		return 7
+	case []interface{}:
+		return 8
+	case map[string]interface{}:
+		return 9
+	}
+	return 10
+}
+
+// compareMemoryValues orders values of different types by type, and values
+// of the same type by their natural order.
+func compareMemoryValues(a interface{}, b interface{}) int {
+	ta, tb := memoryValueTypeOrder(a), memoryValueTypeOrder(b)
+	if ta != tb {
+		if ta < tb {
+			return -1
+		}
+		return 1
+	}
+	switch av := a.(type) {
+	case bool:
+		bv := b.(bool)
+		if av == bv {
+			return 0
+		} else if !av {
+			return -1
+		}
+		return 1
+	case int64, float64:
+		af, bf := toMemoryFloat(a), toMemoryFloat(b)
+		if af < bf {
+			return -1
+		} else if af > bf {
+			return 1
+		}
+		return 0
+	case time.Time:
+		bv := b.(time.Time)
+		if av.Before(bv) {
+			return -1
+		} else if av.After(bv) {
+			return 1
+		}
+		return 0
+	case string:
+		return strings.Compare(av, b.(string))
+	case []byte:
+		return strings.Compare(string(av), string(b.([]byte)))
+	case *Key:
+		return strings.Compare(serializeKey(av), serializeKey(b.(*Key)))
+	case *latlng.LatLng:
+		bv := b.(*latlng.LatLng)
+		if av.GetLatitude() != bv.GetLatitude() {
+			return compareMemoryValues(av.GetLatitude(), bv.GetLatitude())
+		}
+		return compareMemoryValues(av.GetLongitude(), bv.GetLongitude())
+	}
+	return 0
+}
+
+func toMemoryFloat(value interface{}) float64 {
+	switch v := value.(type) {
+	case int64:
+		return float64(v)
+	case float64:
+		return v
+	}
+	return 0
+}
+
+func cloneMemoryValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case *Key:
+		if v == nil {
+			return v
+		}
+		return proto.Clone(v).(*Key)
+	case *latlng.LatLng:
+		if v == nil {
+			return v
+		}
+		return proto.Clone(v).(*latlng.LatLng)
+	case []interface{}:
+		result := make([]interface{}, len(v))
+		for i, elem := range v {
+			result[i] = cloneMemoryValue(elem)
+		}
+		return result
+	case map[string]interface{}:
+		result := make(map[string]interface{})
+		for k, elem := range v {
+			result[k] = cloneMemoryValue(elem)
+		}
+		return result
+	case []byte:
+		if v == nil {
+			return v
+		}
+		return append([]byte(nil), v...)
+	default:
+		return value
+	}
+}
@@ -0,0 +1,172 @@
+package main
+
+import (
+	"context"
+
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gotest.tools/assert"
+)
+
+func createMemoryTestEntity(name string, value uint64) *MetaEntity {
+	return &MetaEntity{
+		Key: &Key{
+			PartitionId: &PartitionId{},
+			Path: []*PathElement{
+				&PathElement{
+					Kind: "IntegerTest",
+					IdType: &PathElement_Name{
+						Name: name,
+					},
+				},
+			},
+		},
+		Values: []*Value{
+			&Value{
+				Id:          2,
+				Type:        ValueType_uint64,
+				Uint64Value: value,
+			},
+		},
+	}
+}
+
+func TestMemoryStorageCreateGetDelete(t *testing.T) {
+	ctx := context.Background()
+
+	genResult, err := generate("./schema.json")
+	assert.NilError(t, err)
+
+	storage := createMemoryStorage()
+	defer storage.Close()
+	processor := createTransactionProcessor(storage)
+
+	// create an entity
+	resp, err := processor.processTransaction(ctx, genResult.Schema, &MetaTransaction{
+		Operations: []*MetaOperation{
+			&MetaOperation{
+				Operation: &MetaOperation_CreateRequest{
+					CreateRequest: &MetaCreateEntityRequest{
+						KindName: "IntegerTest",
+						Entity:   createMemoryTestEntity("a", 5),
+					},
+				},
+			},
+		},
+	})
+	assert.NilError(t, err)
+	assert.Assert(t, resp.OperationResults[0].Error == nil)
+	key := resp.OperationResults[0].GetCreateResponse().Entity.Key
+	assert.Equal(t, key.PartitionId.Namespace, storage.DefaultNamespace())
+
+	// creating it again must fail the whole transaction
+	_, err = processor.processTransaction(ctx, genResult.Schema, &MetaTransaction{
+		Operations: []*MetaOperation{
+			&MetaOperation{
+				Operation: &MetaOperation_CreateRequest{
+					CreateRequest: &MetaCreateEntityRequest{
+						KindName: "IntegerTest",
+						Entity:   createMemoryTestEntity("a", 6),
+					},
+				},
+			},
+		},
+	})
+	assert.Equal(t, status.Code(err), codes.AlreadyExists)
+
+	// delete returns the prior entity
+	resp, err = processor.processTransaction(ctx, genResult.Schema, &MetaTransaction{
+		Operations: []*MetaOperation{
+			&MetaOperation{
+				Operation: &MetaOperation_DeleteRequest{
+					DeleteRequest: &MetaDeleteEntityRequest{
+						KindName: "IntegerTest",
+						Key:      key,
+					},
+				},
+			},
+		},
+	})
+	assert.NilError(t, err)
+	deleted := resp.OperationResults[0].GetDeleteResponse().Entity
+	assert.Equal(t, serializeKey(deleted.Key), serializeKey(key))
+	assert.Equal(t, deleted.Values[0].Uint64Value, uint64(5))
+
+	// and it's gone afterwards
+	resp, err = processor.processTransaction(ctx, genResult.Schema, &MetaTransaction{
+		Operations: []*MetaOperation{
+			&MetaOperation{
+				Operation: &MetaOperation_GetRequest{
+					GetRequest: &MetaGetEntityRequest{
+						KindName: "IntegerTest",
+						Key:      key,
+					},
+				},
+			},
+		},
+	})
+	assert.NilError(t, err)
+	assert.Assert(t, resp.OperationResults[0].Error != nil)
+}
+
+func TestMemoryStorageSnapshotsMatchTransactionTime(t *testing.T) {
+	ctx := context.Background()
+
+	genResult, err := generate("./schema.json")
+	assert.NilError(t, err)
+
+	storage := createMemoryStorage()
+	defer storage.Close()
+	processor := createTransactionProcessor(storage)
+
+	entities := storage.Snapshots(ctx, &storageQuery{KindName: "IntegerTest"})
+	defer entities.Stop()
+	transactions := storage.Snapshots(ctx, &storageQuery{KindName: "Transaction"})
+	defer transactions.Stop()
+
+	// both start with an empty initial snapshot
+	snapshot, err := entities.Next()
+	assert.NilError(t, err)
+	assert.Equal(t, len(snapshot.Changes), 0)
+	snapshot, err = transactions.Next()
+	assert.NilError(t, err)
+	assert.Equal(t, len(snapshot.Changes), 0)
+
+	_, err = processor.processTransaction(ctx, genResult.Schema, &MetaTransaction{
+		Operations: []*MetaOperation{
+			&MetaOperation{
+				Operation: &MetaOperation_CreateRequest{
+					CreateRequest: &MetaCreateEntityRequest{
+						KindName: "IntegerTest",
+						Entity:   createMemoryTestEntity("a", 1),
+					},
+				},
+			},
+			&MetaOperation{
+				Operation: &MetaOperation_CreateRequest{
+					CreateRequest: &MetaCreateEntityRequest{
+						KindName: "IntegerTest",
+						Entity:   createMemoryTestEntity("b", 2),
+					},
+				},
+			},
+		},
+	})
+	assert.NilError(t, err)
+
+	entitySnapshot, err := entities.Next()
+	assert.NilError(t, err)
+	assert.Equal(t, len(entitySnapshot.Changes), 2)
+	transactionSnapshot, err := transactions.Next()
+	assert.NilError(t, err)
+	assert.Equal(t, len(transactionSnapshot.Changes), 1)
+
+	transaction := transactionSnapshot.Changes[0].Snapshot
+	assert.Equal(t, len(transaction.Data["mutatedKeys"].([]interface{})), 2)
+	for _, change := range entitySnapshot.Changes {
+		assert.Equal(t, change.Kind, storageDocumentAdded)
+		assert.Assert(t, change.Snapshot.UpdateTime.Equal(transaction.CreateTime))
+	}
+}
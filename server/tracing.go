@@ -5,8 +5,6 @@ import (
 	"log"
 	"os"
 	"strings"
-
-	"cloud.google.com/go/firestore"
 )
 
 const traceDefaultServiceName = "configstore"
@@ -72,6 +70,15 @@ func serializeMetaEntityForTrace(entity *MetaEntity) string {
 			}
 		case ValueType_uint64:
 			serValue = fmt.Sprintf("%d", value.Uint64Value)
+		case ValueType_duration:
+			serValue = fmt.Sprintf("%+v", value.DurationValue)
+		case ValueType_geopoint:
+			serValue = fmt.Sprintf("%+v", value.GeopointValue)
+		case ValueType_json:
+			serValue = fmt.Sprintf("%+v", value.JsonValue)
+		}
+		if value.NullValue {
+			serValue = "(null)"
 		}
 		lines = append(lines, fmt.Sprintf("  %d = %s", value.Id, serValue))
 	}
@@ -179,7 +186,7 @@ func SerializeKey(key *Key) string {
 	return serializeKey(key)
 }
 
-func RecordPendingChangesByTimestamp(pendingChanges map[string]map[string]*firestore.DocumentChange) {
+func RecordPendingChangesByTimestamp(pendingChanges map[string]map[string]*storageChange) {
 	if !isTracingEnabled() {
 		return
 	}
@@ -188,8 +195,8 @@ func RecordPendingChangesByTimestamp(pendingChanges map[string]map[string]*fires
 		var lines []string
 		lines = append(lines, fmt.Sprintf("  at %s:", ts))
 		for entityKey, change := range entities {
-			if change.Doc != nil {
-				lines = append(lines, fmt.Sprintf("   - %s = %s", entityKey, serializeRef(change.Doc.Ref)))
+			if change.Snapshot != nil {
+				lines = append(lines, fmt.Sprintf("   - %s = %s", entityKey, serializeKey(change.Snapshot.Key)))
 			} else {
 				lines = append(lines, fmt.Sprintf("   - %s = (nil doc)", entityKey))
 			}
@@ -1,15 +1,11 @@
 package main
 
-import (
-	"cloud.google.com/go/firestore"
-)
-
 type transactionProcessor struct {
-	client *firestore.Client
+	storage storageBackend
 }
 
-func createTransactionProcessor(client *firestore.Client) *transactionProcessor {
+func createTransactionProcessor(storage storageBackend) *transactionProcessor {
 	return &transactionProcessor{
-		client: client,
+		storage: storage,
 	}
 }
@@ -0,0 +1,352 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// configstoreMetadataKind is the kind used for documents that configstore
+// stores for its own bookkeeping. It isn't part of the schema.
+const configstoreMetadataKind = "ConfigstoreMetadata"
+
+// transactionMinimumRetention is how long transaction records are always
+// kept for, regardless of the configured retention, so that transaction
+// watchers (which read the last 60 seconds on startup) never miss one.
+const transactionMinimumRetention = time.Minute * 10
+
+const transactionCompactionBatchSize = 200
+
+func getConfigstoreMetadataKey(namespace string, name string) *Key {
+	return &Key{
+		PartitionId: &PartitionId{
+			Namespace: namespace,
+		},
+		Path: []*PathElement{
+			&PathElement{
+				Kind: configstoreMetadataKind,
+				IdType: &PathElement_Name{
+					Name: name,
+				},
+			},
+		},
+	}
+}
+
+// getTransactionLogCompactedBefore returns the time before which
+// transaction records in namespace may have been removed by compaction.
+func getTransactionLogCompactedBefore(ctx context.Context, storage storageBackend, namespace string) (time.Time, error) {
+	var compactedBefore time.Time
+	err := storage.RunTransaction(ctx, func(ctx context.Context, tx storageTransaction) error {
+		snapshot, err := tx.Get(getConfigstoreMetadataKey(namespace, "transactionLog"))
+		if err != nil {
+			if status.Code(err) == codes.NotFound {
+				return nil
+			}
+			return err
+		}
+		compactedBefore, _ = snapshot.Data["compactedBefore"].(time.Time)
+		return nil
+	})
+	return compactedBefore, err
+}
+
+// transactionCompactor periodically removes transaction records that are
+// older than the retention window, or beyond the most recent retention
+// count, optionally archiving them to a local NDJSON file first. Only the
+// replica holding the compaction lease compacts at any one time.
+type transactionCompactor struct {
+	storage        storageBackend
+	retention      time.Duration
+	retentionCount int
+	archivePath    string
+	interval       time.Duration
+	holder         string
+}
+
+func createTransactionCompactor(
+	storage storageBackend,
+	retention time.Duration,
+	retentionCount int,
+	archivePath string,
+	interval time.Duration,
+) *transactionCompactor {
+	hostname, _ := os.Hostname()
+	return &transactionCompactor{
+		storage:        storage,
+		retention:      retention,
+		retentionCount: retentionCount,
+		archivePath:    archivePath,
+		interval:       interval,
+		holder:         fmt.Sprintf("%s-%s", hostname, uuid.New().String()),
+	}
+}
+
+func (c *transactionCompactor) run(ctx context.Context) {
+	for true {
+		err := c.compact(ctx)
+		if err != nil {
+			log.Printf("transaction compaction failed: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(c.interval):
+		}
+	}
+}
+
+// acquireLease takes or renews the compaction lease, returning false if
+// another replica currently holds it.
+func (c *transactionCompactor) acquireLease(ctx context.Context) (bool, error) {
+	acquired := false
+	key := getConfigstoreMetadataKey(c.storage.DefaultNamespace(), "transactionCompactorLease")
+	err := c.storage.RunTransaction(ctx, func(ctx context.Context, tx storageTransaction) error {
+		acquired = false
+		now := time.Now()
+		snapshot, err := tx.Get(key)
+		if err != nil && status.Code(err) != codes.NotFound {
+			return err
+		}
+		if err == nil {
+			holder, _ := snapshot.Data["holder"].(string)
+			expires, _ := snapshot.Data["expires"].(time.Time)
+			if holder != c.holder && expires.After(now) {
+				return nil
+			}
+		}
+		acquired = true
+		return tx.Set(key, map[string]interface{}{
+			"holder":  c.holder,
+			"expires": now.Add(c.interval + time.Minute),
+		})
+	})
+	return acquired, err
+}
+
+func (c *transactionCompactor) compact(ctx context.Context) error {
+	acquired, err := c.acquireLease(ctx)
+	if err != nil {
+		return fmt.Errorf("can't acquire compaction lease: %v", err)
+	}
+	if !acquired {
+		return nil
+	}
+
+	namespaces, err := c.storage.Namespaces(ctx)
+	if err != nil {
+		return err
+	}
+	for _, namespace := range namespaces {
+		err = c.compactNamespace(ctx, namespace)
+		if err != nil {
+			return fmt.Errorf("can't compact transactions in namespace '%s': %v", namespace, err)
+		}
+	}
+	return nil
+}
+
+// getCutoff returns the time before which transaction records in
+// namespace have expired.
+func (c *transactionCompactor) getCutoff(ctx context.Context, namespace string) (time.Time, error) {
+	now := time.Now()
+	var cutoff time.Time
+	if c.retention > 0 {
+		cutoff = now.Add(-c.retention)
+	}
+	if c.retentionCount > 0 {
+		newest, err := c.storage.Query(ctx, &storageQuery{
+			Namespace:  namespace,
+			KindName:   "Transaction",
+			OrderBy:    "dateSubmitted",
+			Descending: true,
+			Limit:      c.retentionCount + 1,
+		})
+		if err != nil {
+			return time.Time{}, err
+		}
+		if len(newest) > c.retentionCount {
+			dateSubmitted, _ := newest[c.retentionCount].Data["dateSubmitted"].(time.Time)
+			countCutoff := dateSubmitted.Add(time.Nanosecond)
+			if countCutoff.After(cutoff) {
+				cutoff = countCutoff
+			}
+		}
+	}
+	if minimumCutoff := now.Add(-transactionMinimumRetention); cutoff.After(minimumCutoff) {
+		cutoff = minimumCutoff
+	}
+	return cutoff, nil
+}
+
+func (c *transactionCompactor) compactNamespace(ctx context.Context, namespace string) error {
+	cutoff, err := c.getCutoff(ctx, namespace)
+	if err != nil {
+		return err
+	}
+	if cutoff.IsZero() {
+		return nil
+	}
+
+	removed := 0
+	for true {
+		acquired, err := c.acquireLease(ctx)
+		if err != nil {
+			return err
+		}
+		if !acquired {
+			return fmt.Errorf("lost the compaction lease")
+		}
+
+		expired, err := c.storage.Query(ctx, &storageQuery{
+			Namespace: namespace,
+			KindName:  "Transaction",
+			Filters: []storageFilter{
+				{Field: "dateSubmitted", Op: "<", Value: cutoff},
+			},
+			OrderBy: "dateSubmitted",
+			Limit:   transactionCompactionBatchSize,
+		})
+		if err != nil {
+			return err
+		}
+		if len(expired) == 0 {
+			break
+		}
+
+		if c.archivePath != "" {
+			err = c.archive(namespace, expired)
+			if err != nil {
+				return fmt.Errorf("can't archive transactions: %v", err)
+			}
+		}
+
+		compactedBefore, _ := expired[len(expired)-1].Data["dateSubmitted"].(time.Time)
+		err = c.storage.RunTransaction(ctx, func(ctx context.Context, tx storageTransaction) error {
+			metadataKey := getConfigstoreMetadataKey(namespace, "transactionLog")
+			err := tx.Set(metadataKey, map[string]interface{}{
+				"compactedBefore": compactedBefore,
+			})
+			if err != nil {
+				return err
+			}
+			for _, record := range expired {
+				err = tx.Delete(record.Key)
+				if err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		removed += len(expired)
+	}
+
+	if removed > 0 {
+		log.Printf("compacted %d transactions in namespace '%s'", removed, namespace)
+	}
+	return nil
+}
+
+type transactionArchiveLine struct {
+	Namespace   string          `json:"namespace"`
+	ID          string          `json:"id"`
+	CreateTime  time.Time       `json:"createTime"`
+	Transaction json.RawMessage `json:"transaction"`
+}
+
+func (c *transactionCompactor) archive(namespace string, records []*storageSnapshot) error {
+	file, err := os.OpenFile(c.archivePath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	for _, record := range records {
+		data, err := convertStorageValueToArchiveJSON(record.Data)
+		if err != nil {
+			return err
+		}
+		encodedData, err := json.Marshal(data)
+		if err != nil {
+			return err
+		}
+		line, err := json.Marshal(&transactionArchiveLine{
+			Namespace:   namespace,
+			ID:          getKeyIDString(record.Key),
+			CreateTime:  record.CreateTime,
+			Transaction: json.RawMessage(encodedData),
+		})
+		if err != nil {
+			return err
+		}
+		_, err = file.Write(append(line, '\n'))
+		if err != nil {
+			return err
+		}
+	}
+
+	// the records are deleted once this returns, so make sure they're on disk
+	return file.Sync()
+}
+
+// convertStorageValueToArchiveJSON converts a storage value into a value
+// that encoding/json can write, with keys in their protobuf JSON form.
+func convertStorageValueToArchiveJSON(value interface{}) (interface{}, error) {
+	switch v := value.(type) {
+	case *Key:
+		if v == nil {
+			return nil, nil
+		}
+		encoded, err := (&jsonpb.Marshaler{}).MarshalToString(v)
+		if err != nil {
+			return nil, err
+		}
+		return json.RawMessage(encoded), nil
+	case []*Key:
+		result := make([]interface{}, len(v))
+		for i, key := range v {
+			converted, err := convertStorageValueToArchiveJSON(key)
+			if err != nil {
+				return nil, err
+			}
+			result[i] = converted
+		}
+		return result, nil
+	case []interface{}:
+		result := make([]interface{}, len(v))
+		for i, elem := range v {
+			converted, err := convertStorageValueToArchiveJSON(elem)
+			if err != nil {
+				return nil, err
+			}
+			result[i] = converted
+		}
+		return result, nil
+	case map[string]interface{}:
+		result := make(map[string]interface{})
+		for k, elem := range v {
+			converted, err := convertStorageValueToArchiveJSON(elem)
+			if err != nil {
+				return nil, err
+			}
+			result[k] = converted
+		}
+		return result, nil
+	case time.Time:
+		return v.UTC().Format(time.RFC3339Nano), nil
+	default:
+		return value, nil
+	}
+}
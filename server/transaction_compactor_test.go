@@ -0,0 +1,72 @@
+package main
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"testing"
+
+	"gotest.tools/assert"
+)
+
+func TestTransactionCompactor(t *testing.T) {
+	ctx := context.Background()
+
+	storage := createMemoryStorage()
+	defer storage.Close()
+
+	now := time.Now()
+	err := storage.RunTransaction(ctx, func(ctx context.Context, tx storageTransaction) error {
+		for i := 0; i < 10; i++ {
+			err := tx.Set(storage.NewKey("", nil, "Transaction"), map[string]interface{}{
+				"dateSubmitted": now.Add(time.Hour * time.Duration(-i)),
+				"description":   "test",
+			})
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	assert.NilError(t, err)
+
+	dir, err := ioutil.TempDir("", "configstore")
+	assert.NilError(t, err)
+	defer os.RemoveAll(dir)
+	archivePath := filepath.Join(dir, "archive.ndjson")
+
+	compactor := createTransactionCompactor(storage, 0, 3, archivePath, time.Minute)
+	err = compactor.compact(ctx)
+	assert.NilError(t, err)
+
+	remaining, err := storage.Query(ctx, &storageQuery{KindName: "Transaction"})
+	assert.NilError(t, err)
+	assert.Equal(t, len(remaining), 3)
+
+	archive, err := ioutil.ReadFile(archivePath)
+	assert.NilError(t, err)
+	assert.Equal(t, strings.Count(string(archive), "\n"), 7)
+
+	compactedBefore, err := getTransactionLogCompactedBefore(ctx, storage, storage.DefaultNamespace())
+	assert.NilError(t, err)
+	assert.Assert(t, compactedBefore.Equal(now.Add(time.Hour*-3)))
+
+	_, err = createTransactionProcessor(storage).restoreToTime(ctx, &Schema{}, storage.DefaultNamespace(), now.Add(time.Hour*-5))
+	assert.ErrorContains(t, err, "compacted")
+
+	// another replica can't compact while the lease is held
+	other := createTransactionCompactor(storage, 0, 1, "", time.Minute)
+	acquired, err := other.acquireLease(ctx)
+	assert.NilError(t, err)
+	assert.Assert(t, !acquired)
+	err = other.compact(ctx)
+	assert.NilError(t, err)
+
+	remaining, err = storage.Query(ctx, &storageQuery{KindName: "Transaction"})
+	assert.NilError(t, err)
+	assert.Equal(t, len(remaining), 3)
+}
@@ -5,25 +5,41 @@ import (
 	"fmt"
 	"time"
 
-	"cloud.google.com/go/firestore"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
+// contextAbortOnErrorKey is set when a transaction must be applied in full
+// or not at all. If any operation fails, the transaction is rolled back and
+// processTransaction returns the error of the first one that failed.
+const contextAbortOnErrorKey c = "abortOnError"
+
 func (s *transactionProcessor) processTransaction(
 	ctx context.Context,
 	schema *Schema,
 	req *MetaTransaction,
 ) (*MetaTransactionResult, error) {
+	err := enforceTransactionTenant(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
 	resp := &MetaTransactionResult{}
 	resp.OperationResults = make([]*MetaOperationResult, len(req.Operations), len(req.Operations))
 
-	err := s.client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
-		opProcessor := createOperationProcessor(s.client, tx)
+	err = s.storage.RunTransaction(ctx, func(ctx context.Context, tx storageTransaction) error {
+		// the transaction ID is allocated up front, so that the version
+		// records written by each operation can refer to it
+		transactionID := getKeyIDString(s.storage.NewKey("", nil, "Transaction"))
+		dateSubmitted := time.Now()
+		opProcessor := createOperationProcessor(s.storage, tx, transactionID, dateSubmitted)
 
 		readStates := make([]interface{}, len(req.Operations), len(req.Operations))
 		readErrors := make([]error, len(req.Operations), len(req.Operations))
 
-		var mutatedKeys []*firestore.DocumentRef
-		var deletedKeys []*firestore.DocumentRef
+		var mutatedKeys []*Key
+		var deletedKeys []*Key
+		var changes []interface{}
 
 		for i, operation := range req.Operations {
 			if opReq := operation.GetListRequest(); opReq != nil {
@@ -88,16 +104,16 @@ func (s *transactionProcessor) processTransaction(
 				} else {
 					opResp, err := opProcessor.operationUpdateWrite(ctx, schema, opReq, readStates[i])
 					if err == nil {
-						ref, err := convertMetaKeyToDocumentRef(
-							s.client,
+						mutatedKeys = append(
+							mutatedKeys,
 							opResp.Entity.Key,
 						)
-						if err == nil {
-							mutatedKeys = append(
-								mutatedKeys,
-								ref,
-							)
+						var change map[string]interface{}
+						change, err = createTransactionChange(schema, opResp.Entity.Key, readStates[i].(*storageSnapshot), opResp.Entity)
+						if err != nil {
+							return err
 						}
+						changes = append(changes, change)
 					}
 					operationResult = toOperationResult(ctx, schema, &MetaOperationResult_UpdateResponse{
 						UpdateResponse: opResp,
@@ -112,16 +128,16 @@ func (s *transactionProcessor) processTransaction(
 				} else {
 					opResp, err := opProcessor.operationCreateWrite(ctx, schema, opReq, readStates[i])
 					if err == nil {
-						ref, err := convertMetaKeyToDocumentRef(
-							s.client,
+						mutatedKeys = append(
+							mutatedKeys,
 							opResp.Entity.Key,
 						)
-						if err == nil {
-							mutatedKeys = append(
-								mutatedKeys,
-								ref,
-							)
+						var change map[string]interface{}
+						change, err = createTransactionChange(schema, opResp.Entity.Key, nil, opResp.Entity)
+						if err != nil {
+							return err
 						}
+						changes = append(changes, change)
 					}
 					operationResult = toOperationResult(ctx, schema, &MetaOperationResult_CreateResponse{
 						CreateResponse: opResp,
@@ -136,16 +152,16 @@ func (s *transactionProcessor) processTransaction(
 				} else {
 					opResp, err := opProcessor.operationDeleteWrite(ctx, schema, opReq, readStates[i])
 					if err == nil {
-						ref, err := convertMetaKeyToDocumentRef(
-							s.client,
+						deletedKeys = append(
+							deletedKeys,
 							opResp.Entity.Key,
 						)
-						if err == nil {
-							deletedKeys = append(
-								deletedKeys,
-								ref,
-							)
+						var change map[string]interface{}
+						change, err = createTransactionChange(schema, opResp.Entity.Key, readStates[i].(*storageSnapshot), nil)
+						if err != nil {
+							return err
 						}
+						changes = append(changes, change)
 					}
 					operationResult = toOperationResult(ctx, schema, &MetaOperationResult_DeleteResponse{
 						DeleteResponse: opResp,
@@ -158,20 +174,58 @@ func (s *transactionProcessor) processTransaction(
 			}
 		}
 
+		if abort, ok := ctx.Value(contextAbortOnErrorKey).(bool); ok && abort {
+			for _, operationResult := range resp.OperationResults {
+				if operationResult != nil && operationResult.Error != nil {
+					return convertOperationResultError(operationResult.Error)
+				}
+			}
+		}
+
+		mutatedKeys = append(mutatedKeys, opProcessor.mutatedKeys...)
+		deletedKeys = append(deletedKeys, opProcessor.deletedKeys...)
+		changes = append(changes, opProcessor.changes...)
+
 		if len(mutatedKeys) > 0 || len(deletedKeys) > 0 {
+			// the transaction record is stored in the namespace of the
+			// entities it modifies, so that each namespace has its own
+			// transaction log.
+			namespace := ""
+			for i, key := range append(append([]*Key{}, mutatedKeys...), deletedKeys...) {
+				keyNamespace := normalizeNamespace(s.storage, getKeyNamespace(key))
+				if i == 0 {
+					namespace = keyNamespace
+				} else if keyNamespace != namespace {
+					return fmt.Errorf("all entities modified in a transaction must be in the same namespace, but found both '%s' and '%s'", namespace, keyNamespace)
+				}
+			}
+
 			authSub, ok := ctx.Value(contextSubjectKey).(string)
-			ref := s.client.Collection("Transaction").NewDoc()
+			key := &Key{
+				PartitionId: &PartitionId{
+					Namespace: namespace,
+				},
+				Path: []*PathElement{
+					&PathElement{
+						Kind: "Transaction",
+						IdType: &PathElement_Name{
+							Name: transactionID,
+						},
+					},
+				},
+			}
 			transaction := make(map[string]interface{})
 			transaction["mutatedKeys"] = mutatedKeys
 			transaction["deletedKeys"] = deletedKeys
-			transaction["dateSubmitted"] = time.Now()
+			transaction["changes"] = changes
+			transaction["dateSubmitted"] = dateSubmitted
 			transaction["description"] = req.Description
 			if ok {
 				transaction["authSub"] = authSub
 			} else {
 				transaction["authSub"] = nil
 			}
-			tx.Create(ref, transaction)
+			tx.Create(key, transaction)
 		}
 
 		return nil
@@ -183,6 +237,32 @@ func (s *transactionProcessor) processTransaction(
 	return resp, nil
 }
 
+// createTransactionChange returns the before and after images of an entity
+// modified by a transaction, for storing in the transaction log. Either
+// image is nil if the entity didn't exist before or after the transaction.
+func createTransactionChange(schema *Schema, key *Key, before *storageSnapshot, after *MetaEntity) (map[string]interface{}, error) {
+	change := map[string]interface{}{
+		"key":    key,
+		"before": nil,
+		"after":  nil,
+	}
+	if before != nil {
+		change["before"] = before.Data
+	}
+	if after != nil {
+		kindInfo, err := findSchemaKindByName(schema, getKeyKindName(key))
+		if err != nil {
+			return nil, err
+		}
+		_, data, err := convertMetaEntityToKeyAndDataMap(after, schema, kindInfo)
+		if err != nil {
+			return nil, err
+		}
+		change["after"] = data
+	}
+	return change, nil
+}
+
 func toOperationResult(
 	ctx context.Context,
 	schema *Schema,
@@ -190,9 +270,15 @@ func toOperationResult(
 	err error,
 ) *MetaOperationResult {
 	if err != nil {
+		message := fmt.Sprintf("%v", err)
+		if st, ok := status.FromError(err); ok {
+			message = st.Message()
+		}
 		return &MetaOperationResult{
 			Error: &MetaOperationResultError{
-				ErrorMessage: fmt.Sprintf("%v", err),
+				ErrorMessage:    message,
+				Code:            uint32(status.Code(err)),
+				FieldViolations: getFieldViolations(err),
 			},
 		}
 	} else {
@@ -201,3 +287,13 @@ func toOperationResult(
 		}
 	}
 }
+
+// convertOperationResultError returns the error stored in an operation
+// result, preserving its gRPC status code.
+func convertOperationResultError(resultError *MetaOperationResultError) error {
+	code := codes.Code(resultError.Code)
+	if code == codes.OK {
+		code = codes.Unknown
+	}
+	return createFieldViolationError(code, resultError.ErrorMessage, resultError.FieldViolations)
+}
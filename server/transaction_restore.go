@@ -0,0 +1,197 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type restoreImage struct {
+	key     *Key
+	before  map[string]interface{}
+	current map[string]interface{}
+	// transactionID is the last logged transaction that modified the
+	// entity, which current is the result of.
+	transactionID string
+}
+
+// getRestoreExpectedVersion returns the version that the entity must still
+// be at when the restore is applied, so that writes made since the
+// transaction log was read aren't silently overwritten.
+func (s *transactionProcessor) getRestoreExpectedVersion(ctx context.Context, image *restoreImage) (int64, error) {
+	records, err := s.storage.Query(ctx, &storageQuery{
+		Parent:     normalizeEntityKey(s.storage, image.key),
+		KindName:   entityVersionKind,
+		OrderBy:    "version",
+		Descending: true,
+		Limit:      1,
+	})
+	if err != nil {
+		return 0, err
+	}
+	if len(records) == 0 {
+		// entities written before versions were recorded can't be checked
+		return 0, nil
+	}
+	transactionID, _ := records[0].Data["transactionId"].(string)
+	if transactionID != image.transactionID {
+		return 0, status.Error(codes.Aborted, fmt.Sprintf("entity %s was modified while it was being restored; try again", serializeKey(image.key)))
+	}
+	version, _ := records[0].Data["version"].(int64)
+	return version, nil
+}
+
+// restoreToTime uses the before images recorded in the transaction log to
+// return every entity in namespace that was modified after restoreTime to
+// the state it was in at restoreTime. The inverse changes are applied as a
+// single transaction.
+func (s *transactionProcessor) restoreToTime(
+	ctx context.Context,
+	schema *Schema,
+	namespace string,
+	restoreTime time.Time,
+) (*RestoreToTimeResponse, error) {
+	compactedBefore, err := getTransactionLogCompactedBefore(ctx, s.storage, namespace)
+	if err != nil {
+		return nil, err
+	}
+	if restoreTime.Before(compactedBefore) {
+		return nil, status.Error(codes.FailedPrecondition, fmt.Sprintf("transactions before %s have been compacted, so entities can't be restored to %s", compactedBefore.UTC().Format(time.RFC3339Nano), restoreTime.UTC().Format(time.RFC3339Nano)))
+	}
+
+	// dateSubmitted is set slightly before the transaction commits, so
+	// query with some leeway and then filter on the commit time
+	records, err := s.storage.Query(ctx, &storageQuery{
+		Namespace: namespace,
+		KindName:  "Transaction",
+		Filters: []storageFilter{
+			{Field: "dateSubmitted", Op: ">=", Value: restoreTime.Add(time.Minute * -1)},
+		},
+		OrderBy: "dateSubmitted",
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(records, func(i, j int) bool {
+		return records[i].CreateTime.Before(records[j].CreateTime)
+	})
+
+	images := make(map[string]*restoreImage)
+	for _, record := range records {
+		if !record.CreateTime.After(restoreTime) {
+			continue
+		}
+
+		changes, ok := record.Data["changes"].([]interface{})
+		if !ok {
+			return nil, status.Error(codes.FailedPrecondition, fmt.Sprintf("transaction %s does not record entity images, so entities can't be restored to before it", getKeyIDString(record.Key)))
+		}
+		for _, rawChange := range changes {
+			change, ok := rawChange.(map[string]interface{})
+			if !ok {
+				return nil, fmt.Errorf("transaction %s has a malformed change", getKeyIDString(record.Key))
+			}
+			key, ok := change["key"].(*Key)
+			if !ok || key == nil {
+				return nil, fmt.Errorf("transaction %s has a change without a key", getKeyIDString(record.Key))
+			}
+			before, _ := change["before"].(map[string]interface{})
+			after, _ := change["after"].(map[string]interface{})
+
+			id := serializeKey(key)
+			image, ok := images[id]
+			if !ok {
+				// the first change after the restore time holds the
+				// version of the entity we're restoring to
+				image = &restoreImage{
+					key:    key,
+					before: before,
+				}
+				images[id] = image
+			}
+			image.current = after
+			image.transactionID = getKeyIDString(record.Key)
+		}
+	}
+
+	var ids []string
+	for id := range images {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	response := &RestoreToTimeResponse{}
+	transaction := &MetaTransaction{
+		Description: fmt.Sprintf("restore to %s", restoreTime.UTC().Format(time.RFC3339Nano)),
+	}
+	var deletes []*MetaOperation
+	var updates []*MetaOperation
+	for _, id := range ids {
+		image := images[id]
+		kindName := getKeyKindName(image.key)
+		if image.before == nil && image.current == nil {
+			// created and deleted again since the restore time
+			continue
+		}
+		expectedVersion, err := s.getRestoreExpectedVersion(ctx, image)
+		if err != nil {
+			return nil, err
+		}
+		if image.before == nil {
+			deletes = append(deletes, &MetaOperation{
+				Operation: &MetaOperation_DeleteRequest{
+					DeleteRequest: &MetaDeleteEntityRequest{
+						KindName:        kindName,
+						Key:             image.key,
+						ExpectedVersion: expectedVersion,
+					},
+				},
+			})
+			response.DeletedKeys = append(response.DeletedKeys, image.key)
+		} else {
+			kindInfo, err := findSchemaKindByName(schema, kindName)
+			if err != nil {
+				return nil, err
+			}
+			entity, err := convertSnapshotToMetaEntity(schema, kindInfo, &storageSnapshot{
+				Key:  image.key,
+				Data: image.before,
+			})
+			if err != nil {
+				return nil, err
+			}
+			updates = append(updates, &MetaOperation{
+				Operation: &MetaOperation_UpdateRequest{
+					UpdateRequest: &MetaUpdateEntityRequest{
+						Entity:          entity,
+						ExpectedVersion: expectedVersion,
+					},
+				},
+			})
+			response.RestoredKeys = append(response.RestoredKeys, image.key)
+		}
+	}
+
+	// deletes release unique values before the updates claim them again
+	transaction.Operations = append(deletes, updates...)
+	if len(transaction.Operations) == 0 {
+		return response, nil
+	}
+
+	// restoring puts back old values of readonly fields too, and data that
+	// was accepted before validators changed. Unique values are still
+	// checked, and if any operation fails, nothing is restored.
+	ctx = context.WithValue(ctx, contextReadonlyOverrideKey, true)
+	ctx = context.WithValue(ctx, contextSkipValidationKey, true)
+	ctx = context.WithValue(ctx, contextAbortOnErrorKey, true)
+	_, err = s.processTransaction(ctx, schema, transaction)
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
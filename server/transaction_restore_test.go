@@ -0,0 +1,175 @@
+package main
+
+import (
+	"context"
+	"time"
+
+	"testing"
+
+	codes "google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gotest.tools/assert"
+)
+
+func TestRestoreToTime(t *testing.T) {
+	ctx := context.Background()
+
+	genResult, err := generate("./schema.json")
+	assert.NilError(t, err)
+
+	storage := createMemoryStorage()
+	defer storage.Close()
+	processor := createTransactionProcessor(storage)
+
+	apply := func(operations ...*MetaOperation) {
+		resp, err := processor.processTransaction(ctx, genResult.Schema, &MetaTransaction{
+			Operations: operations,
+		})
+		assert.NilError(t, err)
+		for _, operationResult := range resp.OperationResults {
+			assert.Assert(t, operationResult.Error == nil)
+		}
+	}
+	create := func(entity *MetaEntity) *MetaOperation {
+		return &MetaOperation{
+			Operation: &MetaOperation_CreateRequest{
+				CreateRequest: &MetaCreateEntityRequest{
+					KindName: "IntegerTest",
+					Entity:   entity,
+				},
+			},
+		}
+	}
+	update := func(entity *MetaEntity) *MetaOperation {
+		return &MetaOperation{
+			Operation: &MetaOperation_UpdateRequest{
+				UpdateRequest: &MetaUpdateEntityRequest{
+					Entity: entity,
+				},
+			},
+		}
+	}
+	remove := func(entity *MetaEntity) *MetaOperation {
+		return &MetaOperation{
+			Operation: &MetaOperation_DeleteRequest{
+				DeleteRequest: &MetaDeleteEntityRequest{
+					KindName: "IntegerTest",
+					Key:      entity.Key,
+				},
+			},
+		}
+	}
+
+	apply(create(createMemoryTestEntity("a", 1)), create(createMemoryTestEntity("b", 1)))
+	time.Sleep(time.Millisecond * 10)
+	restoreTime := time.Now()
+	time.Sleep(time.Millisecond * 10)
+	apply(update(createMemoryTestEntity("a", 2)), create(createMemoryTestEntity("c", 1)))
+	apply(update(createMemoryTestEntity("a", 3)), remove(createMemoryTestEntity("b", 0)))
+	apply(create(createMemoryTestEntity("d", 1)))
+	apply(remove(createMemoryTestEntity("d", 0)))
+
+	resp, err := processor.restoreToTime(ctx, genResult.Schema, storage.DefaultNamespace(), restoreTime)
+	assert.NilError(t, err)
+	assert.Equal(t, len(resp.RestoredKeys), 2)
+	assert.Equal(t, len(resp.DeletedKeys), 1)
+
+	snapshots, err := storage.Query(ctx, &storageQuery{KindName: "IntegerTest"})
+	assert.NilError(t, err)
+	assert.Equal(t, len(snapshots), 2)
+	assert.Equal(t, getKeyIDString(snapshots[0].Key), "a")
+	assert.Equal(t, snapshots[0].Data["unsignedInt"], int64(1))
+	assert.Equal(t, getKeyIDString(snapshots[1].Key), "b")
+	assert.Equal(t, snapshots[1].Data["unsignedInt"], int64(1))
+
+	// a write that isn't in the transaction log yet when the restore reads
+	// it must not be overwritten
+	apply(update(createMemoryTestEntity("a", 4)))
+	key := normalizeEntityKey(storage, createMemoryTestEntity("a", 0).Key)
+	versions, err := storage.Query(ctx, &storageQuery{Parent: key, KindName: entityVersionKind})
+	assert.NilError(t, err)
+	err = storage.RunTransaction(ctx, func(ctx context.Context, tx storageTransaction) error {
+		return tx.Create(getEntityVersionKey(key, int64(len(versions)+1)), map[string]interface{}{
+			"version":       int64(len(versions) + 1),
+			"transactionId": "concurrent",
+			"dateSubmitted": time.Now(),
+			"deleted":       false,
+			"values":        nil,
+			"authSub":       nil,
+		})
+	})
+	assert.NilError(t, err)
+	_, err = processor.restoreToTime(ctx, genResult.Schema, storage.DefaultNamespace(), restoreTime)
+	assert.Equal(t, status.Code(err), codes.Aborted)
+}
+
+func TestRestoreToTimeChecksUniqueValues(t *testing.T) {
+	ctx := context.Background()
+
+	genResult, err := generate("./schema.json")
+	assert.NilError(t, err)
+
+	storage := createMemoryStorage()
+	defer storage.Close()
+	processor := createTransactionProcessor(storage)
+
+	apply := func(operations ...*MetaOperation) {
+		resp, err := processor.processTransaction(ctx, genResult.Schema, &MetaTransaction{
+			Operations: operations,
+		})
+		assert.NilError(t, err)
+		for _, operationResult := range resp.OperationResults {
+			assert.Assert(t, operationResult.Error == nil)
+		}
+	}
+	uniqueCodes := func() map[string]interface{} {
+		snapshots, err := storage.Query(ctx, &storageQuery{KindName: "UniqueTest"})
+		assert.NilError(t, err)
+		result := make(map[string]interface{})
+		for _, snapshot := range snapshots {
+			result[getKeyIDString(snapshot.Key)] = snapshot.Data["code"]
+		}
+		return result
+	}
+
+	apply(createTestOperation(createTestKey("UniqueTest", "a"), createUniqueTestValue("x")), createTestOperation(createTestKey("UniqueTest", "b"), createUniqueTestValue("z")))
+	time.Sleep(time.Millisecond * 10)
+	restoreTime := time.Now()
+	time.Sleep(time.Millisecond * 10)
+	apply(updateTestOperation(createTestKey("UniqueTest", "a"), createUniqueTestValue("y")))
+	apply(updateTestOperation(createTestKey("UniqueTest", "b"), createUniqueTestValue("x")))
+
+	// a value can be moved back to an entity that's restored before the
+	// entity holding it now
+	_, err = processor.restoreToTime(ctx, genResult.Schema, storage.DefaultNamespace(), restoreTime)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, uniqueCodes(), map[string]interface{}{"a": "x", "b": "z"})
+
+	time.Sleep(time.Millisecond * 10)
+	restoreTime = time.Now()
+	time.Sleep(time.Millisecond * 10)
+	apply(updateTestOperation(createTestKey("UniqueTest", "a"), createUniqueTestValue("y")))
+	apply(updateTestOperation(createTestKey("UniqueTest", "b"), createUniqueTestValue("w")))
+
+	// an entity that isn't being restored holds the value, like one that
+	// was written before the field was marked unique
+	err = storage.RunTransaction(ctx, func(ctx context.Context, tx storageTransaction) error {
+		key := normalizeEntityKey(storage, createTestKey("UniqueTest", "c"))
+		err := tx.Create(key, map[string]interface{}{"code": "x"})
+		if err != nil {
+			return err
+		}
+		guardKey := createOperationProcessor(storage, tx, "", time.Now()).getUniqueGuardKey("", "UniqueTest", genResult.Schema.Kinds["UniqueTest"].Fields[0], "x")
+		return tx.Set(guardKey, map[string]interface{}{
+			"kind":  "UniqueTest",
+			"field": "code",
+			"owner": key,
+		})
+	})
+	assert.NilError(t, err)
+
+	// nothing is restored if any entity can't be
+	_, err = processor.restoreToTime(ctx, genResult.Schema, storage.DefaultNamespace(), restoreTime)
+	assert.Equal(t, status.Code(err), codes.AlreadyExists)
+	assert.DeepEqual(t, uniqueCodes(), map[string]interface{}{"a": "y", "b": "w", "c": "x"})
+}
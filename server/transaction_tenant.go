@@ -0,0 +1,102 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// resolveTenantPartitionId returns the partition ID a request should use.
+// If the caller is pinned to a tenant, the partition ID must either be
+// omitted or refer to the tenant's namespace.
+func resolveTenantPartitionId(ctx context.Context, partitionID *PartitionId) (*PartitionId, error) {
+	tenant, ok := getContextTenant(ctx)
+	if !ok {
+		return partitionID, nil
+	}
+	if partitionID != nil && partitionID.Namespace != "" && partitionID.Namespace != tenant {
+		return nil, status.Error(codes.PermissionDenied, fmt.Sprintf("namespace '%s' is not accessible to this tenant", partitionID.Namespace))
+	}
+	return &PartitionId{
+		Namespace: tenant,
+	}, nil
+}
+
+func enforceTenantKey(tenant string, key *Key) error {
+	if key == nil {
+		return nil
+	}
+	if key.PartitionId == nil || key.PartitionId.Namespace == "" {
+		key.PartitionId = &PartitionId{
+			Namespace: tenant,
+		}
+		return nil
+	}
+	if key.PartitionId.Namespace != tenant {
+		return status.Error(codes.PermissionDenied, fmt.Sprintf("key %s is not accessible to this tenant", serializeKey(key)))
+	}
+	return nil
+}
+
+func enforceTenantEntity(tenant string, entity *MetaEntity) error {
+	if entity == nil {
+		return nil
+	}
+	err := enforceTenantKey(tenant, entity.Key)
+	if err != nil {
+		return err
+	}
+	// keys nested in repeated, struct and map values are pinned too
+	return forEachNestedValue(entity.Values, func(value *Value) error {
+		return enforceTenantKey(tenant, value.KeyValue)
+	})
+}
+
+// enforceTransactionTenant pins every key referenced by the transaction to
+// the caller's tenant namespace, rejecting the transaction if it references
+// any other namespace.
+func enforceTransactionTenant(ctx context.Context, req *MetaTransaction) error {
+	tenant, ok := getContextTenant(ctx)
+	if !ok {
+		return nil
+	}
+
+	for _, operation := range req.Operations {
+		var err error
+		if opReq := operation.GetListRequest(); opReq != nil {
+			opReq.PartitionId, err = resolveTenantPartitionId(ctx, opReq.PartitionId)
+			if err == nil {
+				err = enforceTenantKey(tenant, opReq.Ancestor)
+			}
+		}
+		if opReq := operation.GetGetRequest(); opReq != nil {
+			err = enforceTenantKey(tenant, opReq.Key)
+		}
+		if opReq := operation.GetUpdateRequest(); opReq != nil {
+			err = enforceTenantEntity(tenant, opReq.Entity)
+		}
+		if opReq := operation.GetCreateRequest(); opReq != nil {
+			if opReq.Entity != nil && opReq.Entity.Key == nil {
+				// pin automatically generated keys to the tenant's namespace
+				opReq.Entity.Key = &Key{
+					Path: []*PathElement{
+						&PathElement{
+							Kind: opReq.KindName,
+						},
+					},
+				}
+			}
+			err = enforceTenantEntity(tenant, opReq.Entity)
+		}
+		if opReq := operation.GetDeleteRequest(); opReq != nil {
+			err = enforceTenantKey(tenant, opReq.Key)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
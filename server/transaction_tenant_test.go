@@ -0,0 +1,200 @@
+package main
+
+import (
+	"context"
+
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gotest.tools/assert"
+)
+
+func TestTenantIsPinnedToNamespace(t *testing.T) {
+	ctx := context.WithValue(context.Background(), contextTenantKey, "tenant-a")
+
+	genResult, err := generate("./schema.json")
+	assert.NilError(t, err)
+
+	storage := createMemoryStorage()
+	defer storage.Close()
+	processor := createTransactionProcessor(storage)
+
+	// keys without a namespace are created in the tenant's namespace
+	resp, err := processor.processTransaction(ctx, genResult.Schema, &MetaTransaction{
+		Operations: []*MetaOperation{
+			&MetaOperation{
+				Operation: &MetaOperation_CreateRequest{
+					CreateRequest: &MetaCreateEntityRequest{
+						KindName: "IntegerTest",
+						Entity:   createMemoryTestEntity("a", 5),
+					},
+				},
+			},
+		},
+	})
+	assert.NilError(t, err)
+	assert.Assert(t, resp.OperationResults[0].Error == nil)
+	key := resp.OperationResults[0].GetCreateResponse().Entity.Key
+	assert.Equal(t, key.PartitionId.Namespace, "tenant-a")
+
+	// the default namespace can't be read or written
+	entity := createMemoryTestEntity("b", 5)
+	entity.Key.PartitionId.Namespace = storage.DefaultNamespace()
+	_, err = processor.processTransaction(ctx, genResult.Schema, &MetaTransaction{
+		Operations: []*MetaOperation{
+			&MetaOperation{
+				Operation: &MetaOperation_CreateRequest{
+					CreateRequest: &MetaCreateEntityRequest{
+						KindName: "IntegerTest",
+						Entity:   entity,
+					},
+				},
+			},
+		},
+	})
+	assert.Equal(t, status.Code(err), codes.PermissionDenied)
+
+	_, err = processor.processTransaction(ctx, genResult.Schema, &MetaTransaction{
+		Operations: []*MetaOperation{
+			&MetaOperation{
+				Operation: &MetaOperation_ListRequest{
+					ListRequest: &MetaListEntitiesRequest{
+						KindName: "IntegerTest",
+						PartitionId: &PartitionId{
+							Namespace: "tenant-b",
+						},
+					},
+				},
+			},
+		},
+	})
+	assert.Equal(t, status.Code(err), codes.PermissionDenied)
+
+	// nor can entities reference keys in another namespace
+	_, err = processor.processTransaction(ctx, genResult.Schema, &MetaTransaction{
+		Operations: []*MetaOperation{
+			&MetaOperation{
+				Operation: &MetaOperation_CreateRequest{
+					CreateRequest: &MetaCreateEntityRequest{
+						KindName: "NilKeyTest",
+						Entity: &MetaEntity{
+							Values: []*Value{
+								&Value{
+									Id:   2,
+									Type: ValueType_key,
+									KeyValue: &Key{
+										PartitionId: &PartitionId{
+											Namespace: "tenant-b",
+										},
+										Path: key.Path,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	})
+	assert.Equal(t, status.Code(err), codes.PermissionDenied)
+
+	// and the entity isn't visible outside the tenant
+	resp, err = processor.processTransaction(context.Background(), genResult.Schema, &MetaTransaction{
+		Operations: []*MetaOperation{
+			&MetaOperation{
+				Operation: &MetaOperation_ListRequest{
+					ListRequest: &MetaListEntitiesRequest{
+						KindName: "IntegerTest",
+					},
+				},
+			},
+		},
+	})
+	assert.NilError(t, err)
+	assert.Equal(t, len(resp.OperationResults[0].GetListResponse().Entities), 0)
+}
+
+func TestTenantIsPinnedForNestedKeys(t *testing.T) {
+	ctx := context.WithValue(context.Background(), contextTenantKey, "tenant-a")
+
+	genResult, err := generate("./schema.json")
+	assert.NilError(t, err)
+
+	storage := createMemoryStorage()
+	defer storage.Close()
+	processor := createTransactionProcessor(storage)
+
+	createTenantTestKey := func(namespace string) *Key {
+		return &Key{
+			PartitionId: &PartitionId{
+				Namespace: namespace,
+			},
+			Path: []*PathElement{
+				&PathElement{
+					Kind: "User",
+					IdType: &PathElement_Name{
+						Name: "u",
+					},
+				},
+			},
+		}
+	}
+	createLandlordValue := func(namespace string) *Value {
+		return &Value{
+			Id:   2,
+			Type: ValueType_struct,
+			StructValue: &StructValue{
+				Values: []*Value{
+					&Value{
+						Id:       4,
+						Type:     ValueType_key,
+						KeyValue: createTenantTestKey(namespace),
+					},
+				},
+			},
+		}
+	}
+
+	for name, operation := range map[string]*MetaOperation{
+		"repeated": createTestOperation(createTestKey("Project", "p"), &Value{
+			Id:   3,
+			Type: ValueType_key,
+			ArrayValue: []*Value{
+				&Value{
+					Type:     ValueType_key,
+					KeyValue: createTenantTestKey("tenant-b"),
+				},
+			},
+		}),
+		"struct": createTestOperation(createTestKey("StructTest", "s"), createLandlordValue("tenant-b")),
+		"map": createTestOperation(createTestKey("MapTest", "m"), &Value{
+			Id:   3,
+			Type: ValueType_map,
+			MapValue: map[string]*Value{
+				"home": createLandlordValue("tenant-b"),
+			},
+		}),
+	} {
+		_, err = processor.processTransaction(ctx, genResult.Schema, &MetaTransaction{
+			Operations: []*MetaOperation{operation},
+		})
+		assert.Equal(t, status.Code(err), codes.PermissionDenied, name)
+	}
+
+	// nested keys without a namespace are pinned to the tenant's namespace
+	entity := &MetaEntity{
+		Values: []*Value{
+			&Value{
+				Id:   3,
+				Type: ValueType_map,
+				MapValue: map[string]*Value{
+					"home": createLandlordValue(""),
+				},
+			},
+		},
+	}
+	err = enforceTenantEntity("tenant-a", entity)
+	assert.NilError(t, err)
+	assert.Equal(t, entity.Values[0].MapValue["home"].StructValue.Values[0].KeyValue.PartitionId.Namespace, "tenant-a")
+}
@@ -3,23 +3,23 @@ package main
 import (
 	"context"
 	"fmt"
-	"log"
 	"sort"
 	"sync"
 	"time"
-
-	"cloud.google.com/go/firestore"
 )
 
 type transactionWatcher struct {
-	client *firestore.Client
-	schema *Schema
+	ctx       context.Context
+	stop      context.CancelFunc
+	storage   storageBackend
+	schema    *Schema
+	namespace string
 
-	currentEntities               map[string]*firestore.DocumentSnapshot
+	currentEntities               map[string]*storageSnapshot
 	currentEntitiesLock           sync.RWMutex
-	pendingChangesByTimestamp     map[string]map[string]*firestore.DocumentChange
+	pendingChangesByTimestamp     map[string]map[string]*storageChange
 	pendingChangesByTimestampLock sync.RWMutex
-	inboundChanges                chan firestore.DocumentChange
+	inboundChanges                chan storageChange
 
 	transactions     []*MetaTransactionRecord
 	transactionsLock sync.RWMutex
@@ -34,6 +34,10 @@ type transactionWatcher struct {
 	isConsistent bool
 
 	waitTransactionCount int
+
+	// lastUsed is protected by the watchersLock of the transactionWatcherSet
+	// that owns this watcher.
+	lastUsed time.Time
 }
 
 func (watcher *transactionWatcher) CurrentEntitiesTakeReadLock() {
@@ -88,6 +92,14 @@ func (watcher *transactionWatcher) DeregisterChannel(oldCh chan *MetaTransaction
 	watcher.outboundChannels = watcher.outboundChannels[:len(watcher.outboundChannels)-1]
 }
 
+// isWatched returns whether any clients are registered to receive batches.
+func (watcher *transactionWatcher) isWatched() bool {
+	watcher.outboundChannelsLock.Lock()
+	defer watcher.outboundChannelsLock.Unlock()
+
+	return len(watcher.outboundChannels) > 0
+}
+
 func serializeTime(ts time.Time) string {
 	return fmt.Sprintf("%d-%d", ts.Unix(), ts.Nanosecond())
 }
@@ -103,7 +115,7 @@ func (watcher *transactionWatcher) processTransaction(i int, transaction *MetaTr
 		pendingChanges, ok := watcher.pendingChangesByTimestamp[ts]
 		if !ok {
 			// auto-create map for simplicity
-			watcher.pendingChangesByTimestamp[ts] = make(map[string]*firestore.DocumentChange)
+			watcher.pendingChangesByTimestamp[ts] = make(map[string]*storageChange)
 			pendingChanges = watcher.pendingChangesByTimestamp[ts]
 		}
 		for _, mutatedKey := range transaction.MutatedKeys {
@@ -118,16 +130,16 @@ func (watcher *transactionWatcher) processTransaction(i int, transaction *MetaTr
 				// we won't receive a pending change for this entity, pretend that the "current entity" state is the
 				// pending change we're waiting for to make further logic work later
 				if doc, ok := watcher.currentEntities[mks]; ok {
-					watcher.pendingChangesByTimestamp[ts][mks] = &firestore.DocumentChange{
-						Kind:     firestore.DocumentAdded,
-						Doc:      doc,
+					watcher.pendingChangesByTimestamp[ts][mks] = &storageChange{
+						Kind:     storageDocumentAdded,
+						Snapshot: doc,
 						OldIndex: -1,
 						NewIndex: -1,
 					}
 				} else {
-					watcher.pendingChangesByTimestamp[ts][mks] = &firestore.DocumentChange{
-						Kind:     firestore.DocumentRemoved,
-						Doc:      nil,
+					watcher.pendingChangesByTimestamp[ts][mks] = &storageChange{
+						Kind:     storageDocumentRemoved,
+						Snapshot: nil,
 						OldIndex: -1,
 						NewIndex: -1,
 					}
@@ -185,27 +197,24 @@ func (watcher *transactionWatcher) processTransaction(i int, transaction *MetaTr
 			// configstore's in memory version of the database is consistent, and all future
 			// transactions will be applied atomically and consistently via this code (since once
 			// configstore has started, we *can* see historical versions of snapshots that are deleted).
-			if mutatedEntity.Doc != nil {
-				watcher.currentEntities[mks] = mutatedEntity.Doc
-				convertedEntity, err := convertSnapshotToMetaEntity(
+			if mutatedEntity.Snapshot != nil {
+				watcher.currentEntities[mks] = mutatedEntity.Snapshot
+				convertedEntity := convertSnapshotToReadableMetaEntity(
+					watcher.schema,
 					watcher.schema.Kinds[mutatedKey.Path[len(mutatedKey.Path)-1].Kind],
-					mutatedEntity.Doc,
+					mutatedEntity.Snapshot,
+				)
+				RecordTrace(&ConfigstoreTraceEntry{
+					OperatorId:    getTraceServiceName(),
+					Type:          ConfigstoreTraceEntry_TRANSACTION_RECONSTRUCT_APPEND_MUTATED_ENTITY,
+					TransactionId: transaction.Id,
+					Key:           mutatedKey,
+					Entity:        convertedEntity,
+				})
+				batch.MutatedEntities = append(
+					batch.MutatedEntities,
+					convertedEntity,
 				)
-				if err != nil {
-					log.Printf("error during batch construction: %v", err)
-				} else {
-					RecordTrace(&ConfigstoreTraceEntry{
-						OperatorId:    getTraceServiceName(),
-						Type:          ConfigstoreTraceEntry_TRANSACTION_RECONSTRUCT_APPEND_MUTATED_ENTITY,
-						TransactionId: transaction.Id,
-						Key:           mutatedKey,
-						Entity:        convertedEntity,
-					})
-					batch.MutatedEntities = append(
-						batch.MutatedEntities,
-						convertedEntity,
-					)
-				}
 			}
 			delete(pendingChanges, mks)
 		}
@@ -220,7 +229,11 @@ func (watcher *transactionWatcher) processTransaction(i int, transaction *MetaTr
 	}
 
 	// push the batch out
-	watcher.outboundChanges <- batch
+	select {
+	case watcher.outboundChanges <- batch:
+	case <-watcher.ctx.Done():
+		return false
+	}
 	watcher.waitTransactionCount = 0
 
 	// the transaction has been applied and can be removed from the transaction list
@@ -241,13 +254,19 @@ func safeSendBatchToChannel(ch chan *MetaTransactionBatch, batch *MetaTransactio
 	return true
 }
 
-func createTransactionWatcher(ctx context.Context, client *firestore.Client, schema *Schema) (*transactionWatcher, error) {
+// createTransactionWatcher starts a watcher for namespace, which runs until
+// ctx is done or the watcher is stopped.
+func createTransactionWatcher(ctx context.Context, storage storageBackend, schema *Schema, namespace string) (*transactionWatcher, error) {
+	ctx, stop := context.WithCancel(ctx)
 	watcher := &transactionWatcher{
-		client:                    client,
+		ctx:                       ctx,
+		stop:                      stop,
+		storage:                   storage,
 		schema:                    schema,
-		currentEntities:           make(map[string]*firestore.DocumentSnapshot),
-		pendingChangesByTimestamp: make(map[string]map[string]*firestore.DocumentChange),
-		inboundChanges:            make(chan firestore.DocumentChange),
+		namespace:                 namespace,
+		currentEntities:           make(map[string]*storageSnapshot),
+		pendingChangesByTimestamp: make(map[string]map[string]*storageChange),
+		inboundChanges:            make(chan storageChange),
 		outboundChanges:           make(chan *MetaTransactionBatch),
 		transactions:              nil,
 		initialReadTimeByKind:     make(map[string]time.Time),
@@ -265,9 +284,13 @@ func createTransactionWatcher(ctx context.Context, client *firestore.Client, sch
 		watcher.CurrentEntitiesTakeWriteLock()
 		watcher.CurrentEntitiesReleaseWriteLock()
 
-		for true {
+		for {
 			// wait a second
-			time.Sleep(time.Second * 1)
+			select {
+			case <-time.After(time.Second * 1):
+			case <-ctx.Done():
+				return
+			}
 
 			// preemptive lock to check if we have any transactions to process at all
 			watcher.transactionsLock.RLock()
@@ -323,7 +346,13 @@ func createTransactionWatcher(ctx context.Context, client *firestore.Client, sch
 
 	// listen for inbound changes
 	go func() {
-		for elem := range watcher.inboundChanges {
+		for {
+			var elem storageChange
+			select {
+			case elem = <-watcher.inboundChanges:
+			case <-ctx.Done():
+				return
+			}
 
 			// make an explicit copy of elem so we can take the address of
 			// it. if we just did &elem, we'd get a pointer to the for loop
@@ -332,12 +361,12 @@ func createTransactionWatcher(ctx context.Context, client *firestore.Client, sch
 
 			watcher.pendingChangesByTimestampLock.Lock()
 
-			ts := serializeTime(elem.Doc.UpdateTime)
+			ts := serializeTime(elem.Snapshot.UpdateTime)
 
 			if watcher.pendingChangesByTimestamp[ts] == nil {
-				watcher.pendingChangesByTimestamp[ts] = make(map[string]*firestore.DocumentChange)
+				watcher.pendingChangesByTimestamp[ts] = make(map[string]*storageChange)
 			}
-			watcher.pendingChangesByTimestamp[ts][serializeRef(elem.Doc.Ref)] = &copy
+			watcher.pendingChangesByTimestamp[ts][serializeKey(elem.Snapshot.Key)] = &copy
 
 			RecordPendingChangesByTimestamp(watcher.pendingChangesByTimestamp)
 
@@ -347,7 +376,13 @@ func createTransactionWatcher(ctx context.Context, client *firestore.Client, sch
 
 	// propagate outbound changes
 	go func() {
-		for elem := range watcher.outboundChanges {
+		for {
+			var elem *MetaTransactionBatch
+			select {
+			case elem = <-watcher.outboundChanges:
+			case <-ctx.Done():
+				return
+			}
 			watcher.outboundChannelsLock.Lock()
 			for _, ch := range watcher.outboundChannels {
 				safeSendBatchToChannel(ch, elem)
@@ -358,85 +393,111 @@ func createTransactionWatcher(ctx context.Context, client *firestore.Client, sch
 
 	// for each kind, start watching the collection and pipe snapshots into
 	// the inboundChanges channel
-	for kindName := range schema.Kinds {
-		snapshots := watcher.client.Collection(kindName).Snapshots(ctx)
+	for kindName, kind := range schema.Kinds {
+		snapshots := watcher.storage.Snapshots(ctx, &storageQuery{Namespace: namespace, KindName: kindName, AllDescendants: len(kind.Ancestors) > 0})
 		go func() {
 			for true {
 				snapshot, err := snapshots.Next()
 				if err != nil {
+					if ctx.Err() != nil {
+						// the watcher has been stopped
+						snapshots.Stop()
+						return
+					}
 					panic(fmt.Sprintf("unrecoverable error during entity watch: %v", err))
 				}
 
 				for _, change := range snapshot.Changes {
-					watcher.inboundChanges <- change
+					select {
+					case watcher.inboundChanges <- change:
+					case <-ctx.Done():
+						snapshots.Stop()
+						return
+					}
 				}
 			}
 		}()
 	}
 
-	if runWithoutFirestoreTransactionalQueries() {
+	err := storage.RunTransaction(ctx, func(ctx context.Context, tx storageTransaction) error {
 		// for each kind, fill in the current entities
-		for kindName := range schema.Kinds {
-			documents, err := watcher.client.Collection(kindName).Documents(ctx).GetAll()
+		for kindName, kind := range schema.Kinds {
+			documents, err := tx.Query(&storageQuery{Namespace: namespace, KindName: kindName, AllDescendants: len(kind.Ancestors) > 0})
 			if err != nil {
-				return nil, err
+				return err
 			}
 			for _, document := range documents {
 				watcher.initialReadTimeByKind[kindName] = document.ReadTime
-				watcher.currentEntities[serializeRef(document.Ref)] = document
-			}
-		}
-	} else {
-		err := client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
-			// for each kind, fill in the current entities
-			for kindName := range schema.Kinds {
-				documents, err := tx.Documents(watcher.client.Collection(kindName)).GetAll()
-				if err != nil {
-					return err
-				}
-				for _, document := range documents {
-					watcher.initialReadTimeByKind[kindName] = document.ReadTime
-					watcher.currentEntities[serializeRef(document.Ref)] = document
-				}
+				watcher.currentEntities[serializeKey(document.Key)] = document
 			}
-			return nil
-		})
-		if err != nil {
-			return nil, err
 		}
+		return nil
+	})
+	if err != nil {
+		stop()
+		return nil, err
 	}
 
 	// listen for new transactions coming in
 	go func() {
-		transactions := watcher.client.Collection("Transaction").Where("dateSubmitted", ">=", time.Now().Add(time.Second*-60)).OrderBy("dateSubmitted", firestore.Asc).Snapshots(ctx)
+		transactions := watcher.storage.Snapshots(ctx, &storageQuery{
+			Namespace: namespace,
+			KindName:  "Transaction",
+			Filters: []storageFilter{
+				{Field: "dateSubmitted", Op: ">=", Value: time.Now().Add(time.Second * -60)},
+			},
+			OrderBy: "dateSubmitted",
+		})
 		for true {
 			transactionSnapshot, err := transactions.Next()
 			if err != nil {
+				if ctx.Err() != nil {
+					// the watcher has been stopped
+					transactions.Stop()
+					return
+				}
 				panic(fmt.Sprintf("unrecoverable error during transaction watch: %v", err))
 			}
 
 			watcher.transactionsLock.Lock()
 
 			for _, change := range transactionSnapshot.Changes {
-				data := change.Doc.Data()
+				if change.Kind != storageDocumentAdded {
+					// transaction records are never modified, and they're only
+					// removed by the compactor after they've been processed
+					continue
+				}
+
+				data := change.Snapshot.Data
+				transactionID := getKeyIDString(change.Snapshot.Key)
 
-				var mutatedRefs []*firestore.DocumentRef
 				var mutatedKeys []*Key
-				var deletedRefs []*firestore.DocumentRef
 				var deletedKeys []*Key
 				var dateSubmitted time.Time
 				var description string
 				if w, ok := data["mutatedKeys"].([]interface{}); ok {
 					for _, ww := range w {
-						if www, ok := ww.(*firestore.DocumentRef); ok {
-							mutatedRefs = append(mutatedRefs, www)
+						if key, ok := ww.(*Key); ok && key != nil {
+							RecordTrace(&ConfigstoreTraceEntry{
+								OperatorId:    getTraceServiceName(),
+								Type:          ConfigstoreTraceEntry_TRANSACTION_MUTATED_ENTITY_KEY,
+								TransactionId: transactionID,
+								Key:           key,
+							})
+							mutatedKeys = append(mutatedKeys, key)
 						}
 					}
 				}
 				if w, ok := data["deletedKeys"].([]interface{}); ok {
 					for _, ww := range w {
-						if www, ok := ww.(*firestore.DocumentRef); ok {
-							deletedRefs = append(deletedRefs, www)
+						if key, ok := ww.(*Key); ok && key != nil {
+							RecordTrace(&ConfigstoreTraceEntry{
+								OperatorId:    getTraceServiceName(),
+								Type:          ConfigstoreTraceEntry_TRANSACTION_DELETED_ENTITY_KEY,
+								TransactionId: transactionID,
+								Key:           key,
+							})
+							deletedKeys = append(deletedKeys, key)
 						}
 					}
 				}
@@ -446,32 +507,6 @@ func createTransactionWatcher(ctx context.Context, client *firestore.Client, sch
 				if w, ok := data["description"].(string); ok {
 					description = w
 				}
-				for _, ref := range mutatedRefs {
-					key, err := convertDocumentRefToMetaKey(ref)
-					if err != nil {
-						continue
-					}
-					RecordTrace(&ConfigstoreTraceEntry{
-						OperatorId:    getTraceServiceName(),
-						Type:          ConfigstoreTraceEntry_TRANSACTION_MUTATED_ENTITY_KEY,
-						TransactionId: change.Doc.Ref.ID,
-						Key:           key,
-					})
-					mutatedKeys = append(mutatedKeys, key)
-				}
-				for _, ref := range deletedRefs {
-					key, err := convertDocumentRefToMetaKey(ref)
-					if err != nil {
-						continue
-					}
-					RecordTrace(&ConfigstoreTraceEntry{
-						OperatorId:    getTraceServiceName(),
-						Type:          ConfigstoreTraceEntry_TRANSACTION_DELETED_ENTITY_KEY,
-						TransactionId: change.Doc.Ref.ID,
-						Key:           key,
-					})
-					deletedKeys = append(deletedKeys, key)
-				}
 
 				if len(mutatedKeys) == 0 && len(deletedKeys) == 0 {
 					// we decoded these incorrectly, because we never write transactions
@@ -482,7 +517,7 @@ func createTransactionWatcher(ctx context.Context, client *firestore.Client, sch
 				RecordTrace(&ConfigstoreTraceEntry{
 					OperatorId:    getTraceServiceName(),
 					Type:          ConfigstoreTraceEntry_TRANSACTION_ARRIVED,
-					TransactionId: change.Doc.Ref.ID,
+					TransactionId: transactionID,
 				})
 				watcher.transactions = append(
 					watcher.transactions,
@@ -490,9 +525,9 @@ func createTransactionWatcher(ctx context.Context, client *firestore.Client, sch
 						MutatedKeys:   mutatedKeys,
 						DeletedKeys:   deletedKeys,
 						DateSubmitted: convertTimeToTimestamp(dateSubmitted),
-						DateCreated:   convertTimeToTimestamp(change.Doc.CreateTime),
+						DateCreated:   convertTimeToTimestamp(change.Snapshot.CreateTime),
 						Description:   description,
-						Id:            change.Doc.Ref.ID,
+						Id:            transactionID,
 					},
 				)
 			}
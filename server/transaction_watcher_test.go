@@ -0,0 +1,121 @@
+package main
+
+import (
+	"context"
+	"time"
+
+	"testing"
+
+	"gotest.tools/assert"
+)
+
+func waitForWatcherTestBatch(t *testing.T, ch chan *MetaTransactionBatch) *MetaTransactionBatch {
+	select {
+	case batch := <-ch:
+		return batch
+	case <-time.After(time.Second * 10):
+		t.Fatal("timed out waiting for a transaction batch")
+		return nil
+	}
+}
+
+func TestTransactionWatcherIgnoresDeletedTransactions(t *testing.T) {
+	ctx := context.Background()
+
+	genResult, err := generate("./schema.json")
+	assert.NilError(t, err)
+
+	// the storage isn't closed, because the watcher treats its snapshot
+	// iterators stopping as an unrecoverable error
+	storage := createMemoryStorage()
+	processor := createTransactionProcessor(storage)
+
+	watcher, err := createTransactionWatcher(ctx, storage, genResult.Schema, storage.DefaultNamespace())
+	assert.NilError(t, err)
+	ch := make(chan *MetaTransactionBatch, 10)
+	watcher.RegisterChannel(ch)
+
+	create := func(name string) {
+		resp, err := processor.processTransaction(ctx, genResult.Schema, &MetaTransaction{
+			Operations: []*MetaOperation{
+				&MetaOperation{
+					Operation: &MetaOperation_CreateRequest{
+						CreateRequest: &MetaCreateEntityRequest{
+							KindName: "IntegerTest",
+							Entity:   createMemoryTestEntity(name, 1),
+						},
+					},
+				},
+			},
+		})
+		assert.NilError(t, err)
+		assert.Assert(t, resp.OperationResults[0].Error == nil)
+	}
+
+	create("a")
+	batch := waitForWatcherTestBatch(t, ch)
+	assert.Equal(t, batch.MutatedEntities[0].Key.Path[0].GetName(), "a")
+
+	// delete the processed transaction record, like the compactor does
+	records, err := storage.Query(ctx, &storageQuery{KindName: "Transaction"})
+	assert.NilError(t, err)
+	assert.Equal(t, len(records), 1)
+	err = storage.RunTransaction(ctx, func(ctx context.Context, tx storageTransaction) error {
+		return tx.Delete(records[0].Key)
+	})
+	assert.NilError(t, err)
+
+	// the deletion must not be queued as a new transaction, which would
+	// stall every transaction after it
+	create("b")
+	batch = waitForWatcherTestBatch(t, ch)
+	assert.Equal(t, batch.MutatedEntities[0].Key.Path[0].GetName(), "b")
+
+	watcher.transactionsLock.RLock()
+	defer watcher.transactionsLock.RUnlock()
+	assert.Equal(t, len(watcher.transactions), 0)
+}
+
+func TestTransactionWatcherReportsUnreadableFields(t *testing.T) {
+	ctx := context.Background()
+
+	genResult, err := generate("./schema.json")
+	assert.NilError(t, err)
+
+	storage := createMemoryStorage()
+
+	watcher, err := createTransactionWatcher(ctx, storage, genResult.Schema, storage.DefaultNamespace())
+	assert.NilError(t, err)
+	ch := make(chan *MetaTransactionBatch, 10)
+	watcher.RegisterChannel(ch)
+
+	// write a value of the wrong type along with its transaction record,
+	// like an older version of the schema might have
+	key := createTestKey("NullableTest", "n1")
+	err = storage.RunTransaction(ctx, func(ctx context.Context, tx storageTransaction) error {
+		err := tx.Create(key, map[string]interface{}{
+			"replicas": "three",
+		})
+		if err != nil {
+			return err
+		}
+		return tx.Create(storage.NewKey("", nil, "Transaction"), map[string]interface{}{
+			"mutatedKeys":   []*Key{key},
+			"deletedKeys":   []*Key{},
+			"dateSubmitted": time.Now(),
+			"description":   "test",
+		})
+	})
+	assert.NilError(t, err)
+
+	// clients still receive the entity, with the field that can't be read
+	// left unset and reported
+	batch := waitForWatcherTestBatch(t, ch)
+	assert.Equal(t, len(batch.DeletedKeys), 0)
+	assert.Equal(t, len(batch.MutatedEntities), 1)
+	entity := batch.MutatedEntities[0]
+	assert.Equal(t, serializeKey(entity.Key), serializeKey(normalizeEntityKey(storage, key)))
+	assert.Equal(t, len(entity.FieldViolations), 1)
+	assert.Equal(t, entity.FieldViolations[0].KindName, "NullableTest")
+	assert.Equal(t, entity.FieldViolations[0].FieldName, "replicas")
+}
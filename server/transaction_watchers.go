@@ -0,0 +1,159 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// transactionWatcherIdleTimeout is how long a watcher for a namespace other
+// than the default namespace is kept running after its last use.
+const transactionWatcherIdleTimeout = time.Minute * 10
+
+// transactionWatcherLimit is the most namespaces other than the default
+// namespace that are watched at once. Each watcher keeps a copy of its
+// namespace in memory, so callers can't start an unbounded number of them.
+const transactionWatcherLimit = 100
+
+// transactionWatcherSet holds a transaction watcher for each namespace that
+// has been watched. The watcher for the default namespace is started
+// immediately; watchers for other namespaces are started the first time a
+// client watches that namespace, and are stopped once they've been idle for
+// transactionWatcherIdleTimeout, or earlier if limit is reached.
+type transactionWatcherSet struct {
+	ctx     context.Context
+	storage storageBackend
+	schema  *Schema
+	limit   int
+
+	watchers     map[string]*transactionWatcher
+	watchersLock sync.Mutex
+}
+
+func createTransactionWatcherSet(ctx context.Context, storage storageBackend, schema *Schema) (*transactionWatcherSet, error) {
+	set := &transactionWatcherSet{
+		ctx:      ctx,
+		storage:  storage,
+		schema:   schema,
+		limit:    transactionWatcherLimit,
+		watchers: make(map[string]*transactionWatcher),
+	}
+
+	_, err := set.getOrCreate(storage.DefaultNamespace())
+	if err != nil {
+		return nil, err
+	}
+
+	go func() {
+		ticker := time.NewTicker(time.Minute)
+		defer ticker.Stop()
+		for {
+			select {
+			case now := <-ticker.C:
+				set.evictIdle(now)
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return set, nil
+}
+
+func (set *transactionWatcherSet) getOrCreate(namespace string) (*transactionWatcher, error) {
+	set.watchersLock.Lock()
+	defer set.watchersLock.Unlock()
+
+	if watcher, ok := set.watchers[namespace]; ok {
+		watcher.lastUsed = time.Now()
+		return watcher, nil
+	}
+
+	if namespace != set.storage.DefaultNamespace() && len(set.watchers) > set.limit && !set.evictLeastRecentlyUsed() {
+		return nil, status.Error(codes.ResourceExhausted, fmt.Sprintf("too many namespaces are being watched to start watching namespace '%s'", namespace))
+	}
+
+	watcher, err := createTransactionWatcher(set.ctx, set.storage, set.schema, namespace)
+	if err != nil {
+		return nil, err
+	}
+	watcher.lastUsed = time.Now()
+	set.watchers[namespace] = watcher
+	return watcher, nil
+}
+
+// evictIdle stops the watchers for namespaces other than the default
+// namespace that have no clients watching them and haven't been used since
+// transactionWatcherIdleTimeout before now.
+func (set *transactionWatcherSet) evictIdle(now time.Time) {
+	set.watchersLock.Lock()
+	defer set.watchersLock.Unlock()
+
+	for namespace, watcher := range set.watchers {
+		if namespace == set.storage.DefaultNamespace() {
+			continue
+		}
+		if watcher.isWatched() {
+			watcher.lastUsed = now
+			continue
+		}
+		if watcher.lastUsed.Add(transactionWatcherIdleTimeout).Before(now) {
+			watcher.stop()
+			delete(set.watchers, namespace)
+		}
+	}
+}
+
+// evictLeastRecentlyUsed stops the least recently used watcher for a
+// namespace other than the default namespace that has no clients watching
+// it. It returns false if every watcher has clients. The caller must hold
+// watchersLock.
+func (set *transactionWatcherSet) evictLeastRecentlyUsed() bool {
+	var evictNamespace string
+	var evictWatcher *transactionWatcher
+	for namespace, watcher := range set.watchers {
+		if namespace == set.storage.DefaultNamespace() || watcher.isWatched() {
+			continue
+		}
+		if evictWatcher == nil || watcher.lastUsed.Before(evictWatcher.lastUsed) {
+			evictNamespace = namespace
+			evictWatcher = watcher
+		}
+	}
+	if evictWatcher == nil {
+		return false
+	}
+	evictWatcher.stop()
+	delete(set.watchers, evictNamespace)
+	return true
+}
+
+// Get returns the transaction watcher for the namespace in partitionID,
+// starting it if the namespace isn't being watched. Watchers for namespaces
+// other than the default namespace return Unavailable until they're
+// consistent, which happens shortly after they start.
+func (set *transactionWatcherSet) Get(partitionID *PartitionId) (*transactionWatcher, error) {
+	var namespace string
+	if partitionID != nil {
+		namespace = partitionID.Namespace
+	}
+	namespace = normalizeNamespace(set.storage, namespace)
+	if namespace != set.storage.DefaultNamespace() && !isValidNamespaceName(namespace) {
+		return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("namespace '%s' is not a valid namespace name", namespace))
+	}
+
+	watcher, err := set.getOrCreate(namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	if namespace != set.storage.DefaultNamespace() && !watcher.isConsistent {
+		return nil, status.Error(codes.Unavailable, fmt.Sprintf("configstore is still loading namespace '%s', please try again in a moment", namespace))
+	}
+
+	return watcher, nil
+}
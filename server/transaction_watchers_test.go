@@ -0,0 +1,94 @@
+package main
+
+import (
+	"context"
+	"time"
+
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gotest.tools/assert"
+)
+
+func TestTransactionWatcherSetEvictsIdleWatchers(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	genResult, err := generate("./schema.json")
+	assert.NilError(t, err)
+
+	storage := createMemoryStorage()
+	set, err := createTransactionWatcherSet(ctx, storage, genResult.Schema)
+	assert.NilError(t, err)
+
+	// a new namespace isn't available until its watcher is consistent
+	partitionID := &PartitionId{Namespace: "tenant-a"}
+	_, err = set.Get(partitionID)
+	assert.Equal(t, status.Code(err), codes.Unavailable)
+	var watcher *transactionWatcher
+	deadline := time.Now().Add(time.Second * 10)
+	for watcher == nil && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond * 100)
+		watcher, err = set.Get(partitionID)
+		if status.Code(err) != codes.Unavailable {
+			assert.NilError(t, err)
+		}
+	}
+	assert.Assert(t, watcher != nil)
+
+	// watchers aren't evicted while clients are watching them
+	ch := make(chan *MetaTransactionBatch)
+	watcher.RegisterChannel(ch)
+	set.evictIdle(time.Now().Add(time.Hour))
+	assert.Equal(t, len(set.watchers), 2)
+
+	watcher.DeregisterChannel(ch)
+	set.evictIdle(time.Now().Add(time.Hour * 2))
+	assert.Equal(t, len(set.watchers), 1)
+	assert.Assert(t, watcher.ctx.Err() != nil)
+	_, ok := set.watchers[storage.DefaultNamespace()]
+	assert.Assert(t, ok)
+}
+
+func TestTransactionWatcherSetLimitsWatchedNamespaces(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	genResult, err := generate("./schema.json")
+	assert.NilError(t, err)
+
+	storage := createMemoryStorage()
+	set, err := createTransactionWatcherSet(ctx, storage, genResult.Schema)
+	assert.NilError(t, err)
+	set.limit = 2
+
+	_, err = set.Get(&PartitionId{Namespace: "tenant-a"})
+	assert.Equal(t, status.Code(err), codes.Unavailable)
+	_, err = set.Get(&PartitionId{Namespace: "tenant-b"})
+	assert.Equal(t, status.Code(err), codes.Unavailable)
+	a := set.watchers["tenant-a"]
+	b := set.watchers["tenant-b"]
+	a.lastUsed = time.Now().Add(-time.Minute)
+
+	// the least recently used watcher makes way for a new namespace
+	_, err = set.Get(&PartitionId{Namespace: "tenant-c"})
+	assert.Equal(t, status.Code(err), codes.Unavailable)
+	assert.Equal(t, len(set.watchers), 3)
+	_, ok := set.watchers["tenant-a"]
+	assert.Assert(t, !ok)
+	assert.Assert(t, a.ctx.Err() != nil)
+
+	// but watchers with clients aren't stopped
+	ch := make(chan *MetaTransactionBatch)
+	b.RegisterChannel(ch)
+	set.watchers["tenant-c"].RegisterChannel(ch)
+	_, err = set.Get(&PartitionId{Namespace: "tenant-d"})
+	assert.Equal(t, status.Code(err), codes.ResourceExhausted)
+	assert.Equal(t, len(set.watchers), 3)
+	assert.Assert(t, b.ctx.Err() == nil)
+
+	// and the default namespace is always available
+	_, err = set.Get(nil)
+	assert.NilError(t, err)
+}
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"fmt"
+	"math"
+)
+
+const decimalNanosPerUnit = 1000000000
+
+func getDecimalScaleMultiplier(scale int32) int64 {
+	multiplier := int64(1)
+	for i := int32(0); i < scale; i++ {
+		multiplier *= 10
+	}
+	return multiplier
+}
+
+// convertDecimalToScaledInt64 returns value as a count of the smallest unit
+// at scale, which is how decimals are stored, so that they keep their exact
+// value and sort numerically. If value can't be stored exactly, it returns a
+// description of why instead.
+func convertDecimalToScaledInt64(value *DecimalValue, scale int32) (int64, string) {
+	if value == nil {
+		return 0, ""
+	}
+	if value.Nanos <= -decimalNanosPerUnit || value.Nanos >= decimalNanosPerUnit {
+		return 0, "nanos must be between -999999999 and 999999999"
+	}
+	if (value.Units > 0 && value.Nanos < 0) || (value.Units < 0 && value.Nanos > 0) {
+		return 0, "units and nanos must have the same sign"
+	}
+	multiplier := getDecimalScaleMultiplier(scale)
+	step := int32(decimalNanosPerUnit / multiplier)
+	if value.Nanos%step != 0 {
+		return 0, fmt.Sprintf("must have at most %d decimal places", scale)
+	}
+	fraction := int64(value.Nanos / step)
+	limit := math.MaxInt64 / multiplier
+	if value.Units > limit || value.Units < -limit ||
+		(value.Units == limit && fraction > math.MaxInt64-limit*multiplier) ||
+		(value.Units == -limit && fraction < math.MinInt64+limit*multiplier) {
+		return 0, fmt.Sprintf("is too large to store with %d decimal places", scale)
+	}
+	return value.Units*multiplier + fraction, ""
+}
+
+func convertScaledInt64ToDecimal(value int64, scale int32) *DecimalValue {
+	multiplier := getDecimalScaleMultiplier(scale)
+	return &DecimalValue{
+		Units: value / multiplier,
+		Nanos: int32(value%multiplier) * int32(decimalNanosPerUnit/multiplier),
+	}
+}
@@ -0,0 +1,38 @@
+package main
+
+import (
+	"math"
+
+	duration "github.com/golang/protobuf/ptypes/duration"
+)
+
+const durationNanosPerSecond = 1000000000
+
+// convertDurationToNanoseconds returns value as a number of nanoseconds,
+// which is how durations are stored. If value can't be stored, it returns a
+// description of why instead.
+func convertDurationToNanoseconds(value *duration.Duration) (int64, string) {
+	if value == nil {
+		return 0, ""
+	}
+	if value.Nanos <= -durationNanosPerSecond || value.Nanos >= durationNanosPerSecond {
+		return 0, "nanos must be between -999999999 and 999999999"
+	}
+	if (value.Seconds > 0 && value.Nanos < 0) || (value.Seconds < 0 && value.Nanos > 0) {
+		return 0, "seconds and nanos must have the same sign"
+	}
+	limit := int64(math.MaxInt64 / durationNanosPerSecond)
+	if value.Seconds > limit || value.Seconds < -limit ||
+		(value.Seconds == limit && int64(value.Nanos) > math.MaxInt64-limit*durationNanosPerSecond) ||
+		(value.Seconds == -limit && int64(value.Nanos) < math.MinInt64+limit*durationNanosPerSecond) {
+		return 0, "is too long to store as a number of nanoseconds"
+	}
+	return value.Seconds*durationNanosPerSecond + int64(value.Nanos), ""
+}
+
+func convertNanosecondsToDuration(value int64) *duration.Duration {
+	return &duration.Duration{
+		Seconds: value / durationNanosPerSecond,
+		Nanos:   int32(value % durationNanosPerSecond),
+	}
+}
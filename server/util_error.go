@@ -0,0 +1,37 @@
+package main
+
+import (
+	"github.com/golang/protobuf/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// createFieldViolationError returns a gRPC error with the given field
+// violations attached as details, so that clients can tell which fields
+// were at fault.
+func createFieldViolationError(code codes.Code, message string, violations []*MetaFieldViolation) error {
+	st := status.New(code, message)
+	var details []proto.Message
+	for _, violation := range violations {
+		details = append(details, violation)
+	}
+	withDetails, err := st.WithDetails(details...)
+	if err != nil {
+		return st.Err()
+	}
+	return withDetails.Err()
+}
+
+func getFieldViolations(err error) []*MetaFieldViolation {
+	st, ok := status.FromError(err)
+	if !ok {
+		return nil
+	}
+	var violations []*MetaFieldViolation
+	for _, detail := range st.Details() {
+		if violation, ok := detail.(*MetaFieldViolation); ok {
+			violations = append(violations, violation)
+		}
+	}
+	return violations
+}
@@ -0,0 +1,114 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+)
+
+// checkStorableValues returns a violation for each value, including those in
+// the elements of repeated fields, the entries of maps and the fields of
+// structs, that can't be stored as its field's type: nulls for fields that
+// aren't nullable, decimals that can't be stored exactly at their field's
+// scale, durations that don't fit in a number of nanoseconds, locations
+// outside the range of latitudes and longitudes, and JSON that Firestore
+// can't represent. Violations are named like those from validateFieldsData.
+func checkStorableValues(schema *Schema, kindName string, prefix string, fields []*SchemaField, values []*Value) []*MetaFieldViolation {
+	var violations []*MetaFieldViolation
+	check := func(field *SchemaField, path string, element string, value *Value) {
+		if value == nil {
+			return
+		}
+		var description string
+		switch field.Type {
+		case ValueType_decimal:
+			_, description = convertDecimalToScaledInt64(value.DecimalValue, field.DecimalScale)
+		case ValueType_duration:
+			_, description = convertDurationToNanoseconds(value.DurationValue)
+		case ValueType_geopoint:
+			description = checkGeoPoint(value.GeopointValue)
+		case ValueType_json:
+			description = checkJSONStruct(value.JsonValue)
+		case ValueType_struct:
+			if structInfo, ok := schema.Structs[field.StructName]; ok && value.StructValue != nil {
+				violations = append(violations, checkStorableValues(schema, kindName, path, structInfo.Fields, value.StructValue.Values)...)
+			}
+		}
+		if description != "" {
+			violations = append(violations, &MetaFieldViolation{
+				KindName:    kindName,
+				FieldName:   prefix + field.Name,
+				Description: element + description,
+			})
+		}
+	}
+	for _, value := range values {
+		if value == nil {
+			continue
+		}
+		field := findSchemaFieldByID(fields, value.Id)
+		if field == nil {
+			continue
+		}
+		if value.NullValue {
+			if !field.Nullable {
+				violations = append(violations, &MetaFieldViolation{
+					KindName:    kindName,
+					FieldName:   prefix + field.Name,
+					Description: "can't be null, because the field isn't nullable",
+				})
+			}
+			continue
+		}
+		switch {
+		case field.Type == ValueType_map:
+			var entryKeys []string
+			for entryKey := range value.MapValue {
+				entryKeys = append(entryKeys, entryKey)
+			}
+			sort.Strings(entryKeys)
+			valueField := getMapValueField(field)
+			for _, entryKey := range entryKeys {
+				check(valueField, fmt.Sprintf("%s%s.%s.", prefix, field.Name, entryKey), fmt.Sprintf("entry '%s' ", entryKey), value.MapValue[entryKey])
+			}
+		case field.Repeated:
+			for i, element := range value.ArrayValue {
+				check(field, fmt.Sprintf("%s%s.%d.", prefix, field.Name, i), fmt.Sprintf("element %d ", i), element)
+			}
+		default:
+			check(field, fmt.Sprintf("%s%s.", prefix, field.Name), "", value)
+		}
+	}
+	return violations
+}
+
+// forEachNestedValue calls f for each value, and for every value nested in
+// the elements of repeated fields, the entries of maps and the fields of
+// structs, stopping at the first error.
+func forEachNestedValue(values []*Value, f func(value *Value) error) error {
+	for _, value := range values {
+		if value == nil {
+			continue
+		}
+		err := f(value)
+		if err != nil {
+			return err
+		}
+		err = forEachNestedValue(value.ArrayValue, f)
+		if err != nil {
+			return err
+		}
+		if value.StructValue != nil {
+			err = forEachNestedValue(value.StructValue.Values, f)
+			if err != nil {
+				return err
+			}
+		}
+		for _, entry := range value.MapValue {
+			err = forEachNestedValue([]*Value{entry}, f)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
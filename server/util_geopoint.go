@@ -0,0 +1,36 @@
+package main
+
+import (
+	"google.golang.org/genproto/googleapis/type/latlng"
+)
+
+// checkGeoPoint returns a description of why value isn't a valid location,
+// or an empty string if it is.
+func checkGeoPoint(value *GeoPoint) string {
+	if value == nil {
+		return ""
+	}
+	if !(value.Latitude >= -90 && value.Latitude <= 90) {
+		return "latitude must be between -90 and 90"
+	}
+	if !(value.Longitude >= -180 && value.Longitude <= 180) {
+		return "longitude must be between -180 and 180"
+	}
+	return ""
+}
+
+// convertGeoPointToLatLng returns value as it is stored, which is the type
+// the Firestore client uses for GeoPoints.
+func convertGeoPointToLatLng(value *GeoPoint) *latlng.LatLng {
+	return &latlng.LatLng{
+		Latitude:  value.Latitude,
+		Longitude: value.Longitude,
+	}
+}
+
+func convertLatLngToGeoPoint(value *latlng.LatLng) *GeoPoint {
+	return &GeoPoint{
+		Latitude:  value.Latitude,
+		Longitude: value.Longitude,
+	}
+}
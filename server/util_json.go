@@ -0,0 +1,108 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+
+	structpb "github.com/golang/protobuf/ptypes/struct"
+)
+
+// checkJSONValue returns a description of why value can't be stored, or an
+// empty string if it can. Firestore can't store lists directly inside lists,
+// so they're rejected for every backend.
+func checkJSONValue(value *structpb.Value) string {
+	switch kind := value.GetKind().(type) {
+	case *structpb.Value_StructValue:
+		return checkJSONStruct(kind.StructValue)
+	case *structpb.Value_ListValue:
+		for i, element := range kind.ListValue.GetValues() {
+			if _, ok := element.GetKind().(*structpb.Value_ListValue); ok {
+				return fmt.Sprintf("element %d of a list is a list, but lists can't directly contain other lists", i)
+			}
+			if description := checkJSONValue(element); description != "" {
+				return description
+			}
+		}
+	}
+	return ""
+}
+
+func checkJSONStruct(value *structpb.Struct) string {
+	for _, name := range getSortedJSONFieldNames(value) {
+		if description := checkJSONValue(value.Fields[name]); description != "" {
+			return description
+		}
+	}
+	return ""
+}
+
+func getSortedJSONFieldNames(value *structpb.Struct) []string {
+	var names []string
+	for name := range value.GetFields() {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// convertJSONStructToDataMap returns value as it is stored, which is a map
+// holding nil, float64, string, bool, []interface{} and nested maps.
+func convertJSONStructToDataMap(value *structpb.Struct) map[string]interface{} {
+	m := make(map[string]interface{}, len(value.GetFields()))
+	for name, field := range value.GetFields() {
+		m[name] = convertJSONValueToDataMapValue(field)
+	}
+	return m
+}
+
+func convertJSONValueToDataMapValue(value *structpb.Value) interface{} {
+	switch kind := value.GetKind().(type) {
+	case *structpb.Value_NumberValue:
+		return kind.NumberValue
+	case *structpb.Value_StringValue:
+		return kind.StringValue
+	case *structpb.Value_BoolValue:
+		return kind.BoolValue
+	case *structpb.Value_StructValue:
+		return convertJSONStructToDataMap(kind.StructValue)
+	case *structpb.Value_ListValue:
+		elements := make([]interface{}, 0, len(kind.ListValue.GetValues()))
+		for _, element := range kind.ListValue.GetValues() {
+			elements = append(elements, convertJSONValueToDataMapValue(element))
+		}
+		return elements
+	}
+	return nil
+}
+
+func convertDataMapToJSONStruct(m map[string]interface{}) *structpb.Struct {
+	value := &structpb.Struct{
+		Fields: make(map[string]*structpb.Value, len(m)),
+	}
+	for name, field := range m {
+		value.Fields[name] = convertDataMapValueToJSONValue(field)
+	}
+	return value
+}
+
+func convertDataMapValueToJSONValue(value interface{}) *structpb.Value {
+	switch v := value.(type) {
+	case float64:
+		return &structpb.Value{Kind: &structpb.Value_NumberValue{NumberValue: v}}
+	case int64:
+		return &structpb.Value{Kind: &structpb.Value_NumberValue{NumberValue: float64(v)}}
+	case string:
+		return &structpb.Value{Kind: &structpb.Value_StringValue{StringValue: v}}
+	case bool:
+		return &structpb.Value{Kind: &structpb.Value_BoolValue{BoolValue: v}}
+	case map[string]interface{}:
+		return &structpb.Value{Kind: &structpb.Value_StructValue{StructValue: convertDataMapToJSONStruct(v)}}
+	case []interface{}:
+		elements := make([]*structpb.Value, 0, len(v))
+		for _, element := range v {
+			elements = append(elements, convertDataMapValueToJSONValue(element))
+		}
+		return &structpb.Value{Kind: &structpb.Value_ListValue{ListValue: &structpb.ListValue{Values: elements}}}
+	}
+	return &structpb.Value{Kind: &structpb.Value_NullValue{}}
+}
@@ -16,14 +16,6 @@ func getTopLevelParent(ref *firestore.DocumentRef) *firestore.CollectionRef {
 	return lastCollection
 }
 
-func serializeRef(ref *firestore.DocumentRef) string {
-	key, err := convertDocumentRefToMetaKey(ref)
-	if err != nil {
-		return ""
-	}
-	return serializeKey(key)
-}
-
 func serializeKey(key *Key) string {
 	if key == nil {
 		return ""
@@ -1,7 +1,11 @@
 package main
 
-func findSchemaFieldByID(schemaKind *SchemaKind, id int32) *SchemaField {
-	for _, field := range schemaKind.Fields {
+import (
+	"fmt"
+)
+
+func findSchemaFieldByID(fields []*SchemaField, id int32) *SchemaField {
+	for _, field := range fields {
 		if field.Id == id {
 			return field
 		}
@@ -9,3 +13,162 @@ func findSchemaFieldByID(schemaKind *SchemaKind, id int32) *SchemaField {
 
 	return nil
 }
+
+func findSchemaFieldByName(schemaKind *SchemaKind, name string) *SchemaField {
+	for _, field := range schemaKind.Fields {
+		if field.Name == name {
+			return field
+		}
+	}
+
+	return nil
+}
+
+// getMapValueField returns a field with the type of the values of a map
+// field, so that map values can be converted like any other value.
+func getMapValueField(field *SchemaField) *SchemaField {
+	return &SchemaField{
+		Id:           field.Id,
+		Name:         field.Name,
+		Type:         field.MapValueType,
+		StructName:   field.StructName,
+		EnumName:     field.EnumName,
+		DecimalScale: field.DecimalScale,
+		Editor:       field.Editor,
+	}
+}
+
+// checkSchemaStructs returns an error if a struct or enum field refers to a
+// struct or enum that isn't in the schema, if a map field doesn't have a valid value type,
+// if a field that can't be null is nullable, or if a struct has a field that
+// uses a feature that only applies to the fields of kinds.
+func checkSchemaStructs(schema *Schema) error {
+	for kindName, kind := range schema.Kinds {
+		if _, ok := schema.Structs[kindName]; ok {
+			return fmt.Errorf("struct '%s' has the same name as a kind", kindName)
+		}
+		err := checkSchemaStructFields(schema, fmt.Sprintf("kind '%s'", kindName), kind.Fields)
+		if err != nil {
+			return err
+		}
+	}
+	for structName, structInfo := range schema.Structs {
+		err := checkSchemaStructFields(schema, fmt.Sprintf("struct '%s'", structName), structInfo.Fields)
+		if err != nil {
+			return err
+		}
+		for _, field := range structInfo.Fields {
+			if field.Unique {
+				return fmt.Errorf("field '%s' of struct '%s' is unique, but only the fields of kinds can be unique", field.Name, structName)
+			}
+		}
+	}
+	return nil
+}
+
+func checkSchemaStructFields(schema *Schema, owner string, fields []*SchemaField) error {
+	for _, field := range fields {
+		valueType := field.Type
+		if field.Type == ValueType_map {
+			if field.Repeated {
+				return fmt.Errorf("field '%s' of %s is a map, so it can't be repeated", field.Name, owner)
+			}
+			switch field.MapValueType {
+			case ValueType_unknown, ValueType_map:
+				return fmt.Errorf("field '%s' of %s is a map, so it needs a mapValueType other than map", field.Name, owner)
+			}
+			valueType = field.MapValueType
+		} else if field.MapValueType != ValueType_unknown {
+			return fmt.Errorf("field '%s' of %s has a mapValueType, but it isn't a map field", field.Name, owner)
+		}
+		if field.Nullable && (field.Repeated || field.Type == ValueType_map) {
+			return fmt.Errorf("field '%s' of %s holds a list or map, so it can't be nullable; leave it empty instead", field.Name, owner)
+		}
+		if field.Nullable && field.Type == ValueType_enum {
+			return fmt.Errorf("field '%s' of %s is an enum, so it can't be nullable; use the value numbered 0 for unset values instead", field.Name, owner)
+		}
+		if valueType == ValueType_decimal && (field.DecimalScale < 0 || field.DecimalScale > 9) {
+			return fmt.Errorf("field '%s' of %s is a decimal, so it needs a decimalScale from 0 to 9", field.Name, owner)
+		}
+		if valueType != ValueType_decimal && field.DecimalScale != 0 {
+			return fmt.Errorf("field '%s' of %s has a decimalScale, but it isn't a decimal field", field.Name, owner)
+		}
+		if valueType != ValueType_enum && field.EnumName != "" {
+			return fmt.Errorf("field '%s' of %s has an enumName, but it isn't an enum field", field.Name, owner)
+		}
+		if valueType == ValueType_enum {
+			if _, ok := schema.Enums[field.EnumName]; !ok {
+				return fmt.Errorf("field '%s' of %s refers to enum '%s', which isn't in the schema", field.Name, owner, field.EnumName)
+			}
+		}
+		if valueType != ValueType_struct {
+			if field.StructName != "" {
+				return fmt.Errorf("field '%s' of %s has a structName, but it isn't a struct field", field.Name, owner)
+			}
+			continue
+		}
+		if _, ok := schema.Structs[field.StructName]; !ok {
+			return fmt.Errorf("field '%s' of %s refers to struct '%s', which isn't in the schema", field.Name, owner, field.StructName)
+		}
+	}
+	return nil
+}
+
+// checkSchemaEnums returns an error if an enum can't be generated as a
+// protobuf enum. Enum values share a scope in the generated file, so their
+// names must be unique across every enum.
+func checkSchemaEnums(schema *Schema) error {
+	valueEnums := make(map[string]string)
+	for enumName, enumInfo := range schema.Enums {
+		if _, ok := schema.Kinds[enumName]; ok {
+			return fmt.Errorf("enum '%s' has the same name as a kind", enumName)
+		}
+		if _, ok := schema.Structs[enumName]; ok {
+			return fmt.Errorf("enum '%s' has the same name as a struct", enumName)
+		}
+		if len(enumInfo.Values) == 0 || enumInfo.Values[0].Number != 0 {
+			return fmt.Errorf("the first value of enum '%s' must be numbered 0, which unset fields have", enumName)
+		}
+		numbers := make(map[int32]bool)
+		for _, value := range enumInfo.Values {
+			if value.Name == "" {
+				return fmt.Errorf("enum '%s' has a value without a name", enumName)
+			}
+			_, isKind := schema.Kinds[value.Name]
+			_, isStruct := schema.Structs[value.Name]
+			_, isEnum := schema.Enums[value.Name]
+			if isKind || isStruct || isEnum {
+				return fmt.Errorf("enum '%s' has the value '%s', which is also the name of a kind, struct or enum", enumName, value.Name)
+			}
+			if otherEnumName, ok := valueEnums[value.Name]; ok {
+				return fmt.Errorf("enum '%s' has the value '%s', which enum '%s' already has", enumName, value.Name, otherEnumName)
+			}
+			valueEnums[value.Name] = enumName
+			if numbers[value.Number] {
+				return fmt.Errorf("enum '%s' has more than one value numbered %d", enumName, value.Number)
+			}
+			numbers[value.Number] = true
+		}
+	}
+	return nil
+}
+
+// findSchemaEnumValueByName returns the value of the enum with the given
+// name, or nil if the enum doesn't have one.
+func findSchemaEnumValueByName(enumInfo *SchemaEnum, name string) *SchemaEnumValue {
+	for _, value := range enumInfo.GetValues() {
+		if value.Name == name {
+			return value
+		}
+	}
+	return nil
+}
+
+func findSchemaEnumValueByNumber(enumInfo *SchemaEnum, number int32) *SchemaEnumValue {
+	for _, value := range enumInfo.GetValues() {
+		if value.Number == number {
+			return value
+		}
+	}
+	return nil
+}